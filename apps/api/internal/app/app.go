@@ -142,6 +142,7 @@ func (a *App) setupServer() {
 	architectureHandler := handlers.NewArchitectureHandler(layerValidatorService, a.logger)
 	uploadHandler := handlers.NewUploadHandler(uploadService, a.logger, a.config.Upload.Directory)
 	githubHandler := handlers.NewGitHubHandler(integratedAnalysis, uploadService, a.logger)
+	resultHandler := handlers.NewResultHandler(analysisRepo, projectRepo, a.logger)
 
 	// API routes (simplified for MVP - no session management)
 	v1 := router.Group("/api/v1")
@@ -161,6 +162,10 @@ func (a *App) setupServer() {
 			projects.GET("/:id/analyses/dependencies", analysisHandler.GetProjectDependencyAnalyses)
 			projects.GET("/:id/analyses/dependencies/latest", analysisHandler.GetLatestDependencyAnalysis)
 			projects.GET("/:id/health-score/latest", analysisHandler.GetLatestHealthScore)
+
+			// Analysis engine results uploaded by the CLI
+			projects.POST("/:id/results", resultHandler.UploadResult)
+			projects.GET("/:id/results/latest", resultHandler.GetLatestResult)
 		}
 
 		// Owner-specific routes
@@ -186,6 +191,9 @@ func (a *App) setupServer() {
 			analysis.POST("/github", githubHandler.AnalyzeGitHubRepository)
 		}
 
+		// Analysis engine result routes
+		v1.GET("/results/:id", resultHandler.GetResult)
+
 		// Upload routes
 		upload := v1.Group("/upload")
 		{
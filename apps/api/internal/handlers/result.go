@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/monoguard/api/internal/models"
+	"github.com/monoguard/api/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// ResultHandler handles analysis engine results uploaded by the CLI
+type ResultHandler struct {
+	analysisRepo *repository.AnalysisRepository
+	projectRepo  *repository.ProjectRepository
+	logger       *logrus.Logger
+}
+
+// NewResultHandler creates a new result handler
+func NewResultHandler(analysisRepo *repository.AnalysisRepository, projectRepo *repository.ProjectRepository, logger *logrus.Logger) *ResultHandler {
+	return &ResultHandler{
+		analysisRepo: analysisRepo,
+		projectRepo:  projectRepo,
+		logger:       logger,
+	}
+}
+
+// UploadResultRequest is the body of an engine result upload
+type UploadResultRequest struct {
+	Commit string          `json:"commit"`
+	Result json.RawMessage `json:"result" binding:"required"`
+}
+
+// UploadResult handles POST /projects/:id/results
+func (h *ResultHandler) UploadResult(c *gin.Context) {
+	projectID := c.Param("id")
+	if projectID == "" {
+		BadRequest(c, "Project ID is required", nil)
+		return
+	}
+
+	var req UploadResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithError(err).Warn("Invalid request body")
+		BadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	// Only the summary is read; the result is stored as submitted
+	var summary struct {
+		HealthScore int `json:"healthScore"`
+		Packages    int `json:"packages"`
+	}
+	if err := json.Unmarshal(req.Result, &summary); err != nil {
+		BadRequest(c, "Result is not an analysis result", err.Error())
+		return
+	}
+
+	if _, err := h.projectRepo.GetByID(c.Request.Context(), projectID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			NotFound(c, "Project not found")
+			return
+		}
+		h.logger.WithError(err).Error("Failed to get project")
+		InternalError(c, "Failed to get project")
+		return
+	}
+
+	result := &models.EngineResult{
+		ProjectID:   projectID,
+		Commit:      req.Commit,
+		HealthScore: summary.HealthScore,
+		Packages:    summary.Packages,
+		Result:      req.Result,
+	}
+	if err := h.analysisRepo.CreateEngineResult(c.Request.Context(), result); err != nil {
+		h.logger.WithError(err).Error("Failed to store engine result")
+		InternalError(c, "Failed to store analysis result")
+		return
+	}
+
+	if err := h.projectRepo.UpdateHealthScore(c.Request.Context(), projectID, summary.HealthScore); err != nil {
+		h.logger.WithError(err).Warn("Failed to update project health score")
+	}
+
+	// The client already has the result; only return where it is stored
+	result.Result = nil
+	Created(c, result, "Analysis result stored successfully")
+}
+
+// GetResult handles GET /results/:id
+func (h *ResultHandler) GetResult(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		BadRequest(c, "Result ID is required", nil)
+		return
+	}
+
+	result, err := h.analysisRepo.GetEngineResultByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			NotFound(c, "Analysis result not found")
+			return
+		}
+		h.logger.WithError(err).Error("Failed to get engine result")
+		InternalError(c, "Failed to get analysis result")
+		return
+	}
+
+	Success(c, result, "Analysis result retrieved successfully")
+}
+
+// GetLatestResult handles GET /projects/:id/results/latest
+func (h *ResultHandler) GetLatestResult(c *gin.Context) {
+	projectID := c.Param("id")
+	if projectID == "" {
+		BadRequest(c, "Project ID is required", nil)
+		return
+	}
+
+	result, err := h.analysisRepo.GetLatestEngineResult(c.Request.Context(), projectID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			NotFound(c, "No analysis result found for this project")
+			return
+		}
+		h.logger.WithError(err).Error("Failed to get latest engine result")
+		InternalError(c, "Failed to get latest analysis result")
+		return
+	}
+
+	Success(c, result, "Latest analysis result retrieved successfully")
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/monoguard/api/internal/models"
+	"github.com/monoguard/api/internal/repository"
+	"github.com/monoguard/api/pkg/database"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newResultRouter serves the result handler over an in-memory SQLite
+// database holding a single project
+func newResultRouter(t *testing.T) (*gin.Engine, *database.DB) {
+	t.Helper()
+
+	gdb, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, gdb.AutoMigrate(
+		&models.Project{},
+		&models.DependencyAnalysis{},
+		&models.ArchitectureValidation{},
+		&models.EngineResult{},
+	))
+	sqlDB, err := gdb.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) // each connection opens its own in-memory database
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db := &database.DB{DB: gdb}
+	require.NoError(t, gdb.Create(&models.Project{
+		ID:     "project-1",
+		Name:   "monorepo",
+		Status: models.StatusCompleted,
+	}).Error)
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel) // Reduce noise in tests
+	handler := NewResultHandler(repository.NewAnalysisRepository(db), repository.NewProjectRepository(db), logger)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/projects/:id/results", handler.UploadResult)
+	return router, db
+}
+
+// TestUploadResult tests uploading an analysis engine result
+func TestUploadResult(t *testing.T) {
+	router, db := newResultRouter(t)
+
+	body := `{"commit":"abc123","result":{"healthScore":72,"packages":5}}`
+	req := httptest.NewRequest(http.MethodPost, "/projects/project-1/results", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var resp struct {
+		Success bool                `json:"success"`
+		Data    models.EngineResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Success)
+	assert.NotEmpty(t, resp.Data.ID)
+	assert.Equal(t, "abc123", resp.Data.Commit)
+	assert.Equal(t, 72, resp.Data.HealthScore)
+	assert.Equal(t, 5, resp.Data.Packages)
+	assert.Empty(t, resp.Data.Result, "Should not echo the result back")
+
+	stored, err := repository.NewAnalysisRepository(db).GetEngineResultByID(context.Background(), resp.Data.ID)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"healthScore":72,"packages":5}`, string(stored.Result))
+
+	project, err := repository.NewProjectRepository(db).GetByID(context.Background(), "project-1")
+	require.NoError(t, err)
+	assert.Equal(t, 72, project.HealthScore, "Should update the project health score")
+}
+
+// TestUploadResultErrors tests rejected engine result uploads
+func TestUploadResultErrors(t *testing.T) {
+	router, _ := newResultRouter(t)
+
+	tests := []struct {
+		name    string
+		project string
+		body    string
+		status  int
+	}{
+		{"missing result", "project-1", `{"commit":"abc123"}`, http.StatusBadRequest},
+		{"result not an object", "project-1", `{"result":[1,2]}`, http.StatusBadRequest},
+		{"invalid JSON", "project-1", `{"result":`, http.StatusBadRequest},
+		{"unknown project", "project-2", `{"result":{"healthScore":72}}`, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/projects/"+tt.project+"/results", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+		})
+	}
+}
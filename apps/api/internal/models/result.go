@@ -0,0 +1,35 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/monoguard/api/internal/utils"
+	"gorm.io/gorm"
+)
+
+// EngineResult is an analysis result produced by the MonoGuard analysis
+// engine outside the server, e.g. uploaded by the CLI. The result is stored
+// as submitted; the health score and package count are kept for listings.
+type EngineResult struct {
+	ID          string          `json:"id" gorm:"primaryKey"`
+	ProjectID   string          `json:"projectId" gorm:"column:project_id;not null;index"`
+	Commit      string          `json:"commit,omitempty" gorm:"column:commit_sha"`
+	HealthScore int             `json:"healthScore" gorm:"column:health_score"`
+	Packages    int             `json:"packages" gorm:"column:packages"`
+	Result      json.RawMessage `json:"result,omitempty" gorm:"type:jsonb"`
+	CreatedAt   time.Time       `json:"createdAt" gorm:"column:created_at"`
+}
+
+// BeforeCreate generates a UUID for the engine result
+func (r *EngineResult) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = utils.GenerateUUID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the EngineResult model
+func (EngineResult) TableName() string {
+	return "engine_results"
+}
@@ -199,4 +199,45 @@ func (r *AnalysisRepository) UpdateHealthScore(ctx context.Context, id string, u
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// CreateEngineResult stores an analysis engine result
+func (r *AnalysisRepository) CreateEngineResult(ctx context.Context, result *models.EngineResult) error {
+	if err := r.db.WithContext(ctx).Create(result).Error; err != nil {
+		return fmt.Errorf("failed to create engine result: %w", err)
+	}
+	return nil
+}
+
+// GetEngineResultByID gets an analysis engine result by ID
+func (r *AnalysisRepository) GetEngineResultByID(ctx context.Context, id string) (*models.EngineResult, error) {
+	var result models.EngineResult
+	err := r.db.WithContext(ctx).First(&result, "id = ?", id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get engine result: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetLatestEngineResult gets the most recently stored analysis engine result of a project
+func (r *AnalysisRepository) GetLatestEngineResult(ctx context.Context, projectID string) (*models.EngineResult, error) {
+	var result models.EngineResult
+	err := r.db.WithContext(ctx).
+		Where("project_id = ?", projectID).
+		Order("created_at DESC").
+		First(&result).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get latest engine result: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/monoguard/api/internal/models"
+	"github.com/monoguard/api/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB opens an in-memory SQLite database with the engine result table
+func newTestDB(t *testing.T) *database.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.EngineResult{}))
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) // each connection opens its own in-memory database
+	t.Cleanup(func() { sqlDB.Close() })
+
+	return &database.DB{DB: db}
+}
+
+// TestEngineResults tests storing and reading analysis engine results
+func TestEngineResults(t *testing.T) {
+	repo := NewAnalysisRepository(newTestDB(t))
+	ctx := context.Background()
+
+	first := &models.EngineResult{
+		ProjectID:   "project-1",
+		Commit:      "abc123",
+		HealthScore: 80,
+		Packages:    3,
+		Result:      json.RawMessage(`{"healthScore":80,"packages":3}`),
+		CreatedAt:   time.Now().Add(-time.Hour),
+	}
+	require.NoError(t, repo.CreateEngineResult(ctx, first))
+	assert.NotEmpty(t, first.ID, "Should generate an ID")
+
+	latest := &models.EngineResult{
+		ProjectID:   "project-1",
+		HealthScore: 90,
+		Packages:    4,
+		Result:      json.RawMessage(`{"healthScore":90,"packages":4}`),
+	}
+	require.NoError(t, repo.CreateEngineResult(ctx, latest))
+
+	got, err := repo.GetEngineResultByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "project-1", got.ProjectID)
+	assert.Equal(t, "abc123", got.Commit)
+	assert.Equal(t, 80, got.HealthScore)
+	assert.JSONEq(t, `{"healthScore":80,"packages":3}`, string(got.Result), "Should store the result as submitted")
+
+	got, err = repo.GetLatestEngineResult(ctx, "project-1")
+	require.NoError(t, err)
+	assert.Equal(t, latest.ID, got.ID)
+	assert.Equal(t, 90, got.HealthScore)
+
+	_, err = repo.GetEngineResultByID(ctx, "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = repo.GetLatestEngineResult(ctx, "project-2")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -112,6 +112,7 @@ func (db *DB) AutoMigrate() error {
 		&models.FileProcessingResult{},
 		&models.UploadedFile{},
 		&models.PackageJsonFile{},
+		&models.EngineResult{},
 	)
 	
 	if err != nil {
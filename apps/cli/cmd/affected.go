@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/affected"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/git"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/output"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	affectedBase  string
+	affectedStdin bool
+)
+
+var affectedCmd = &cobra.Command{
+	Use:   "affected [path]",
+	Short: "List the packages affected by changed files",
+	Long: `Map changed files to the workspace packages containing them, add
+every package that transitively depends on one of those, and print
+the result in topological order (dependencies before dependents).
+
+The changed files are either taken from git with --base, as the
+changes since the merge base of the ref and HEAD including
+uncommitted and untracked files, or read from stdin with --stdin,
+one path per line, relative to the workspace root:
+
+  monoguard affected --base origin/main
+  git diff --name-only HEAD~1 | monoguard affected --stdin
+
+Use --format json to drive selective builds and tests, e.g.
+  monoguard affected --base origin/main --format json | jq -r '.packages[].name'`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+		if (affectedBase == "") == !affectedStdin {
+			return fmt.Errorf("exactly one of --base or --stdin is required")
+		}
+
+		var files []string
+		var err error
+		if affectedStdin {
+			files, err = readFileList(cmd.InOrStdin(), path)
+		} else {
+			files, err = gitChangedFiles(path, affectedBase)
+		}
+		if err != nil {
+			return err
+		}
+
+		snap, err := workspace.Scan(path)
+		if err != nil {
+			return err
+		}
+		ws, err := analysis.Parse(snap)
+		if err != nil {
+			return err
+		}
+		result, err := affected.Compute(ws, files)
+		if err != nil {
+			return err
+		}
+		result.Base = affectedBase
+
+		return output.NewFormatter(viper.GetString("format")).PrintTo(cmd.OutOrStdout(), result)
+	},
+}
+
+// gitChangedFiles returns the files changed since ref, relative to the workspace
+func gitChangedFiles(workspaceDir, ref string) ([]string, error) {
+	repo, err := git.Open(workspaceDir)
+	if err != nil {
+		return nil, err
+	}
+	return repo.ChangedFiles(workspaceDir, ref)
+}
+
+// readFileList reads one path per line. Absolute paths are made relative to
+// the workspace.
+func readFileList(r io.Reader, workspaceDir string) ([]string, error) {
+	root, err := filepath.Abs(workspaceDir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		f := strings.TrimSpace(scanner.Text())
+		if f == "" {
+			continue
+		}
+		if filepath.IsAbs(f) {
+			if rel, err := filepath.Rel(root, f); err == nil {
+				f = rel
+			}
+		}
+		files = append(files, filepath.ToSlash(f))
+	}
+	return files, scanner.Err()
+}
+
+func init() {
+	// Command registration is handled by root.go registerCommands()
+	// Local flags are registered here
+	affectedCmd.Flags().StringVar(&affectedBase, "base", "",
+		"git ref to compare against, e.g. origin/main")
+	affectedCmd.Flags().BoolVar(&affectedStdin, "stdin", false,
+		"read changed files from stdin, one per line")
+}
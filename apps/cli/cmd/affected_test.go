@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestAffectedCommandRegistered verifies affected command is registered
+func TestAffectedCommandRegistered(t *testing.T) {
+	if findCommand("affected") == nil {
+		t.Error("affected command not registered on rootCmd")
+	}
+}
+
+// affectedNames runs affected with --format json and returns the package names
+func affectedNames(t *testing.T, args ...string) []string {
+	t.Helper()
+	out, err := runCommand(t, append([]string{"affected", "--format", "json"}, args...)...)
+	if err != nil {
+		t.Fatalf("Execute() error = %v\n%s", err, out)
+	}
+	var result struct {
+		Packages []struct {
+			Name string `json:"name"`
+		} `json:"packages"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	names := []string{}
+	for _, p := range result.Packages {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// TestAffectedCommandStdin verifies changed files on stdin expand to dependents
+func TestAffectedCommandStdin(t *testing.T) {
+	root := writeWorkspace(t, layeredWorkspace)
+	rootCmd.SetIn(strings.NewReader("libs/ui/src/button.ts\n\n" + filepath.Join(root, "libs/ui/package.json") + "\n"))
+	t.Cleanup(func() { rootCmd.SetIn(nil) })
+
+	got := affectedNames(t, root, "--stdin")
+	want := []string{"@mono/ui", "@mono/web", "@mono/example-basic"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("affected = %v, want %v", got, want)
+	}
+}
+
+// TestAffectedCommandGitBase verifies changes since a git ref are used
+func TestAffectedCommandGitBase(t *testing.T) {
+	root := gitWorkspace(t, layeredWorkspace)
+	writeFiles(t, root, map[string]string{"apps/web/src/index.ts": "export {};\n"})
+
+	got := affectedNames(t, root, "--base", "HEAD")
+	want := []string{"@mono/web", "@mono/example-basic"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("affected = %v, want %v", got, want)
+	}
+}
+
+// TestAffectedCommandRequiresSource verifies exactly one file source is given
+func TestAffectedCommandRequiresSource(t *testing.T) {
+	root := writeWorkspace(t, layeredWorkspace)
+	for _, args := range [][]string{
+		{"affected", root},
+		{"affected", root, "--stdin", "--base", "HEAD"},
+	} {
+		if _, err := runCommand(t, args...); err == nil || !strings.Contains(err.Error(), "exactly one of --base or --stdin") {
+			t.Errorf("%v: error = %v", args, err)
+		}
+	}
+}
@@ -1,48 +1,192 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"time"
 
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/api"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/cache"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/git"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/history"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/output"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/plugin"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/analyzer"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-// analyzeOutput represents the JSON output structure for analyze command
-type analyzeOutput struct {
-	Status  string `json:"status"`
-	Path    string `json:"path"`
-	Message string `json:"message"`
-}
+var (
+	maxLength      int
+	analyzeNoCache bool
+	analyzeRecord  bool
+	analyzeUpload  bool
+	analyzeProject string
+)
 
 var analyzeCmd = &cobra.Command{
 	Use:   "analyze [path]",
 	Short: "Analyze monorepo dependencies",
 	Long: `Analyze the dependency structure of a monorepo and generate
 a comprehensive report including circular dependencies, health score,
-and fix suggestions.`,
-	Args: cobra.MaximumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+and fix suggestions.
+
+Plugins and custom rules from .monoguard.yaml are included, and results
+are cached in .monoguard/cache. See docs/cli.md for the cache, history,
+uploads and the plugin protocol.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		path := "."
 		if len(args) > 0 {
 			path = args[0]
 		}
+		if maxLength < 0 {
+			return fmt.Errorf("--max-length must not be negative")
+		}
+		if analyzeProject != "" && !analyzeUpload {
+			return fmt.Errorf("--project requires --upload")
+		}
+
+		snap, err := workspace.Scan(path)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("verbose") {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Scanned %s: %d manifest files, %d source files\n",
+				snap.Root, len(snap.Files), len(snap.SourceFiles))
+		}
 
-		format := viper.GetString("format")
-		if format == "json" {
-			output := analyzeOutput{
-				Status:  "placeholder",
-				Path:    path,
-				Message: "Analysis will be implemented in Epic 2",
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		var client *api.Client
+		var project string
+		if analyzeUpload {
+			if client, project, err = serverClient(cfg, analyzeProject); err != nil {
+				return err
+			}
+		}
+
+		analysisConfig := cfg.AnalysisConfig()
+		result, workspaceData, err := runAnalysis(cmd, snap, analysisConfig, analyzeNoCache)
+		if err != nil {
+			return err
+		}
+		if err := runPlugins(cmd, snap, workspaceData, cfg, analysisConfig, result); err != nil {
+			return err
+		}
+		if analyzeRecord {
+			if err := recordHistory(snap.Root, result); err != nil {
+				return fmt.Errorf("failed to record history: %w", err)
 			}
-			jsonBytes, _ := json.Marshal(output)
-			fmt.Fprintln(cmd.OutOrStdout(), string(jsonBytes))
-		} else {
-			fmt.Fprintf(cmd.OutOrStdout(), "🔍 MonoGuard Analysis (Placeholder)\n")
-			fmt.Fprintf(cmd.OutOrStdout(), "   Path: %s\n", path)
-			fmt.Fprintf(cmd.OutOrStdout(), "   Status: Will be implemented in Epic 2\n")
 		}
+
+		formatter := output.NewFormatter(viper.GetString("format"))
+		formatter.MaxLength = maxLength
+		if err := formatter.PrintTo(cmd.OutOrStdout(), result); err != nil {
+			return err
+		}
+
+		if client != nil {
+			stored, err := client.UploadResult(cmd.Context(), project, headCommit(snap.Root), result)
+			if err != nil {
+				return fmt.Errorf("failed to upload analysis: %w", err)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Uploaded analysis: %s\n", client.ResultURL(stored.ID))
+		}
+		return nil
 	},
 }
 
-// Command registration is handled by root.go registerCommands()
+func init() {
+	// Command registration is handled by root.go registerCommands()
+	// Local flags are registered here
+	analyzeCmd.Flags().IntVar(&maxLength, "max-length", 0,
+		fmt.Sprintf("maximum size in bytes of markdown output, 0 for no limit (GitHub comments allow %d)", output.GitHubCommentLimit))
+	analyzeCmd.Flags().BoolVar(&analyzeNoCache, "no-cache", false,
+		"analyze without reading or writing the analysis cache")
+	analyzeCmd.Flags().BoolVar(&analyzeRecord, "record", false,
+		"append a summary of the run to .monoguard/history.jsonl")
+	analyzeCmd.Flags().BoolVar(&analyzeUpload, "upload", false,
+		"upload the result to the MonoGuard API server")
+	analyzeCmd.Flags().StringVar(&analyzeProject, "project", "",
+		"server project ID for --upload (default server.project from the configuration)")
+}
+
+// recordHistory appends a summary of result to the history file of the
+// workspace at root, with the current git commit when root is in a repository
+func recordHistory(root string, result *types.AnalysisResult) error {
+	record := history.FromResult(result, headCommit(root), time.Now())
+	return history.Append(filepath.Join(root, history.DefaultFile), record)
+}
+
+// headCommit returns the git HEAD commit of the repository containing root,
+// or "" outside a repository
+func headCommit(root string) string {
+	commit := ""
+	if repo, err := git.Open(root); err == nil {
+		commit, _ = repo.ResolveCommit("HEAD")
+	}
+	return commit
+}
+
+// runAnalysis analyzes the snapshot, reusing the analysis cache in the
+// workspace root unless noCache is set, and returns the result with the
+// workspace data it was computed from. Cache statistics are reported with
+// --verbose; a cache that cannot be written only produces a warning.
+func runAnalysis(cmd *cobra.Command, snap *workspace.Snapshot, config *types.AnalysisConfig, noCache bool) (*types.AnalysisResult, *types.WorkspaceData, error) {
+	if noCache {
+		return analysis.RunWorkspace(snap, config)
+	}
+	result, workspaceData, stats, err := analysis.RunCached(snap, config, filepath.Join(snap.Root, cache.DefaultDir))
+	if err != nil {
+		return nil, nil, err
+	}
+	if stats.SaveErr != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to write analysis cache: %v\n", stats.SaveErr)
+	}
+	if viper.GetBool("verbose") {
+		graph := "rebuilt"
+		if stats.Graph {
+			graph = "reused"
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Cache: graph %s, imports of %d source files reused, %d parsed\n",
+			graph, stats.ReusedSources, stats.ParsedSources)
+	}
+	return result, workspaceData, nil
+}
+
+// runPlugins runs the plugins of the configuration on the analyzed snapshot
+// and its workspace data and merges their findings into result. A failed
+// plugin only produces a warning.
+func runPlugins(cmd *cobra.Command, snap *workspace.Snapshot, workspaceData *types.WorkspaceData, cfg *config.Config, analysisConfig *types.AnalysisConfig, result *types.AnalysisResult) error {
+	if len(cfg.Plugins) == 0 {
+		return nil
+	}
+	plugins, err := plugin.FromConfig(cfg.Plugins)
+	if err != nil {
+		return err
+	}
+
+	runs := plugin.RunAll(cmd.Context(), plugins, &plugin.Input{
+		Root:      snap.Root,
+		Workspace: workspaceData,
+		Graph:     result.Graph,
+		Config:    analysisConfig,
+	})
+	for _, run := range runs {
+		if run.Error != "" {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: plugin %s failed: %s\n", run.Plugin, run.Error)
+		} else if viper.GetBool("verbose") {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Plugin %s: %d findings\n", run.Plugin, len(run.Findings))
+		}
+	}
+	analyzer.ApplyPluginResults(result, runs, analysisConfig.HealthWeights)
+	return nil
+}
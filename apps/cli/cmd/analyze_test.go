@@ -5,9 +5,13 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
 	"github.com/spf13/cobra"
 )
 
@@ -41,14 +45,14 @@ func TestAnalyzeCommandUsage(t *testing.T) {
 }
 
 // TestAnalyzeCommandTextOutput verifies text output format
-// AC6: ./monoguard analyze outputs placeholder message
 func TestAnalyzeCommandTextOutput(t *testing.T) {
 	ResetForTesting()
+	root := writeWorkspace(t, cycleWorkspace)
 
 	buf := new(bytes.Buffer)
 	rootCmd.SetOut(buf)
 	rootCmd.SetErr(buf)
-	rootCmd.SetArgs([]string{"analyze", "--format", "text"})
+	rootCmd.SetArgs([]string{"analyze", root, "--format", "text"})
 
 	err := rootCmd.Execute()
 	if err != nil {
@@ -57,28 +61,28 @@ func TestAnalyzeCommandTextOutput(t *testing.T) {
 
 	output := buf.String()
 
-	// Should contain placeholder indicator
-	if !strings.Contains(strings.ToLower(output), "placeholder") &&
-		!strings.Contains(strings.ToLower(output), "epic 2") {
-		t.Errorf("Text output should mention placeholder or Epic 2: %q", output)
+	wantContains := []string{
+		"MonoGuard Analysis",
+		"Health Score:",
+		"Circular Dependencies (1)",
+		"@mono/a → @mono/b → @mono/a",
 	}
-
-	// Should mention analysis
-	if !strings.Contains(strings.ToLower(output), "analysis") &&
-		!strings.Contains(strings.ToLower(output), "analyze") {
-		t.Errorf("Text output should mention analysis: %q", output)
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("Text output missing %q: %q", want, output)
+		}
 	}
 }
 
 // TestAnalyzeCommandJSONOutput verifies JSON output format
-// AC6: ./monoguard analyze --format json outputs valid JSON
 func TestAnalyzeCommandJSONOutput(t *testing.T) {
 	ResetForTesting()
+	root := writeWorkspace(t, cycleWorkspace)
 
 	buf := new(bytes.Buffer)
 	rootCmd.SetOut(buf)
 	rootCmd.SetErr(buf)
-	rootCmd.SetArgs([]string{"analyze", "--format", "json"})
+	rootCmd.SetArgs([]string{"analyze", root, "--format", "json"})
 
 	err := rootCmd.Execute()
 	if err != nil {
@@ -87,71 +91,151 @@ func TestAnalyzeCommandJSONOutput(t *testing.T) {
 
 	output := buf.String()
 
-	// Must be valid JSON
-	var parsed map[string]interface{}
+	// Must be a valid AnalysisResult
+	var parsed types.AnalysisResult
 	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
 		t.Fatalf("Output is not valid JSON: %v\nOutput: %s", err, output)
 	}
 
-	// Must contain status field
-	if _, ok := parsed["status"]; !ok {
-		t.Error("JSON output should contain 'status' field")
+	if parsed.Packages != 2 {
+		t.Errorf("packages = %d, want 2", parsed.Packages)
 	}
-
-	// Must contain message field
-	if _, ok := parsed["message"]; !ok {
-		t.Error("JSON output should contain 'message' field")
+	if len(parsed.CircularDependencies) != 1 {
+		t.Fatalf("circularDependencies = %d, want 1", len(parsed.CircularDependencies))
+	}
+	if len(parsed.CircularDependencies[0].ImportTraces) == 0 {
+		t.Error("circular dependency should include import traces from source files")
+	}
+	if parsed.Graph == nil || parsed.Graph.RootPath != root {
+		t.Errorf("graph.rootPath should be %q", root)
 	}
 }
 
 // TestAnalyzeCommandWithPath verifies path argument handling
-// AC6: analyze accepts optional path argument
 func TestAnalyzeCommandWithPath(t *testing.T) {
-	tests := []struct {
-		name     string
-		args     []string
-		wantPath string
-	}{
-		{
-			name:     "no path defaults to current dir",
-			args:     []string{"analyze"},
-			wantPath: ".",
-		},
-		{
-			name:     "explicit path",
-			args:     []string{"analyze", "/some/path"},
-			wantPath: "/some/path",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ResetForTesting()
-
-			buf := new(bytes.Buffer)
-			rootCmd.SetOut(buf)
-			rootCmd.SetErr(buf)
-			rootCmd.SetArgs(append(tt.args, "--format", "json"))
-
-			err := rootCmd.Execute()
-			if err != nil {
-				t.Fatalf("Execute() error = %v", err)
-			}
-
-			output := buf.String()
-
-			var parsed map[string]interface{}
-			if err := json.Unmarshal([]byte(output), &parsed); err != nil {
-				t.Fatalf("Output is not valid JSON: %v", err)
-			}
-
-			if path, ok := parsed["path"].(string); ok {
-				if path != tt.wantPath {
-					t.Errorf("path = %q, want %q", path, tt.wantPath)
-				}
-			}
-		})
+	t.Run("no path defaults to current dir", func(t *testing.T) {
+		ResetForTesting()
+		root := writeWorkspace(t, cycleWorkspace)
+		t.Chdir(root)
+
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetErr(buf)
+		rootCmd.SetArgs([]string{"analyze", "--format", "json"})
+
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if !strings.Contains(buf.String(), `"packages": 2`) {
+			t.Errorf("expected current directory to be analyzed: %s", buf.String())
+		}
+	})
+
+	t.Run("missing path returns error", func(t *testing.T) {
+		ResetForTesting()
+
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetErr(buf)
+		rootCmd.SetArgs([]string{"analyze", filepath.Join(t.TempDir(), "missing")})
+
+		if err := rootCmd.Execute(); err == nil {
+			t.Error("Execute() should fail for a missing path")
+		}
+	})
+
+	t.Run("directory without package.json returns error", func(t *testing.T) {
+		ResetForTesting()
+
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetErr(buf)
+		rootCmd.SetArgs([]string{"analyze", t.TempDir()})
+
+		err := rootCmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "package.json") {
+			t.Errorf("Execute() error = %v, want missing package.json error", err)
+		}
+	})
+}
+
+// TestAnalyzeCommandHonoursIgnores verifies node_modules and .gitignore are skipped
+func TestAnalyzeCommandHonoursIgnores(t *testing.T) {
+	ResetForTesting()
+	files := map[string]string{
+		".gitignore":                        "packages/legacy/\n",
+		"packages/legacy/package.json":      `{"name": "@mono/legacy", "dependencies": {"@mono/a": "workspace:*"}}`,
+		"node_modules/@mono/c/package.json": `{"name": "@mono/c"}`,
+	}
+	for k, v := range cycleWorkspace {
+		files[k] = v
+	}
+	root := writeWorkspace(t, files)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"analyze", root, "--format", "json"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	var parsed types.AnalysisResult
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
 	}
+	if _, ok := parsed.Graph.Nodes["@mono/legacy"]; ok {
+		t.Error("package ignored by .gitignore should not be analyzed")
+	}
+	if parsed.Packages != 2 {
+		t.Errorf("packages = %d, want 2", parsed.Packages)
+	}
+}
+
+// cycleWorkspace is a pnpm workspace where @mono/a and @mono/b depend on each other.
+var cycleWorkspace = map[string]string{
+	"package.json":            `{"name": "root", "private": true}`,
+	"pnpm-workspace.yaml":     "packages:\n  - 'packages/*'\n",
+	"pnpm-lock.yaml":          "",
+	"packages/a/package.json": `{"name": "@mono/a", "version": "1.0.0", "dependencies": {"@mono/b": "workspace:*"}}`,
+	"packages/a/src/index.ts": "import { b } from '@mono/b';\nexport const a = b;\n",
+	"packages/b/package.json": `{"name": "@mono/b", "version": "1.0.0", "dependencies": {"@mono/a": "workspace:*"}}`,
+	"packages/b/src/index.ts": "import { a } from '@mono/a';\nexport const b = 1;\n",
+}
+
+// writeWorkspace creates a temporary workspace from a map of relative path to content
+func writeWorkspace(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	writeFiles(t, root, files)
+	return root
+}
+
+// writeFiles writes a map of relative path to content below root
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// runCommand executes rootCmd with args and returns its output
+func runCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	ResetForTesting()
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	return buf.String(), err
 }
 
 // Helper function to find a command by name
@@ -163,3 +247,156 @@ func findCommand(name string) *cobra.Command {
 	}
 	return nil
 }
+
+// TestAnalyzeCommandSARIFOutput verifies --format sarif produces a SARIF log
+func TestAnalyzeCommandSARIFOutput(t *testing.T) {
+	ResetForTesting()
+	root := writeWorkspace(t, cycleWorkspace)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"analyze", root, "--format", "sarif"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	var parsed struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID string `json:"ruleId"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\nOutput: %s", err, buf.String())
+	}
+	if parsed.Version != "2.1.0" || len(parsed.Runs) != 1 {
+		t.Fatalf("not a SARIF 2.1.0 log: %s", buf.String())
+	}
+
+	results := parsed.Runs[0].Results
+	if len(results) != 1 || results[0].RuleID != "circular-dependency" {
+		t.Errorf("results = %+v, want one circular-dependency result", results)
+	}
+}
+
+func TestAnalyzeCommandMarkdownOutput(t *testing.T) {
+	ResetForTesting()
+	root := writeWorkspace(t, cycleWorkspace)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"analyze", root, "--format", "markdown"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"## 🔍 MonoGuard Report", "| Circular Dependencies | 1 |", "<details>"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("markdown output missing %q\n%s", want, output)
+		}
+	}
+
+	// A limit below the full report condenses the cycle to one line
+	ResetForTesting()
+	bounded := new(bytes.Buffer)
+	rootCmd.SetOut(bounded)
+	rootCmd.SetErr(bounded)
+	rootCmd.SetArgs([]string{"analyze", root, "--format", "markdown", "--max-length", strconv.Itoa(len(output) - 1)})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if bounded.Len() >= len(output) || strings.Contains(bounded.String(), "<details>") {
+		t.Errorf("--max-length %d not applied\n%s", len(output)-1, bounded.String())
+	}
+}
+
+func TestAnalyzeCommandNegativeMaxLength(t *testing.T) {
+	ResetForTesting()
+	root := writeWorkspace(t, cycleWorkspace)
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"analyze", root, "--max-length", "-1"})
+
+	if err := rootCmd.Execute(); err == nil {
+		t.Error("Execute() should fail for a negative --max-length")
+	}
+}
+
+// TestAnalyzeCommandCache verifies the analysis cache is written, reused and
+// skipped with --no-cache
+func TestAnalyzeCommandCache(t *testing.T) {
+	root := writeWorkspace(t, cycleWorkspace)
+	cacheDir := filepath.Join(root, ".monoguard", "cache")
+
+	if _, err := runCommand(t, "analyze", root, "--no-cache"); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if _, err := os.Stat(cacheDir); !os.IsNotExist(err) {
+		t.Errorf("--no-cache should not write %s (stat error %v)", cacheDir, err)
+	}
+
+	out, err := runCommand(t, "analyze", root, "--verbose")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(out, "Cache: graph rebuilt, imports of 0 source files reused, 2 parsed") {
+		t.Errorf("first run output missing cache statistics:\n%s", out)
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "analysis.json")); err != nil {
+		t.Errorf("analysis cache not written: %v", err)
+	}
+
+	out, err = runCommand(t, "analyze", root, "--verbose")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(out, "Cache: graph reused, imports of 2 source files reused, 0 parsed") {
+		t.Errorf("second run output missing cache reuse:\n%s", out)
+	}
+	if !strings.Contains(out, "Circular") {
+		t.Errorf("cached run lost the circular dependency:\n%s", out)
+	}
+}
+
+// TestAnalyzeCommandPlugins verifies plugin findings are merged into the
+// result and the health score, using the cached analysis
+func TestAnalyzeCommandPlugins(t *testing.T) {
+	root := writeWorkspace(t, cleanWorkspace)
+	writeFiles(t, root, map[string]string{
+		".monoguard.yaml": "plugins:\n  - name: lint\n    command: ./tools/lint.sh\n    options:\n      minLevel: warning\n",
+		// The plugin reports the minLevel option it received, key case included
+		"tools/lint.sh": "#!/bin/sh\nlevel=$(sed -n 's/.*\"options\":{\"minLevel\":\"\\([a-z]*\\)\"}.*/\\1/p')\n" +
+			"echo \"{\\\"findings\\\": [{\\\"rule\\\": \\\"r\\\", \\\"severity\\\": \\\"$level\\\", \\\"message\\\": \\\"m\\\"}]}\"\n",
+	})
+	if err := os.Chmod(filepath.Join(root, "tools/lint.sh"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(root)
+
+	for run := 0; run < 2; run++ {
+		out, err := runCommand(t, "analyze", "--format", "json")
+		if err != nil {
+			t.Fatalf("Execute() error = %v\n%s", err, out)
+		}
+		var result types.AnalysisResult
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("Output is not valid JSON: %v\nOutput: %s", err, out)
+		}
+		if len(result.PluginFindings) != 1 || result.PluginFindings[0].Severity != types.PluginSeverityWarning {
+			t.Errorf("run %d: pluginFindings = %+v, want one warning", run, result.PluginFindings)
+		}
+		factors := result.HealthScoreDetails.Factors
+		if last := factors[len(factors)-1]; last.Name != "Plugin Findings" || last.Score != 95 {
+			t.Errorf("run %d: last factor = %+v, want Plugin Findings scored 95", run, last)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/baseline"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/output"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var baselineOut string
+
+var baselineCmd = &cobra.Command{
+	Use:   "baseline [path]",
+	Short: "Record known issues so check only fails on new ones",
+	Long: `Analyze the monorepo and record its current circular dependencies,
+boundary violations, version conflicts, plugin findings and custom rule
+violations in a baseline file (default: .monoguard-baseline.json in the
+workspace root).
+
+Commit the file. "monoguard check" then ignores the recorded issues
+and only fails on issues introduced afterwards. Issues are matched by
+stable IDs: cycles by their set of packages, boundary violations by the
+dependency, version conflicts by package name, plugin findings by plugin,
+rule, package, file and message, and custom rule violations by rule,
+package and dependency.
+
+check also lists baseline entries that no longer occur; run baseline
+again to remove them from the file.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		snap, err := workspace.Scan(path)
+		if err != nil {
+			return err
+		}
+		analysisConfig := cfg.AnalysisConfig()
+		result, workspaceData, err := analysis.RunWorkspace(snap, analysisConfig)
+		if err != nil {
+			return err
+		}
+		if err := runPlugins(cmd, snap, workspaceData, cfg, analysisConfig, result); err != nil {
+			return err
+		}
+
+		target := baselineOut
+		if target == "" {
+			target = filepath.Join(path, baseline.DefaultFile)
+		}
+		known := baseline.FromResult(result)
+		if err := known.Save(target); err != nil {
+			return err
+		}
+		return output.NewFormatter(viper.GetString("format")).PrintTo(cmd.OutOrStdout(),
+			fmt.Sprintf("✅ Baseline written to %s (%d known issues)", target, known.Len()))
+	},
+}
+
+func init() {
+	// Command registration is handled by root.go registerCommands()
+	// Local flags are registered here
+	baselineCmd.Flags().StringVar(&baselineOut, "output", "",
+		"baseline file (default is .monoguard-baseline.json in the workspace)")
+}
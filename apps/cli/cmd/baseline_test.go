@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/baseline"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// TestBaselineCommandRegistered verifies baseline command is registered
+func TestBaselineCommandRegistered(t *testing.T) {
+	if findCommand("baseline") == nil {
+		t.Error("baseline command not registered on rootCmd")
+	}
+}
+
+// TestBaselineCommandWritesFile verifies the known cycle is recorded
+func TestBaselineCommandWritesFile(t *testing.T) {
+	root := writeWorkspace(t, cycleWorkspace)
+
+	out, err := runCommand(t, "baseline", root)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	target := filepath.Join(root, baseline.DefaultFile)
+	if !strings.Contains(out, "Baseline written to "+target+" (1 known issues)") {
+		t.Errorf("unexpected output: %q", out)
+	}
+
+	known, err := baseline.Load(target)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(known.CircularDependencies) != 1 || known.CircularDependencies[0].ID != "cycle:@mono/a,@mono/b" {
+		t.Errorf("CircularDependencies = %+v", known.CircularDependencies)
+	}
+}
+
+// TestCheckCommandBaseline verifies check ignores baselined issues and
+// fails on new ones
+func TestCheckCommandBaseline(t *testing.T) {
+	root := writeWorkspace(t, cycleWorkspace)
+	if _, err := runCommand(t, "baseline", root); err != nil {
+		t.Fatalf("baseline error = %v", err)
+	}
+
+	t.Run("known cycle passes", func(t *testing.T) {
+		out, err := runCommand(t, "check", root, "--format", "json")
+		if err != nil {
+			t.Fatalf("Execute() error = %v\n%s", err, out)
+		}
+		var check types.CheckResult
+		if err := json.Unmarshal([]byte(out), &check); err != nil {
+			t.Fatalf("output is not JSON: %v", err)
+		}
+		if !check.Passed || check.Baseline == nil || check.Baseline.Known != 1 {
+			t.Errorf("check = %+v, baseline = %+v", check, check.Baseline)
+		}
+	})
+
+	t.Run("new cycle fails", func(t *testing.T) {
+		files := map[string]string{
+			"packages/c/package.json": `{"name": "@mono/c", "version": "1.0.0", "dependencies": {"@mono/d": "workspace:*"}}`,
+			"packages/d/package.json": `{"name": "@mono/d", "version": "1.0.0", "dependencies": {"@mono/c": "workspace:*"}}`,
+		}
+		writeFiles(t, root, files)
+		t.Cleanup(func() {
+			os.RemoveAll(filepath.Join(root, "packages", "c"))
+			os.RemoveAll(filepath.Join(root, "packages", "d"))
+		})
+
+		out, err := runCommand(t, "check", root)
+		var exitErr *exitError
+		if !errors.As(err, &exitErr) || exitErr.code != 1 {
+			t.Errorf("Execute() error = %v, want exit code 1", err)
+		}
+		if !strings.Contains(out, "@mono/c -> @mono/d") || strings.Contains(out, "@mono/a -> @mono/b") {
+			t.Errorf("only the new cycle should be reported\n%s", out)
+		}
+	})
+}
+
+// TestCheckCommandBaselinePlugins verifies baselined plugin findings are
+// ignored by check, also after their line moved
+func TestCheckCommandBaselinePlugins(t *testing.T) {
+	finding := func(rule string, line int) string {
+		return fmt.Sprintf(`{"rule": %q, "severity": "error", "message": "banned", "file": "packages/a/index.ts", "line": %d}`, rule, line)
+	}
+	files := map[string]string{
+		".monoguard.yaml":     "plugins:\n  - name: lint\n    command: ./tools/lint.sh\n",
+		"tools/lint.sh":       "#!/bin/sh\ncat > /dev/null\ncat tools/findings.json\n",
+		"tools/findings.json": `{"findings": [` + finding("no-lodash", 3) + `]}`,
+	}
+	for k, v := range cleanWorkspace {
+		files[k] = v
+	}
+	root := writeWorkspace(t, files)
+	if err := os.Chmod(filepath.Join(root, "tools/lint.sh"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(root)
+
+	out, err := runCommand(t, "baseline")
+	if err != nil || !strings.Contains(out, "(1 known issues)") {
+		t.Fatalf("baseline error = %v\n%s", err, out)
+	}
+
+	writeFiles(t, root, map[string]string{"tools/findings.json": `{"findings": [` + finding("no-lodash", 12) + `]}`})
+	if out, err := runCommand(t, "check", "--no-cache"); err != nil {
+		t.Errorf("check with the known finding on another line error = %v\n%s", err, out)
+	}
+
+	writeFiles(t, root, map[string]string{"tools/findings.json": `{"findings": [` + finding("no-lodash", 12) + `, ` + finding("no-moment", 1) + `]}`})
+	out, err = runCommand(t, "check", "--no-cache")
+	var exitErr *exitError
+	if !errors.As(err, &exitErr) || exitErr.code != 1 {
+		t.Errorf("check with a new finding error = %v, want exit code 1\n%s", err, out)
+	}
+}
+
+// TestCheckCommandBaselineCustomRules verifies baselined custom rule
+// violations are ignored by check
+func TestCheckCommandBaselineCustomRules(t *testing.T) {
+	files := map[string]string{
+		".monoguard.yaml": "customRules:\n  - name: b-is-a-leaf\n    scope: dependency\n    forbid: 'to.name == \"@mono/b\"'\n",
+	}
+	for k, v := range cleanWorkspace {
+		files[k] = v
+	}
+	root := writeWorkspace(t, files)
+	t.Chdir(root)
+
+	if out, err := runCommand(t, "check"); err == nil {
+		t.Fatalf("check without a baseline passed, want the violation to fail it\n%s", out)
+	}
+	out, err := runCommand(t, "baseline")
+	if err != nil || !strings.Contains(out, "(1 known issues)") {
+		t.Fatalf("baseline error = %v\n%s", err, out)
+	}
+	if out, err := runCommand(t, "check"); err != nil {
+		t.Errorf("check with the known violation error = %v\n%s", err, out)
+	}
+
+	// A new dependent of @mono/b violates the rule again
+	writeFiles(t, root, map[string]string{
+		"packages/c/package.json": `{"name": "@mono/c", "version": "1.0.0", "dependencies": {"@mono/b": "workspace:*"}}`,
+	})
+	out, err = runCommand(t, "check")
+	var exitErr *exitError
+	if !errors.As(err, &exitErr) || exitErr.code != 1 || !strings.Contains(out, "@mono/c → @mono/b") {
+		t.Errorf("check with a new violation error = %v, want exit code 1 for @mono/c\n%s", err, out)
+	}
+}
+
+// TestCheckCommandBaselineFixed verifies fixed baseline entries are listed
+func TestCheckCommandBaselineFixed(t *testing.T) {
+	cyclic := writeWorkspace(t, cycleWorkspace)
+	file := filepath.Join(t.TempDir(), "baseline.json")
+	if _, err := runCommand(t, "baseline", cyclic, "--output", file); err != nil {
+		t.Fatalf("baseline error = %v", err)
+	}
+
+	clean := writeWorkspace(t, cleanWorkspace)
+	out, err := runCommand(t, "check", clean, "--baseline", file)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{"Baseline: 0 known issues ignored", "✓ circular dependency @mono/a → @mono/b → @mono/a"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+}
+
+// TestCheckCommandBaselineMissing verifies an explicit baseline must exist
+func TestCheckCommandBaselineMissing(t *testing.T) {
+	root := writeWorkspace(t, cleanWorkspace)
+	if _, err := runCommand(t, "check", root, "--baseline", filepath.Join(root, "missing.json")); err == nil {
+		t.Error("Execute() error = nil, want error for missing baseline")
+	}
+}
@@ -1,24 +1,26 @@
 package cmd
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/baseline"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/output"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/analyzer"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-// checkOutput represents the JSON output structure for check command
-type checkOutput struct {
-	Status  string `json:"status"`
-	Path    string `json:"path"`
-	Passed  bool   `json:"passed"`
-	Message string `json:"message"`
-}
-
 var (
-	failOn    string
-	threshold int
+	failOn       string
+	threshold    int
+	baselineFile string
+	checkNoCache bool
 )
 
 var checkCmd = &cobra.Command{
@@ -26,38 +28,146 @@ var checkCmd = &cobra.Command{
 	Short: "Validate dependencies for CI/CD",
 	Long: `Run validation checks on the monorepo dependencies.
 Returns exit code 0 on success, 1 on failure.
-Designed for CI/CD integration.`,
-	Args: cobra.MaximumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+Designed for CI/CD integration.
+
+Rules, thresholds and custom rules are read from .monoguard.yaml and the
+package configurations below it; issues recorded by "monoguard baseline"
+are ignored. See docs/cli.md for the configuration reference.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		path := "."
 		if len(args) > 0 {
 			path = args[0]
 		}
 
-		format := viper.GetString("format")
-		if format == "json" {
-			output := checkOutput{
-				Status:  "placeholder",
-				Path:    path,
-				Passed:  true,
-				Message: "Check will be implemented in Epic 2",
-			}
-			jsonBytes, _ := json.Marshal(output)
-			fmt.Fprintln(cmd.OutOrStdout(), string(jsonBytes))
-		} else {
-			fmt.Fprintf(cmd.OutOrStdout(), "✅ MonoGuard Check (Placeholder)\n")
-			fmt.Fprintf(cmd.OutOrStdout(), "   Path: %s\n", path)
-			fmt.Fprintf(cmd.OutOrStdout(), "   Status: Passed (placeholder)\n")
-		}
-		// Exit code 0 is default when no error is returned
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		snap, err := workspace.Scan(path)
+		if err != nil {
+			return err
+		}
+		analysisConfig := cfg.AnalysisConfig()
+		overrides, warnings, err := config.Overrides(snap)
+		if err != nil {
+			return err
+		}
+		for _, warning := range warnings {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", warning)
+		}
+		analysisConfig.Overrides = overrides
+		if err := applyCheckFlags(analysisConfig); err != nil {
+			return err
+		}
+
+		result, workspaceData, err := runAnalysis(cmd, snap, analysisConfig, checkNoCache)
+		if err != nil {
+			return err
+		}
+		if err := runPlugins(cmd, snap, workspaceData, cfg, analysisConfig, result); err != nil {
+			return err
+		}
+
+		known, knownFile, err := loadCheckBaseline(path)
+		if err != nil {
+			return err
+		}
+		var status *types.BaselineStatus
+		if known != nil {
+			result, status = known.Apply(result)
+			status.File = knownFile
+		}
+
+		check := analyzer.NewRuleEvaluator(analysisConfig).Evaluate(result)
+		check.Baseline = status
+
+		if err := output.NewFormatter(viper.GetString("format")).PrintTo(cmd.OutOrStdout(), check); err != nil {
+			return err
+		}
+		if !check.Passed {
+			return &exitError{code: 1}
+		}
+		return nil
 	},
 }
 
+// applyCheckFlags applies --fail-on and --threshold on top of the configured
+// rules, including the rules of package configurations
+func applyCheckFlags(config *types.AnalysisConfig) error {
+	switch failOn {
+	case "all", "circular", "boundary", "conflicts", "plugins", "custom":
+	default:
+		return fmt.Errorf("invalid --fail-on value %q (expected circular|boundary|conflicts|plugins|custom|all)", failOn)
+	}
+	capRules(config.Rules, false)
+	for _, override := range config.Overrides {
+		if override.Rules != nil {
+			capRules(override.Rules, true)
+		}
+	}
+
+	if threshold < 0 || threshold > 100 {
+		return fmt.Errorf("invalid --threshold value %d (expected 0-100)", threshold)
+	}
+	if threshold > 0 {
+		config.Thresholds.HealthScore = threshold
+	}
+	return nil
+}
+
+// loadCheckBaseline returns the --baseline file, or the default baseline file
+// of the workspace if there is one, and its path
+func loadCheckBaseline(workspace string) (*baseline.Baseline, string, error) {
+	if baselineFile != "" {
+		known, err := baseline.Load(baselineFile)
+		return known, baselineFile, err
+	}
+	path := filepath.Join(workspace, baseline.DefaultFile)
+	known, err := baseline.Load(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, "", nil
+	}
+	return known, path, err
+}
+
+// capSeverity downgrades a rule so it cannot fail the check
+func capSeverity(severity types.RuleSeverity) types.RuleSeverity {
+	if severity == types.RuleSeverityOff {
+		return severity
+	}
+	return types.RuleSeverityWarn
+}
+
+// capRules downgrades the rules --fail-on leaves out. Unset rules are
+// downgraded too unless they inherit their severity; the version conflict
+// rule is off when unset.
+func capRules(rules *types.RulesConfig, inherit bool) {
+	capRule := func(severity *types.RuleSeverity, rule string) {
+		if failOn == "all" || failOn == rule || (*severity == "" && inherit) {
+			return
+		}
+		*severity = capSeverity(*severity)
+	}
+	capRule(&rules.CircularDependencies, "circular")
+	capRule(&rules.BoundaryViolations, "boundary")
+	if rules.VersionConflicts != "" {
+		capRule(&rules.VersionConflicts, "conflicts")
+	}
+	capRule(&rules.Plugins, "plugins")
+	capRule(&rules.CustomRules, "custom")
+}
+
 func init() {
 	// Command registration is handled by root.go registerCommands()
 	// Local flags are registered here
 	checkCmd.Flags().StringVar(&failOn, "fail-on", "all",
-		"fail on: circular|boundary|all")
+		"fail on: circular|boundary|conflicts|plugins|custom|all")
 	checkCmd.Flags().IntVar(&threshold, "threshold", 0,
 		"fail if health score below threshold (0-100)")
+	checkCmd.Flags().StringVar(&baselineFile, "baseline", "",
+		"baseline file (default is .monoguard-baseline.json in the workspace)")
+	checkCmd.Flags().BoolVar(&checkNoCache, "no-cache", false,
+		"analyze without reading or writing the analysis cache")
 }
@@ -5,8 +5,13 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
 )
 
 // TestCheckCommandRegistered verifies check command is registered
@@ -25,87 +30,120 @@ func TestCheckCommandRegistered(t *testing.T) {
 	}
 }
 
-// TestCheckCommandExitCode verifies exit code 0 for placeholder success
-// AC6: ./monoguard check exits with code 0 (placeholder success)
+// TestCheckCommandExitCode verifies the exit code reflects the check result
 func TestCheckCommandExitCode(t *testing.T) {
-	ResetForTesting()
+	t.Run("clean workspace exits 0", func(t *testing.T) {
+		ResetForTesting()
+		root := writeWorkspace(t, cleanWorkspace)
 
-	buf := new(bytes.Buffer)
-	rootCmd.SetOut(buf)
-	rootCmd.SetErr(buf)
-	rootCmd.SetArgs([]string{"check"})
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetErr(buf)
+		rootCmd.SetArgs([]string{"check", root})
 
-	err := rootCmd.Execute()
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("Execute() error = %v, want nil (exit code 0)", err)
+		}
+	})
 
-	// Should not return error (exit code 0)
-	if err != nil {
-		t.Errorf("Execute() error = %v, want nil (exit code 0)", err)
-	}
+	t.Run("circular dependency exits 1", func(t *testing.T) {
+		ResetForTesting()
+		root := writeWorkspace(t, cycleWorkspace)
+
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetErr(buf)
+		rootCmd.SetArgs([]string{"check", root})
+
+		err := rootCmd.Execute()
+		var exitErr *exitError
+		if !errors.As(err, &exitErr) || exitErr.code != 1 {
+			t.Errorf("Execute() error = %v, want exit code 1", err)
+		}
+	})
 }
 
 // TestCheckCommandTextOutput verifies text output format
-// AC6: ./monoguard check outputs placeholder message
 func TestCheckCommandTextOutput(t *testing.T) {
 	ResetForTesting()
+	root := writeWorkspace(t, cycleWorkspace)
 
 	buf := new(bytes.Buffer)
 	rootCmd.SetOut(buf)
 	rootCmd.SetErr(buf)
-	rootCmd.SetArgs([]string{"check", "--format", "text"})
+	rootCmd.SetArgs([]string{"check", root, "--format", "text"})
 
-	err := rootCmd.Execute()
-	if err != nil {
-		t.Fatalf("Execute() error = %v", err)
-	}
+	rootCmd.Execute()
 
 	output := buf.String()
-
-	// Should contain check or placeholder indicator
-	if !strings.Contains(strings.ToLower(output), "check") &&
-		!strings.Contains(strings.ToLower(output), "placeholder") {
-		t.Errorf("Text output should mention check or placeholder: %q", output)
+	wantContains := []string{
+		"MonoGuard Check: FAILED",
+		"[CIRCULAR_DETECTED]",
+		"packages/a/src/index.ts:1",
 	}
-
-	// Should indicate success (passed)
-	if !strings.Contains(strings.ToLower(output), "pass") &&
-		!strings.Contains(output, "✅") {
-		t.Errorf("Text output should indicate success: %q", output)
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("Text output missing %q: %q", want, output)
+		}
 	}
 }
 
 // TestCheckCommandJSONOutput verifies JSON output format
-// AC6: ./monoguard check --format json outputs valid JSON
 func TestCheckCommandJSONOutput(t *testing.T) {
 	ResetForTesting()
+	root := writeWorkspace(t, cycleWorkspace)
 
 	buf := new(bytes.Buffer)
 	rootCmd.SetOut(buf)
 	rootCmd.SetErr(buf)
-	rootCmd.SetArgs([]string{"check", "--format", "json"})
-
-	err := rootCmd.Execute()
-	if err != nil {
-		t.Fatalf("Execute() error = %v", err)
-	}
+	rootCmd.SetArgs([]string{"check", root, "--format", "json"})
 
-	output := buf.String()
+	rootCmd.Execute()
 
-	// Must be valid JSON
-	var parsed map[string]interface{}
-	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
-		t.Fatalf("Output is not valid JSON: %v\nOutput: %s", err, output)
+	var parsed types.CheckResult
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\nOutput: %s", err, buf.String())
 	}
+	if parsed.Passed {
+		t.Error("JSON output 'passed' should be false for a circular dependency")
+	}
+	if len(parsed.Errors) != 1 || parsed.Errors[0].Code != types.CheckCodeCircularDetected {
+		t.Errorf("errors = %+v, want one CIRCULAR_DETECTED error", parsed.Errors)
+	}
+}
 
-	// Must contain passed field (boolean)
-	if _, ok := parsed["passed"]; !ok {
-		t.Error("JSON output should contain 'passed' field")
+// TestCheckCommandCIFormats verifies the CI formats report the failure and keep the exit code
+func TestCheckCommandCIFormats(t *testing.T) {
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{"junit", []string{`<testsuite name="circular-dependency" tests="2" failures="1">`, `<testcase classname="circular-dependency" name="@mono/a">`}},
+		{"github", []string{"::error file=packages/a/src/index.ts,line=1,title=MonoGuard circular-dependency::"}},
+		{"gitlab-codequality", []string{`"check_name": "circular-dependency"`, `"path": "packages/a/src/index.ts"`}},
 	}
 
-	// passed should be true for placeholder
-	if passed, ok := parsed["passed"].(bool); ok {
-		if !passed {
-			t.Error("JSON output 'passed' should be true for placeholder")
-		}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			ResetForTesting()
+			root := writeWorkspace(t, cycleWorkspace)
+
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetErr(buf)
+			rootCmd.SetArgs([]string{"check", root, "--format", tt.format})
+
+			err := rootCmd.Execute()
+			var exitErr *exitError
+			if !errors.As(err, &exitErr) || exitErr.code != 1 {
+				t.Errorf("Execute() error = %v, want exit code 1", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, buf.String())
+				}
+			}
+		})
 	}
 }
 
@@ -130,33 +168,393 @@ func TestCheckCommandFlags(t *testing.T) {
 	}
 }
 
-// TestCheckCommandCIMode verifies behavior suitable for CI/CD
-// AC6: Check command designed for CI/CD integration
-func TestCheckCommandCIMode(t *testing.T) {
-	// In CI mode, check should:
-	// 1. Exit 0 if all checks pass
-	// 2. Exit 1 if any checks fail
-	// 3. Output machine-readable format
+// TestCheckCommandRules verifies config rules and flags decide the outcome
+func TestCheckCommandRules(t *testing.T) {
+	boundaryConfig := `layers:
+  - name: libs
+    pattern: "packages/*"
+  - name: apps
+    pattern: "apps/*"
+    canDependOn: [libs]
+`
+	boundaryWorkspace := map[string]string{
+		"package.json":             `{"name": "root", "private": true}`,
+		"pnpm-workspace.yaml":      "packages:\n  - 'packages/*'\n  - 'apps/*'\n",
+		"pnpm-lock.yaml":           "",
+		"apps/web/package.json":    `{"name": "@mono/web", "version": "1.0.0"}`,
+		"packages/ui/package.json": `{"name": "@mono/ui", "version": "1.0.0", "dependencies": {"@mono/web": "workspace:*"}}`,
+	}
+	conflictWorkspace := map[string]string{
+		"package.json":            `{"name": "root", "private": true}`,
+		"pnpm-workspace.yaml":     "packages:\n  - 'packages/*'\n",
+		"pnpm-lock.yaml":          "",
+		"packages/a/package.json": `{"name": "@mono/a", "version": "1.0.0", "dependencies": {"lodash": "^4.17.21"}}`,
+		"packages/b/package.json": `{"name": "@mono/b", "version": "1.0.0", "dependencies": {"lodash": "^3.10.1"}}`,
+	}
+
+	tests := []struct {
+		name       string
+		workspace  map[string]string
+		config     string
+		packages   map[string]string // Package configuration files
+		args       []string
+		wantPassed bool
+		wantCode   string
+	}{
+		{
+			name:       "circular rule set to warn passes",
+			workspace:  cycleWorkspace,
+			config:     "rules:\n  circularDependencies: warn\n",
+			wantPassed: true,
+		},
+		{
+			name:       "fail-on boundary ignores cycles",
+			workspace:  cycleWorkspace,
+			args:       []string{"--fail-on", "boundary"},
+			wantPassed: true,
+		},
+		{
+			name:       "boundary violation fails",
+			workspace:  boundaryWorkspace,
+			config:     boundaryConfig,
+			wantPassed: false,
+			wantCode:   types.CheckCodeBoundaryViolation,
+		},
+		{
+			name:       "fail-on circular ignores boundary violations",
+			workspace:  boundaryWorkspace,
+			config:     boundaryConfig,
+			args:       []string{"--fail-on", "circular"},
+			wantPassed: true,
+		},
+		{
+			name:       "version conflicts are off by default",
+			workspace:  conflictWorkspace,
+			wantPassed: true,
+		},
+		{
+			name:       "version conflict rule fails",
+			workspace:  conflictWorkspace,
+			config:     "rules:\n  versionConflicts: error\n",
+			wantPassed: false,
+			wantCode:   types.CheckCodeVersionConflict,
+		},
+		{
+			name:       "fail-on circular ignores version conflicts",
+			workspace:  conflictWorkspace,
+			config:     "rules:\n  versionConflicts: error\n",
+			args:       []string{"--fail-on", "circular"},
+			wantPassed: true,
+		},
+		{
+			name:       "package config relaxes its packages",
+			workspace:  boundaryWorkspace,
+			config:     boundaryConfig,
+			packages:   map[string]string{"packages/ui/.monoguard.yaml": "rules:\n  boundaryViolations: warn\n"},
+			wantPassed: true,
+		},
+		{
+			name:       "package config leaves other packages alone",
+			workspace:  boundaryWorkspace,
+			config:     boundaryConfig,
+			packages:   map[string]string{"apps/web/.monoguard.yaml": "rules:\n  boundaryViolations: warn\n"},
+			wantPassed: false,
+			wantCode:   types.CheckCodeBoundaryViolation,
+		},
+		{
+			name:       "fail-on caps package config rules",
+			workspace:  boundaryWorkspace,
+			config:     boundaryConfig + "rules:\n  boundaryViolations: warn\n",
+			packages:   map[string]string{"packages/ui/.monoguard.yaml": "rules:\n  boundaryViolations: error\n"},
+			args:       []string{"--fail-on", "circular"},
+			wantPassed: true,
+		},
+		{
+			name:       "directory config excludes a dependency",
+			workspace:  conflictWorkspace,
+			config:     "rules:\n  versionConflicts: error\n",
+			packages:   map[string]string{"packages/.monoguard.yaml": "exclude: [lodash]\n"},
+			wantPassed: true,
+		},
+		{
+			name:       "threshold flag fails low health score",
+			workspace:  cycleWorkspace,
+			config:     "rules:\n  circularDependencies: off\n",
+			args:       []string{"--threshold", "100"},
+			wantPassed: false,
+			wantCode:   types.CheckCodeLowHealthScore,
+		},
+		{
+			name:       "threshold from config fails low health score",
+			workspace:  cycleWorkspace,
+			config:     "rules:\n  circularDependencies: off\nthresholds:\n  healthScore: 100\n",
+			wantPassed: false,
+			wantCode:   types.CheckCodeLowHealthScore,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ResetForTesting()
+			files := map[string]string{}
+			for k, v := range tt.workspace {
+				files[k] = v
+			}
+			if tt.config != "" {
+				files[".monoguard.yaml"] = tt.config
+			}
+			for k, v := range tt.packages {
+				files[k] = v
+			}
+			root := writeWorkspace(t, files)
+			t.Chdir(root)
+
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetErr(buf)
+			rootCmd.SetArgs(append([]string{"check", "--format", "json"}, tt.args...))
+
+			err := rootCmd.Execute()
+			if tt.wantPassed && err != nil {
+				t.Fatalf("Execute() error = %v, want nil", err)
+			}
+			if !tt.wantPassed && err == nil {
+				t.Fatal("Execute() should fail")
+			}
+
+			var parsed types.CheckResult
+			if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+				t.Fatalf("Output is not valid JSON: %v\nOutput: %s", err, buf.String())
+			}
+			if parsed.Passed != tt.wantPassed {
+				t.Errorf("passed = %v, want %v", parsed.Passed, tt.wantPassed)
+			}
+			if tt.wantCode != "" {
+				found := false
+				for _, e := range parsed.Errors {
+					if e.Code == tt.wantCode {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("errors = %+v, want code %s", parsed.Errors, tt.wantCode)
+				}
+			}
+		})
+	}
+}
+
+// TestCheckCommandPlugins verifies plugin findings fail the check and a failed
+// plugin only produces a warning
+func TestCheckCommandPlugins(t *testing.T) {
+	lint := "#!/bin/sh\ncat > /dev/null\necho '{\"findings\": [{\"rule\": \"no-lodash\", \"severity\": \"error\", " +
+		"\"message\": \"lodash is banned\", \"package\": \"@mono/a\"}]}'\n"
+	broken := "#!/bin/sh\necho 'boom' >&2\nexit 2\n"
+	config := "plugins:\n  - name: lint\n    command: ./tools/lint.sh\n  - name: broken\n    command: ./tools/broken.sh\n"
+
+	tests := []struct {
+		name       string
+		config     string
+		args       []string
+		wantPassed bool
+		wantError  string // Code of an expected error
+	}{
+		{"error finding fails", config, nil, false, types.CheckCodePluginFinding},
+		{"plugins rule caps findings", config + "rules:\n  plugins: warn\n", nil, true, ""},
+		{"fail-on circular ignores plugins", config, []string{"--fail-on", "circular"}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ResetForTesting()
+			files := map[string]string{".monoguard.yaml": tt.config, "tools/lint.sh": lint, "tools/broken.sh": broken}
+			for k, v := range cleanWorkspace {
+				files[k] = v
+			}
+			root := writeWorkspace(t, files)
+			for _, script := range []string{"tools/lint.sh", "tools/broken.sh"} {
+				if err := os.Chmod(filepath.Join(root, script), 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			t.Chdir(root)
+
+			stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+			rootCmd.SetOut(stdout)
+			rootCmd.SetErr(stderr)
+			rootCmd.SetArgs(append([]string{"check", "--format", "json", "--no-cache"}, tt.args...))
+
+			err := rootCmd.Execute()
+			if tt.wantPassed != (err == nil) {
+				t.Fatalf("Execute() error = %v, want passed %v", err, tt.wantPassed)
+			}
+			if !strings.Contains(stderr.String(), "Warning: plugin broken failed: exit status 2: boom") {
+				t.Errorf("stderr = %q, want plugin failure warning", stderr.String())
+			}
+
+			var parsed types.CheckResult
+			if err := json.Unmarshal(stdout.Bytes(), &parsed); err != nil {
+				t.Fatalf("Output is not valid JSON: %v\nOutput: %s", err, stdout.String())
+			}
+			codes := map[string]bool{}
+			for _, e := range parsed.Errors {
+				codes[e.Code] = true
+			}
+			if tt.wantError != "" && !codes[tt.wantError] {
+				t.Errorf("errors = %+v, want code %s", parsed.Errors, tt.wantError)
+			}
+			failed := false
+			for _, w := range parsed.Warnings {
+				failed = failed || w.Code == types.CheckCodePluginFailed
+			}
+			if !failed {
+				t.Errorf("warnings = %+v, want %s", parsed.Warnings, types.CheckCodePluginFailed)
+			}
+		})
+	}
+}
+
+func TestCheckCommandCustomRules(t *testing.T) {
+	config := `customRules:
+  - name: b-is-a-leaf
+    description: "@mono/b must not be depended on"
+    scope: dependency
+    forbid: 'to.name == "@mono/b"'
+  - name: max-dependencies
+    scope: package
+    forbid: len(dependencies) > 0
+    severity: warn
+`
+	leaf := "b-is-a-leaf: @mono/b must not be depended on (@mono/a → @mono/b)"
+
+	tests := []struct {
+		name         string
+		files        map[string]string
+		args         []string
+		wantPassed   bool
+		wantErrors   []string // Messages of the expected errors
+		wantWarnings []string
+	}{
+		{"error rule fails", map[string]string{".monoguard.yaml": config}, nil, false,
+			[]string{leaf}, []string{"max-dependencies: @mono/a"}},
+		{"customRules rule caps violations", map[string]string{".monoguard.yaml": config + "rules:\n  customRules: warn\n"}, nil, true,
+			nil, []string{leaf, "max-dependencies: @mono/a"}},
+		{"fail-on circular ignores custom rules", map[string]string{".monoguard.yaml": config}, []string{"--fail-on", "circular"}, true,
+			nil, []string{leaf, "max-dependencies: @mono/a"}},
+		{"package config turns rules off", map[string]string{
+			".monoguard.yaml":            config,
+			"packages/a/.monoguard.yaml": "rules:\n  customRules: off\n",
+		}, nil, true, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := map[string]string{}
+			for k, v := range cleanWorkspace {
+				files[k] = v
+			}
+			for k, v := range tt.files {
+				files[k] = v
+			}
+			root := writeWorkspace(t, files)
+			t.Chdir(root)
+
+			out, err := runCommand(t, append([]string{"check", "--format", "json", "--no-cache"}, tt.args...)...)
+			if tt.wantPassed != (err == nil) {
+				t.Fatalf("Execute() error = %v, want passed %v", err, tt.wantPassed)
+			}
+
+			var parsed types.CheckResult
+			if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+				t.Fatalf("Output is not valid JSON: %v\nOutput: %s", err, out)
+			}
+			var errs, warnings []string
+			for _, e := range parsed.Errors {
+				errs = append(errs, e.Message)
+			}
+			for _, w := range parsed.Warnings {
+				warnings = append(warnings, w.Message)
+			}
+			if strings.Join(errs, "|") != strings.Join(tt.wantErrors, "|") {
+				t.Errorf("errors = %q, want %q", errs, tt.wantErrors)
+			}
+			if strings.Join(warnings, "|") != strings.Join(tt.wantWarnings, "|") {
+				t.Errorf("warnings = %q, want %q", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+// TestCheckCommandInvalidCustomRule verifies an invalid forbid expression is
+// reported with its position in the configuration file
+func TestCheckCommandInvalidCustomRule(t *testing.T) {
+	root := writeWorkspace(t, cleanWorkspace)
+	writeFiles(t, root, map[string]string{
+		".monoguard.yaml": "customRules:\n  - name: deps\n    scope: package\n    forbid: dependencies > 15\n",
+	})
+	t.Chdir(root)
+
+	_, err := runCommand(t, "check", root)
+	if err == nil || !strings.Contains(err.Error(), "4:13: customRules[0].forbid: invalid expression: column 1: > needs a number, not a list") {
+		t.Errorf("Execute() error = %v, want invalid expression error", err)
+	}
+}
+
+// TestCheckCommandPackageConfigRootOnly verifies workspace-wide settings in
+// a package configuration are ignored with a warning
+func TestCheckCommandPackageConfigRootOnly(t *testing.T) {
+	root := writeWorkspace(t, cleanWorkspace)
+	writeFiles(t, root, map[string]string{"packages/a/.monoguard.yaml": "layers:\n  - name: a\n    pattern: packages/a\n"})
+
+	out, err := runCommand(t, "check", root)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(out, "Warning: "+filepath.Join(root, "packages", "a", ".monoguard.yaml")+": ignoring layers") {
+		t.Errorf("output should warn about the ignored setting: %q", out)
+	}
+}
+
+// TestCheckCommandPackageThreshold verifies a package configuration loosens
+// the health score threshold for its packages
+func TestCheckCommandPackageThreshold(t *testing.T) {
+	root := writeWorkspace(t, cycleWorkspace)
+	writeFiles(t, root, map[string]string{
+		".monoguard.yaml": "rules:\n  circularDependencies: off\nthresholds:\n  healthScore: 100\n",
+	})
+	t.Chdir(root)
+
+	out, err := runCommand(t, "check", root)
+	if err == nil || !strings.Contains(out, "is below threshold 100") {
+		t.Fatalf("Execute() error = %v, want the workspace threshold to fail: %q", err, out)
+	}
+
+	writeFiles(t, root, map[string]string{"packages/.monoguard.yaml": "thresholds:\n  healthScore: 50\n"})
+	out, err = runCommand(t, "check", root)
+	if err != nil {
+		t.Errorf("Execute() error = %v, want the package threshold to pass: %q", err, out)
+	}
+}
 
+// TestCheckCommandInvalidFailOn verifies --fail-on validation
+func TestCheckCommandInvalidFailOn(t *testing.T) {
 	ResetForTesting()
+	root := writeWorkspace(t, cleanWorkspace)
 
 	buf := new(bytes.Buffer)
 	rootCmd.SetOut(buf)
 	rootCmd.SetErr(buf)
-	rootCmd.SetArgs([]string{"check", "--format", "json"})
+	rootCmd.SetArgs([]string{"check", root, "--fail-on", "everything"})
 
 	err := rootCmd.Execute()
-
-	// Placeholder always passes
-	if err != nil {
-		t.Errorf("CI mode should return exit code 0 for placeholder: %v", err)
+	if err == nil || !strings.Contains(err.Error(), "--fail-on") {
+		t.Errorf("Execute() error = %v, want invalid --fail-on error", err)
 	}
+}
 
-	output := buf.String()
-
-	// Output should be parseable by CI tools
-	var parsed map[string]interface{}
-	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
-		t.Fatalf("CI output must be valid JSON: %v", err)
-	}
+// cleanWorkspace is a pnpm workspace where @mono/a depends on @mono/b without a cycle.
+var cleanWorkspace = map[string]string{
+	"package.json":            `{"name": "root", "private": true}`,
+	"pnpm-workspace.yaml":     "packages:\n  - 'packages/*'\n",
+	"pnpm-lock.yaml":          "",
+	"packages/a/package.json": `{"name": "@mono/a", "version": "1.0.0", "dependencies": {"@mono/b": "workspace:*"}}`,
+	"packages/b/package.json": `{"name": "@mono/b", "version": "1.0.0"}`,
 }
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the MonoGuard configuration",
+	Long: `Inspect the MonoGuard configuration.
+
+A configuration can build on presets with extends:
+
+  extends:
+    - ./config/base.yaml        # file relative to this one
+    - "@acme/monoguard-config"  # package in node_modules
+
+A package preset is read from the file named by the "monoguard"
+field of its package.json, or from its .monoguard.yaml. Presets are
+merged in order and the configuration itself is merged last:
+mappings merge key by key, exclude patterns are combined, layers
+merge by name, and any other value replaces the inherited one.`,
+}
+
+var configPrintCmd = &cobra.Command{
+	Use:   "print",
+	Short: "Print the resolved configuration and where each value came from",
+	Long: `Print the configuration after merging the presets it extends.
+
+In text format every value is annotated with the file it came from.
+The json format prints the merged configuration, the merged files and
+the origin of each value by key path.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f := output.NewFormatter(viper.GetString("format"))
+		file := viper.ConfigFileUsed()
+		if file == "" {
+			return f.PrintTo(cmd.OutOrStdout(), "No configuration file found")
+		}
+		resolved, err := config.Resolve(file)
+		if err != nil {
+			return err
+		}
+		return f.PrintTo(cmd.OutOrStdout(), resolved)
+	},
+}
+
+func init() {
+	// Command registration is handled by root.go registerCommands()
+	configCmd.AddCommand(configPrintCmd)
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestConfigExtends verifies commands use the settings of extended presets
+func TestConfigExtends(t *testing.T) {
+	root := writeWorkspace(t, cycleWorkspace)
+	t.Chdir(root)
+	writeFiles(t, root, map[string]string{
+		".monoguard.yaml":  "extends: ./config/base.yaml\n",
+		"config/base.yaml": "rules:\n  circularDependencies: off\n",
+	})
+
+	if _, err := runCommand(t, "check"); err != nil {
+		t.Errorf("check error = %v, want the preset to turn the cycle rule off", err)
+	}
+}
+
+// TestConfigPrintCommand verifies the resolved configuration and its origins are printed
+func TestConfigPrintCommand(t *testing.T) {
+	root := writeWorkspace(t, cleanWorkspace)
+	t.Chdir(root)
+	writeFiles(t, root, map[string]string{
+		".monoguard.yaml": "extends: \"@acme/monoguard-config\"\nrules:\n  boundaryViolations: warn\n",
+		"node_modules/@acme/monoguard-config/.monoguard.yaml": "rules:\n  circularDependencies: warn\n",
+	})
+
+	out, err := runCommand(t, "config", "print")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{
+		"circularDependencies: warn # from node_modules/@acme/monoguard-config/.monoguard.yaml",
+		"boundaryViolations: warn # from .monoguard.yaml",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+
+	out, err = runCommand(t, "config", "print", "--format", "json")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	var resolved struct {
+		Files   []string          `json:"files"`
+		Origins map[string]string `json:"origins"`
+	}
+	if err := json.Unmarshal([]byte(out), &resolved); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if len(resolved.Files) != 2 || !strings.HasSuffix(resolved.Origins["rules.circularDependencies"], "monoguard-config/.monoguard.yaml") {
+		t.Errorf("files = %v, origins = %v", resolved.Files, resolved.Origins)
+	}
+}
+
+// TestConfigPrintWithoutConfig verifies a missing configuration is reported
+func TestConfigPrintWithoutConfig(t *testing.T) {
+	t.Chdir(writeWorkspace(t, cleanWorkspace))
+
+	out, err := runCommand(t, "config", "print")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(out, "No configuration file found") {
+		t.Errorf("output = %q", out)
+	}
+}
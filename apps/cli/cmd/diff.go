@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	diffPath         string
+	failOnRegression bool
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <base> [head]",
+	Short: "Compare two analyses and report regressions",
+	Long: `Compare the analysis of a base and a head revision and report what
+changed: new, resolved and changed circular dependencies, new and
+resolved version conflicts, added and removed dependencies between
+workspace packages, and the change of each health score factor.
+
+Each revision is one of:
+
+  - a JSON file written by "monoguard analyze --format json"
+  - a workspace directory
+  - a git ref, checked out into a temporary worktree
+
+head defaults to the workspace at --path as it is on disk. For git
+refs, the workspace at the same location relative to the repository
+root as --path is analyzed.
+
+  monoguard diff origin/main
+  monoguard diff base.json head.json --format markdown
+
+Cycles are matched by the cycle ID also used in the fix summary.
+With --fail-on-regression the command exits with code 1 when head adds
+or changes cycles, adds version conflicts or lowers the health score.`,
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		analysisConfig := cfg.AnalysisConfig()
+
+		base, err := analysis.LoadRevision(args[0], diffPath, analysisConfig)
+		if err != nil {
+			return err
+		}
+
+		headLabel, headRev := "working tree", diffPath
+		if len(args) > 1 {
+			headLabel, headRev = args[1], args[1]
+		}
+		head, err := analysis.LoadRevision(headRev, diffPath, analysisConfig)
+		if err != nil {
+			return err
+		}
+
+		diff := analysis.CompareRevisions(args[0], base, headLabel, head)
+		if err := output.NewFormatter(viper.GetString("format")).PrintTo(cmd.OutOrStdout(), diff); err != nil {
+			return err
+		}
+		if failOnRegression && diff.Regressed() {
+			return &exitError{code: 1}
+		}
+		return nil
+	},
+}
+
+func init() {
+	// Command registration is handled by root.go registerCommands()
+	// Local flags are registered here
+	diffCmd.Flags().StringVar(&diffPath, "path", ".",
+		"workspace directory")
+	diffCmd.Flags().BoolVar(&failOnRegression, "fail-on-regression", false,
+		"exit with code 1 if head introduces regressions")
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestDiffCommandRegistered verifies diff command is registered
+func TestDiffCommandRegistered(t *testing.T) {
+	if findCommand("diff") == nil {
+		t.Error("diff command not registered on rootCmd")
+	}
+}
+
+// TestDiffCommandDirectories verifies a new cycle between two workspaces is reported
+func TestDiffCommandDirectories(t *testing.T) {
+	base := writeWorkspace(t, cleanWorkspace)
+	head := writeWorkspace(t, cycleWorkspace)
+
+	out, err := runCommand(t, "diff", base, head, "--format", "json")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	var diff struct {
+		NewCycles []struct {
+			ID string `json:"id"`
+		} `json:"newCycles"`
+		AddedEdges []struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"addedEdges"`
+	}
+	if err := json.Unmarshal([]byte(out), &diff); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if len(diff.NewCycles) != 1 {
+		t.Errorf("newCycles = %d, want 1", len(diff.NewCycles))
+	}
+	if len(diff.AddedEdges) != 1 || diff.AddedEdges[0].From != "@mono/b" || diff.AddedEdges[0].To != "@mono/a" {
+		t.Errorf("addedEdges = %+v, want @mono/b → @mono/a", diff.AddedEdges)
+	}
+}
+
+// TestDiffCommandFailOnRegression verifies the exit code on regressions
+func TestDiffCommandFailOnRegression(t *testing.T) {
+	clean := writeWorkspace(t, cleanWorkspace)
+	cyclic := writeWorkspace(t, cycleWorkspace)
+
+	_, err := runCommand(t, "diff", clean, cyclic, "--fail-on-regression")
+	var exitErr *exitError
+	if !errors.As(err, &exitErr) || exitErr.code != 1 {
+		t.Errorf("Execute() error = %v, want exit code 1", err)
+	}
+
+	// Resolving the cycle is not a regression
+	out, err := runCommand(t, "diff", cyclic, clean, "--fail-on-regression")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(out, "✅ Resolved Circular Dependencies (1)") {
+		t.Errorf("output missing resolved cycle\n%s", out)
+	}
+}
+
+// TestDiffCommandJSONFiles verifies saved analyze results can be compared
+func TestDiffCommandJSONFiles(t *testing.T) {
+	dir := t.TempDir()
+	saved := map[string]map[string]string{"base.json": cleanWorkspace, "head.json": cycleWorkspace}
+	for name, files := range saved {
+		out, err := runCommand(t, "analyze", writeWorkspace(t, files), "--format", "json")
+		if err != nil {
+			t.Fatalf("analyze error = %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(out), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := runCommand(t, "diff", filepath.Join(dir, "base.json"), filepath.Join(dir, "head.json"))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{"🔄 New Circular Dependencies (1)", "❌ Regressions found"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+}
+
+// TestDiffCommandGitRef verifies a git ref is compared with the working tree
+func TestDiffCommandGitRef(t *testing.T) {
+	root := gitWorkspace(t, cleanWorkspace)
+	writeFiles(t, root, cycleWorkspace)
+
+	out, err := runCommand(t, "diff", "HEAD", "--path", root)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{"📊 MonoGuard Diff: HEAD → working tree", "🔄 New Circular Dependencies (1)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+}
+
+// gitWorkspace creates a workspace and commits it to a new git repository
+func gitWorkspace(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root := writeWorkspace(t, files)
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", append([]string{"-C", root}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	return root
+}
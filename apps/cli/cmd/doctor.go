@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/output"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor [path]",
+	Short: "Check the workspace for packages left out of the analysis",
+	Long: `Check the workspace manifests for problems that silently leave
+packages out of the analysis:
+
+  invalid-package-json   package.json cannot be parsed
+  missing-name           package.json has no name
+  missing-package-json   directory matched by a workspace pattern has no package.json
+  duplicate-name         package name already used by another package
+  unmatched-package      package.json not matched by any workspace pattern
+  unmatched-pattern      workspace pattern that matches no package
+
+The command exits with code 1 when any problem is found.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		snap, err := workspace.Scan(path)
+		if err != nil {
+			return err
+		}
+		checkup, err := analysis.Diagnose(snap)
+		if err != nil {
+			return err
+		}
+
+		if err := output.NewFormatter(viper.GetString("format")).PrintTo(cmd.OutOrStdout(), checkup); err != nil {
+			return err
+		}
+		if len(checkup.Diagnostics) > 0 {
+			return &exitError{code: 1}
+		}
+		return nil
+	},
+}
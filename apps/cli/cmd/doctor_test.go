@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestDoctorCommandRegistered verifies doctor command is registered
+func TestDoctorCommandRegistered(t *testing.T) {
+	if findCommand("doctor") == nil {
+		t.Error("doctor command not registered on rootCmd")
+	}
+}
+
+// TestDoctorCommandClean verifies a healthy workspace passes
+func TestDoctorCommandClean(t *testing.T) {
+	root := writeWorkspace(t, cleanWorkspace)
+
+	out, err := runCommand(t, "doctor", root)
+	if err != nil {
+		t.Fatalf("Execute() error = %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "No workspace problems found") {
+		t.Errorf("output = %q", out)
+	}
+}
+
+// TestDoctorCommandProblems verifies diagnostics are reported and fail the command
+func TestDoctorCommandProblems(t *testing.T) {
+	root := writeWorkspace(t, cleanWorkspace)
+	writeFiles(t, root, map[string]string{
+		"packages/c/package.json": `{"name": "@mono/c",}`,
+		"packages/d/package.json": `{"name": "@mono/a", "version": "2.0.0"}`,
+	})
+
+	out, err := runCommand(t, "doctor", root, "--format", "json")
+	var exitErr *exitError
+	if !errors.As(err, &exitErr) || exitErr.code != 1 {
+		t.Fatalf("Execute() error = %v, want exit code 1", err)
+	}
+
+	var checkup struct {
+		Packages    int `json:"packages"`
+		Diagnostics []struct {
+			File string `json:"file"`
+			Kind string `json:"kind"`
+		} `json:"diagnostics"`
+	}
+	if err := json.Unmarshal([]byte(out), &checkup); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if checkup.Packages != 2 {
+		t.Errorf("packages = %d, want 2", checkup.Packages)
+	}
+	kinds := map[string]string{}
+	for _, d := range checkup.Diagnostics {
+		kinds[d.File] = d.Kind
+	}
+	if kinds["packages/c/package.json"] != "invalid-package-json" || kinds["packages/d/package.json"] != "duplicate-name" {
+		t.Errorf("diagnostics = %+v", checkup.Diagnostics)
+	}
+}
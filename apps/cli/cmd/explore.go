@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/explore"
+	"github.com/spf13/cobra"
+)
+
+var exploreCmd = &cobra.Command{
+	Use:   "explore [path|result.json|ref]",
+	Short: "Browse an analysis interactively in the terminal",
+	Long: `Open a full-screen explorer for an analysis.
+
+The explorer starts at the circular dependencies, highest priority
+first. Open a cycle to see its root cause, import traces and fix
+strategies; open a strategy to read its step-by-step guide; open a
+package to walk the dependency graph neighbour by neighbour.
+
+The argument is a workspace directory (default "."), a JSON file
+written by "monoguard analyze --format json", or a git ref:
+
+  monoguard explore
+  monoguard explore analysis.json
+
+Keys: ↑/↓ or j/k move, enter opens, esc goes back, p lists all
+packages, c returns to the cycles, q quits.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out, ok := cmd.OutOrStdout().(*os.File)
+		if !ok || !term.IsTerminal(out.Fd()) {
+			return fmt.Errorf("explore needs an interactive terminal; use \"monoguard analyze\" for scripted output")
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		target := "."
+		if len(args) > 0 {
+			target = args[0]
+		}
+		result, err := analysis.LoadRevision(target, ".", cfg.AnalysisConfig())
+		if err != nil {
+			return err
+		}
+
+		program := tea.NewProgram(explore.New(result),
+			tea.WithAltScreen(),
+			tea.WithInput(cmd.InOrStdin()),
+			tea.WithOutput(out),
+		)
+		_, err = program.Run()
+		return err
+	},
+}
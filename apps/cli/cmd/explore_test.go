@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestExploreCommandRegistered verifies explore command is registered
+func TestExploreCommandRegistered(t *testing.T) {
+	if findCommand("explore") == nil {
+		t.Error("explore command not registered on rootCmd")
+	}
+}
+
+// TestExploreCommandNeedsTerminal verifies explore refuses to run without a terminal
+func TestExploreCommandNeedsTerminal(t *testing.T) {
+	root := writeWorkspace(t, cycleWorkspace)
+
+	_, err := runCommand(t, "explore", root)
+	if err == nil || !strings.Contains(err.Error(), "interactive terminal") {
+		t.Errorf("Execute() error = %v, want interactive terminal error", err)
+	}
+}
@@ -1,57 +1,130 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/fix"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/output"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-// fixOutput represents the JSON output structure for fix command
-type fixOutput struct {
-	Status  string `json:"status"`
-	Path    string `json:"path"`
-	DryRun  bool   `json:"dryRun"`
-	Message string `json:"message"`
-}
-
-var dryRun bool
+var (
+	dryRun      bool
+	apply       bool
+	strategy    string
+	cycleNumber int
+)
 
 var fixCmd = &cobra.Command{
 	Use:   "fix [path]",
 	Short: "Generate fix suggestions for issues",
 	Long: `Analyze the monorepo and generate fix suggestions
-for circular dependencies and other issues.`,
-	Args: cobra.MaximumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+for circular dependencies and other issues.
+
+A fix strategy is planned for one circular dependency (--cycle, default
+the first). --strategy selects extract-module, dependency-injection or
+boundary-refactoring; the default is extract-module when available.
+
+  --dry-run  print the changes as a unified diff
+  --apply    write the changes and re-run the analysis to confirm
+             the cycle is gone
+
+Applying scaffolds new packages, rewrites package.json dependency
+blocks and rewrites the traced import statements. Code that has to
+move between packages is listed under "Next steps".`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		path := "."
 		if len(args) > 0 {
 			path = args[0]
 		}
 
-		format := viper.GetString("format")
-		if format == "json" {
-			output := fixOutput{
-				Status:  "placeholder",
-				Path:    path,
-				DryRun:  dryRun,
-				Message: "Fix will be implemented in Epic 3",
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		analysisConfig := cfg.AnalysisConfig()
+
+		result, err := analysis.AnalyzePath(path, analysisConfig)
+		if err != nil {
+			return err
+		}
+		formatter := output.NewFormatter(viper.GetString("format"))
+
+		if len(result.CircularDependencies) == 0 {
+			return formatter.PrintTo(cmd.OutOrStdout(), "✅ No circular dependencies to fix")
+		}
+		if cycleNumber < 1 || cycleNumber > len(result.CircularDependencies) {
+			return fmt.Errorf("invalid --cycle %d (found %d circular dependencies)",
+				cycleNumber, len(result.CircularDependencies))
+		}
+		cycle := result.CircularDependencies[cycleNumber-1]
+
+		selected, err := fix.SelectStrategy(cycle, strategy)
+		if err != nil {
+			return err
+		}
+		plan, err := fix.NewPlan(result.Graph.RootPath, cycle, selected)
+		if err != nil {
+			return err
+		}
+
+		report := fix.NewReport(result.Graph.RootPath, plan)
+		report.DryRun = dryRun
+		if dryRun {
+			report.Diff = plan.Diff()
+		}
+
+		if apply {
+			if err := plan.Apply(); err != nil {
+				return fmt.Errorf("failed to apply fix: %w", err)
+			}
+			report.Applied = true
+
+			after, err := analysis.AnalyzePath(path, analysisConfig)
+			if err != nil {
+				return fmt.Errorf("re-analysis after fix failed: %w", err)
 			}
-			jsonBytes, _ := json.Marshal(output)
-			fmt.Fprintln(cmd.OutOrStdout(), string(jsonBytes))
-		} else {
-			fmt.Fprintf(cmd.OutOrStdout(), "🔧 MonoGuard Fix (Placeholder)\n")
-			fmt.Fprintf(cmd.OutOrStdout(), "   Path: %s\n", path)
-			fmt.Fprintf(cmd.OutOrStdout(), "   Dry Run: %t\n", dryRun)
-			fmt.Fprintf(cmd.OutOrStdout(), "   Status: Will be implemented in Epic 3\n")
+			resolved := !hasCycle(after, cycle.Cycle)
+			report.Resolved = &resolved
 		}
+
+		if err := formatter.PrintTo(cmd.OutOrStdout(), report); err != nil {
+			return err
+		}
+		if report.Resolved != nil && !*report.Resolved {
+			return &exitError{code: 1}
+		}
+		return nil
 	},
 }
 
+// hasCycle reports whether result still contains a cycle over the same packages
+func hasCycle(result *types.AnalysisResult, cycle []string) bool {
+	key := analysis.CycleKey(cycle)
+	for _, c := range result.CircularDependencies {
+		if analysis.CycleKey(c.Cycle) == key {
+			return true
+		}
+	}
+	return false
+}
+
 func init() {
 	// Command registration is handled by root.go registerCommands()
 	// Local flags are registered here
 	fixCmd.Flags().BoolVar(&dryRun, "dry-run", false,
 		"preview fixes without applying")
+	fixCmd.Flags().BoolVar(&apply, "apply", false,
+		"apply the fix to the workspace")
+	fixCmd.Flags().StringVar(&strategy, "strategy", "",
+		"fix strategy: extract-module|dependency-injection|boundary-refactoring")
+	fixCmd.Flags().IntVar(&cycleNumber, "cycle", 1,
+		"circular dependency to fix (1-based, as listed by analyze)")
+	fixCmd.MarkFlagsMutuallyExclusive("dry-run", "apply")
 }
@@ -5,6 +5,8 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -25,68 +27,67 @@ func TestFixCommandRegistered(t *testing.T) {
 	}
 }
 
-// TestFixCommandTextOutput verifies text output format
-// AC6: ./monoguard fix --dry-run outputs placeholder message
-func TestFixCommandTextOutput(t *testing.T) {
+// TestFixCommandDryRunDiff verifies --dry-run prints a unified diff and writes nothing
+func TestFixCommandDryRunDiff(t *testing.T) {
 	ResetForTesting()
+	root := writeWorkspace(t, cycleWorkspace)
 
 	buf := new(bytes.Buffer)
 	rootCmd.SetOut(buf)
 	rootCmd.SetErr(buf)
-	rootCmd.SetArgs([]string{"fix", "--dry-run", "--format", "text"})
+	rootCmd.SetArgs([]string{"fix", root, "--dry-run", "--format", "text"})
 
-	err := rootCmd.Execute()
-	if err != nil {
+	if err := rootCmd.Execute(); err != nil {
 		t.Fatalf("Execute() error = %v", err)
 	}
 
 	output := buf.String()
-
-	// Should contain fix or placeholder indicator
-	if !strings.Contains(strings.ToLower(output), "fix") &&
-		!strings.Contains(strings.ToLower(output), "placeholder") {
-		t.Errorf("Text output should mention fix or placeholder: %q", output)
+	wantContains := []string{
+		"dry run",
+		"extract-module",
+		"--- a/packages/a/src/index.ts",
+		"+++ b/packages/a/src/index.ts",
+		"-import { b } from '@mono/b';",
+		"--- /dev/null",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("Text output missing %q: %q", want, output)
+		}
 	}
 
-	// Should indicate dry run mode
-	if !strings.Contains(strings.ToLower(output), "dry") {
-		t.Errorf("Text output should mention dry run: %q", output)
+	data, err := os.ReadFile(filepath.Join(root, "packages/a/src/index.ts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != cycleWorkspace["packages/a/src/index.ts"] {
+		t.Error("--dry-run should not modify files")
 	}
 }
 
 // TestFixCommandJSONOutput verifies JSON output format
-// AC6: ./monoguard fix --format json outputs valid JSON
 func TestFixCommandJSONOutput(t *testing.T) {
 	ResetForTesting()
+	root := writeWorkspace(t, cycleWorkspace)
 
 	buf := new(bytes.Buffer)
 	rootCmd.SetOut(buf)
 	rootCmd.SetErr(buf)
-	rootCmd.SetArgs([]string{"fix", "--dry-run", "--format", "json"})
+	rootCmd.SetArgs([]string{"fix", root, "--dry-run", "--format", "json"})
 
-	err := rootCmd.Execute()
-	if err != nil {
+	if err := rootCmd.Execute(); err != nil {
 		t.Fatalf("Execute() error = %v", err)
 	}
 
-	output := buf.String()
-
-	// Must be valid JSON
 	var parsed map[string]interface{}
-	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
-		t.Fatalf("Output is not valid JSON: %v\nOutput: %s", err, output)
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\nOutput: %s", err, buf.String())
 	}
-
-	// Must contain dryRun field
-	if _, ok := parsed["dryRun"]; !ok {
-		t.Error("JSON output should contain 'dryRun' field")
+	if dryRun, ok := parsed["dryRun"].(bool); !ok || !dryRun {
+		t.Error("JSON output 'dryRun' should be true when --dry-run flag passed")
 	}
-
-	// dryRun should be true when --dry-run flag is passed
-	if dryRun, ok := parsed["dryRun"].(bool); ok {
-		if !dryRun {
-			t.Error("JSON output 'dryRun' should be true when --dry-run flag passed")
-		}
+	if diff, ok := parsed["diff"].(string); !ok || !strings.Contains(diff, "@@") {
+		t.Errorf("JSON output should contain the unified diff: %v", parsed["diff"])
 	}
 }
 
@@ -108,33 +109,122 @@ func TestFixCommandDryRunFlag(t *testing.T) {
 	if dryRunFlag.DefValue != "false" {
 		t.Errorf("--dry-run default = %q, want 'false'", dryRunFlag.DefValue)
 	}
+
+	for _, name := range []string{"apply", "strategy", "cycle"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("--%s flag not registered", name)
+		}
+	}
 }
 
-// TestFixCommandWithoutDryRun verifies fix without dry-run
+// TestFixCommandWithoutDryRun verifies fix without flags only plans the fix
 func TestFixCommandWithoutDryRun(t *testing.T) {
 	ResetForTesting()
+	root := writeWorkspace(t, cycleWorkspace)
 
 	buf := new(bytes.Buffer)
 	rootCmd.SetOut(buf)
 	rootCmd.SetErr(buf)
-	rootCmd.SetArgs([]string{"fix", "--format", "json"})
+	rootCmd.SetArgs([]string{"fix", root, "--format", "json"})
 
-	err := rootCmd.Execute()
-	if err != nil {
+	if err := rootCmd.Execute(); err != nil {
 		t.Fatalf("Execute() error = %v", err)
 	}
 
-	output := buf.String()
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	if parsed["dryRun"] != false || parsed["applied"] != false {
+		t.Errorf("dryRun and applied should be false when no flag is specified: %v", parsed)
+	}
+	if _, ok := parsed["diff"]; ok {
+		t.Error("diff should only be included with --dry-run")
+	}
+}
+
+// TestFixCommandApply verifies --apply writes the changes and resolves the cycle
+func TestFixCommandApply(t *testing.T) {
+	ResetForTesting()
+	root := writeWorkspace(t, cycleWorkspace)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"fix", root, "--apply", "--format", "json"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v\nOutput: %s", err, buf.String())
+	}
 
 	var parsed map[string]interface{}
-	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
 		t.Fatalf("Output is not valid JSON: %v", err)
 	}
+	if parsed["applied"] != true || parsed["resolved"] != true {
+		t.Errorf("fix should be applied and resolve the cycle: %v", parsed)
+	}
 
-	// dryRun should be false when not specified
-	if dryRun, ok := parsed["dryRun"].(bool); ok {
-		if dryRun {
-			t.Error("JSON output 'dryRun' should be false when flag not specified")
-		}
+	data, err := os.ReadFile(filepath.Join(root, "packages/a/src/index.ts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "'@mono/b'") {
+		t.Errorf("import should be rewritten after --apply: %q", data)
+	}
+
+	// check passes on the fixed workspace
+	ResetForTesting()
+	rootCmd.SetArgs([]string{"check", root})
+	if err := rootCmd.Execute(); err != nil {
+		t.Errorf("check after fix --apply error = %v", err)
+	}
+}
+
+// TestFixCommandErrors verifies invalid selections are rejected
+func TestFixCommandErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "cycle out of range", args: []string{"--cycle", "5"}, wantErr: "--cycle"},
+		{name: "unknown strategy", args: []string{"--strategy", "rewrite"}, wantErr: "rewrite"},
+		{name: "apply and dry-run", args: []string{"--apply", "--dry-run"}, wantErr: "dry-run"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ResetForTesting()
+			root := writeWorkspace(t, cycleWorkspace)
+
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetErr(buf)
+			rootCmd.SetArgs(append([]string{"fix", root}, tt.args...))
+
+			err := rootCmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Execute() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestFixCommandNoCycles verifies a clean workspace has nothing to fix
+func TestFixCommandNoCycles(t *testing.T) {
+	ResetForTesting()
+	root := writeWorkspace(t, cleanWorkspace)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"fix", root})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "No circular dependencies") {
+		t.Errorf("output should report nothing to fix: %q", buf.String())
 	}
 }
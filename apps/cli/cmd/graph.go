@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/graph"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	focus       string
+	graphDepth  int
+	noDev       bool
+	noPeer      bool
+	clusterDirs bool
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph [path]",
+	Short: "Export the dependency graph",
+	Long: `Export the workspace dependency graph.
+
+Use --format to choose the export:
+
+  dot      Graphviz, e.g. monoguard graph --format dot | dot -Tsvg > graph.svg
+  mermaid  Mermaid flowchart for Markdown documents
+  graphml  GraphML for yEd, Gephi and other graph tools
+  json     the selected nodes and edges
+  text     an adjacency list (default)
+
+Edges of circular dependencies are highlighted and packages excluded
+in .monoguard.yaml are drawn in a distinct style. --focus limits the
+export to the packages within --depth of one package, in both
+directions.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+		if graphDepth < 0 {
+			return fmt.Errorf("--depth must not be negative")
+		}
+		if graphDepth > 0 && focus == "" {
+			return fmt.Errorf("--depth requires --focus")
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		result, err := analysis.AnalyzePath(path, cfg.AnalysisConfig())
+		if err != nil {
+			return err
+		}
+
+		view, err := graph.Build(result, graph.Options{
+			Focus:       focus,
+			Depth:       graphDepth,
+			HideDev:     noDev,
+			HidePeer:    noPeer,
+			ClusterDirs: clusterDirs,
+		})
+		if err != nil {
+			return err
+		}
+
+		return output.NewFormatter(viper.GetString("format")).PrintTo(cmd.OutOrStdout(), view)
+	},
+}
+
+func init() {
+	// Command registration is handled by root.go registerCommands()
+	// Local flags are registered here
+	graphCmd.Flags().StringVar(&focus, "focus", "",
+		"only export packages around this package")
+	graphCmd.Flags().IntVar(&graphDepth, "depth", 0,
+		"with --focus, maximum distance from the package (0 for unlimited)")
+	graphCmd.Flags().BoolVar(&noDev, "no-dev", false,
+		"hide devDependencies edges")
+	graphCmd.Flags().BoolVar(&noPeer, "no-peer", false,
+		"hide peerDependencies edges")
+	graphCmd.Flags().BoolVar(&clusterDirs, "cluster", false,
+		"group packages by parent directory")
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestGraphCommandRegistered verifies graph command is registered
+func TestGraphCommandRegistered(t *testing.T) {
+	if findCommand("graph") == nil {
+		t.Error("graph command not registered on rootCmd")
+	}
+}
+
+// TestGraphCommandFormats verifies each export format highlights the cycle
+func TestGraphCommandFormats(t *testing.T) {
+	root := writeWorkspace(t, cycleWorkspace)
+	tests := map[string]string{
+		"dot":     `"@mono/a" -> "@mono/b" [color="#dc2626", penwidth=2];`,
+		"mermaid": "linkStyle 0,1 stroke:#dc2626",
+		"graphml": `<data key="edgeInCycle">true</data>`,
+		"text":    "→ @mono/b 🔄",
+	}
+	for format, want := range tests {
+		t.Run(format, func(t *testing.T) {
+			ResetForTesting()
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetErr(buf)
+			rootCmd.SetArgs([]string{"graph", root, "--format", format})
+
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("output missing %q\n%s", want, buf.String())
+			}
+		})
+	}
+}
+
+// TestGraphCommandFocus verifies --focus and --depth select a neighborhood
+func TestGraphCommandFocus(t *testing.T) {
+	ResetForTesting()
+	root := writeWorkspace(t, layeredWorkspace)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"graph", root, "--focus", "@mono/ui", "--depth", "1", "--format", "json"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	var view struct {
+		Focus string `json:"focus"`
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &view); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\nOutput: %s", err, buf.String())
+	}
+	if view.Focus != "@mono/ui" {
+		t.Errorf("focus = %q", view.Focus)
+	}
+	// @mono/example-basic depends on @mono/ui through @mono/web
+	if len(view.Nodes) != 2 || view.Nodes[0].Name != "@mono/ui" || view.Nodes[1].Name != "@mono/web" {
+		t.Errorf("nodes = %+v, want @mono/ui and @mono/web", view.Nodes)
+	}
+}
+
+// TestGraphCommandInvalidFlags verifies flag validation
+func TestGraphCommandInvalidFlags(t *testing.T) {
+	root := writeWorkspace(t, cycleWorkspace)
+	tests := map[string][]string{
+		"depth without focus": {"graph", root, "--depth", "2"},
+		"unknown focus":       {"graph", root, "--focus", "@mono/missing"},
+		"negative depth":      {"graph", root, "--focus", "@mono/a", "--depth", "-1"},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			ResetForTesting()
+			rootCmd.SetOut(new(bytes.Buffer))
+			rootCmd.SetErr(new(bytes.Buffer))
+			rootCmd.SetArgs(args)
+			if err := rootCmd.Execute(); err == nil {
+				t.Error("Execute() should fail")
+			}
+		})
+	}
+}
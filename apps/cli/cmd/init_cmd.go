@@ -1,26 +1,77 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/output"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
+var force bool
+
 var initCmd = &cobra.Command{
-	Use:   "init",
+	Use:   "init [path]",
 	Short: "Initialize MonoGuard configuration",
 	Long: `Create a .monoguard.yaml configuration file in the
-current directory with default settings.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		format := viper.GetString("format")
-		if format == "json" {
-			fmt.Fprintln(cmd.OutOrStdout(), `{"status":"placeholder","message":"Init will be implemented in Epic 8"}`)
-		} else {
-			fmt.Fprintf(cmd.OutOrStdout(), "🚀 MonoGuard Init (Placeholder)\n")
-			fmt.Fprintf(cmd.OutOrStdout(), "   Status: Will be implemented in Epic 8\n")
+workspace root (default: current directory).
+
+The workspace type and package globs are detected from
+pnpm-workspace.yaml or package.json. Packages under example,
+fixture, demo or sandbox directories are suggested for exclusion,
+and architecture layers are proposed from the apps/, services/,
+libs/, packages/ and modules/ directory conventions.
+
+An existing configuration file is only overwritten with --force.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		target := filepath.Join(path, config.FileName)
+		status := "created"
+		if _, err := os.Stat(target); err == nil {
+			if !force {
+				return fmt.Errorf("%s already exists (use --force to overwrite)", target)
+			}
+			status = "overwritten"
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		snap, err := workspace.Scan(path)
+		if err != nil {
+			return err
 		}
+		detection, err := config.Detect(snap)
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(target, []byte(config.Render(detection.Config())), 0644); err != nil {
+			return err
+		}
+
+		result := &config.InitResult{
+			Status:    status,
+			Path:      target,
+			Detection: detection,
+		}
+		return output.NewFormatter(viper.GetString("format")).PrintTo(cmd.OutOrStdout(), result)
 	},
 }
 
-// Command registration is handled by root.go registerCommands()
+func init() {
+	// Command registration is handled by root.go registerCommands()
+	// Local flags are registered here
+	initCmd.Flags().BoolVar(&force, "force", false,
+		"overwrite an existing configuration file")
+}
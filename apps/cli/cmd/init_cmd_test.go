@@ -5,10 +5,22 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
 
+// layeredWorkspace has apps and libs directories plus an example package
+var layeredWorkspace = map[string]string{
+	"package.json":                `{"name": "root", "private": true}`,
+	"pnpm-workspace.yaml":         "packages:\n  - 'apps/*'\n  - 'libs/*'\n  - 'examples/*'\n",
+	"pnpm-lock.yaml":              "",
+	"apps/web/package.json":       `{"name": "@mono/web", "dependencies": {"@mono/ui": "workspace:*"}}`,
+	"libs/ui/package.json":        `{"name": "@mono/ui"}`,
+	"examples/basic/package.json": `{"name": "@mono/example-basic", "dependencies": {"@mono/web": "workspace:*"}}`,
+}
+
 // TestInitCommandRegistered verifies init command is registered
 // AC3: Available commands include "init"
 func TestInitCommandRegistered(t *testing.T) {
@@ -25,15 +37,15 @@ func TestInitCommandRegistered(t *testing.T) {
 	}
 }
 
-// TestInitCommandTextOutput verifies text output format
-// AC6: ./monoguard init outputs placeholder message
+// TestInitCommandTextOutput verifies the config file is written and summarized
 func TestInitCommandTextOutput(t *testing.T) {
 	ResetForTesting()
+	root := writeWorkspace(t, layeredWorkspace)
 
 	buf := new(bytes.Buffer)
 	rootCmd.SetOut(buf)
 	rootCmd.SetErr(buf)
-	rootCmd.SetArgs([]string{"init", "--format", "text"})
+	rootCmd.SetArgs([]string{"init", root, "--format", "text"})
 
 	err := rootCmd.Execute()
 	if err != nil {
@@ -41,11 +53,27 @@ func TestInitCommandTextOutput(t *testing.T) {
 	}
 
 	output := buf.String()
+	for _, want := range []string{"MonoGuard Init", "Created", "Workspace: pnpm", "@mono/example-basic"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Text output missing %q:\n%s", want, output)
+		}
+	}
 
-	// Should contain init or placeholder indicator
-	if !strings.Contains(strings.ToLower(output), "init") &&
-		!strings.Contains(strings.ToLower(output), "placeholder") {
-		t.Errorf("Text output should mention init or placeholder: %q", output)
+	data, err := os.ReadFile(filepath.Join(root, ".monoguard.yaml"))
+	if err != nil {
+		t.Fatalf("config not written: %v", err)
+	}
+	content := string(data)
+	for _, want := range []string{
+		`- "apps/*"`,
+		`- "@mono/example-basic"`,
+		`- name: "apps"`,
+		`canDependOn: ["libs"]`,
+		"circularDependencies: error",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf(".monoguard.yaml missing %q:\n%s", want, content)
+		}
 	}
 }
 
@@ -53,11 +81,12 @@ func TestInitCommandTextOutput(t *testing.T) {
 // AC6: ./monoguard init --format json outputs valid JSON
 func TestInitCommandJSONOutput(t *testing.T) {
 	ResetForTesting()
+	root := writeWorkspace(t, layeredWorkspace)
 
 	buf := new(bytes.Buffer)
 	rootCmd.SetOut(buf)
 	rootCmd.SetErr(buf)
-	rootCmd.SetArgs([]string{"init", "--format", "json"})
+	rootCmd.SetArgs([]string{"init", root, "--format", "json"})
 
 	err := rootCmd.Execute()
 	if err != nil {
@@ -72,14 +101,50 @@ func TestInitCommandJSONOutput(t *testing.T) {
 		t.Fatalf("Output is not valid JSON: %v\nOutput: %s", err, output)
 	}
 
-	// Must contain status field
-	if _, ok := parsed["status"]; !ok {
-		t.Error("JSON output should contain 'status' field")
+	if parsed["status"] != "created" {
+		t.Errorf("status = %v, want created", parsed["status"])
+	}
+	if parsed["workspaceType"] != "pnpm" {
+		t.Errorf("workspaceType = %v, want pnpm", parsed["workspaceType"])
+	}
+	if layers, ok := parsed["layers"].([]interface{}); !ok || len(layers) != 2 {
+		t.Errorf("layers = %v, want 2 layers", parsed["layers"])
+	}
+}
+
+// TestInitCommandForce verifies an existing config is only overwritten with --force
+func TestInitCommandForce(t *testing.T) {
+	root := writeWorkspace(t, layeredWorkspace)
+	configPath := filepath.Join(root, ".monoguard.yaml")
+	if err := os.WriteFile(configPath, []byte("exclude: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ResetForTesting()
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"init", root})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("Execute() error = %v, want refusal mentioning --force", err)
+	}
+	if data, _ := os.ReadFile(configPath); string(data) != "exclude: []\n" {
+		t.Errorf("config was modified without --force:\n%s", data)
 	}
 
-	// Must contain message field
-	if _, ok := parsed["message"]; !ok {
-		t.Error("JSON output should contain 'message' field")
+	ResetForTesting()
+	buf.Reset()
+	rootCmd.SetArgs([]string{"init", root, "--force", "--format", "json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() with --force error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `"status": "overwritten"`) {
+		t.Errorf("Output should report overwritten: %s", buf.String())
+	}
+	if data, _ := os.ReadFile(configPath); !strings.Contains(string(data), "MonoGuard configuration") {
+		t.Errorf("config not overwritten:\n%s", data)
 	}
 }
 
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/output"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	ownersTeam    string
+	ownersNoCache bool
+)
+
+var ownersCmd = &cobra.Command{
+	Use:   "owners [path]",
+	Short: "Report dependencies and issues by owning team",
+	Long: `Map workspace packages to their owners and report the dependencies
+between packages of different owners and the issues of each owner, so
+that fix work can be routed to the owning team.
+
+Owners are read from the CODEOWNERS file at the git repository root
+(.github/CODEOWNERS, CODEOWNERS or docs/CODEOWNERS) followed by the
+owners section of .monoguard.yaml; the last rule matching a package
+directory wins. CODEOWNERS patterns are relative to the repository
+root and owner rules to the workspace:
+
+  owners:
+    - pattern: "/apps/"
+      owners: ["@org/web"]
+
+Each team lists its packages, the cycles and version conflicts that
+involve them, the boundary violations they cause, and the number of
+dependencies from and to other teams. --team limits the report to one
+owner and the dependencies that cross its boundary.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		snap, err := workspace.Scan(path)
+		if err != nil {
+			return err
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		result, _, err := runAnalysis(cmd, snap, cfg.AnalysisConfig(), ownersNoCache)
+		if err != nil {
+			return err
+		}
+		if result.Ownership == nil {
+			return fmt.Errorf("no owners found: add a CODEOWNERS file or an owners section to .monoguard.yaml")
+		}
+
+		ownership := result.Ownership
+		if ownersTeam != "" {
+			if ownership = filterOwnership(ownership, ownersTeam); ownership == nil {
+				return fmt.Errorf("unknown owner %q", ownersTeam)
+			}
+		}
+		return output.NewFormatter(viper.GetString("format")).PrintTo(cmd.OutOrStdout(), ownership)
+	},
+}
+
+func init() {
+	// Command registration is handled by root.go registerCommands()
+	// Local flags are registered here
+	ownersCmd.Flags().StringVar(&ownersTeam, "team", "",
+		"only report this owner, e.g. @org/web")
+	ownersCmd.Flags().BoolVar(&ownersNoCache, "no-cache", false,
+		"analyze without reading or writing the analysis cache")
+}
+
+// filterOwnership returns the report of owner and the cross-team edges from
+// or to its packages, or nil if owner owns no package
+func filterOwnership(ownership *types.Ownership, owner string) *types.Ownership {
+	filtered := &types.Ownership{CrossTeamEdges: []*types.CrossTeamEdge{}}
+	for _, team := range ownership.Teams {
+		if team.Owner == owner {
+			filtered.Teams = []*types.TeamReport{team}
+		}
+	}
+	if filtered.Teams == nil {
+		return nil
+	}
+	for _, edge := range ownership.CrossTeamEdges {
+		if hasOwner(edge.FromOwners, owner) != hasOwner(edge.ToOwners, owner) {
+			filtered.CrossTeamEdges = append(filtered.CrossTeamEdges, edge)
+		}
+	}
+	return filtered
+}
+
+// hasOwner reports whether owners contains owner
+func hasOwner(owners []string, owner string) bool {
+	for _, o := range owners {
+		if o == owner {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// ownedWorkspace is cycleWorkspace with a CODEOWNERS file assigning its
+// packages to different teams
+var ownedWorkspace = map[string]string{
+	".github/CODEOWNERS": "*             @org/platform\n/packages/a/  @org/web\n",
+}
+
+func init() {
+	for rel, content := range cycleWorkspace {
+		ownedWorkspace[rel] = content
+	}
+}
+
+// TestOwnersCommandRegistered verifies owners command is registered
+func TestOwnersCommandRegistered(t *testing.T) {
+	if findCommand("owners") == nil {
+		t.Error("owners command not registered on rootCmd")
+	}
+}
+
+// TestOwnersCommand verifies cross-team edges and team issues are reported
+func TestOwnersCommand(t *testing.T) {
+	root := writeWorkspace(t, ownedWorkspace)
+
+	out, err := runCommand(t, "owners", root, "--no-cache")
+	if err != nil {
+		t.Fatalf("Execute() error = %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"👥 MonoGuard Ownership: 2 teams",
+		"🔗 Cross-Team Dependencies (2)",
+		"@mono/a (@org/web) → @mono/b (@org/platform)",
+		"@org/web: 1 packages, 1 incoming, 1 outgoing",
+		"🔄 Cycles (1)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+}
+
+// TestOwnersCommandTeam verifies --team limits the report to one owner
+func TestOwnersCommandTeam(t *testing.T) {
+	root := writeWorkspace(t, ownedWorkspace)
+
+	out, err := runCommand(t, "owners", root, "--no-cache", "--team", "@org/web", "--format", "json")
+	if err != nil {
+		t.Fatalf("Execute() error = %v\n%s", err, out)
+	}
+	var ownership struct {
+		CrossTeamEdges []struct {
+			From string `json:"from"`
+		} `json:"crossTeamEdges"`
+		Teams []struct {
+			Owner    string   `json:"owner"`
+			Packages []string `json:"packages"`
+		} `json:"teams"`
+	}
+	if err := json.Unmarshal([]byte(out), &ownership); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if len(ownership.Teams) != 1 || ownership.Teams[0].Owner != "@org/web" || len(ownership.Teams[0].Packages) != 1 {
+		t.Errorf("teams = %+v, want only @org/web", ownership.Teams)
+	}
+	if len(ownership.CrossTeamEdges) != 2 {
+		t.Errorf("crossTeamEdges = %+v, want 2", ownership.CrossTeamEdges)
+	}
+
+	if _, err := runCommand(t, "owners", root, "--no-cache", "--team", "@org/nobody"); err == nil ||
+		!strings.Contains(err.Error(), `unknown owner "@org/nobody"`) {
+		t.Errorf("Execute() error = %v, want unknown owner", err)
+	}
+}
+
+// TestOwnersCommandNoOwners verifies a workspace without owners is an error
+func TestOwnersCommandNoOwners(t *testing.T) {
+	root := writeWorkspace(t, cleanWorkspace)
+
+	_, err := runCommand(t, "owners", root, "--no-cache")
+	if err == nil || !strings.Contains(err.Error(), "no owners found") {
+		t.Errorf("Execute() error = %v, want no owners found", err)
+	}
+}
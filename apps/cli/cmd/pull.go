@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/api"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var pullProject string
+
+var pullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Fetch the latest analysis result stored on the MonoGuard server",
+	Long: `Fetch the latest analysis result uploaded to the MonoGuard API server
+with "monoguard analyze --upload" and print it like analyze does.
+
+The server is read from server.url in .monoguard.yaml or the
+MONOGUARD_SERVER_URL environment variable, the project from --project
+or server.project. MONOGUARD_TOKEN or server.token is sent as a bearer
+token.
+
+Save the result as JSON to compare it with the local workspace:
+
+  monoguard pull --project my-project --format json > server.json
+  monoguard diff server.json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		client, project, err := serverClient(cfg, pullProject)
+		if err != nil {
+			return err
+		}
+
+		stored, err := client.LatestResult(cmd.Context(), project)
+		if api.IsNotFound(err) {
+			return fmt.Errorf("no analysis result stored for project %s", project)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to fetch analysis: %w", err)
+		}
+		if stored.Result == nil {
+			return fmt.Errorf("server returned analysis %s without a result", stored.ID)
+		}
+
+		commit := ""
+		if stored.Commit != "" {
+			commit = " of commit " + stored.Commit
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Analysis %s%s, uploaded %s\n",
+			stored.ID, commit, stored.CreatedAt.Format("2006-01-02 15:04:05 MST"))
+		return output.NewFormatter(viper.GetString("format")).PrintTo(cmd.OutOrStdout(), stored.Result)
+	},
+}
+
+func init() {
+	// Command registration is handled by root.go registerCommands()
+	// Local flags are registered here
+	pullCmd.Flags().StringVar(&pullProject, "project", "",
+		"server project ID (default server.project from the configuration)")
+}
+
+// serverClient returns a client for the configured API server and the
+// project ID from project or server.project
+func serverClient(cfg *config.Config, project string) (*api.Client, string, error) {
+	server := cfg.ServerSettings()
+	if server.URL == "" {
+		return nil, "", fmt.Errorf("no server configured: set server.url in %s or %s", config.FileName, config.ServerURLEnv)
+	}
+	if project == "" {
+		project = server.Project
+	}
+	if project == "" {
+		return nil, "", fmt.Errorf("no project given: use --project or set server.project in %s", config.FileName)
+	}
+	return api.New(server.URL, server.Token), project, nil
+}
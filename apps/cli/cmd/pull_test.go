@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestPullCommandRegistered verifies pull command is registered
+func TestPullCommandRegistered(t *testing.T) {
+	if findCommand("pull") == nil {
+		t.Error("pull command not registered on rootCmd")
+	}
+}
+
+// fakeServer stores uploaded results in memory like the API server does
+func fakeServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	var latest json.RawMessage
+	respond := func(w http.ResponseWriter, status int, data interface{}) {
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": status < 300, "data": data, "message": http.StatusText(status)})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			respond(w, http.StatusUnauthorized, nil)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/projects/p1/results":
+			var body struct {
+				Result json.RawMessage `json:"result"`
+			}
+			data, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(data, &body); err != nil {
+				respond(w, http.StatusBadRequest, nil)
+				return
+			}
+			latest = body.Result
+			respond(w, http.StatusCreated, map[string]string{"id": "r1", "projectId": "p1"})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/projects/p1/results/latest" && latest != nil:
+			respond(w, http.StatusOK, map[string]interface{}{"id": "r1", "projectId": "p1", "result": latest})
+		default:
+			respond(w, http.StatusNotFound, nil)
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("MONOGUARD_SERVER_URL", server.URL)
+	t.Setenv("MONOGUARD_TOKEN", "secret")
+	return server
+}
+
+// TestAnalyzeUploadAndPull verifies analyze --upload stores the result and
+// pull fetches it back
+func TestAnalyzeUploadAndPull(t *testing.T) {
+	server := fakeServer(t)
+	root := writeWorkspace(t, cycleWorkspace)
+
+	out, err := runCommand(t, "pull", "--project", "p1")
+	if err == nil || !strings.Contains(err.Error(), "no analysis result stored for project p1") {
+		t.Errorf("pull before upload error = %v", err)
+	}
+
+	out, err = runCommand(t, "analyze", root, "--no-cache", "--upload", "--project", "p1")
+	if err != nil {
+		t.Fatalf("analyze --upload error = %v\n%s", err, out)
+	}
+	if want := "Uploaded analysis: " + server.URL + "/api/v1/results/r1"; !strings.Contains(out, want) {
+		t.Errorf("output missing %q\n%s", want, out)
+	}
+
+	out, err = runCommand(t, "pull", "--project", "p1", "--format", "json")
+	if err != nil {
+		t.Fatalf("pull error = %v\n%s", err, out)
+	}
+	header, body, _ := strings.Cut(out, "\n")
+	if !strings.HasPrefix(header, "Analysis r1") {
+		t.Errorf("header = %q", header)
+	}
+	var result struct {
+		Packages             int               `json:"packages"`
+		CircularDependencies []json.RawMessage `json:"circularDependencies"`
+	}
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, body)
+	}
+	if result.Packages != 2 || len(result.CircularDependencies) != 1 {
+		t.Errorf("pulled result = %+v", result)
+	}
+}
+
+// TestUploadRequiresServer verifies upload settings are checked before analyzing
+func TestUploadRequiresServer(t *testing.T) {
+	t.Setenv("MONOGUARD_SERVER_URL", "")
+	root := writeWorkspace(t, cleanWorkspace)
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"analyze", root, "--project", "p1"}, "--project requires --upload"},
+		{[]string{"analyze", root, "--upload", "--project", "p1"}, "no server configured"},
+		{[]string{"pull", "--project", "p1"}, "no server configured"},
+	}
+	for _, tt := range tests {
+		_, err := runCommand(t, tt.args...)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v error = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var htmlOut string
+
+var reportCmd = &cobra.Command{
+	Use:   "report [path]",
+	Short: "Write a shareable analysis report",
+	Long: `Analyze the monorepo and write the results as a standalone report.
+
+--html writes a single HTML file with inline styles, scripts and
+diagrams; it can be opened offline and shared with people who do not
+have the CLI. It contains the health score breakdown, a sortable table
+of version conflicts, and a card per circular dependency with its fix
+guides and before/after dependency diagrams. Use "-" to write to stdout.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		result, err := analysis.AnalyzePath(path, cfg.AnalysisConfig())
+		if err != nil {
+			return err
+		}
+
+		if htmlOut == "-" {
+			return output.WriteHTML(cmd.OutOrStdout(), result)
+		}
+
+		var buf bytes.Buffer
+		if err := output.WriteHTML(&buf, result); err != nil {
+			return err
+		}
+		if err := os.WriteFile(htmlOut, buf.Bytes(), 0644); err != nil {
+			return err
+		}
+		return output.NewFormatter(viper.GetString("format")).PrintTo(cmd.OutOrStdout(),
+			fmt.Sprintf("✅ Report written to %s", htmlOut))
+	},
+}
+
+func init() {
+	// Command registration is handled by root.go registerCommands()
+	// Local flags are registered here
+	reportCmd.Flags().StringVar(&htmlOut, "html", "",
+		`write an HTML report to this file ("-" for stdout)`)
+	reportCmd.MarkFlagRequired("html")
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestReportCommandRegistered verifies report command is registered
+func TestReportCommandRegistered(t *testing.T) {
+	if findCommand("report") == nil {
+		t.Error("report command not registered on rootCmd")
+	}
+}
+
+// TestReportCommandHTML verifies the HTML report is written to the given file
+func TestReportCommandHTML(t *testing.T) {
+	ResetForTesting()
+	root := writeWorkspace(t, cycleWorkspace)
+	out := filepath.Join(t.TempDir(), "report.html")
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"report", root, "--html", out})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "Report written to "+out) {
+		t.Errorf("output = %q, want confirmation", buf.String())
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+	html := string(data)
+	for _, want := range []string{"<!DOCTYPE html>", "Circular Dependencies", "<svg viewBox"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+}
+
+// TestReportCommandStdout verifies "-" writes the report to stdout
+func TestReportCommandStdout(t *testing.T) {
+	ResetForTesting()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"report", writeWorkspace(t, cleanWorkspace), "--html", "-"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "<!DOCTYPE html>") {
+		t.Errorf("stdout should contain the report, got %.100q", buf.String())
+	}
+}
+
+// TestReportCommandRequiresHTML verifies --html is required
+func TestReportCommandRequiresHTML(t *testing.T) {
+	ResetForTesting()
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"report", writeWorkspace(t, cleanWorkspace)})
+
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "html") {
+		t.Errorf("Execute() error = %v, want missing --html", err)
+	}
+}
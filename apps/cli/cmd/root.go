@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/watch"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -24,11 +27,42 @@ var rootCmd = &cobra.Command{
 dependencies, detecting circular dependencies, and providing
 actionable fix suggestions.`,
 	Version: version,
+	// Errors are printed once by Execute
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// init must run even when the existing configuration is broken,
+		// since it is how the configuration gets replaced
+		if file := viper.ConfigFileUsed(); file != "" && cmd != initCmd {
+			resolved, err := config.Resolve(file)
+			if err != nil {
+				return err
+			}
+			if err := viper.MergeConfigMap(resolved.Values); err != nil {
+				return err
+			}
+		}
+		applyOutputDefaults(cmd)
+		return nil
+	},
+}
+
+// exitError makes Execute exit with the given code without printing anything.
+// Commands return it after they have already reported the failure themselves.
+type exitError struct {
+	code int
+}
+
+func (e *exitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
 }
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
+		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
 }
@@ -37,6 +71,8 @@ func init() {
 	cobra.OnInitialize(initConfig)
 	registerFlags()
 	registerCommands()
+
+	resetInheritedFlags(rootCmd)
 }
 
 // registerFlags adds all persistent flags to rootCmd
@@ -46,7 +82,7 @@ func registerFlags() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false,
 		"verbose output")
 	rootCmd.PersistentFlags().StringVar(&format, "format", "text",
-		"output format (text|json)")
+		"output format (text|json|markdown|sarif|junit|github|gitlab-codequality|dot|mermaid|graphml)")
 
 	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
 	viper.BindPFlag("format", rootCmd.PersistentFlags().Lookup("format"))
@@ -54,10 +90,23 @@ func registerFlags() {
 
 // registerCommands adds all subcommands to rootCmd
 func registerCommands() {
+	rootCmd.AddCommand(affectedCmd)
 	rootCmd.AddCommand(analyzeCmd)
+	rootCmd.AddCommand(baselineCmd)
 	rootCmd.AddCommand(checkCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(exploreCmd)
 	rootCmd.AddCommand(fixCmd)
+	rootCmd.AddCommand(graphCmd)
 	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(ownersCmd)
+	rootCmd.AddCommand(pullCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(trendCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(whyCmd)
 }
 
 // ResetForTesting resets and re-registers all commands and flags
@@ -65,6 +114,7 @@ func registerCommands() {
 func ResetForTesting() {
 	rootCmd.ResetCommands()
 	rootCmd.ResetFlags()
+	viper.Reset()
 
 	// Reset global flag variables to defaults
 	cfgFile = ""
@@ -72,22 +122,161 @@ func ResetForTesting() {
 	format = "text"
 
 	// Reset command-specific flags
+	resetAffectedFlags()
+	resetAnalyzeFlags()
+	resetBaselineFlags()
 	resetCheckFlags()
+	resetDiffFlags()
 	resetFixFlags()
+	resetGraphFlags()
+	resetInitFlags()
+	resetOwnersFlags()
+	resetPullFlags()
+	resetReportFlags()
+	resetTrendFlags()
+	resetWatchFlags()
+	resetWhyFlags()
 
 	registerFlags()
 	registerCommands()
+
+	resetInheritedFlags(rootCmd)
+}
+
+// resetInheritedFlags clears the persistent flags that subcommands keep
+// merged in from previous runs
+func resetInheritedFlags(parent *cobra.Command) {
+	for _, cmd := range parent.Commands() {
+		for _, name := range []string{"config", "verbose", "format"} {
+			if f := cmd.Flags().Lookup(name); f != nil {
+				f.Changed = false
+			}
+		}
+		resetInheritedFlags(cmd)
+	}
+}
+
+// resetAffectedFlags resets affected command flags to defaults
+func resetAffectedFlags() {
+	affectedBase = ""
+	affectedStdin = false
+	for _, name := range []string{"base", "stdin"} {
+		affectedCmd.Flags().Lookup(name).Changed = false
+	}
+}
+
+// resetAnalyzeFlags resets analyze command flags to defaults
+func resetAnalyzeFlags() {
+	maxLength = 0
+	analyzeNoCache = false
+	analyzeRecord = false
+	analyzeUpload = false
+	analyzeProject = ""
+	for _, name := range []string{"max-length", "no-cache", "record", "upload", "project"} {
+		analyzeCmd.Flags().Lookup(name).Changed = false
+	}
+}
+
+// resetBaselineFlags resets baseline command flags to defaults
+func resetBaselineFlags() {
+	baselineOut = ""
+	baselineCmd.Flags().Lookup("output").Changed = false
 }
 
 // resetCheckFlags resets check command flags to defaults
 func resetCheckFlags() {
 	failOn = "all"
 	threshold = 0
+	baselineFile = ""
+	checkNoCache = false
+	for _, name := range []string{"baseline", "no-cache"} {
+		checkCmd.Flags().Lookup(name).Changed = false
+	}
+}
+
+// resetDiffFlags resets diff command flags to defaults
+func resetDiffFlags() {
+	diffPath = "."
+	failOnRegression = false
+	for _, name := range []string{"path", "fail-on-regression"} {
+		diffCmd.Flags().Lookup(name).Changed = false
+	}
 }
 
 // resetFixFlags resets fix command flags to defaults
 func resetFixFlags() {
 	dryRun = false
+	apply = false
+	strategy = ""
+	cycleNumber = 1
+	for _, name := range []string{"dry-run", "apply", "strategy", "cycle"} {
+		fixCmd.Flags().Lookup(name).Changed = false
+	}
+}
+
+// resetGraphFlags resets graph command flags to defaults
+func resetGraphFlags() {
+	focus = ""
+	graphDepth = 0
+	noDev = false
+	noPeer = false
+	clusterDirs = false
+	for _, name := range []string{"focus", "depth", "no-dev", "no-peer", "cluster"} {
+		graphCmd.Flags().Lookup(name).Changed = false
+	}
+}
+
+// resetInitFlags resets init command flags to defaults
+func resetInitFlags() {
+	force = false
+	initCmd.Flags().Lookup("force").Changed = false
+}
+
+// resetOwnersFlags resets owners command flags to defaults
+func resetOwnersFlags() {
+	ownersTeam = ""
+	ownersNoCache = false
+	for _, name := range []string{"team", "no-cache"} {
+		ownersCmd.Flags().Lookup(name).Changed = false
+	}
+}
+
+// resetPullFlags resets pull command flags to defaults
+func resetPullFlags() {
+	pullProject = ""
+	pullCmd.Flags().Lookup("project").Changed = false
+}
+
+// resetReportFlags resets report command flags to defaults
+func resetReportFlags() {
+	htmlOut = ""
+	reportCmd.Flags().Lookup("html").Changed = false
+}
+
+// resetTrendFlags resets trend command flags to defaults
+func resetTrendFlags() {
+	trendLast = 20
+	trendFailOnRegression = false
+	for _, name := range []string{"last", "fail-on-regression"} {
+		trendCmd.Flags().Lookup(name).Changed = false
+	}
+}
+
+// resetWatchFlags resets watch command flags to defaults
+func resetWatchFlags() {
+	debounce = watch.DefaultDebounce
+	watchCmd.Flags().Lookup("debounce").Changed = false
+}
+
+// resetWhyFlags resets why command flags to defaults
+func resetWhyFlags() {
+	whyPath = "."
+	maxPaths = 10
+	whyNoDev = false
+	whyNoPeer = false
+	for _, name := range []string{"path", "max-paths", "no-dev", "no-peer"} {
+		whyCmd.Flags().Lookup(name).Changed = false
+	}
 }
 
 func initConfig() {
@@ -110,3 +299,13 @@ func initConfig() {
 	// Read config file (ignore error if not found)
 	viper.ReadInConfig()
 }
+
+// applyOutputDefaults uses output.format and output.verbose from the
+// configuration unless --format or --verbose is given
+func applyOutputDefaults(cmd *cobra.Command) {
+	for _, key := range []string{"format", "verbose"} {
+		if viper.IsSet("output."+key) && !cmd.Flags().Changed(key) {
+			viper.SetDefault(key, viper.Get("output."+key))
+		}
+	}
+}
@@ -36,7 +36,7 @@ func TestRootCommandHelp(t *testing.T) {
 	}
 
 	// AC3: Available commands list
-	expectedCommands := []string{"analyze", "check", "fix", "init"}
+	expectedCommands := []string{"affected", "analyze", "baseline", "check", "config", "diff", "doctor", "explore", "fix", "graph", "init", "owners", "pull", "report", "trend", "watch", "why"}
 	for _, cmd := range expectedCommands {
 		if !strings.Contains(output, cmd) {
 			t.Errorf("Help output should list '%s' command", cmd)
@@ -130,9 +130,9 @@ func TestRootCommandDescription(t *testing.T) {
 }
 
 // TestSubcommandsRegistered verifies all subcommands are registered
-// AC3: Available commands: analyze, check, fix, init
+// AC3: Available commands: analyze, check, fix, init, watch
 func TestSubcommandsRegistered(t *testing.T) {
-	expectedCommands := []string{"analyze", "check", "fix", "init"}
+	expectedCommands := []string{"affected", "analyze", "baseline", "check", "config", "diff", "doctor", "explore", "fix", "graph", "init", "owners", "pull", "report", "trend", "watch", "why"}
 
 	for _, cmdName := range expectedCommands {
 		found := false
@@ -171,3 +171,44 @@ func TestViperInitialization(t *testing.T) {
 		t.Log("Viper initialized successfully")
 	}
 }
+
+// TestConfigValidation verifies commands refuse an invalid configuration file
+func TestConfigValidation(t *testing.T) {
+	root := writeWorkspace(t, cleanWorkspace)
+	t.Chdir(root)
+	writeFiles(t, root, map[string]string{".monoguard.yaml": "rules:\n  circularDependencies: eror\n"})
+
+	_, err := runCommand(t, "analyze")
+	want := `.monoguard.yaml:2:25: rules.circularDependencies: must be one of error, warn, off (got "eror")`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Execute() error = %v, want %q", err, want)
+	}
+
+	// init can still replace the broken file
+	if _, err := runCommand(t, "init", "--force"); err != nil {
+		t.Errorf("init error = %v", err)
+	}
+}
+
+// TestConfigOutputDefaults verifies output.format applies unless --format is given
+func TestConfigOutputDefaults(t *testing.T) {
+	root := writeWorkspace(t, cleanWorkspace)
+	t.Chdir(root)
+	writeFiles(t, root, map[string]string{".monoguard.yaml": "output:\n  format: json\n"})
+
+	out, err := runCommand(t, "analyze")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.HasPrefix(out, "{") {
+		t.Errorf("output is not JSON\n%s", out)
+	}
+
+	out, err = runCommand(t, "analyze", "--format", "text")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if strings.HasPrefix(out, "{") {
+		t.Errorf("--format text did not override output.format\n%s", out)
+	}
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/history"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	trendLast             int
+	trendFailOnRegression bool
+)
+
+var trendCmd = &cobra.Command{
+	Use:   "trend [path]",
+	Short: "Show the health score trend of recorded analyses",
+	Long: `Show how the health score, its factors and the issue counts developed
+over the runs recorded by "monoguard analyze --record" in
+.monoguard/history.jsonl of the workspace.
+
+Each metric is printed as a sparkline with its change over the shown
+runs and in the last run. A metric regressed when the last run is
+worse than the one before it: a lower score or more issues.
+
+  monoguard analyze --record
+  monoguard trend --last 10
+
+With --fail-on-regression the command exits with code 1 when the last
+run regressed.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+		if trendLast < 0 {
+			return fmt.Errorf("--last must not be negative")
+		}
+
+		records, err := history.Load(filepath.Join(path, history.DefaultFile))
+		if err != nil {
+			return err
+		}
+		trend := history.NewTrend(records, trendLast)
+
+		if err := output.NewFormatter(viper.GetString("format")).PrintTo(cmd.OutOrStdout(), trend); err != nil {
+			return err
+		}
+		if trendFailOnRegression && trend.Regressed() {
+			return &exitError{code: 1}
+		}
+		return nil
+	},
+}
+
+func init() {
+	// Command registration is handled by root.go registerCommands()
+	// Local flags are registered here
+	trendCmd.Flags().IntVar(&trendLast, "last", 20,
+		"number of most recent runs to show, 0 for all")
+	trendCmd.Flags().BoolVar(&trendFailOnRegression, "fail-on-regression", false,
+		"exit with code 1 if the last run regressed")
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestTrendCommandRegistered verifies trend command is registered
+func TestTrendCommandRegistered(t *testing.T) {
+	if findCommand("trend") == nil {
+		t.Error("trend command not registered on rootCmd")
+	}
+}
+
+// TestTrendCommandRecordedRuns verifies analyze --record feeds the trend and
+// a regression fails with --fail-on-regression
+func TestTrendCommandRecordedRuns(t *testing.T) {
+	root := writeWorkspace(t, cleanWorkspace)
+
+	out, err := runCommand(t, "trend", root)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(out, "no runs recorded yet") {
+		t.Errorf("empty history output = %q", out)
+	}
+
+	if _, err := runCommand(t, "analyze", root, "--record"); err != nil {
+		t.Fatalf("analyze --record error = %v", err)
+	}
+	writeFiles(t, root, cycleWorkspace)
+	if _, err := runCommand(t, "analyze", root, "--record"); err != nil {
+		t.Fatalf("analyze --record error = %v", err)
+	}
+
+	out, err = runCommand(t, "trend", root, "--format", "json")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	var trend struct {
+		Records int `json:"records"`
+		Metrics []struct {
+			Name      string `json:"name"`
+			Values    []int  `json:"values"`
+			Regressed bool   `json:"regressed"`
+		} `json:"metrics"`
+	}
+	if err := json.Unmarshal([]byte(out), &trend); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if trend.Records != 2 {
+		t.Errorf("records = %d, want 2", trend.Records)
+	}
+	for _, m := range trend.Metrics {
+		if m.Name == "Cycles" && (len(m.Values) != 2 || m.Values[1] != 1 || !m.Regressed) {
+			t.Errorf("Cycles metric = %+v, want a regression to 1 cycle", m)
+		}
+	}
+
+	_, err = runCommand(t, "trend", root, "--fail-on-regression")
+	var exitErr *exitError
+	if !errors.As(err, &exitErr) || exitErr.code != 1 {
+		t.Errorf("Execute() error = %v, want exit code 1", err)
+	}
+}
+
+// TestTrendCommandNegativeLast verifies --last validation
+func TestTrendCommandNegativeLast(t *testing.T) {
+	if _, err := runCommand(t, "trend", t.TempDir(), "--last", "-1"); err == nil {
+		t.Error("Execute() should fail for a negative --last")
+	}
+}
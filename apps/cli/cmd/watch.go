@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/output"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/watch"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var debounce time.Duration
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [path]",
+	Short: "Re-analyze the monorepo as files change",
+	Long: `Watch the workspace and re-run the analysis whenever a
+package.json, workspace configuration or JS/TS source file changes.
+
+Only the changed files are re-read from disk. After each change the
+cycles and version conflicts that appeared or were resolved are printed,
+so a new circular import shows up as soon as it is saved. Changes are
+batched until none arrive for --debounce.
+
+node_modules and paths matched by .gitignore are not watched.
+Stop with Ctrl+C.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+		if debounce <= 0 {
+			return fmt.Errorf("invalid --debounce %s (must be positive)", debounce)
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		session, err := watch.NewSession(path, cfg.AnalysisConfig())
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("verbose") {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Watching %s (Ctrl+C to stop)\n", session.Root())
+		}
+
+		formatter := output.NewFormatter(viper.GetString("format"))
+		if err := formatter.PrintTo(cmd.OutOrStdout(), session.Initial()); err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		var printErr error
+		err = session.Watch(ctx, debounce, func(e *watch.Event) {
+			if printErr == nil {
+				printErr = formatter.PrintTo(cmd.OutOrStdout(), e)
+			}
+		}, func(err error) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: file watcher: %v\n", err)
+		})
+		if err != nil {
+			return err
+		}
+		return printErr
+	},
+}
+
+func init() {
+	// Command registration is handled by root.go registerCommands()
+	// Local flags are registered here
+	watchCmd.Flags().DurationVar(&debounce, "debounce", watch.DefaultDebounce,
+		"wait this long after the last change before re-analyzing")
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestWatchCommandRegistered verifies watch command is registered
+func TestWatchCommandRegistered(t *testing.T) {
+	if findCommand("watch") == nil {
+		t.Error("watch command not registered on rootCmd")
+	}
+}
+
+// TestWatchCommandInitialEvent verifies the initial analysis is printed and
+// the command stops cleanly when its context is cancelled
+func TestWatchCommandInitialEvent(t *testing.T) {
+	ResetForTesting()
+	root := writeWorkspace(t, cycleWorkspace)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"watch", root, "--format", "json"})
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\nOutput: %s", err, buf.String())
+	}
+	if cycles, ok := parsed["newCycles"].([]interface{}); !ok || len(cycles) != 1 {
+		t.Errorf("newCycles = %v, want the existing cycle", parsed["newCycles"])
+	}
+	if files, ok := parsed["files"].([]interface{}); !ok || len(files) != 0 {
+		t.Errorf("files = %v, want empty for the initial event", parsed["files"])
+	}
+}
+
+// TestWatchCommandInvalidDebounce verifies --debounce must be positive
+func TestWatchCommandInvalidDebounce(t *testing.T) {
+	ResetForTesting()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"watch", writeWorkspace(t, cycleWorkspace), "--debounce", "0s"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--debounce") {
+		t.Errorf("Execute() error = %v, want invalid --debounce", err)
+	}
+}
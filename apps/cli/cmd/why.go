@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/graph"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/output"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/analyzer"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	whyPath   string
+	maxPaths  int
+	whyNoDev  bool
+	whyNoPeer bool
+)
+
+var whyCmd = &cobra.Command{
+	Use:   "why <from> <to>",
+	Short: "Explain how one package depends on another",
+	Long: `List the dependency paths by which one workspace package depends on
+another, shortest first.
+
+Each hop shows the package.json entries behind it (dependency type
+and version range) and the import statements that use the
+dependency, when the sources are in the workspace:
+
+  monoguard why @mono/app @mono/core
+
+--max-paths limits the number of paths (0 lists up to 1000). In large
+graphs the search may stop early; a shortest path is always listed.
+--no-dev and --no-peer ignore devDependencies and peerDependencies.`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if maxPaths < 0 {
+			return fmt.Errorf("--max-paths must not be negative")
+		}
+
+		snap, err := workspace.Scan(whyPath)
+		if err != nil {
+			return err
+		}
+		ws, err := analysis.Parse(snap)
+		if err != nil {
+			return err
+		}
+		g, err := analyzer.NewGraphBuilder().Build(ws)
+		if err != nil {
+			return err
+		}
+
+		ex, err := graph.Explain(g, args[0], args[1], graph.PathOptions{
+			Limit:    maxPaths,
+			HideDev:  whyNoDev,
+			HidePeer: whyNoPeer,
+		})
+		if err != nil {
+			return err
+		}
+		if len(snap.SourceFiles) > 0 {
+			ex.TraceImports(analyzer.NewImportTracer(ws, snap.SourceFiles).TraceEdge)
+		}
+
+		return output.NewFormatter(viper.GetString("format")).PrintTo(cmd.OutOrStdout(), ex)
+	},
+}
+
+func init() {
+	// Command registration is handled by root.go registerCommands()
+	// Local flags are registered here
+	whyCmd.Flags().StringVar(&whyPath, "path", ".",
+		"workspace directory")
+	whyCmd.Flags().IntVar(&maxPaths, "max-paths", 10,
+		"maximum number of paths to list, shortest first (0 for up to 1000)")
+	whyCmd.Flags().BoolVar(&whyNoDev, "no-dev", false,
+		"ignore devDependencies")
+	whyCmd.Flags().BoolVar(&whyNoPeer, "no-peer", false,
+		"ignore peerDependencies")
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestWhyCommandRegistered verifies why command is registered
+func TestWhyCommandRegistered(t *testing.T) {
+	if findCommand("why") == nil {
+		t.Error("why command not registered on rootCmd")
+	}
+}
+
+// TestWhyCommandTransitive verifies the path and the import behind each hop
+func TestWhyCommandTransitive(t *testing.T) {
+	files := map[string]string{"apps/web/src/index.ts": "import { Button } from '@mono/ui';\n"}
+	for rel, content := range layeredWorkspace {
+		files[rel] = content
+	}
+	root := writeWorkspace(t, files)
+
+	out, err := runCommand(t, "why", "@mono/example-basic", "@mono/ui", "--path", root)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	wantContains := []string{
+		"🔍 Why @mono/example-basic depends on @mono/ui: 1 paths",
+		"   @mono/example-basic → @mono/web [production workspace:*]",
+		"   @mono/web → @mono/ui [production workspace:*]\n      apps/web/src/index.ts:1  import { Button } from '@mono/ui'",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+}
+
+// TestWhyCommandJSON verifies the JSON output of a cycle
+func TestWhyCommandJSON(t *testing.T) {
+	root := writeWorkspace(t, cycleWorkspace)
+
+	out, err := runCommand(t, "why", "@mono/b", "@mono/a", "--path", root, "--format", "json")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	var ex struct {
+		Paths []struct {
+			Hops []struct {
+				From    string            `json:"from"`
+				Imports []json.RawMessage `json:"imports"`
+			} `json:"hops"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal([]byte(out), &ex); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if len(ex.Paths) != 1 || len(ex.Paths[0].Hops) != 1 || len(ex.Paths[0].Hops[0].Imports) != 1 {
+		t.Errorf("unexpected explanation\n%s", out)
+	}
+}
+
+// TestWhyCommandErrors verifies unknown packages and invalid flags are rejected
+func TestWhyCommandErrors(t *testing.T) {
+	root := writeWorkspace(t, cleanWorkspace)
+	tests := map[string][]string{
+		"unknown package": {"why", "@mono/a", "@mono/missing", "--path", root},
+		"negative limit":  {"why", "@mono/a", "@mono/b", "--path", root, "--max-paths", "-1"},
+	}
+	for name, args := range tests {
+		if _, err := runCommand(t, args...); err == nil {
+			t.Errorf("%s: Execute() error = nil, want error", name)
+		}
+	}
+}
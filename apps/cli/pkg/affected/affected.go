@@ -0,0 +1,154 @@
+// Package affected maps changed files to the workspace packages that need
+// to be rebuilt and retested.
+package affected
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/analyzer"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// Result lists the packages affected by a set of changed files
+type Result struct {
+	Base           string    `json:"base,omitempty"` // Git ref the files changed since, if any
+	ChangedFiles   int       `json:"changedFiles"`
+	Packages       []Package `json:"packages"`       // Dependencies before their dependents
+	UnmatchedFiles []string  `json:"unmatchedFiles"` // Changed files outside every package
+}
+
+// Package is an affected workspace package
+type Package struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Changed  bool   `json:"changed"`            // Contains changed files
+	Via      string `json:"via,omitempty"`      // Changed package it depends on, if not changed itself
+	Distance int    `json:"distance,omitempty"` // Dependency hops from Via
+}
+
+// Names returns the names of the affected packages in order
+func (r *Result) Names() []string {
+	names := make([]string, len(r.Packages))
+	for i, p := range r.Packages {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// Compute maps files, relative to the workspace root, to the packages whose
+// directory contains them, and adds every package that transitively depends
+// on one of those. Packages are sorted topologically; packages in a cycle
+// are ordered by name.
+func Compute(ws *types.WorkspaceData, files []string) (*Result, error) {
+	graph, err := analyzer.NewGraphBuilder().Build(ws)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{ChangedFiles: len(files), Packages: []Package{}, UnmatchedFiles: []string{}}
+	affected := map[string]Package{}
+	for _, f := range files {
+		name, ok := owner(ws, f)
+		if !ok {
+			result.UnmatchedFiles = append(result.UnmatchedFiles, f)
+			continue
+		}
+		affected[name] = Package{Name: name, Path: ws.Packages[name].Path, Changed: true}
+	}
+
+	changed := make([]string, 0, len(affected))
+	for name := range affected {
+		changed = append(changed, name)
+	}
+	sort.Strings(changed)
+	for _, dep := range analyzer.NewImpactAnalyzer(graph, ws).Dependents(changed) {
+		affected[dep.PackageName] = Package{
+			Name:     dep.PackageName,
+			Path:     ws.Packages[dep.PackageName].Path,
+			Via:      dep.DependsOn,
+			Distance: dep.Distance,
+		}
+	}
+
+	for _, name := range topologicalOrder(graph, affected) {
+		result.Packages = append(result.Packages, affected[name])
+	}
+	return result, nil
+}
+
+// owner returns the package with the deepest directory containing file
+func owner(ws *types.WorkspaceData, file string) (string, bool) {
+	file = path.Clean(strings.TrimPrefix(file, "./"))
+	best, bestLen := "", -1
+	for name, pkg := range ws.Packages {
+		dir := path.Clean(pkg.Path)
+		if dir != "." && file != dir && !strings.HasPrefix(file, dir+"/") {
+			continue
+		}
+		if len(dir) > bestLen || (len(dir) == bestLen && name < best) {
+			best, bestLen = name, len(dir)
+		}
+	}
+	return best, bestLen >= 0
+}
+
+// topologicalOrder sorts the selected packages so that each comes after the
+// selected packages it depends on. Ties, and packages left in a cycle, are
+// ordered by name.
+func topologicalOrder(graph *types.DependencyGraph, selected map[string]Package) []string {
+	pending := map[string]int{} // Number of selected dependencies not yet emitted
+	dependents := map[string][]string{}
+	seen := map[[2]string]bool{}
+	for name := range selected {
+		pending[name] = 0
+	}
+	for _, e := range graph.Edges {
+		key := [2]string{e.From, e.To}
+		if _, ok := selected[e.From]; !ok || seen[key] || e.From == e.To {
+			continue
+		}
+		if _, ok := selected[e.To]; !ok {
+			continue
+		}
+		seen[key] = true
+		pending[e.From]++
+		dependents[e.To] = append(dependents[e.To], e.From)
+	}
+
+	var order, ready []string
+	for name, n := range pending {
+		if n == 0 {
+			ready = append(ready, name)
+		}
+	}
+	for len(order) < len(selected) {
+		if len(ready) == 0 {
+			// Break a cycle by emitting the first remaining package
+			var rest []string
+			for name, n := range pending {
+				if n > 0 {
+					rest = append(rest, name)
+				}
+			}
+			sort.Strings(rest)
+			ready = rest[:1]
+			pending[rest[0]] = 0
+		}
+		sort.Strings(ready)
+		name := ready[0]
+		ready = ready[1:]
+		order = append(order, name)
+		pending[name] = -1
+		for _, d := range dependents[name] {
+			if pending[d] > 0 {
+				pending[d]--
+				if pending[d] == 0 {
+					ready = append(ready, d)
+				}
+			}
+		}
+	}
+	return order
+}
@@ -0,0 +1,108 @@
+package affected
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// workspace creates packages at packages/<short name> with the given
+// internal dependencies
+func workspace(deps map[string][]string) *types.WorkspaceData {
+	ws := &types.WorkspaceData{
+		RootPath:      "/repo",
+		WorkspaceType: types.WorkspaceTypePnpm,
+		Packages:      map[string]*types.PackageInfo{},
+	}
+	for name, on := range deps {
+		pkg := &types.PackageInfo{
+			Name:         name,
+			Version:      "1.0.0",
+			Path:         "packages/" + name[len("@mono/"):],
+			Dependencies: map[string]string{},
+		}
+		for _, d := range on {
+			pkg.Dependencies[d] = "workspace:*"
+		}
+		ws.Packages[name] = pkg
+	}
+	return ws
+}
+
+func TestCompute(t *testing.T) {
+	// app -> ui -> core, api -> core, docs is independent
+	ws := workspace(map[string][]string{
+		"@mono/app":  {"@mono/ui", "@mono/api"},
+		"@mono/ui":   {"@mono/core"},
+		"@mono/api":  {"@mono/core"},
+		"@mono/core": nil,
+		"@mono/docs": nil,
+	})
+
+	result, err := Compute(ws, []string{"packages/core/src/index.ts", "./packages/core/package.json", "README.md"})
+	if err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+
+	want := []string{"@mono/core", "@mono/api", "@mono/ui", "@mono/app"}
+	if got := result.Names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+	if !result.Packages[0].Changed || result.Packages[0].Path != "packages/core" {
+		t.Errorf("Packages[0] = %+v, want changed packages/core", result.Packages[0])
+	}
+	app := result.Packages[3]
+	if app.Changed || app.Via != "@mono/core" || app.Distance != 2 {
+		t.Errorf("app = %+v, want via @mono/core at distance 2", app)
+	}
+	if !reflect.DeepEqual(result.UnmatchedFiles, []string{"README.md"}) {
+		t.Errorf("UnmatchedFiles = %v, want [README.md]", result.UnmatchedFiles)
+	}
+	if result.ChangedFiles != 3 {
+		t.Errorf("ChangedFiles = %d, want 3", result.ChangedFiles)
+	}
+}
+
+func TestCompute_NestedPackage(t *testing.T) {
+	ws := workspace(map[string][]string{
+		"@mono/ui":         nil,
+		"@mono/ui/plugins": nil,
+	})
+
+	result, err := Compute(ws, []string{"packages/ui/plugins/index.ts"})
+	if err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+	if got := result.Names(); !reflect.DeepEqual(got, []string{"@mono/ui/plugins"}) {
+		t.Errorf("Names() = %v, want the deepest package only", got)
+	}
+}
+
+func TestCompute_Cycle(t *testing.T) {
+	// a <-> b, c -> a; the cycle is ordered by name, c comes last
+	ws := workspace(map[string][]string{
+		"@mono/a": {"@mono/b"},
+		"@mono/b": {"@mono/a"},
+		"@mono/c": {"@mono/a"},
+	})
+
+	result, err := Compute(ws, []string{"packages/b/index.ts"})
+	if err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+	want := []string{"@mono/a", "@mono/b", "@mono/c"}
+	if got := result.Names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+}
+
+func TestCompute_NoChanges(t *testing.T) {
+	result, err := Compute(workspace(map[string][]string{"@mono/a": nil}), nil)
+	if err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+	if len(result.Packages) != 0 || len(result.UnmatchedFiles) != 0 {
+		t.Errorf("Compute() = %+v, want nothing affected", result)
+	}
+}
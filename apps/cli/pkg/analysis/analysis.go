@@ -0,0 +1,82 @@
+// Package analysis runs the analysis engine pipeline against a local workspace.
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/analyzer"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/parser"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// Run parses the workspace snapshot and runs the full analysis pipeline,
+// including import tracing for the collected source files.
+// config is optional; nil analyzes every workspace package.
+func Run(snap *workspace.Snapshot, config *types.AnalysisConfig) (*types.AnalysisResult, error) {
+	result, _, err := RunWorkspace(snap, config)
+	return result, err
+}
+
+// RunWorkspace is Run that also returns the workspace data the result was
+// computed from, for callers that hand both to plugins.
+func RunWorkspace(snap *workspace.Snapshot, config *types.AnalysisConfig) (*types.AnalysisResult, *types.WorkspaceData, error) {
+	workspaceData, err := Parse(snap)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	a, err := analyzer.NewAnalyzerWithConfig(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid analysis config: %w", err)
+	}
+
+	result, err := a.AnalyzeWithSources(workspaceData, snap.SourceFiles)
+	if err != nil {
+		return nil, nil, fmt.Errorf("analysis failed: %w", err)
+	}
+
+	return result, workspaceData, nil
+}
+
+// Parse reads the packages of the workspace snapshot without analyzing them.
+func Parse(snap *workspace.Snapshot) (*types.WorkspaceData, error) {
+	if snap == nil {
+		return nil, fmt.Errorf("no workspace snapshot provided")
+	}
+
+	p := parser.NewParser(snap.Root)
+	workspaceData, err := p.Parse(snap.Files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workspace %s: %w", snap.Root, err)
+	}
+	workspaceData.CodeOwners = codeOwners(snap)
+	return workspaceData, nil
+}
+
+// codeOwners reads the CODEOWNERS rules of the snapshot. Below the repository
+// root the rules come from the repository's CODEOWNERS file and keep the
+// workspace directory, as their patterns are relative to the repository root.
+func codeOwners(snap *workspace.Snapshot) []types.OwnerRule {
+	content := snap.Codeowners
+	if snap.CodeownersRoot == "" {
+		_, content = parser.FindCodeowners(snap.Files)
+	}
+	if content == nil {
+		return nil
+	}
+	rules := parser.ParseCodeowners(content)
+	for i := range rules {
+		rules[i].Root = snap.CodeownersRoot
+	}
+	return rules
+}
+
+// AnalyzePath scans the workspace rooted at path and analyzes it.
+func AnalyzePath(path string, config *types.AnalysisConfig) (*types.AnalysisResult, error) {
+	snap, err := workspace.Scan(path)
+	if err != nil {
+		return nil, err
+	}
+	return Run(snap, config)
+}
@@ -0,0 +1,136 @@
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// writeFiles creates files under root from a map of relative path to content.
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// cycleWorkspace is a pnpm workspace where @mono/a and @mono/b depend on each other.
+var cycleWorkspace = map[string]string{
+	"package.json":            `{"name": "root", "private": true}`,
+	"pnpm-workspace.yaml":     "packages:\n  - 'packages/*'\n",
+	"pnpm-lock.yaml":          "",
+	"packages/a/package.json": `{"name": "@mono/a", "version": "1.0.0", "dependencies": {"@mono/b": "workspace:*", "lodash": "^4.17.21"}}`,
+	"packages/a/src/index.ts": "import { b } from '@mono/b';\nexport const a = b;\n",
+	"packages/b/package.json": `{"name": "@mono/b", "version": "1.0.0", "dependencies": {"@mono/a": "workspace:*", "lodash": "^3.10.0"}}`,
+	"packages/b/src/index.ts": "import { a } from '@mono/a';\nexport const b = 1;\n",
+}
+
+func TestAnalyzePath(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, cycleWorkspace)
+
+	result, err := AnalyzePath(root, nil)
+	if err != nil {
+		t.Fatalf("AnalyzePath() error = %v", err)
+	}
+
+	if result.Packages != 2 {
+		t.Errorf("Packages = %d, want 2", result.Packages)
+	}
+	if result.Graph == nil || result.Graph.WorkspaceType != types.WorkspaceTypePnpm {
+		t.Errorf("expected pnpm workspace graph, got %+v", result.Graph)
+	}
+	if len(result.CircularDependencies) != 1 {
+		t.Fatalf("CircularDependencies = %d, want 1", len(result.CircularDependencies))
+	}
+	if len(result.CircularDependencies[0].ImportTraces) == 0 {
+		t.Error("expected import traces from scanned source files")
+	}
+	if len(result.VersionConflicts) != 1 || result.VersionConflicts[0].PackageName != "lodash" {
+		t.Errorf("expected lodash version conflict, got %+v", result.VersionConflicts)
+	}
+}
+
+func TestAnalyzePath_RepositoryCodeowners(t *testing.T) {
+	repo := t.TempDir()
+	files := map[string]string{
+		".git/HEAD":          "ref: refs/heads/main\n",
+		".github/CODEOWNERS": "/frontend/packages/a/ @org/a\n/packages/ @org/backend\n",
+	}
+	for rel, content := range cycleWorkspace {
+		files["frontend/"+rel] = content
+	}
+	writeFiles(t, repo, files)
+
+	result, err := AnalyzePath(filepath.Join(repo, "frontend"), nil)
+	if err != nil {
+		t.Fatalf("AnalyzePath() error = %v", err)
+	}
+
+	// /packages/ is the repository's packages/, not the workspace's
+	want := map[string]string{"@mono/a": "@org/a", "@mono/b": ""}
+	for name, owner := range want {
+		node := result.Graph.Nodes[name]
+		if node == nil {
+			t.Fatalf("package %s missing from the graph", name)
+		}
+		if got := strings.Join(node.Owners, ","); got != owner {
+			t.Errorf("%s owners = %q, want %q", name, got, owner)
+		}
+	}
+}
+
+func TestRun_WithExclusions(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, cycleWorkspace)
+
+	snap, err := workspace.Scan(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Run(snap, &types.AnalysisConfig{Exclude: []string{"@mono/b"}})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.ExcludedPackages != 1 {
+		t.Errorf("ExcludedPackages = %d, want 1", result.ExcludedPackages)
+	}
+	if len(result.CircularDependencies) != 0 {
+		t.Errorf("excluded package should break the cycle, got %d cycles", len(result.CircularDependencies))
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	t.Run("nil snapshot", func(t *testing.T) {
+		if _, err := Run(nil, nil); err == nil {
+			t.Error("Run(nil) should fail")
+		}
+	})
+
+	t.Run("missing root package.json", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{"packages/a/package.json": `{"name": "a"}`})
+		if _, err := AnalyzePath(root, nil); err == nil {
+			t.Error("AnalyzePath() should fail without a root package.json")
+		}
+	})
+
+	t.Run("invalid exclusion regex", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, cycleWorkspace)
+		if _, err := AnalyzePath(root, &types.AnalysisConfig{Exclude: []string{"regex:["}}); err == nil {
+			t.Error("AnalyzePath() should fail for an invalid regex exclusion")
+		}
+	})
+}
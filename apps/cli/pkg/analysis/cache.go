@@ -0,0 +1,141 @@
+package analysis
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/cache"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/analyzer"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/parser"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// CacheStats reports what a cached run reused
+type CacheStats struct {
+	// Graph is true when the workspace data and dependency graph were reused
+	Graph bool
+
+	// ReusedSources counts the source files whose cached imports were still
+	// valid; ParsedSources counts those parsed by this run
+	ReusedSources int
+	ParsedSources int
+
+	// SaveErr is the error writing the cache, if any. The result is still
+	// valid; the next run rebuilds what could not be saved.
+	SaveErr error
+}
+
+// RunCached is Run with the intermediate results kept in the cache directory
+// dir. Workspace data and the dependency graph are reused when no manifest
+// changed; package configurations and CODEOWNERS are not manifests, and the
+// owners are read again on every run. The parsed imports of a source file
+// are reused while its content and the workspace package names are
+// unchanged. The cache is discarded when the engine version, workspace root
+// or analysis settings differ, and an unreadable cache is rebuilt. Like
+// RunWorkspace, it also returns the workspace data.
+func RunCached(snap *workspace.Snapshot, config *types.AnalysisConfig, dir string) (*types.AnalysisResult, *types.WorkspaceData, *CacheStats, error) {
+	if snap == nil {
+		return nil, nil, nil, fmt.Errorf("no workspace snapshot provided")
+	}
+	configHash, err := cache.ConfigHash(config)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	a, err := analyzer.NewAnalyzerWithConfig(config)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid analysis config: %w", err)
+	}
+
+	cached, _ := cache.Load(dir)
+	if !cached.Valid(snap.Root, configHash) {
+		cached = nil
+	}
+	entry := cache.NewEntry(snap.Root, configHash)
+	for rel, content := range snap.Files {
+		if isManifest(rel) {
+			entry.Manifests[rel] = cache.Hash(content)
+		}
+	}
+
+	stats := &CacheStats{}
+	if cached != nil && maps.Equal(cached.Manifests, entry.Manifests) && cached.Workspace != nil && cached.Graph != nil {
+		ws := *cached.Workspace
+		ws.CodeOwners = codeOwners(snap)
+		entry.Workspace, entry.Graph = &ws, cached.Graph
+		clearOwners(entry.Graph)
+		stats.Graph = true
+	} else {
+		if entry.Workspace, err = Parse(snap); err != nil {
+			return nil, nil, nil, err
+		}
+		if entry.Graph, err = a.BuildGraph(entry.Workspace); err != nil {
+			return nil, nil, nil, fmt.Errorf("analysis failed: %w", err)
+		}
+	}
+
+	// Imports are parsed for every workspace package, so they stay valid
+	// only while the set of package names is the same
+	hashes := map[string]string{}
+	imports := map[string][]types.ImportTrace{}
+	if cached != nil && cached.Workspace != nil && samePackages(cached.Workspace, entry.Workspace) {
+		for rel, source := range cached.Sources {
+			content, ok := snap.SourceFiles[rel]
+			if !ok {
+				continue
+			}
+			hashes[rel] = cache.Hash(content)
+			if hashes[rel] == source.Hash {
+				imports[rel] = source.Imports
+			}
+		}
+	}
+
+	tracer := analyzer.NewImportTracerWithImports(entry.Workspace, snap.SourceFiles, imports)
+	result := a.AnalyzeGraph(entry.Workspace, entry.Graph, tracer)
+
+	for rel, traces := range tracer.Imports() {
+		hash, ok := hashes[rel]
+		if !ok {
+			hash = cache.Hash(snap.SourceFiles[rel])
+		}
+		entry.Sources[rel] = cache.Source{Hash: hash, Imports: traces}
+	}
+	stats.ReusedSources = len(imports)
+	stats.ParsedSources = len(entry.Sources) - len(imports)
+	stats.SaveErr = entry.Save(dir)
+	return result, entry.Workspace, stats, nil
+}
+
+// isManifest reports whether a snapshot file is read to build the workspace
+// data and graph: everything but package configurations, which only affect
+// check, and CODEOWNERS, which only affects owners
+func isManifest(rel string) bool {
+	return path.Base(rel) != workspace.ConfigFileName && !slices.Contains(parser.CodeownersFiles, rel)
+}
+
+// clearOwners removes the owners a previous analysis tagged a cached graph
+// with, so that owners no longer in CODEOWNERS are not kept
+func clearOwners(graph *types.DependencyGraph) {
+	for _, node := range graph.Nodes {
+		node.Owners = nil
+	}
+	for _, edge := range graph.Edges {
+		edge.FromOwners, edge.ToOwners = nil, nil
+	}
+}
+
+// samePackages reports whether two workspaces have the same package names
+func samePackages(a, b *types.WorkspaceData) bool {
+	if len(a.Packages) != len(b.Packages) {
+		return false
+	}
+	for name := range a.Packages {
+		if _, ok := b.Packages[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
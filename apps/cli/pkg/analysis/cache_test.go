@@ -0,0 +1,125 @@
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+func TestRunCached(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, cycleWorkspace)
+	dir := filepath.Join(root, ".monoguard", "cache")
+
+	run := func(config *types.AnalysisConfig) (*types.AnalysisResult, *CacheStats) {
+		t.Helper()
+		snap, err := workspace.Scan(root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, workspaceData, stats, err := RunCached(snap, config, dir)
+		if err != nil {
+			t.Fatalf("RunCached() error = %v", err)
+		}
+		if workspaceData == nil || len(workspaceData.Packages) != 2 {
+			t.Fatalf("RunCached() workspace = %+v, want 2 packages", workspaceData)
+		}
+		if stats.SaveErr != nil {
+			t.Fatalf("RunCached() SaveErr = %v", stats.SaveErr)
+		}
+		return result, stats
+	}
+	assertStats := func(stats *CacheStats, graph bool, reused, parsed int) {
+		t.Helper()
+		if stats.Graph != graph || stats.ReusedSources != reused || stats.ParsedSources != parsed {
+			t.Errorf("stats = %+v, want graph %v, %d reused, %d parsed", stats, graph, reused, parsed)
+		}
+	}
+
+	result, stats := run(nil)
+	assertStats(stats, false, 0, 2)
+	if len(result.CircularDependencies) != 1 || len(result.CircularDependencies[0].ImportTraces) != 2 {
+		t.Fatalf("first run cycles = %+v, want 1 cycle with 2 import traces", result.CircularDependencies)
+	}
+
+	result, stats = run(nil)
+	assertStats(stats, true, 2, 0)
+	if len(result.CircularDependencies) != 1 || len(result.CircularDependencies[0].ImportTraces) != 2 {
+		t.Errorf("cached run cycles = %+v, want 1 cycle with 2 import traces", result.CircularDependencies)
+	}
+	if len(result.VersionConflicts) != 1 {
+		t.Errorf("cached run conflicts = %+v, want lodash", result.VersionConflicts)
+	}
+
+	// A changed source file is re-parsed
+	writeFiles(t, root, map[string]string{"packages/b/src/index.ts": "export const b = 1;\n\nimport { a } from '@mono/a';\n"})
+	result, stats = run(nil)
+	assertStats(stats, true, 1, 1)
+	for _, trace := range result.CircularDependencies[0].ImportTraces {
+		if trace.FromPackage == "@mono/b" && trace.LineNumber != 3 {
+			t.Errorf("trace of changed file = %+v, want line 3", trace)
+		}
+	}
+
+	// A changed manifest rebuilds the graph but keeps the imports
+	writeFiles(t, root, map[string]string{"packages/a/package.json": `{"name": "@mono/a", "version": "1.0.1", "dependencies": {"@mono/b": "workspace:*"}}`})
+	result, stats = run(nil)
+	assertStats(stats, false, 2, 0)
+	if len(result.VersionConflicts) != 0 {
+		t.Errorf("conflicts after manifest change = %+v, want none", result.VersionConflicts)
+	}
+
+	// Package configurations and CODEOWNERS keep the graph; owners follow
+	// the current CODEOWNERS file
+	writeFiles(t, root, map[string]string{
+		"packages/a/.monoguard.yaml": "rules:\n  circularDependencies: warn\n",
+		"CODEOWNERS":                 "/packages/a/ @org/a\n",
+	})
+	result, stats = run(nil)
+	assertStats(stats, true, 2, 0)
+	if owners := result.Graph.Nodes["@mono/a"].Owners; len(owners) != 1 || owners[0] != "@org/a" {
+		t.Errorf("owners of @mono/a = %v, want [@org/a]", owners)
+	}
+	if err := os.Remove(filepath.Join(root, "CODEOWNERS")); err != nil {
+		t.Fatal(err)
+	}
+	result, stats = run(nil)
+	assertStats(stats, true, 2, 0)
+	if owners := result.Graph.Nodes["@mono/a"].Owners; len(owners) != 0 || result.Ownership != nil {
+		t.Errorf("owners of @mono/a = %v, ownership %+v; want none without CODEOWNERS", owners, result.Ownership)
+	}
+
+	// Check-only settings keep the cache; analysis settings discard it
+	_, stats = run(&types.AnalysisConfig{Rules: &types.RulesConfig{CircularDependencies: types.RuleSeverityWarn}})
+	assertStats(stats, true, 2, 0)
+	result, stats = run(&types.AnalysisConfig{Exclude: []string{"@mono/b"}})
+	assertStats(stats, false, 0, 0)
+	if result.ExcludedPackages != 1 {
+		t.Errorf("ExcludedPackages = %d, want 1", result.ExcludedPackages)
+	}
+}
+
+func TestRunCached_CorruptCache(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, cycleWorkspace)
+	dir := filepath.Join(root, ".monoguard", "cache")
+	writeFiles(t, dir, map[string]string{"analysis.json": "not json"})
+
+	snap, err := workspace.Scan(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, _, stats, err := RunCached(snap, nil, dir)
+	if err != nil {
+		t.Fatalf("RunCached() error = %v", err)
+	}
+	if stats.Graph || len(result.CircularDependencies) != 1 {
+		t.Errorf("RunCached() = %+v, stats %+v; want a rebuilt analysis", result.CircularDependencies, stats)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "analysis.json")); err != nil || string(data) == "not json" {
+		t.Errorf("cache was not rewritten: %s, %v", data, err)
+	}
+}
@@ -0,0 +1,124 @@
+// Package analysis runs the analysis engine pipeline against a local workspace.
+package analysis
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// Delta lists the cycles and version conflicts that appeared or disappeared
+// between two analysis results
+type Delta struct {
+	NewCycles         [][]string        `json:"newCycles"`
+	ResolvedCycles    [][]string        `json:"resolvedCycles"`
+	NewConflicts      []ConflictSummary `json:"newConflicts"`
+	ResolvedConflicts []ConflictSummary `json:"resolvedConflicts"`
+}
+
+// ConflictSummary identifies a version conflict by package and versions
+type ConflictSummary struct {
+	PackageName string   `json:"packageName"`
+	Versions    []string `json:"versions"`
+}
+
+// Compare returns the changes from before to after. A nil before is treated
+// as an empty result, so every finding in after is reported as new.
+// A conflict whose set of versions changed counts as resolved and new.
+func Compare(before, after *types.AnalysisResult) *Delta {
+	if before == nil {
+		before = &types.AnalysisResult{}
+	}
+	if after == nil {
+		after = &types.AnalysisResult{}
+	}
+
+	d := &Delta{
+		NewCycles:         [][]string{},
+		ResolvedCycles:    [][]string{},
+		NewConflicts:      []ConflictSummary{},
+		ResolvedConflicts: []ConflictSummary{},
+	}
+
+	beforeCycles := cycleSet(before.CircularDependencies)
+	afterCycles := cycleSet(after.CircularDependencies)
+	for _, c := range after.CircularDependencies {
+		if _, ok := beforeCycles[CycleKey(c.Cycle)]; !ok {
+			d.NewCycles = append(d.NewCycles, c.Cycle)
+		}
+	}
+	for _, c := range before.CircularDependencies {
+		if _, ok := afterCycles[CycleKey(c.Cycle)]; !ok {
+			d.ResolvedCycles = append(d.ResolvedCycles, c.Cycle)
+		}
+	}
+
+	beforeConflicts := conflictSet(before.VersionConflicts)
+	afterConflicts := conflictSet(after.VersionConflicts)
+	for key, summary := range afterConflicts {
+		if _, ok := beforeConflicts[key]; !ok {
+			d.NewConflicts = append(d.NewConflicts, summary)
+		}
+	}
+	for key, summary := range beforeConflicts {
+		if _, ok := afterConflicts[key]; !ok {
+			d.ResolvedConflicts = append(d.ResolvedConflicts, summary)
+		}
+	}
+	sortConflicts(d.NewConflicts)
+	sortConflicts(d.ResolvedConflicts)
+
+	return d
+}
+
+// Empty reports whether nothing appeared or disappeared
+func (d *Delta) Empty() bool {
+	return len(d.NewCycles) == 0 && len(d.ResolvedCycles) == 0 &&
+		len(d.NewConflicts) == 0 && len(d.ResolvedConflicts) == 0
+}
+
+// CycleKey identifies a cycle by its sorted set of packages, so the same
+// cycle reported from a different starting package compares equal
+func CycleKey(cycle []string) string {
+	seen := map[string]bool{}
+	for _, pkg := range cycle {
+		seen[pkg] = true
+	}
+	pkgs := make([]string, 0, len(seen))
+	for pkg := range seen {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+	return strings.Join(pkgs, "\x00")
+}
+
+// cycleSet indexes cycles by CycleKey
+func cycleSet(cycles []*types.CircularDependencyInfo) map[string]bool {
+	set := make(map[string]bool, len(cycles))
+	for _, c := range cycles {
+		set[CycleKey(c.Cycle)] = true
+	}
+	return set
+}
+
+// conflictSet indexes conflicts by package name and sorted versions
+func conflictSet(conflicts []*types.VersionConflictInfo) map[string]ConflictSummary {
+	set := make(map[string]ConflictSummary, len(conflicts))
+	for _, c := range conflicts {
+		summary := ConflictSummary{PackageName: c.PackageName, Versions: []string{}}
+		for _, v := range c.ConflictingVersions {
+			summary.Versions = append(summary.Versions, v.Version)
+		}
+		sort.Strings(summary.Versions)
+		set[c.PackageName+"\x00"+strings.Join(summary.Versions, "\x00")] = summary
+	}
+	return set
+}
+
+// sortConflicts orders conflicts by package name
+func sortConflicts(conflicts []ConflictSummary) {
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].PackageName < conflicts[j].PackageName
+	})
+}
@@ -0,0 +1,87 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+func conflict(name string, versions ...string) *types.VersionConflictInfo {
+	c := &types.VersionConflictInfo{PackageName: name}
+	for _, v := range versions {
+		c.ConflictingVersions = append(c.ConflictingVersions, &types.ConflictingVersion{Version: v})
+	}
+	return c
+}
+
+func TestCompare(t *testing.T) {
+	before := &types.AnalysisResult{
+		CircularDependencies: []*types.CircularDependencyInfo{
+			{Cycle: []string{"@mono/a", "@mono/b", "@mono/a"}},
+			{Cycle: []string{"@mono/c", "@mono/d", "@mono/c"}},
+		},
+		VersionConflicts: []*types.VersionConflictInfo{
+			conflict("lodash", "^4.17.21", "^3.10.0"),
+			conflict("react", "^18.0.0", "^17.0.0"),
+		},
+	}
+	after := &types.AnalysisResult{
+		CircularDependencies: []*types.CircularDependencyInfo{
+			// Same cycle reported from another starting package
+			{Cycle: []string{"@mono/b", "@mono/a", "@mono/b"}},
+			{Cycle: []string{"@mono/e", "@mono/f", "@mono/e"}},
+		},
+		VersionConflicts: []*types.VersionConflictInfo{
+			conflict("lodash", "^3.10.0", "^4.17.21"),
+			conflict("react", "^18.0.0", "^16.0.0"),
+		},
+	}
+
+	d := Compare(before, after)
+
+	if want := [][]string{{"@mono/e", "@mono/f", "@mono/e"}}; !reflect.DeepEqual(d.NewCycles, want) {
+		t.Errorf("NewCycles = %v, want %v", d.NewCycles, want)
+	}
+	if want := [][]string{{"@mono/c", "@mono/d", "@mono/c"}}; !reflect.DeepEqual(d.ResolvedCycles, want) {
+		t.Errorf("ResolvedCycles = %v, want %v", d.ResolvedCycles, want)
+	}
+	if want := []ConflictSummary{{PackageName: "react", Versions: []string{"^16.0.0", "^18.0.0"}}}; !reflect.DeepEqual(d.NewConflicts, want) {
+		t.Errorf("NewConflicts = %v, want %v", d.NewConflicts, want)
+	}
+	if want := []ConflictSummary{{PackageName: "react", Versions: []string{"^17.0.0", "^18.0.0"}}}; !reflect.DeepEqual(d.ResolvedConflicts, want) {
+		t.Errorf("ResolvedConflicts = %v, want %v", d.ResolvedConflicts, want)
+	}
+	if d.Empty() {
+		t.Error("Empty() = true, want false")
+	}
+}
+
+func TestCompare_NilBefore(t *testing.T) {
+	after := &types.AnalysisResult{
+		CircularDependencies: []*types.CircularDependencyInfo{
+			{Cycle: []string{"@mono/a", "@mono/b", "@mono/a"}},
+		},
+	}
+
+	d := Compare(nil, after)
+	if len(d.NewCycles) != 1 || len(d.ResolvedCycles) != 0 {
+		t.Errorf("Compare(nil, after) = %+v, want one new cycle", d)
+	}
+
+	if !Compare(after, after).Empty() {
+		t.Error("comparing a result with itself should be empty")
+	}
+}
+
+func TestCycleKey(t *testing.T) {
+	a := CycleKey([]string{"@mono/a", "@mono/b", "@mono/a"})
+	b := CycleKey([]string{"@mono/b", "@mono/a", "@mono/b"})
+	c := CycleKey([]string{"@mono/a", "@mono/c", "@mono/a"})
+	if a != b {
+		t.Errorf("rotated cycles should share a key: %q != %q", a, b)
+	}
+	if a == c {
+		t.Error("different cycles should have different keys")
+	}
+}
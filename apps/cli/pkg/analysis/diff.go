@@ -0,0 +1,254 @@
+// Package analysis runs the analysis engine pipeline against a local workspace.
+package analysis
+
+import (
+	"sort"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/analyzer"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// Diff is what changed between the analysis of a base and a head revision
+type Diff struct {
+	Base string `json:"base"` // Label of the base analysis, e.g. a git ref or file
+	Head string `json:"head"`
+
+	HealthScore ScoreChange    `json:"healthScore"`
+	Factors     []FactorChange `json:"factors"`
+
+	NewCycles      []DiffCycle   `json:"newCycles"`
+	ResolvedCycles []DiffCycle   `json:"resolvedCycles"`
+	ChangedCycles  []CycleChange `json:"changedCycles"`
+
+	NewConflicts      []ConflictSummary `json:"newConflicts"`
+	ResolvedConflicts []ConflictSummary `json:"resolvedConflicts"`
+
+	AddedEdges   []EdgeSummary `json:"addedEdges"`
+	RemovedEdges []EdgeSummary `json:"removedEdges"`
+}
+
+// ScoreChange is a score in the base and head analysis
+type ScoreChange struct {
+	Base  int `json:"base"`
+	Head  int `json:"head"`
+	Delta int `json:"delta"`
+}
+
+// FactorChange is the change of one health score factor
+type FactorChange struct {
+	Name string `json:"name"`
+	ScoreChange
+	WeightedDelta int `json:"weightedDelta"` // Change of the factor's contribution to the overall score
+}
+
+// DiffCycle is a cycle identified by the engine's cycle ID
+type DiffCycle struct {
+	ID       string                 `json:"id"`
+	Cycle    []string               `json:"cycle"`
+	Severity types.CircularSeverity `json:"severity"`
+}
+
+// CycleChange is a cycle whose ID is in both analyses but whose packages changed
+type CycleChange struct {
+	ID     string   `json:"id"`
+	Before []string `json:"before"`
+	After  []string `json:"after"`
+}
+
+// EdgeSummary is a dependency between workspace packages
+type EdgeSummary struct {
+	From string               `json:"from"`
+	To   string               `json:"to"`
+	Type types.DependencyType `json:"type"`
+}
+
+// CompareRevisions returns what changed from base to head. Cycles are matched
+// by their engine cycle ID (see analyzer.CycleID); when several cycles share
+// an ID, cycles over the same packages are paired first.
+func CompareRevisions(baseLabel string, base *types.AnalysisResult, headLabel string, head *types.AnalysisResult) *Diff {
+	d := &Diff{
+		Base:           baseLabel,
+		Head:           headLabel,
+		HealthScore:    scoreChange(base.HealthScore, head.HealthScore),
+		Factors:        factorChanges(base.HealthScoreDetails, head.HealthScoreDetails),
+		NewCycles:      []DiffCycle{},
+		ResolvedCycles: []DiffCycle{},
+		ChangedCycles:  []CycleChange{},
+	}
+
+	baseCycles := cyclesByID(base.CircularDependencies)
+	headCycles := cyclesByID(head.CircularDependencies)
+	ids := map[string]bool{}
+	for id := range baseCycles {
+		ids[id] = true
+	}
+	for id := range headCycles {
+		ids[id] = true
+	}
+	for _, id := range sortedKeys(ids) {
+		before, after := unmatchedCycles(baseCycles[id], headCycles[id])
+		for len(before) > 0 && len(after) > 0 {
+			d.ChangedCycles = append(d.ChangedCycles, CycleChange{ID: id, Before: before[0].Cycle, After: after[0].Cycle})
+			before, after = before[1:], after[1:]
+		}
+		for _, c := range after {
+			d.NewCycles = append(d.NewCycles, DiffCycle{ID: id, Cycle: c.Cycle, Severity: c.Severity})
+		}
+		for _, c := range before {
+			d.ResolvedCycles = append(d.ResolvedCycles, DiffCycle{ID: id, Cycle: c.Cycle, Severity: c.Severity})
+		}
+	}
+
+	delta := Compare(base, head)
+	d.NewConflicts = delta.NewConflicts
+	d.ResolvedConflicts = delta.ResolvedConflicts
+
+	baseEdges := edgeSet(base.Graph)
+	headEdges := edgeSet(head.Graph)
+	d.AddedEdges = edgeDifference(headEdges, baseEdges)
+	d.RemovedEdges = edgeDifference(baseEdges, headEdges)
+
+	return d
+}
+
+// Regressed reports whether head introduced or changed cycles, introduced
+// version conflicts or lowered the health score
+func (d *Diff) Regressed() bool {
+	return len(d.NewCycles) > 0 || len(d.ChangedCycles) > 0 || len(d.NewConflicts) > 0 || d.HealthScore.Delta < 0
+}
+
+// Unchanged reports whether the two analyses are equivalent
+func (d *Diff) Unchanged() bool {
+	if d.HealthScore.Delta != 0 || len(d.NewCycles) > 0 || len(d.ResolvedCycles) > 0 ||
+		len(d.ChangedCycles) > 0 || len(d.NewConflicts) > 0 || len(d.ResolvedConflicts) > 0 ||
+		len(d.AddedEdges) > 0 || len(d.RemovedEdges) > 0 {
+		return false
+	}
+	for _, f := range d.Factors {
+		if f.Delta != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// scoreChange builds a ScoreChange
+func scoreChange(base, head int) ScoreChange {
+	return ScoreChange{Base: base, Head: head, Delta: head - base}
+}
+
+// factorChanges pairs health factors by name, in head order followed by
+// factors only present in base
+func factorChanges(base, head *types.HealthScoreResult) []FactorChange {
+	changes := []FactorChange{}
+	baseFactors := map[string]*types.HealthFactor{}
+	if base != nil {
+		for _, f := range base.Factors {
+			baseFactors[f.Name] = f
+		}
+	}
+	seen := map[string]bool{}
+	if head != nil {
+		for _, f := range head.Factors {
+			seen[f.Name] = true
+			c := FactorChange{Name: f.Name, ScoreChange: scoreChange(0, f.Score), WeightedDelta: f.WeightedScore}
+			if b, ok := baseFactors[f.Name]; ok {
+				c.ScoreChange = scoreChange(b.Score, f.Score)
+				c.WeightedDelta = f.WeightedScore - b.WeightedScore
+			}
+			changes = append(changes, c)
+		}
+	}
+	if base != nil {
+		for _, f := range base.Factors {
+			if !seen[f.Name] {
+				changes = append(changes, FactorChange{
+					Name:          f.Name,
+					ScoreChange:   scoreChange(f.Score, 0),
+					WeightedDelta: -f.WeightedScore,
+				})
+			}
+		}
+	}
+	return changes
+}
+
+// cyclesByID groups cycles by their engine cycle ID
+func cyclesByID(cycles []*types.CircularDependencyInfo) map[string][]*types.CircularDependencyInfo {
+	byID := map[string][]*types.CircularDependencyInfo{}
+	for _, c := range cycles {
+		id := analyzer.CycleID(c.Cycle)
+		byID[id] = append(byID[id], c)
+	}
+	return byID
+}
+
+// unmatchedCycles drops the cycles over the same packages from both lists
+func unmatchedCycles(before, after []*types.CircularDependencyInfo) ([]*types.CircularDependencyInfo, []*types.CircularDependencyInfo) {
+	inAfter := map[string]int{}
+	for _, c := range after {
+		inAfter[CycleKey(c.Cycle)]++
+	}
+	var restBefore []*types.CircularDependencyInfo
+	matched := map[string]int{}
+	for _, c := range before {
+		key := CycleKey(c.Cycle)
+		if inAfter[key] > matched[key] {
+			matched[key]++
+			continue
+		}
+		restBefore = append(restBefore, c)
+	}
+	var restAfter []*types.CircularDependencyInfo
+	for _, c := range after {
+		key := CycleKey(c.Cycle)
+		if matched[key] > 0 {
+			matched[key]--
+			continue
+		}
+		restAfter = append(restAfter, c)
+	}
+	return restBefore, restAfter
+}
+
+// edgeSet indexes the edges of a graph
+func edgeSet(graph *types.DependencyGraph) map[EdgeSummary]bool {
+	set := map[EdgeSummary]bool{}
+	if graph == nil {
+		return set
+	}
+	for _, e := range graph.Edges {
+		set[EdgeSummary{From: e.From, To: e.To, Type: e.Type}] = true
+	}
+	return set
+}
+
+// edgeDifference returns the edges in a that are not in b, sorted
+func edgeDifference(a, b map[EdgeSummary]bool) []EdgeSummary {
+	out := []EdgeSummary{}
+	for e := range a {
+		if !b[e] {
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].From != out[j].From {
+			return out[i].From < out[j].From
+		}
+		if out[i].To != out[j].To {
+			return out[i].To < out[j].To
+		}
+		return out[i].Type < out[j].Type
+	})
+	return out
+}
+
+// sortedKeys returns the keys of a set in order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,162 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+func cycle(pkgs ...string) *types.CircularDependencyInfo {
+	return &types.CircularDependencyInfo{Cycle: pkgs, Severity: types.CircularSeverityWarning}
+}
+
+func graphWithEdges(edges ...*types.DependencyEdge) *types.DependencyGraph {
+	g := types.NewDependencyGraph("/repo", types.WorkspaceTypePnpm)
+	g.Edges = edges
+	return g
+}
+
+func TestCompareRevisions(t *testing.T) {
+	base := &types.AnalysisResult{
+		HealthScore: 80,
+		HealthScoreDetails: &types.HealthScoreResult{Factors: []*types.HealthFactor{
+			{Name: "Circular Dependencies", Score: 100, WeightedScore: 40},
+			{Name: "Version Conflicts", Score: 80, WeightedScore: 20},
+		}},
+		CircularDependencies: []*types.CircularDependencyInfo{
+			cycle("@mono/a", "@mono/b", "@mono/a"),
+			cycle("@mono/c", "@mono/d", "@mono/c"),
+			cycle("@mono/x", "@mono/y", "@mono/x"),
+		},
+		VersionConflicts: []*types.VersionConflictInfo{conflict("react", "^18.0.0", "^17.0.0")},
+		Graph: graphWithEdges(
+			&types.DependencyEdge{From: "@mono/a", To: "@mono/b", Type: types.DependencyTypeProduction},
+			&types.DependencyEdge{From: "@mono/e", To: "@mono/f", Type: types.DependencyTypeDevelopment},
+		),
+	}
+	head := &types.AnalysisResult{
+		HealthScore: 72,
+		HealthScoreDetails: &types.HealthScoreResult{Factors: []*types.HealthFactor{
+			{Name: "Circular Dependencies", Score: 80, WeightedScore: 32},
+			{Name: "Version Conflicts", Score: 80, WeightedScore: 20},
+		}},
+		CircularDependencies: []*types.CircularDependencyInfo{
+			cycle("@mono/a", "@mono/b", "@mono/a"),
+			// Same ID c→d, but the cycle grew
+			cycle("@mono/c", "@mono/d", "@mono/g", "@mono/c"),
+			cycle("@mono/p", "@mono/q", "@mono/p"),
+		},
+		VersionConflicts: []*types.VersionConflictInfo{conflict("lodash", "^4.17.21", "^3.10.0")},
+		Graph: graphWithEdges(
+			&types.DependencyEdge{From: "@mono/a", To: "@mono/b", Type: types.DependencyTypeProduction},
+			&types.DependencyEdge{From: "@mono/b", To: "@mono/a", Type: types.DependencyTypeProduction},
+		),
+	}
+
+	d := CompareRevisions("main", base, "HEAD", head)
+
+	if d.Base != "main" || d.Head != "HEAD" {
+		t.Errorf("labels = %q, %q", d.Base, d.Head)
+	}
+	if d.HealthScore != (ScoreChange{Base: 80, Head: 72, Delta: -8}) {
+		t.Errorf("HealthScore = %+v", d.HealthScore)
+	}
+	wantFactors := []FactorChange{
+		{Name: "Circular Dependencies", ScoreChange: ScoreChange{Base: 100, Head: 80, Delta: -20}, WeightedDelta: -8},
+		{Name: "Version Conflicts", ScoreChange: ScoreChange{Base: 80, Head: 80}},
+	}
+	if !reflect.DeepEqual(d.Factors, wantFactors) {
+		t.Errorf("Factors = %+v, want %+v", d.Factors, wantFactors)
+	}
+
+	wantNew := []DiffCycle{{ID: "p→q", Cycle: []string{"@mono/p", "@mono/q", "@mono/p"}, Severity: types.CircularSeverityWarning}}
+	if !reflect.DeepEqual(d.NewCycles, wantNew) {
+		t.Errorf("NewCycles = %+v, want %+v", d.NewCycles, wantNew)
+	}
+	wantResolved := []DiffCycle{{ID: "x→y", Cycle: []string{"@mono/x", "@mono/y", "@mono/x"}, Severity: types.CircularSeverityWarning}}
+	if !reflect.DeepEqual(d.ResolvedCycles, wantResolved) {
+		t.Errorf("ResolvedCycles = %+v, want %+v", d.ResolvedCycles, wantResolved)
+	}
+	wantChanged := []CycleChange{{
+		ID:     "c→d",
+		Before: []string{"@mono/c", "@mono/d", "@mono/c"},
+		After:  []string{"@mono/c", "@mono/d", "@mono/g", "@mono/c"},
+	}}
+	if !reflect.DeepEqual(d.ChangedCycles, wantChanged) {
+		t.Errorf("ChangedCycles = %+v, want %+v", d.ChangedCycles, wantChanged)
+	}
+
+	if len(d.NewConflicts) != 1 || d.NewConflicts[0].PackageName != "lodash" {
+		t.Errorf("NewConflicts = %+v", d.NewConflicts)
+	}
+	if len(d.ResolvedConflicts) != 1 || d.ResolvedConflicts[0].PackageName != "react" {
+		t.Errorf("ResolvedConflicts = %+v", d.ResolvedConflicts)
+	}
+
+	wantAdded := []EdgeSummary{{From: "@mono/b", To: "@mono/a", Type: types.DependencyTypeProduction}}
+	if !reflect.DeepEqual(d.AddedEdges, wantAdded) {
+		t.Errorf("AddedEdges = %+v, want %+v", d.AddedEdges, wantAdded)
+	}
+	wantRemoved := []EdgeSummary{{From: "@mono/e", To: "@mono/f", Type: types.DependencyTypeDevelopment}}
+	if !reflect.DeepEqual(d.RemovedEdges, wantRemoved) {
+		t.Errorf("RemovedEdges = %+v, want %+v", d.RemovedEdges, wantRemoved)
+	}
+
+	if !d.Regressed() || d.Unchanged() {
+		t.Errorf("Regressed() = %v, Unchanged() = %v", d.Regressed(), d.Unchanged())
+	}
+}
+
+func TestCompareRevisions_SharedID(t *testing.T) {
+	// Both cycles have ID a→b; only the one over new packages is reported
+	base := &types.AnalysisResult{CircularDependencies: []*types.CircularDependencyInfo{
+		cycle("@mono/a", "@mono/b", "@mono/a"),
+	}}
+	head := &types.AnalysisResult{CircularDependencies: []*types.CircularDependencyInfo{
+		cycle("@mono/a", "@mono/b", "@mono/c", "@mono/a"),
+		cycle("@mono/a", "@mono/b", "@mono/a"),
+	}}
+
+	d := CompareRevisions("base", base, "head", head)
+	if len(d.ChangedCycles) != 0 || len(d.ResolvedCycles) != 0 {
+		t.Errorf("changed = %+v, resolved = %+v", d.ChangedCycles, d.ResolvedCycles)
+	}
+	if len(d.NewCycles) != 1 || len(d.NewCycles[0].Cycle) != 4 {
+		t.Errorf("NewCycles = %+v, want the three-package cycle", d.NewCycles)
+	}
+}
+
+func TestCompareRevisions_ChangedCycleRegresses(t *testing.T) {
+	base := &types.AnalysisResult{
+		HealthScore:          80,
+		CircularDependencies: []*types.CircularDependencyInfo{cycle("@mono/a", "@mono/b", "@mono/a")},
+	}
+	head := &types.AnalysisResult{
+		HealthScore:          80,
+		CircularDependencies: []*types.CircularDependencyInfo{cycle("@mono/a", "@mono/b", "@mono/c", "@mono/a")},
+	}
+
+	d := CompareRevisions("base", base, "head", head)
+	if len(d.ChangedCycles) != 1 || len(d.NewCycles) != 0 {
+		t.Fatalf("changed = %+v, new = %+v, want one changed cycle", d.ChangedCycles, d.NewCycles)
+	}
+	if !d.Regressed() {
+		t.Error("a cycle that grew should count as a regression")
+	}
+}
+
+func TestCompareRevisions_Unchanged(t *testing.T) {
+	result := &types.AnalysisResult{
+		HealthScore:          90,
+		CircularDependencies: []*types.CircularDependencyInfo{cycle("@mono/a", "@mono/b", "@mono/a")},
+		Graph:                graphWithEdges(&types.DependencyEdge{From: "@mono/a", To: "@mono/b"}),
+	}
+	d := CompareRevisions("base", result, "head", result)
+	if !d.Unchanged() || d.Regressed() {
+		t.Errorf("Unchanged() = %v, Regressed() = %v for identical results", d.Unchanged(), d.Regressed())
+	}
+	if d.NewCycles == nil || d.AddedEdges == nil || d.NewConflicts == nil {
+		t.Error("empty lists should be non-nil so JSON output has arrays")
+	}
+}
@@ -0,0 +1,50 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/parser"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// Checkup lists the workspace problems that leave packages out of the analysis
+type Checkup struct {
+	Root          string              `json:"root"`
+	WorkspaceType types.WorkspaceType `json:"workspaceType"`
+	Packages      int                 `json:"packages"` // Packages that are analyzed
+	Diagnostics   []types.Diagnostic  `json:"diagnostics"`
+}
+
+// Diagnose parses the workspace snapshot and collects its diagnostics,
+// including the directories matched by a workspace pattern that have no
+// package.json.
+func Diagnose(snap *workspace.Snapshot) (*Checkup, error) {
+	workspaceData, err := Parse(snap)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs, err := snap.Dirs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan workspace: %w", err)
+	}
+	missing, err := parser.NewParser(snap.Root).MissingPackageDiagnostics(snap.Files, dirs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workspace %s: %w", snap.Root, err)
+	}
+
+	diagnostics := append([]types.Diagnostic{}, workspaceData.Diagnostics...)
+	diagnostics = append(diagnostics, missing...)
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].File < diagnostics[j].File
+	})
+
+	return &Checkup{
+		Root:          snap.Root,
+		WorkspaceType: workspaceData.WorkspaceType,
+		Packages:      len(workspaceData.Packages),
+		Diagnostics:   diagnostics,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+func TestDiagnose(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, cycleWorkspace)
+	writeFiles(t, root, map[string]string{
+		"packages/c/package.json": `{"version": "1.0.0"}`,
+		"apps/web/package.json":   `{"name": "@mono/web"}`,
+	})
+	if err := os.MkdirAll(filepath.Join(root, "packages", "d", "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := workspace.Scan(root)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	checkup, err := Diagnose(snap)
+	if err != nil {
+		t.Fatalf("Diagnose() error = %v", err)
+	}
+
+	if checkup.Packages != 2 || checkup.WorkspaceType != types.WorkspaceTypePnpm {
+		t.Errorf("checkup = %+v, want 2 packages of a pnpm workspace", checkup)
+	}
+	want := []struct {
+		file string
+		kind types.DiagnosticKind
+	}{
+		{"apps/web/package.json", types.DiagnosticUnmatchedPackage},
+		{"packages/c/package.json", types.DiagnosticMissingName},
+		{"packages/d", types.DiagnosticMissingPackageJSON},
+	}
+	if len(checkup.Diagnostics) != len(want) {
+		t.Fatalf("Diagnostics = %+v, want %d", checkup.Diagnostics, len(want))
+	}
+	for i, w := range want {
+		if got := checkup.Diagnostics[i]; got.File != w.file || got.Kind != w.kind {
+			t.Errorf("Diagnostics[%d] = %s %s, want %s %s", i, got.File, got.Kind, w.file, w.kind)
+		}
+	}
+}
+
+func TestDiagnoseCleanWorkspace(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, cycleWorkspace)
+
+	snap, err := workspace.Scan(root)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	checkup, err := Diagnose(snap)
+	if err != nil {
+		t.Fatalf("Diagnose() error = %v", err)
+	}
+	if checkup.Diagnostics == nil || len(checkup.Diagnostics) != 0 {
+		t.Errorf("Diagnostics = %#v, want an empty list", checkup.Diagnostics)
+	}
+}
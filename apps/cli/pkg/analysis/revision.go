@@ -0,0 +1,59 @@
+// Package analysis runs the analysis engine pipeline against a local workspace.
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/git"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// LoadRevision returns the analysis of one side of a comparison. rev is
+// either a JSON file written by `monoguard analyze --format json`, a
+// workspace directory, or a git ref. A git ref is checked out into a
+// temporary worktree and the workspace at the same path relative to the
+// repository root as workspaceDir is analyzed there.
+func LoadRevision(rev, workspaceDir string, config *types.AnalysisConfig) (*types.AnalysisResult, error) {
+	if info, err := os.Stat(rev); err == nil {
+		if info.IsDir() {
+			return AnalyzePath(rev, config)
+		}
+		return LoadResult(rev)
+	}
+
+	repo, err := git.Open(workspaceDir)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a file, directory or git ref: %w", rev, err)
+	}
+	rel, err := repo.RelPath(workspaceDir)
+	if err != nil {
+		return nil, err
+	}
+	dir, remove, err := repo.Worktree(rev)
+	if err != nil {
+		return nil, err
+	}
+	defer remove()
+
+	result, err := AnalyzePath(filepath.Join(dir, rel), config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze %s: %w", rev, err)
+	}
+	return result, nil
+}
+
+// LoadResult reads an analysis result saved as JSON
+func LoadResult(path string) (*types.AnalysisResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var result types.AnalysisResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("%s is not an analysis result: %w", path, err)
+	}
+	return &result, nil
+}
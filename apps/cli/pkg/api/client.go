@@ -0,0 +1,292 @@
+// Package api is a typed client for the /api/v1 routes of the MonoGuard API
+// server, used to upload analysis results and fetch them back.
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// Defaults for retrying failed requests
+const (
+	DefaultRetries = 3
+	DefaultBackoff = 500 * time.Millisecond
+)
+
+// Client calls the API server at BaseURL. GET requests that fail with a
+// network error or a temporary server error are retried with exponential
+// backoff. Other requests are only retried when the connection was refused,
+// so that an upload the server may have stored is never sent twice.
+type Client struct {
+	// BaseURL is the server root, e.g. https://monoguard.example.com
+	BaseURL string
+
+	// Token is sent as a bearer token when set
+	Token string
+
+	// Retries is the number of retries after the first attempt
+	Retries int
+
+	// Backoff is the delay before the first retry; it doubles on each retry
+	Backoff time.Duration
+
+	HTTPClient *http.Client
+}
+
+// New creates a client with the default retry policy
+func New(baseURL, token string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		Token:      token,
+		Retries:    DefaultRetries,
+		Backoff:    DefaultBackoff,
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// Project is a project registered on the server
+type Project struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	RepositoryURL  string     `json:"repositoryUrl"`
+	Branch         string     `json:"branch"`
+	HealthScore    int        `json:"healthScore"`
+	LastAnalysisAt *time.Time `json:"lastAnalysisAt,omitempty"`
+}
+
+// StoredResult is an analysis result stored on the server
+type StoredResult struct {
+	ID          string                `json:"id"`
+	ProjectID   string                `json:"projectId"`
+	Commit      string                `json:"commit,omitempty"`
+	HealthScore int                   `json:"healthScore"`
+	Packages    int                   `json:"packages"`
+	Result      *types.AnalysisResult `json:"result,omitempty"` // Not returned by uploads
+	CreatedAt   time.Time             `json:"createdAt"`
+}
+
+// uploadRequest is the body of a result upload
+type uploadRequest struct {
+	Commit string                `json:"commit,omitempty"`
+	Result *types.AnalysisResult `json:"result"`
+}
+
+// envelope is the response format shared by all routes
+type envelope struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+	Message string          `json:"message"`
+	Error   *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Error is an error response of the server
+type Error struct {
+	StatusCode int
+	Code       string // e.g. NOT_FOUND
+	Message    string
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("server returned %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("server returned %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether err is a not found response
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Project fetches a project by ID
+func (c *Client) Project(ctx context.Context, id string) (*Project, error) {
+	var p Project
+	if err := c.do(ctx, http.MethodGet, "/api/v1/projects/"+url.PathEscape(id), nil, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// UploadResult stores an analysis result for a project. commit is the git
+// commit the result was computed for and may be empty.
+func (c *Client) UploadResult(ctx context.Context, projectID, commit string, result *types.AnalysisResult) (*StoredResult, error) {
+	var stored StoredResult
+	body := uploadRequest{Commit: commit, Result: result}
+	if err := c.do(ctx, http.MethodPost, "/api/v1/projects/"+url.PathEscape(projectID)+"/results", body, &stored); err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// LatestResult fetches the most recently stored result of a project
+func (c *Client) LatestResult(ctx context.Context, projectID string) (*StoredResult, error) {
+	var stored StoredResult
+	if err := c.do(ctx, http.MethodGet, "/api/v1/projects/"+url.PathEscape(projectID)+"/results/latest", nil, &stored); err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// Result fetches a stored result by ID
+func (c *Client) Result(ctx context.Context, id string) (*StoredResult, error) {
+	var stored StoredResult
+	if err := c.do(ctx, http.MethodGet, "/api/v1/results/"+url.PathEscape(id), nil, &stored); err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// ResultURL returns the URL of a stored result
+func (c *Client) ResultURL(id string) string {
+	return c.BaseURL + "/api/v1/results/" + url.PathEscape(id)
+}
+
+// do sends a request, retrying temporary failures, and decodes the data of
+// a successful response into out
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	if u, err := url.Parse(c.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid server URL %q: expected http(s)://host", c.BaseURL)
+	}
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	backoff := c.Backoff
+	for attempt := 0; ; attempt++ {
+		data, retryAfter, err := c.send(ctx, method, path, payload)
+		if err == nil {
+			return decode(data, out)
+		}
+		if attempt >= c.Retries || !retryable(ctx, method, err) {
+			return err
+		}
+
+		delay := backoff
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		backoff *= 2
+	}
+}
+
+// send performs a single request and returns the response body of a
+// successful response, or the delay requested by a Retry-After header
+// together with the error
+func (c *Client) send(ctx context.Context, method, path string, payload []byte) ([]byte, time.Duration, error) {
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reader)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return data, 0, nil
+	}
+
+	apiErr := &Error{StatusCode: resp.StatusCode}
+	var env envelope
+	if json.Unmarshal(data, &env) == nil {
+		apiErr.Message = env.Message
+		if env.Error != nil {
+			apiErr.Code, apiErr.Message = env.Error.Code, env.Error.Message
+		}
+	}
+	retryAfter := time.Duration(0)
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		retryAfter = time.Duration(seconds) * time.Second
+	}
+	return nil, retryAfter, apiErr
+}
+
+// retryable reports whether a failed request may succeed when sent again
+// without being applied twice. A refused connection never reached the
+// server, so any request is retried. GET requests are also retried on other
+// network errors, rate limiting and gateway errors. Other error responses
+// and a cancelled context are not retried.
+func retryable(ctx context.Context, method string, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	if method != http.MethodGet {
+		return false
+	}
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// decode reads the data of a response envelope into out
+func decode(data []byte, out interface{}) error {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return fmt.Errorf("invalid server response: %w", err)
+	}
+	if !env.Success {
+		return &Error{StatusCode: http.StatusOK, Message: env.Message}
+	}
+	if out == nil || len(env.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(env.Data, out); err != nil {
+		return fmt.Errorf("invalid server response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// respond writes a response in the server's envelope format
+func respond(w http.ResponseWriter, status int, data interface{}, message string) {
+	body := map[string]interface{}{"success": status < 300, "data": data, "message": message}
+	if status >= 300 {
+		body["error"] = map[string]string{"code": "ERROR", "message": message}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// newTestClient returns a client for server without retry delays
+func newTestClient(server *httptest.Server) *Client {
+	c := New(server.URL+"/", "secret")
+	c.Backoff = time.Millisecond
+	return c
+}
+
+func TestUploadResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/projects/p1/results" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		var body struct {
+			Commit string          `json:"commit"`
+			Result json.RawMessage `json:"result"`
+		}
+		data, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(data, &body); err != nil || body.Commit != "abc123" || len(body.Result) == 0 {
+			t.Errorf("body = %s", data)
+		}
+		respond(w, http.StatusCreated, map[string]interface{}{"id": "r1", "projectId": "p1", "healthScore": 87}, "stored")
+	}))
+	defer server.Close()
+
+	c := newTestClient(server)
+	stored, err := c.UploadResult(context.Background(), "p1", "abc123", &types.AnalysisResult{HealthScore: 87})
+	if err != nil {
+		t.Fatalf("UploadResult() error = %v", err)
+	}
+	if stored.ID != "r1" || stored.HealthScore != 87 {
+		t.Errorf("stored = %+v", stored)
+	}
+	if got, want := c.ResultURL(stored.ID), server.URL+"/api/v1/results/r1"; got != want {
+		t.Errorf("ResultURL() = %q, want %q", got, want)
+	}
+}
+
+func TestLatestResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/projects/p1/results/latest" {
+			respond(w, http.StatusNotFound, nil, "No analysis result found for this project")
+			return
+		}
+		respond(w, http.StatusOK, map[string]interface{}{
+			"id":     "r2",
+			"result": map[string]interface{}{"healthScore": 70, "packages": 4},
+		}, "")
+	}))
+	defer server.Close()
+
+	c := newTestClient(server)
+	stored, err := c.LatestResult(context.Background(), "p1")
+	if err != nil {
+		t.Fatalf("LatestResult() error = %v", err)
+	}
+	if stored.Result == nil || stored.Result.HealthScore != 70 || stored.Result.Packages != 4 {
+		t.Errorf("result = %+v", stored.Result)
+	}
+
+	_, err = c.LatestResult(context.Background(), "missing")
+	if !IsNotFound(err) {
+		t.Errorf("LatestResult() error = %v, want not found", err)
+	}
+	if err != nil && err.Error() != "server returned 404: No analysis result found for this project" {
+		t.Errorf("error message = %q", err.Error())
+	}
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		wantCalls int32
+		wantErr   bool
+	}{
+		{"temporary errors are retried", http.StatusServiceUnavailable, 3, false},
+		{"client errors are not retried", http.StatusBadRequest, 1, true},
+		{"retries are limited", http.StatusBadGateway, 4, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&calls, 1)
+				if tt.name == "temporary errors are retried" && n == 3 {
+					respond(w, http.StatusOK, map[string]string{"id": "p1"}, "")
+					return
+				}
+				respond(w, tt.status, nil, "failed")
+			}))
+			defer server.Close()
+
+			_, err := newTestClient(server).Project(context.Background(), "p1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Project() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestUploadNotRetried(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		respond(w, http.StatusBadGateway, nil, "failed")
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).UploadResult(context.Background(), "p1", "", &types.AnalysisResult{})
+	if err == nil || calls != 1 {
+		t.Errorf("UploadResult() error = %v after %d calls, want an error after 1 call", err, calls)
+	}
+}
+
+func TestRetriesRefusedConnection(t *testing.T) {
+	// A closed server refuses connections, so even an upload is retried
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	c := newTestClient(server)
+	c.Retries = 2
+	var retried int32
+	c.HTTPClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&retried, 1)
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+	_, err := c.UploadResult(context.Background(), "p1", "", &types.AnalysisResult{})
+	if err == nil || !errors.Is(err, syscall.ECONNREFUSED) || retried != 3 {
+		t.Errorf("UploadResult() error = %v after %d attempts, want connection refused after 3", err, retried)
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestInvalidServerURL(t *testing.T) {
+	for _, baseURL := range []string{"", "localhost:8080", "ftp://example.com"} {
+		if _, err := New(baseURL, "").Project(context.Background(), "p1"); err == nil {
+			t.Errorf("Project() with server %q succeeded", baseURL)
+		}
+	}
+}
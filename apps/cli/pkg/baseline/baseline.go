@@ -0,0 +1,257 @@
+// Package baseline records the known issues of a workspace so that checks
+// only fail on issues introduced afterwards.
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// DefaultFile is the baseline file name, relative to the workspace root
+const DefaultFile = ".monoguard-baseline.json"
+
+// fileVersion is the version of the baseline file format
+const fileVersion = 1
+
+// Baseline is the set of known issues, meant to be committed next to the
+// workspace configuration
+type Baseline struct {
+	Version              int     `json:"version"`
+	CircularDependencies []Entry `json:"circularDependencies"`
+	BoundaryViolations   []Entry `json:"boundaryViolations"`
+	VersionConflicts     []Entry `json:"versionConflicts"`
+	PluginFindings       []Entry `json:"pluginFindings"`
+	CustomRuleViolations []Entry `json:"customRuleViolations"`
+}
+
+// Entry is a known issue. ID is stable across analyses; Description is for
+// reviewers of the file and is ignored when matching.
+type Entry struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
+
+// FromResult records every issue of an analysis result, sorted by ID
+func FromResult(result *types.AnalysisResult) *Baseline {
+	b := &Baseline{
+		Version:              fileVersion,
+		CircularDependencies: []Entry{},
+		BoundaryViolations:   []Entry{},
+		VersionConflicts:     []Entry{},
+		PluginFindings:       []Entry{},
+		CustomRuleViolations: []Entry{},
+	}
+	seen := map[string]bool{}
+	add := func(entries *[]Entry, e Entry) {
+		if !seen[e.ID] {
+			seen[e.ID] = true
+			*entries = append(*entries, e)
+		}
+	}
+	for _, c := range result.CircularDependencies {
+		add(&b.CircularDependencies, cycleEntry(c))
+	}
+	for _, v := range result.BoundaryViolations {
+		add(&b.BoundaryViolations, violationEntry(v))
+	}
+	for _, c := range result.VersionConflicts {
+		add(&b.VersionConflicts, conflictEntry(c))
+	}
+	for _, f := range result.PluginFindings {
+		add(&b.PluginFindings, findingEntry(f))
+	}
+	for _, v := range result.CustomRuleViolations {
+		add(&b.CustomRuleViolations, customEntry(v))
+	}
+	for _, entries := range [][]Entry{b.CircularDependencies, b.BoundaryViolations, b.VersionConflicts, b.PluginFindings, b.CustomRuleViolations} {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	}
+	return b
+}
+
+// Load reads a baseline file
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%s is not a baseline file: %w", path, err)
+	}
+	if b.Version != fileVersion {
+		return nil, fmt.Errorf("%s has unsupported baseline version %d (expected %d)", path, b.Version, fileVersion)
+	}
+	return &b, nil
+}
+
+// Save writes the baseline as indented JSON
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Len returns the number of recorded issues
+func (b *Baseline) Len() int {
+	return len(b.CircularDependencies) + len(b.BoundaryViolations) + len(b.VersionConflicts) +
+		len(b.PluginFindings) + len(b.CustomRuleViolations)
+}
+
+// Apply returns a copy of result without the issues recorded in the
+// baseline, and the status to attach to the check of that copy. Entries
+// that no longer occur in result are reported as fixed.
+func (b *Baseline) Apply(result *types.AnalysisResult) (*types.AnalysisResult, *types.BaselineStatus) {
+	filtered := *result
+	status := &types.BaselineStatus{Fixed: []string{}}
+
+	known := ids(b.CircularDependencies)
+	found := map[string]bool{}
+	filtered.CircularDependencies = nil
+	for _, c := range result.CircularDependencies {
+		id := cycleEntry(c).ID
+		found[id] = true
+		if known[id] {
+			status.Known++
+			continue
+		}
+		filtered.CircularDependencies = append(filtered.CircularDependencies, c)
+	}
+
+	known = ids(b.BoundaryViolations)
+	filtered.BoundaryViolations = nil
+	for _, v := range result.BoundaryViolations {
+		id := violationEntry(v).ID
+		found[id] = true
+		if known[id] {
+			status.Known++
+			continue
+		}
+		filtered.BoundaryViolations = append(filtered.BoundaryViolations, v)
+	}
+
+	known = ids(b.VersionConflicts)
+	filtered.VersionConflicts = nil
+	for _, c := range result.VersionConflicts {
+		id := conflictEntry(c).ID
+		found[id] = true
+		if known[id] {
+			status.Known++
+			continue
+		}
+		filtered.VersionConflicts = append(filtered.VersionConflicts, c)
+	}
+
+	known = ids(b.PluginFindings)
+	filtered.PluginFindings = nil
+	for _, f := range result.PluginFindings {
+		id := findingEntry(f).ID
+		found[id] = true
+		if known[id] {
+			status.Known++
+			continue
+		}
+		filtered.PluginFindings = append(filtered.PluginFindings, f)
+	}
+
+	known = ids(b.CustomRuleViolations)
+	filtered.CustomRuleViolations = nil
+	for _, v := range result.CustomRuleViolations {
+		id := customEntry(v).ID
+		found[id] = true
+		if known[id] {
+			status.Known++
+			continue
+		}
+		filtered.CustomRuleViolations = append(filtered.CustomRuleViolations, v)
+	}
+
+	for _, entries := range [][]Entry{b.CircularDependencies, b.BoundaryViolations, b.VersionConflicts, b.PluginFindings, b.CustomRuleViolations} {
+		for _, e := range entries {
+			if !found[e.ID] {
+				status.Fixed = append(status.Fixed, e.Description)
+			}
+		}
+	}
+	return &filtered, status
+}
+
+// ids indexes entries by ID
+func ids(entries []Entry) map[string]bool {
+	set := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		set[e.ID] = true
+	}
+	return set
+}
+
+// cycleEntry identifies a cycle by its set of packages (see
+// analysis.CycleKey), so a cycle that gains or loses a package is new
+func cycleEntry(c *types.CircularDependencyInfo) Entry {
+	return Entry{
+		ID:          "cycle:" + strings.ReplaceAll(analysis.CycleKey(c.Cycle), "\x00", ","),
+		Description: "circular dependency " + strings.Join(c.Cycle, " → "),
+	}
+}
+
+// violationEntry identifies a boundary violation by its dependency
+func violationEntry(v *types.BoundaryViolation) Entry {
+	return Entry{
+		ID:          fmt.Sprintf("boundary:%s->%s", v.From, v.To),
+		Description: fmt.Sprintf("boundary violation %s → %s (%s → %s)", v.From, v.To, v.FromLayer, v.ToLayer),
+	}
+}
+
+// conflictEntry identifies a version conflict by its package, so a conflict
+// stays known while its set of versions changes
+func conflictEntry(c *types.VersionConflictInfo) Entry {
+	versions := make([]string, 0, len(c.ConflictingVersions))
+	for _, v := range c.ConflictingVersions {
+		versions = append(versions, v.Version)
+	}
+	sort.Strings(versions)
+	return Entry{
+		ID:          "conflict:" + c.PackageName,
+		Description: fmt.Sprintf("version conflict %s (%s)", c.PackageName, strings.Join(versions, " vs ")),
+	}
+}
+
+// findingEntry identifies a plugin finding by its plugin, rule, package, file
+// and message. The line is left out, so a finding stays known while the code
+// around it moves.
+func findingEntry(f *types.PluginFinding) Entry {
+	description := fmt.Sprintf("plugin finding %s/%s: %s", f.Plugin, f.Rule, f.Message)
+	if f.File != "" {
+		description += " (" + f.File + ")"
+	} else if f.Package != "" {
+		description += " (" + f.Package + ")"
+	}
+	return Entry{
+		ID:          fmt.Sprintf("plugin:%s/%s:%s:%s:%s", f.Plugin, f.Rule, f.Package, f.File, f.Message),
+		Description: description,
+	}
+}
+
+// customEntry identifies a custom rule violation by its rule and the package
+// and dependency that violated it
+func customEntry(v *types.CustomRuleViolation) Entry {
+	id := "custom:" + v.Rule
+	if v.Package != "" {
+		id += ":" + v.Package
+	}
+	if v.Dependency != "" {
+		id += "->" + v.Dependency
+	}
+	return Entry{
+		ID:          id,
+		Description: fmt.Sprintf("custom rule %s: %s", v.Rule, v.Message),
+	}
+}
@@ -0,0 +1,194 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+func sampleResult() *types.AnalysisResult {
+	return &types.AnalysisResult{
+		HealthScore: 60,
+		CircularDependencies: []*types.CircularDependencyInfo{
+			{Cycle: []string{"@mono/a", "@mono/b", "@mono/a"}},
+			{Cycle: []string{"@mono/c", "@mono/d", "@mono/c"}},
+		},
+		BoundaryViolations: []*types.BoundaryViolation{
+			{From: "@mono/ui", To: "@mono/web", FromLayer: "libs", ToLayer: "apps"},
+		},
+		VersionConflicts: []*types.VersionConflictInfo{
+			{
+				PackageName: "lodash",
+				ConflictingVersions: []*types.ConflictingVersion{
+					{Version: "^4.18.0"}, {Version: "^4.17.0"},
+				},
+			},
+		},
+	}
+}
+
+func TestFromResult(t *testing.T) {
+	b := FromResult(sampleResult())
+
+	if b.Len() != 4 {
+		t.Fatalf("Len() = %d, want 4", b.Len())
+	}
+	wantCycles := []Entry{
+		{ID: "cycle:@mono/a,@mono/b", Description: "circular dependency @mono/a → @mono/b → @mono/a"},
+		{ID: "cycle:@mono/c,@mono/d", Description: "circular dependency @mono/c → @mono/d → @mono/c"},
+	}
+	if !reflect.DeepEqual(b.CircularDependencies, wantCycles) {
+		t.Errorf("CircularDependencies = %+v, want %+v", b.CircularDependencies, wantCycles)
+	}
+	if got := b.BoundaryViolations[0].ID; got != "boundary:@mono/ui->@mono/web" {
+		t.Errorf("violation ID = %q", got)
+	}
+	if got := b.VersionConflicts[0]; got.ID != "conflict:lodash" || !strings.Contains(got.Description, "^4.17.0 vs ^4.18.0") {
+		t.Errorf("conflict entry = %+v", got)
+	}
+}
+
+func TestApply(t *testing.T) {
+	b := FromResult(sampleResult())
+
+	// @mono/c ⇄ @mono/d was fixed, the known cycle is reported from another
+	// package, and a new violation and three new cycles appeared: one through
+	// the known pair and one between packages with the same short names, both
+	// with the same engine cycle ID as the known cycle
+	result := sampleResult()
+	result.CircularDependencies = []*types.CircularDependencyInfo{
+		{Cycle: []string{"@mono/b", "@mono/a", "@mono/b"}},
+		{Cycle: []string{"@mono/a", "@mono/b", "@mono/g", "@mono/a"}},
+		{Cycle: []string{"@mono/e", "@mono/f", "@mono/e"}},
+		{Cycle: []string{"@other/a", "@other/b", "@other/a"}},
+	}
+	result.BoundaryViolations = append(result.BoundaryViolations,
+		&types.BoundaryViolation{From: "@mono/ui", To: "@mono/api"})
+
+	filtered, status := b.Apply(result)
+
+	if len(filtered.CircularDependencies) != 3 || filtered.CircularDependencies[0].Cycle[2] != "@mono/g" ||
+		filtered.CircularDependencies[1].Cycle[0] != "@mono/e" || filtered.CircularDependencies[2].Cycle[0] != "@other/a" {
+		t.Errorf("filtered cycles = %+v, want the @mono/g, @mono/e and @other/a cycles", filtered.CircularDependencies)
+	}
+	if len(filtered.BoundaryViolations) != 1 || filtered.BoundaryViolations[0].To != "@mono/api" {
+		t.Errorf("filtered violations = %+v, want only @mono/ui -> @mono/api", filtered.BoundaryViolations)
+	}
+	if len(filtered.VersionConflicts) != 0 {
+		t.Errorf("filtered conflicts = %+v, want none", filtered.VersionConflicts)
+	}
+	if status.Known != 3 {
+		t.Errorf("Known = %d, want 3", status.Known)
+	}
+	wantFixed := []string{"circular dependency @mono/c → @mono/d → @mono/c"}
+	if !reflect.DeepEqual(status.Fixed, wantFixed) {
+		t.Errorf("Fixed = %v, want %v", status.Fixed, wantFixed)
+	}
+	if len(result.CircularDependencies) != 4 {
+		t.Error("Apply() must not modify the result")
+	}
+}
+
+func TestPluginFindings(t *testing.T) {
+	finding := func(rule, file string, line int) *types.PluginFinding {
+		return &types.PluginFinding{Plugin: "lint", Rule: rule, Severity: types.PluginSeverityError,
+			Message: rule + " is not allowed", Package: "@mono/a", File: file, Line: line}
+	}
+	result := &types.AnalysisResult{PluginFindings: []*types.PluginFinding{
+		finding("no-lodash", "packages/a/src/index.ts", 3),
+		finding("no-moment", "packages/a/src/date.ts", 1),
+	}}
+	b := FromResult(result)
+
+	want := Entry{
+		ID:          "plugin:lint/no-lodash:@mono/a:packages/a/src/index.ts:no-lodash is not allowed",
+		Description: "plugin finding lint/no-lodash: no-lodash is not allowed (packages/a/src/index.ts)",
+	}
+	if b.Len() != 2 || b.PluginFindings[0] != want {
+		t.Fatalf("PluginFindings = %+v, want %+v first", b.PluginFindings, want)
+	}
+
+	// no-lodash moved to another line, no-moment was fixed and no-axios is new
+	result.PluginFindings = []*types.PluginFinding{
+		finding("no-lodash", "packages/a/src/index.ts", 12),
+		finding("no-axios", "packages/a/src/http.ts", 1),
+	}
+	filtered, status := b.Apply(result)
+	if len(filtered.PluginFindings) != 1 || filtered.PluginFindings[0].Rule != "no-axios" {
+		t.Errorf("filtered findings = %+v, want only no-axios", filtered.PluginFindings)
+	}
+	wantFixed := []string{"plugin finding lint/no-moment: no-moment is not allowed (packages/a/src/date.ts)"}
+	if status.Known != 1 || !reflect.DeepEqual(status.Fixed, wantFixed) {
+		t.Errorf("status = %+v, want 1 known and no-moment fixed", status)
+	}
+}
+
+func TestCustomRuleViolations(t *testing.T) {
+	result := &types.AnalysisResult{CustomRuleViolations: []*types.CustomRuleViolation{
+		{Rule: "b-is-a-leaf", Severity: types.RuleSeverityError, Message: "leaf (@mono/a → @mono/b)", Package: "@mono/a", Dependency: "@mono/b"},
+		{Rule: "max-dependencies", Severity: types.RuleSeverityWarn, Message: "@mono/a", Package: "@mono/a"},
+		{Rule: "few-packages", Severity: types.RuleSeverityError, Message: "workspace"},
+	}}
+	b := FromResult(result)
+
+	wantIDs := []string{"custom:b-is-a-leaf:@mono/a->@mono/b", "custom:few-packages", "custom:max-dependencies:@mono/a"}
+	var gotIDs []string
+	for _, e := range b.CustomRuleViolations {
+		gotIDs = append(gotIDs, e.ID)
+	}
+	if !reflect.DeepEqual(gotIDs, wantIDs) {
+		t.Fatalf("CustomRuleViolations IDs = %v, want %v", gotIDs, wantIDs)
+	}
+
+	// The message changed with the rule description; max-dependencies now
+	// fails for @mono/b too
+	result.CustomRuleViolations[0].Message = "@mono/b must stay a leaf (@mono/a → @mono/b)"
+	result.CustomRuleViolations = append(result.CustomRuleViolations,
+		&types.CustomRuleViolation{Rule: "max-dependencies", Severity: types.RuleSeverityWarn, Message: "@mono/b", Package: "@mono/b"})
+	filtered, status := b.Apply(result)
+	if len(filtered.CustomRuleViolations) != 1 || filtered.CustomRuleViolations[0].Package != "@mono/b" {
+		t.Errorf("filtered violations = %+v, want only max-dependencies of @mono/b", filtered.CustomRuleViolations)
+	}
+	if status.Known != 3 || len(status.Fixed) != 0 {
+		t.Errorf("status = %+v, want 3 known and none fixed", status)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultFile)
+	b := FromResult(sampleResult())
+	if err := b.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded, b) {
+		t.Errorf("Load() = %+v, want %+v", loaded, b)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	tests := map[string]string{
+		"not json":        "cycles: []",
+		"unknown version": `{"version": 2}`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(name, " ", "-")+".json")
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load(path); err == nil {
+				t.Error("Load() error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Package cache keeps intermediate analysis results on disk, keyed by the
+// content hashes of the scanned files, so that re-running an analysis only
+// re-parses what changed.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/analyzer"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// DefaultDir is the cache directory, relative to the workspace root
+const DefaultDir = ".monoguard/cache"
+
+// fileName is the cache file in the cache directory
+const fileName = "analysis.json"
+
+// fileVersion is the version of the cache file format
+const fileVersion = 1
+
+// Entry is the cached state of a workspace analysis. It is only valid for
+// the engine version, workspace root and analysis settings it was built with.
+type Entry struct {
+	Version       int    `json:"version"`
+	EngineVersion string `json:"engineVersion"`
+	Root          string `json:"root"`
+	ConfigHash    string `json:"configHash"`
+
+	// Manifests holds the content hash of each package.json and workspace
+	// file the workspace data and graph were built from
+	Manifests map[string]string      `json:"manifests"`
+	Workspace *types.WorkspaceData   `json:"workspace"`
+	Graph     *types.DependencyGraph `json:"graph"`
+
+	// Sources holds the parsed imports of the source files traced so far
+	Sources map[string]Source `json:"sources"`
+}
+
+// Source is the content hash and parsed workspace package imports of a
+// source file
+type Source struct {
+	Hash    string              `json:"hash"`
+	Imports []types.ImportTrace `json:"imports"`
+}
+
+// NewEntry creates an empty entry for the current engine version
+func NewEntry(root, configHash string) *Entry {
+	return &Entry{
+		Version:       fileVersion,
+		EngineVersion: analyzer.Version,
+		Root:          root,
+		ConfigHash:    configHash,
+		Manifests:     map[string]string{},
+		Sources:       map[string]Source{},
+	}
+}
+
+// Load reads the cache entry in dir. Returns nil without error when there is
+// no cache yet.
+func Load(dir string) (*Entry, error) {
+	path := filepath.Join(dir, fileName)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var e Entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("%s is not an analysis cache: %w", path, err)
+	}
+	return &e, nil
+}
+
+// Save writes the entry to dir, creating it with a .gitignore that keeps
+// the cache out of version control. The file is replaced atomically so that
+// concurrent runs never read a partial cache.
+func (e *Entry) Save(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	gitignore := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(gitignore); errors.Is(err, os.ErrNotExist) {
+		if err := os.WriteFile(gitignore, []byte("*\n"), 0644); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, fileName+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, fileName))
+}
+
+// Valid reports whether the entry was built by this engine version for the
+// same workspace root and analysis settings
+func (e *Entry) Valid(root, configHash string) bool {
+	return e != nil &&
+		e.Version == fileVersion &&
+		e.EngineVersion == analyzer.Version &&
+		e.Root == root &&
+		e.ConfigHash == configHash
+}
+
+// Hash returns the content hash of a file
+func Hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// ConfigHash returns the hash of the analysis settings the cached results
+// depend on. Rules, thresholds and package overrides only affect how check
+// evaluates a result, so changing them keeps the cache.
+func ConfigHash(config *types.AnalysisConfig) (string, error) {
+	var settings types.AnalysisConfig
+	if config != nil {
+		settings = *config
+	}
+	settings.Rules = nil
+	settings.Thresholds = nil
+	settings.Overrides = nil
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return "", err
+	}
+	return Hash(data), nil
+}
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/analyzer"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+func TestSaveLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".monoguard", "cache")
+
+	if e, err := Load(dir); e != nil || err != nil {
+		t.Fatalf("Load() of a missing cache = %v, %v; want nil, nil", e, err)
+	}
+
+	e := NewEntry("/repo", "abc")
+	e.Manifests["package.json"] = Hash([]byte("{}"))
+	e.Workspace = &types.WorkspaceData{RootPath: "/repo", Packages: map[string]*types.PackageInfo{"a": {Name: "a", Path: "packages/a"}}}
+	e.Graph = types.NewDependencyGraph("/repo", types.WorkspaceTypePnpm)
+	e.Sources["packages/a/index.ts"] = Source{Hash: "h", Imports: []types.ImportTrace{{ToPackage: "b", LineNumber: 3}}}
+	if err := e.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, ".gitignore")); err != nil || string(data) != "*\n" {
+		t.Errorf(".gitignore = %q, %v; want \"*\\n\"", data, err)
+	}
+
+	got, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !got.Valid("/repo", "abc") {
+		t.Errorf("loaded entry %+v should be valid", got)
+	}
+	if got.Workspace.Packages["a"].Path != "packages/a" || got.Graph.WorkspaceType != types.WorkspaceTypePnpm {
+		t.Errorf("loaded workspace and graph = %+v, %+v", got.Workspace, got.Graph)
+	}
+	if imports := got.Sources["packages/a/index.ts"].Imports; len(imports) != 1 || imports[0].LineNumber != 3 {
+		t.Errorf("loaded imports = %+v", imports)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, fileName), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err == nil {
+		t.Error("Load() of a corrupt cache should fail")
+	}
+}
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(e *Entry)
+		want   bool
+	}{
+		{"same", func(e *Entry) {}, true},
+		{"engine version", func(e *Entry) { e.EngineVersion = analyzer.Version + "-old" }, false},
+		{"file version", func(e *Entry) { e.Version = fileVersion + 1 }, false},
+		{"root", func(e *Entry) { e.Root = "/other" }, false},
+		{"config", func(e *Entry) { e.ConfigHash = "def" }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEntry("/repo", "abc")
+			tt.modify(e)
+			if got := e.Valid("/repo", "abc"); got != tt.want {
+				t.Errorf("Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var missing *Entry
+	if missing.Valid("/repo", "abc") {
+		t.Error("nil entry should not be valid")
+	}
+}
+
+func TestConfigHash(t *testing.T) {
+	hash := func(config *types.AnalysisConfig) string {
+		t.Helper()
+		h, err := ConfigHash(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+
+	base := hash(&types.AnalysisConfig{Exclude: []string{"a"}})
+	if hash(&types.AnalysisConfig{Exclude: []string{"b"}}) == base {
+		t.Error("changing exclude should change the hash")
+	}
+	if hash(&types.AnalysisConfig{Exclude: []string{"a"}, HealthWeights: &types.HealthWeights{Circular: 1}}) == base {
+		t.Error("changing health weights should change the hash")
+	}
+	checkOnly := &types.AnalysisConfig{
+		Exclude:    []string{"a"},
+		Rules:      &types.RulesConfig{CircularDependencies: types.RuleSeverityWarn},
+		Thresholds: &types.ThresholdsConfig{HealthScore: 80},
+		Overrides:  []types.PackageOverride{{Path: "apps"}},
+	}
+	if hash(checkOnly) != base {
+		t.Error("check rules, thresholds and overrides should not change the hash")
+	}
+	if checkOnly.Rules == nil {
+		t.Error("ConfigHash() modified the config")
+	}
+	if hash(nil) != hash(&types.AnalysisConfig{}) {
+		t.Error("nil config should hash like an empty config")
+	}
+}
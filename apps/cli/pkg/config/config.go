@@ -1,19 +1,70 @@
 // Package config provides configuration management using Viper
 package config
 
-import "github.com/spf13/viper"
+import (
+	"os"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/analyzer"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+	"github.com/spf13/viper"
+)
 
 // Config represents the MonoGuard configuration structure
 type Config struct {
-	Workspaces []string   `mapstructure:"workspaces" json:"workspaces"`
-	Rules      Rules      `mapstructure:"rules" json:"rules"`
-	Thresholds Thresholds `mapstructure:"thresholds" json:"thresholds"`
+	Workspaces  []string     `mapstructure:"workspaces" json:"workspaces"`
+	Exclude     []string     `mapstructure:"exclude" json:"exclude,omitempty"`
+	Layers      []Layer      `mapstructure:"layers" json:"layers,omitempty"`
+	Owners      []Owner      `mapstructure:"owners" json:"owners,omitempty"`
+	Plugins     []Plugin     `mapstructure:"plugins" json:"plugins,omitempty"`
+	CustomRules []CustomRule `mapstructure:"customRules" json:"customRules,omitempty"`
+	Rules       Rules        `mapstructure:"rules" json:"rules"`
+	Thresholds  Thresholds   `mapstructure:"thresholds" json:"thresholds"`
+	Health      Health       `mapstructure:"health" json:"health"`
+	Output      Output       `mapstructure:"output" json:"output"`
+	Server      Server       `mapstructure:"server" json:"server"`
+}
+
+// Layer defines an architecture layer for boundary checks
+type Layer struct {
+	Name        string   `mapstructure:"name" json:"name"`
+	Pattern     string   `mapstructure:"pattern" json:"pattern"`
+	CanDependOn []string `mapstructure:"canDependOn" json:"canDependOn,omitempty"`
+}
+
+// Owner assigns owners to the packages whose path matches a CODEOWNERS-style
+// pattern. Owner rules are applied after the CODEOWNERS file, so they win.
+type Owner struct {
+	Pattern string   `mapstructure:"pattern" json:"pattern"`
+	Owners  []string `mapstructure:"owners" json:"owners"`
+}
+
+// Plugin registers an external rule plugin. The command runs in the
+// workspace root; relative paths are resolved against it.
+type Plugin struct {
+	Name    string                 `mapstructure:"name" json:"name"`
+	Command string                 `mapstructure:"command" json:"command"`
+	Args    []string               `mapstructure:"args" json:"args,omitempty"`
+	Timeout string                 `mapstructure:"timeout" json:"timeout,omitempty"` // Go duration, e.g. "30s"
+	Options map[string]interface{} `mapstructure:"options" json:"options,omitempty"` // Passed to the plugin as is
+}
+
+// CustomRule is a declarative rule: every package, dependency or external
+// dependency (per Scope) for which the Forbid expression is true is reported
+type CustomRule struct {
+	Name        string `mapstructure:"name" json:"name"`
+	Description string `mapstructure:"description" json:"description,omitempty"`
+	Scope       string `mapstructure:"scope" json:"scope"` // package, dependency, external or workspace
+	Forbid      string `mapstructure:"forbid" json:"forbid"`
+	Severity    string `mapstructure:"severity" json:"severity,omitempty"` // error (default), warn or off
 }
 
 // Rules defines validation rules configuration
 type Rules struct {
 	CircularDependencies string `mapstructure:"circularDependencies" json:"circularDependencies"`
 	BoundaryViolations   string `mapstructure:"boundaryViolations" json:"boundaryViolations"`
+	VersionConflicts     string `mapstructure:"versionConflicts" json:"versionConflicts,omitempty"`
+	Plugins              string `mapstructure:"plugins" json:"plugins,omitempty"`
+	CustomRules          string `mapstructure:"customRules" json:"customRules,omitempty"`
 }
 
 // Thresholds defines threshold configuration
@@ -21,11 +72,150 @@ type Thresholds struct {
 	HealthScore int `mapstructure:"healthScore" json:"healthScore"`
 }
 
-// Load reads configuration from Viper
+// Health defines health score configuration
+type Health struct {
+	Weights HealthWeights `mapstructure:"weights" json:"weights"`
+}
+
+// HealthWeights sets the relative weight of each health score factor.
+// Unset factors keep their default weight.
+type HealthWeights struct {
+	Circular *float64 `mapstructure:"circular" yaml:"circular" json:"circular,omitempty"`
+	Conflict *float64 `mapstructure:"conflict" yaml:"conflict" json:"conflict,omitempty"`
+	Depth    *float64 `mapstructure:"depth" yaml:"depth" json:"depth,omitempty"`
+	Coupling *float64 `mapstructure:"coupling" yaml:"coupling" json:"coupling,omitempty"`
+	Plugins  *float64 `mapstructure:"plugins" yaml:"plugins" json:"plugins,omitempty"`
+}
+
+// Output defines output defaults. Command line flags take precedence.
+type Output struct {
+	Format  string `mapstructure:"format" json:"format,omitempty"`
+	Verbose bool   `mapstructure:"verbose" json:"verbose,omitempty"`
+}
+
+// Environment variables that take precedence over the server settings
+const (
+	ServerURLEnv = "MONOGUARD_SERVER_URL"
+	TokenEnv     = "MONOGUARD_TOKEN"
+)
+
+// Server configures the MonoGuard API server results are uploaded to
+type Server struct {
+	URL     string `mapstructure:"url" json:"url,omitempty"`
+	Project string `mapstructure:"project" json:"project,omitempty"`
+	Token   string `mapstructure:"token" json:"-"` // Never printed
+}
+
+// ServerSettings returns the server settings with MONOGUARD_SERVER_URL and
+// MONOGUARD_TOKEN applied
+func (c *Config) ServerSettings() Server {
+	s := c.Server
+	if v := os.Getenv(ServerURLEnv); v != "" {
+		s.URL = v
+	}
+	if v := os.Getenv(TokenEnv); v != "" {
+		s.Token = v
+	}
+	return s
+}
+
+// Load reads configuration from Viper. Viper lowercases keys, so plugin
+// options are read from the configuration file as written.
 func Load() (*Config, error) {
 	var cfg Config
 	if err := viper.Unmarshal(&cfg); err != nil {
 		return nil, err
 	}
+	if file := viper.ConfigFileUsed(); file != "" && len(cfg.Plugins) > 0 {
+		resolved, err := Resolve(file)
+		if err != nil {
+			return nil, err
+		}
+		cfg.pluginOptions(resolved.Values)
+	}
 	return &cfg, nil
 }
+
+// pluginOptions replaces the options of the plugins with those of the
+// resolved configuration values, which keep the case of their keys
+func (c *Config) pluginOptions(values map[string]interface{}) {
+	plugins := listValue(values["plugins"])
+	for i := range c.Plugins {
+		if i >= len(plugins) {
+			break
+		}
+		plugin, _ := plugins[i].(map[string]interface{})
+		if options, ok := plugin["options"].(map[string]interface{}); ok {
+			c.Plugins[i].Options = options
+		}
+	}
+}
+
+// AnalysisConfig converts the configuration into the engine's analysis config
+func (c *Config) AnalysisConfig() *types.AnalysisConfig {
+	ac := &types.AnalysisConfig{
+		Exclude: c.Exclude,
+		Rules: &types.RulesConfig{
+			CircularDependencies: types.RuleSeverity(c.Rules.CircularDependencies),
+			BoundaryViolations:   types.RuleSeverity(c.Rules.BoundaryViolations),
+			VersionConflicts:     types.RuleSeverity(c.Rules.VersionConflicts),
+			Plugins:              types.RuleSeverity(c.Rules.Plugins),
+			CustomRules:          types.RuleSeverity(c.Rules.CustomRules),
+		},
+		Thresholds: &types.ThresholdsConfig{
+			HealthScore: c.Thresholds.HealthScore,
+		},
+	}
+	if !c.Health.Weights.isZero() {
+		weights := c.Health.Weights.resolve()
+		ac.HealthWeights = &weights
+	}
+	for _, layer := range c.Layers {
+		ac.Layers = append(ac.Layers, types.LayerDefinition{
+			Name:        layer.Name,
+			Pattern:     layer.Pattern,
+			CanDependOn: layer.CanDependOn,
+		})
+	}
+	for _, owner := range c.Owners {
+		ac.Owners = append(ac.Owners, types.OwnerRule{
+			Pattern: owner.Pattern,
+			Owners:  owner.Owners,
+		})
+	}
+	for _, rule := range c.CustomRules {
+		ac.CustomRules = append(ac.CustomRules, types.CustomRule{
+			Name:        rule.Name,
+			Description: rule.Description,
+			Scope:       types.CustomRuleScope(rule.Scope),
+			Forbid:      rule.Forbid,
+			Severity:    types.RuleSeverity(rule.Severity),
+		})
+	}
+	return ac
+}
+
+// isZero reports whether no weight is set
+func (w HealthWeights) isZero() bool {
+	return w.Circular == nil && w.Conflict == nil && w.Depth == nil && w.Coupling == nil && w.Plugins == nil
+}
+
+// resolve returns the weights with defaults for unset factors
+func (w HealthWeights) resolve() types.HealthWeights {
+	resolved := analyzer.DefaultHealthWeights()
+	for _, f := range []struct {
+		value  *float64
+		target *float64
+	}{
+		{w.Circular, &resolved.Circular},
+		{w.Conflict, &resolved.Conflict},
+		{w.Depth, &resolved.Depth},
+		{w.Coupling, &resolved.Coupling},
+		{w.Plugins, &resolved.Plugins},
+	} {
+		if f.value != nil {
+			*f.target = *f.value
+		}
+	}
+	return resolved
+}
@@ -6,8 +6,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
 	"github.com/spf13/viper"
 )
 
@@ -207,3 +209,145 @@ thresholds:
 		t.Errorf("Config healthScore = %d, want 50", cfg.Thresholds.HealthScore)
 	}
 }
+
+// TestLoadPluginOptions verifies plugin option keys keep their case
+func TestLoadPluginOptions(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".monoguard.yaml")
+	content := `plugins:
+  - name: depth
+    command: ./depth.sh
+    options:
+      maxDepth: 3
+      BannedList: ["lodash", "moment"]
+      nested:
+        innerKey: true
+  - name: plain
+    command: ./plain.sh
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.SetConfigFile(configPath)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatalf("ReadInConfig() error = %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := map[string]interface{}{
+		"maxDepth":   3,
+		"BannedList": []interface{}{"lodash", "moment"},
+		"nested":     map[string]interface{}{"innerKey": true},
+	}
+	if len(cfg.Plugins) != 2 || !reflect.DeepEqual(cfg.Plugins[0].Options, want) {
+		t.Fatalf("Plugins = %+v, want options %v", cfg.Plugins, want)
+	}
+	if cfg.Plugins[1].Options != nil {
+		t.Errorf("plain plugin options = %v, want none", cfg.Plugins[1].Options)
+	}
+}
+
+// TestAnalysisConfig verifies conversion to the engine analysis config
+func TestAnalysisConfig(t *testing.T) {
+	cfg := &Config{
+		Exclude: []string{"packages/legacy"},
+		Layers: []Layer{
+			{Name: "apps", Pattern: "apps/*", CanDependOn: []string{"libs"}},
+			{Name: "libs", Pattern: "packages/*"},
+		},
+		Owners: []Owner{
+			{Pattern: "/apps/", Owners: []string{"@org/web", "@alice"}},
+		},
+		CustomRules: []CustomRule{
+			{Name: "max-deps", Description: "Too many dependencies", Scope: "package", Forbid: "len(dependencies) > 15", Severity: "warn"},
+		},
+		Rules: Rules{
+			CircularDependencies: "warn",
+			BoundaryViolations:   "error",
+			Plugins:              "warn",
+			CustomRules:          "off",
+		},
+		Thresholds: Thresholds{HealthScore: 80},
+	}
+
+	ac := cfg.AnalysisConfig()
+
+	if len(ac.Exclude) != 1 || ac.Exclude[0] != "packages/legacy" {
+		t.Errorf("Exclude = %v, want [packages/legacy]", ac.Exclude)
+	}
+	if len(ac.Layers) != 2 {
+		t.Fatalf("Layers length = %d, want 2", len(ac.Layers))
+	}
+	if ac.Layers[0].Name != "apps" || ac.Layers[0].Pattern != "apps/*" ||
+		len(ac.Layers[0].CanDependOn) != 1 || ac.Layers[0].CanDependOn[0] != "libs" {
+		t.Errorf("Layers[0] = %+v", ac.Layers[0])
+	}
+	if len(ac.Owners) != 1 || ac.Owners[0].Pattern != "/apps/" || len(ac.Owners[0].Owners) != 2 {
+		t.Errorf("Owners = %+v, want the /apps/ rule", ac.Owners)
+	}
+	if ac.Rules.CircularDependencies != types.RuleSeverityWarn {
+		t.Errorf("Rules.CircularDependencies = %q, want %q", ac.Rules.CircularDependencies, types.RuleSeverityWarn)
+	}
+	if ac.Rules.BoundaryViolations != types.RuleSeverityError {
+		t.Errorf("Rules.BoundaryViolations = %q, want %q", ac.Rules.BoundaryViolations, types.RuleSeverityError)
+	}
+	if ac.Rules.Plugins != types.RuleSeverityWarn {
+		t.Errorf("Rules.Plugins = %q, want %q", ac.Rules.Plugins, types.RuleSeverityWarn)
+	}
+	if ac.Rules.CustomRules != types.RuleSeverityOff {
+		t.Errorf("Rules.CustomRules = %q, want %q", ac.Rules.CustomRules, types.RuleSeverityOff)
+	}
+	wantRule := types.CustomRule{Name: "max-deps", Description: "Too many dependencies",
+		Scope: types.CustomRuleScopePackage, Forbid: "len(dependencies) > 15", Severity: types.RuleSeverityWarn}
+	if len(ac.CustomRules) != 1 || ac.CustomRules[0] != wantRule {
+		t.Errorf("CustomRules = %+v, want %+v", ac.CustomRules, wantRule)
+	}
+	if ac.Thresholds.HealthScore != 80 {
+		t.Errorf("Thresholds.HealthScore = %d, want 80", ac.Thresholds.HealthScore)
+	}
+}
+
+// TestAnalysisConfigHealthWeights verifies unset weights keep their defaults
+func TestAnalysisConfigHealthWeights(t *testing.T) {
+	if ac := (&Config{}).AnalysisConfig(); ac.HealthWeights != nil {
+		t.Errorf("HealthWeights = %+v, want nil without configured weights", ac.HealthWeights)
+	}
+
+	depth := 0.0
+	cfg := &Config{
+		Rules:  Rules{VersionConflicts: "warn"},
+		Health: Health{Weights: HealthWeights{Depth: &depth}},
+	}
+	ac := cfg.AnalysisConfig()
+
+	want := types.HealthWeights{Circular: 0.40, Conflict: 0.25, Depth: 0, Coupling: 0.15, Plugins: 0.25}
+	if ac.HealthWeights == nil || *ac.HealthWeights != want {
+		t.Errorf("HealthWeights = %+v, want %+v", ac.HealthWeights, want)
+	}
+	if ac.Rules.VersionConflicts != types.RuleSeverityWarn {
+		t.Errorf("Rules.VersionConflicts = %q, want %q", ac.Rules.VersionConflicts, types.RuleSeverityWarn)
+	}
+}
+
+func TestServerSettings(t *testing.T) {
+	cfg := &Config{Server: Server{URL: "https://file.example.com", Project: "p1", Token: "file-token"}}
+
+	t.Setenv(ServerURLEnv, "")
+	t.Setenv(TokenEnv, "")
+	if got := cfg.ServerSettings(); got != cfg.Server {
+		t.Errorf("ServerSettings() = %+v, want the configured settings", got)
+	}
+
+	t.Setenv(ServerURLEnv, "https://env.example.com")
+	t.Setenv(TokenEnv, "env-token")
+	got := cfg.ServerSettings()
+	if got.URL != "https://env.example.com" || got.Token != "env-token" || got.Project != "p1" {
+		t.Errorf("ServerSettings() = %+v, want URL and token from the environment", got)
+	}
+}
@@ -0,0 +1,137 @@
+// Package config provides configuration management using Viper
+package config
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/parser"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// excludeDirs are directory names whose packages are suggested for exclusion
+var excludeDirs = map[string]bool{
+	"example":      true,
+	"examples":     true,
+	"fixtures":     true,
+	"__fixtures__": true,
+	"demo":         true,
+	"demos":        true,
+	"sandbox":      true,
+	"playground":   true,
+}
+
+// appLayerDirs and libLayerDirs are the top-level directory conventions used
+// to propose layers. Application layers may depend on library layers;
+// library layers may depend on each other but not on applications.
+var (
+	appLayerDirs = []string{"apps", "services"}
+	libLayerDirs = []string{"libs", "packages", "modules"}
+)
+
+// Detection is what init discovered about a workspace
+type Detection struct {
+	WorkspaceType types.WorkspaceType `json:"workspaceType"`
+	Workspaces    []string            `json:"workspaces"`
+	Packages      []string            `json:"packages"` // Package paths relative to the root
+	Exclude       []string            `json:"exclude"`
+	Layers        []Layer             `json:"layers"`
+}
+
+// Detect inspects a scanned workspace and proposes a configuration
+func Detect(snap *workspace.Snapshot) (*Detection, error) {
+	p := parser.NewParser(snap.Root)
+
+	patterns, err := p.WorkspacePatterns(snap.Files)
+	if err != nil {
+		return nil, err
+	}
+	data, err := p.Parse(snap.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Detection{
+		WorkspaceType: p.DetectWorkspaceType(snap.Files),
+		Workspaces:    patterns,
+		Packages:      []string{},
+		Exclude:       []string{},
+		Layers:        []Layer{},
+	}
+
+	topDirs := map[string]bool{}
+	for name, pkg := range data.Packages {
+		d.Packages = append(d.Packages, pkg.Path)
+		segments := strings.Split(pkg.Path, "/")
+		topDirs[segments[0]] = true
+		for _, segment := range segments {
+			if excludeDirs[segment] {
+				d.Exclude = append(d.Exclude, name)
+				break
+			}
+		}
+	}
+	sort.Strings(d.Packages)
+	sort.Strings(d.Exclude)
+
+	d.Layers = proposeLayers(topDirs)
+	return d, nil
+}
+
+// Config returns the configuration proposed by the detection
+func (d *Detection) Config() *Config {
+	return &Config{
+		Workspaces: d.Workspaces,
+		Exclude:    d.Exclude,
+		Layers:     d.Layers,
+		Rules: Rules{
+			CircularDependencies: string(types.RuleSeverityError),
+			BoundaryViolations:   string(types.RuleSeverityWarn),
+			VersionConflicts:     string(types.RuleSeverityWarn),
+		},
+	}
+}
+
+// proposeLayers creates layers for the conventional top-level directories that
+// contain packages. Layers are only proposed when there is something to
+// constrain, i.e. at least one application and one library layer.
+func proposeLayers(topDirs map[string]bool) []Layer {
+	var apps, libs []string
+	for _, dir := range appLayerDirs {
+		if topDirs[dir] {
+			apps = append(apps, dir)
+		}
+	}
+	for _, dir := range libLayerDirs {
+		if topDirs[dir] {
+			libs = append(libs, dir)
+		}
+	}
+	if len(apps) == 0 || len(libs) == 0 {
+		return []Layer{}
+	}
+
+	layers := []Layer{}
+	for _, dir := range apps {
+		layers = append(layers, Layer{Name: dir, Pattern: path.Join(dir, "**"), CanDependOn: libs})
+	}
+	for _, dir := range libs {
+		var others []string
+		for _, other := range libs {
+			if other != dir {
+				others = append(others, other)
+			}
+		}
+		layers = append(layers, Layer{Name: dir, Pattern: path.Join(dir, "**"), CanDependOn: others})
+	}
+	return layers
+}
+
+// InitResult reports the outcome of `monoguard init`
+type InitResult struct {
+	Status string `json:"status"` // "created" or "overwritten"
+	Path   string `json:"path"`   // Written configuration file
+	*Detection
+}
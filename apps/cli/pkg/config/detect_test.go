@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// scanFiles writes files under a temp root and scans it
+func scanFiles(t *testing.T, files map[string]string) *workspace.Snapshot {
+	t.Helper()
+	root := t.TempDir()
+	for rel, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	snap, err := workspace.Scan(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return snap
+}
+
+func TestDetect(t *testing.T) {
+	snap := scanFiles(t, map[string]string{
+		"package.json":                      `{"name": "root"}`,
+		"pnpm-workspace.yaml":               "packages:\n  - 'apps/*'\n  - 'libs/*'\n  - 'examples/*'\n",
+		"apps/web/package.json":             `{"name": "@mono/web"}`,
+		"libs/ui/package.json":              `{"name": "@mono/ui"}`,
+		"examples/basic/package.json":       `{"name": "@mono/example-basic"}`,
+		"libs/ui/fixtures/app/package.json": `{"name": "fixture-app"}`,
+	})
+
+	d, err := Detect(snap)
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	if d.WorkspaceType != types.WorkspaceTypePnpm {
+		t.Errorf("WorkspaceType = %q, want pnpm", d.WorkspaceType)
+	}
+	if want := []string{"apps/*", "libs/*", "examples/*"}; !reflect.DeepEqual(d.Workspaces, want) {
+		t.Errorf("Workspaces = %v, want %v", d.Workspaces, want)
+	}
+	if want := []string{"apps/web", "examples/basic", "libs/ui"}; !reflect.DeepEqual(d.Packages, want) {
+		t.Errorf("Packages = %v, want %v", d.Packages, want)
+	}
+	if want := []string{"@mono/example-basic"}; !reflect.DeepEqual(d.Exclude, want) {
+		t.Errorf("Exclude = %v, want %v", d.Exclude, want)
+	}
+
+	wantLayers := []Layer{
+		{Name: "apps", Pattern: "apps/**", CanDependOn: []string{"libs"}},
+		{Name: "libs", Pattern: "libs/**"},
+	}
+	if !reflect.DeepEqual(d.Layers, wantLayers) {
+		t.Errorf("Layers = %+v, want %+v", d.Layers, wantLayers)
+	}
+}
+
+func TestDetect_NoLayersWithoutApplications(t *testing.T) {
+	snap := scanFiles(t, map[string]string{
+		"package.json":            `{"name": "root", "workspaces": ["packages/*"]}`,
+		"package-lock.json":       `{}`,
+		"packages/a/package.json": `{"name": "@mono/a"}`,
+	})
+
+	d, err := Detect(snap)
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if d.WorkspaceType != types.WorkspaceTypeNpm {
+		t.Errorf("WorkspaceType = %q, want npm", d.WorkspaceType)
+	}
+	if len(d.Layers) != 0 {
+		t.Errorf("Layers = %+v, want none", d.Layers)
+	}
+}
+
+func TestDetect_MissingRootPackageJSON(t *testing.T) {
+	snap := scanFiles(t, map[string]string{"README.md": "# empty"})
+	if _, err := Detect(snap); err == nil {
+		t.Error("Detect() should fail without a root package.json")
+	}
+}
@@ -0,0 +1,416 @@
+// Package config provides configuration management using Viper
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Resolved is a configuration file merged with the presets it extends.
+//
+// Presets are merged in the order they are listed, each after its own
+// presets, and the file itself is merged last. When merging:
+//
+//   - mappings (rules, thresholds, health, output) are merged key by key
+//   - exclude patterns are added to the inherited ones, without duplicates
+//   - layers are merged by name: fields of a layer with an inherited name
+//     replace the inherited fields, other layers are appended
+//   - any other value, including workspaces and canDependOn, replaces the
+//     inherited value
+type Resolved struct {
+	File    string                 `json:"file"`    // The configuration file
+	Files   []string               `json:"files"`   // Every merged file, presets first
+	Values  map[string]interface{} `json:"config"`  // Merged configuration without extends
+	Origins map[string]string      `json:"origins"` // File each value came from, by key path
+}
+
+// Resolve reads a configuration file and merges it with the presets it
+// extends. Every file is validated; problems are returned as a
+// *ValidationError.
+func Resolve(file string) (*Resolved, error) {
+	r := &Resolved{File: file, Values: map[string]interface{}{}, Origins: map[string]string{}}
+	if err := r.load(file, nil); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// load merges a file and its presets into r. stack holds the files that
+// extend file, to detect cycles.
+func (r *Resolved) load(file string, stack []string) error {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return err
+	}
+	for i, f := range stack {
+		if f == abs {
+			return fmt.Errorf("configuration extends itself: %s", strings.Join(append(stack[i:], abs), " -> "))
+		}
+	}
+	stack = append(stack, abs)
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	root, issues := checkSchema(data)
+	if len(issues) > 0 {
+		return &ValidationError{File: file, Issues: issues}
+	}
+	if root == nil {
+		r.Files = append(r.Files, file)
+		return nil
+	}
+
+	var values map[string]interface{}
+	if err := root.Decode(&values); err != nil {
+		return fmt.Errorf("failed to read config %s: %w", file, err)
+	}
+	for _, spec := range extendsList(values["extends"]) {
+		preset, err := findPreset(spec, filepath.Dir(file))
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		if err := r.load(preset, stack); err != nil {
+			return err
+		}
+	}
+	delete(values, "extends")
+
+	if issues := checkReferences(root, r.layerNames()); len(issues) > 0 {
+		sortIssues(issues)
+		return &ValidationError{File: file, Issues: issues}
+	}
+	r.merge(r.Values, values, "", file)
+	r.Files = append(r.Files, file)
+	return nil
+}
+
+// merge merges src, read from file, into dst at path
+func (r *Resolved) merge(dst, src map[string]interface{}, path, file string) {
+	for _, key := range sortedKeys(src) {
+		value := src[key]
+		keyPath := joinPath(path, key)
+		switch {
+		case keyPath == "exclude":
+			r.mergeExclude(value, file)
+		case keyPath == "layers":
+			r.mergeLayers(value, file)
+		default:
+			if m, ok := value.(map[string]interface{}); ok {
+				sub, ok := dst[key].(map[string]interface{})
+				if !ok {
+					r.forget(keyPath)
+					sub = map[string]interface{}{}
+					dst[key] = sub
+				}
+				r.merge(sub, m, keyPath, file)
+				continue
+			}
+			r.forget(keyPath)
+			dst[key] = value
+			r.Origins[keyPath] = file
+		}
+	}
+}
+
+// mergeExclude adds exclude patterns that are not inherited yet
+func (r *Resolved) mergeExclude(value interface{}, file string) {
+	patterns, _ := r.Values["exclude"].([]interface{})
+	for _, p := range listValue(value) {
+		if containsValue(patterns, p) {
+			continue
+		}
+		r.Origins[fmt.Sprintf("exclude[%d]", len(patterns))] = file
+		patterns = append(patterns, p)
+	}
+	r.Values["exclude"] = patterns
+}
+
+// mergeLayers merges layers by name
+func (r *Resolved) mergeLayers(value interface{}, file string) {
+	layers, _ := r.Values["layers"].([]interface{})
+	for _, item := range listValue(value) {
+		layer, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		i := len(layers)
+		for j, existing := range layers {
+			if existing.(map[string]interface{})["name"] == layer["name"] {
+				i = j
+				break
+			}
+		}
+		if i == len(layers) {
+			layers = append(layers, map[string]interface{}{})
+		}
+		merged := layers[i].(map[string]interface{})
+		for _, key := range sortedKeys(layer) {
+			merged[key] = layer[key]
+			r.Origins[fmt.Sprintf("layers[%d].%s", i, key)] = file
+		}
+	}
+	r.Values["layers"] = layers
+}
+
+// forget removes the origins of a value and everything below it
+func (r *Resolved) forget(path string) {
+	for p := range r.Origins {
+		if p == path || strings.HasPrefix(p, path+".") || strings.HasPrefix(p, path+"[") {
+			delete(r.Origins, p)
+		}
+	}
+}
+
+// layerNames returns the names of the layers merged so far
+func (r *Resolved) layerNames() map[string]bool {
+	names := map[string]bool{}
+	layers, _ := r.Values["layers"].([]interface{})
+	for _, item := range layers {
+		if name, ok := item.(map[string]interface{})["name"].(string); ok {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// Origin returns the file a value came from, looking at the enclosing values
+// for values replaced as a whole
+func (r *Resolved) Origin(path string) string {
+	for path != "" {
+		if file, ok := r.Origins[path]; ok {
+			return file
+		}
+		cut := max(strings.LastIndex(path, "."), strings.LastIndex(path, "["))
+		if cut < 0 {
+			break
+		}
+		path = path[:cut]
+	}
+	return ""
+}
+
+// ============================================================
+// Preset lookup
+// ============================================================
+
+// findPreset returns the file of an extends entry. Entries starting with
+// "." or "/" are files relative to dir; others are packages, optionally
+// followed by a path, found in the node_modules directories of dir and its
+// parents. A package directory is read from the file named by the
+// "monoguard" field of its package.json, or its .monoguard.yaml.
+func findPreset(spec, dir string) (string, error) {
+	if strings.HasPrefix(spec, ".") || filepath.IsAbs(spec) {
+		path := spec
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, spec)
+		}
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("preset %q not found", spec)
+		}
+		return path, nil
+	}
+
+	name, sub := splitPackage(spec)
+	for d := dir; ; d = filepath.Dir(d) {
+		pkgDir := filepath.Join(d, "node_modules", filepath.FromSlash(name))
+		if info, err := os.Stat(pkgDir); err == nil && info.IsDir() {
+			if sub != "" {
+				path := filepath.Join(pkgDir, filepath.FromSlash(sub))
+				if _, err := os.Stat(path); err != nil {
+					return "", fmt.Errorf("preset %q not found in %s", spec, pkgDir)
+				}
+				return path, nil
+			}
+			return packagePreset(pkgDir)
+		}
+		if filepath.Dir(d) == d {
+			return "", fmt.Errorf("preset package %q not found in node_modules", name)
+		}
+	}
+}
+
+// packagePreset returns the configuration file of a preset package
+func packagePreset(pkgDir string) (string, error) {
+	var manifest struct {
+		MonoGuard string `json:"monoguard"`
+	}
+	if data, err := os.ReadFile(filepath.Join(pkgDir, "package.json")); err == nil {
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return "", fmt.Errorf("invalid package.json in %s: %w", pkgDir, err)
+		}
+	}
+	if manifest.MonoGuard != "" {
+		return filepath.Join(pkgDir, filepath.FromSlash(manifest.MonoGuard)), nil
+	}
+	path := filepath.Join(pkgDir, FileName)
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("%s has no %s and no \"monoguard\" field in package.json", pkgDir, FileName)
+	}
+	return path, nil
+}
+
+// splitPackage splits a package specifier into the package name, which has
+// two segments for scoped packages, and the path within the package
+func splitPackage(spec string) (string, string) {
+	segments := strings.SplitN(spec, "/", 3)
+	n := 1
+	if strings.HasPrefix(spec, "@") && len(segments) > 1 {
+		n = 2
+	}
+	if len(segments) <= n {
+		return spec, ""
+	}
+	return strings.Join(segments[:n], "/"), strings.Join(segments[n:], "/")
+}
+
+// ============================================================
+// Printing
+// ============================================================
+
+// keyOrder is the order keys are printed in; other keys follow sorted
+var keyOrder = []string{
+	"extends", "workspaces", "exclude", "layers", "name", "pattern", "canDependOn", "owners",
+	"command", "args", "timeout", "options", "customRules", "description", "scope", "forbid", "severity",
+	"rules", "circularDependencies", "boundaryViolations", "versionConflicts", "plugins",
+	"thresholds", "healthScore", "health", "weights", "circular", "conflict", "depth", "coupling",
+	"output", "format", "verbose",
+}
+
+// YAML renders the merged configuration with the file each value came from
+// as a comment. Files are shown relative to the configuration file.
+func (r *Resolved) YAML() (string, error) {
+	dir, err := filepath.Abs(filepath.Dir(r.File))
+	if err != nil {
+		return "", err
+	}
+	root := r.node(r.Values, "", dir)
+	var b strings.Builder
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if len(r.Values) > 0 {
+		if err := enc.Encode(root); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+// node builds the YAML node of a value with origin comments
+func (r *Resolved) node(value interface{}, path, dir string) *yaml.Node {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		n := &yaml.Node{Kind: yaml.MappingNode}
+		for _, key := range orderedKeys(v) {
+			keyPath := joinPath(path, key)
+			k := &yaml.Node{Kind: yaml.ScalarNode, Value: key}
+			child := r.node(v[key], keyPath, dir)
+			if file, ok := r.Origins[keyPath]; ok {
+				if child.Kind == yaml.ScalarNode {
+					child.LineComment = "from " + relPath(dir, file)
+				} else {
+					k.LineComment = "from " + relPath(dir, file)
+				}
+			}
+			n.Content = append(n.Content, k, child)
+		}
+		return n
+	case []interface{}:
+		n := &yaml.Node{Kind: yaml.SequenceNode}
+		for i, item := range v {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			child := r.node(item, itemPath, dir)
+			if file, ok := r.Origins[itemPath]; ok && child.Kind == yaml.ScalarNode {
+				child.LineComment = "from " + relPath(dir, file)
+			}
+			n.Content = append(n.Content, child)
+		}
+		return n
+	default:
+		n := &yaml.Node{}
+		if err := n.Encode(v); err != nil {
+			return &yaml.Node{Kind: yaml.ScalarNode, Value: fmt.Sprint(v)}
+		}
+		return n
+	}
+}
+
+// ============================================================
+// Helpers
+// ============================================================
+
+// extendsList returns the entries of an extends value
+func extendsList(value interface{}) []string {
+	if s, ok := value.(string); ok {
+		return []string{s}
+	}
+	var specs []string
+	for _, item := range listValue(value) {
+		if s, ok := item.(string); ok {
+			specs = append(specs, s)
+		}
+	}
+	return specs
+}
+
+// listValue returns a list value, or nil
+func listValue(value interface{}) []interface{} {
+	list, _ := value.([]interface{})
+	return list
+}
+
+// containsValue reports whether values includes value
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of a mapping in sorted order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// orderedKeys returns the keys of a mapping in keyOrder
+func orderedKeys(m map[string]interface{}) []string {
+	rank := func(key string) string {
+		for i, k := range keyOrder {
+			if k == key {
+				return strconv.Itoa(1000 + i)
+			}
+		}
+		return "9999" + key
+	}
+	keys := sortedKeys(m)
+	sort.SliceStable(keys, func(i, j int) bool { return rank(keys[i]) < rank(keys[j]) })
+	return keys
+}
+
+// relPath returns file relative to dir, or file if that is not possible
+func relPath(dir, file string) string {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return file
+	}
+	rel, err := filepath.Rel(dir, abs)
+	if err != nil {
+		return file
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,259 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeConfigFiles writes files below a temporary directory and returns it
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// presetWorkspace extends a local file and a scoped package preset
+var presetWorkspace = map[string]string{
+	".monoguard.yaml": `extends:
+  - ./config/base.yaml
+  - "@acme/monoguard-config"
+exclude: ["@mono/demo", "@mono/fixture"]
+layers:
+  - name: libs
+    pattern: "packages/**"
+  - name: tools
+    pattern: "tools/**"
+    canDependOn: [libs]
+rules:
+  boundaryViolations: warn
+`,
+	"config/base.yaml": `workspaces: ["apps/*", "libs/*"]
+exclude: ["@mono/fixture"]
+layers:
+  - name: apps
+    pattern: "apps/**"
+    canDependOn: [libs]
+  - name: libs
+    pattern: "libs/**"
+rules:
+  circularDependencies: warn
+  boundaryViolations: error
+thresholds:
+  healthScore: 60
+`,
+	"node_modules/@acme/monoguard-config/package.json": `{"name": "@acme/monoguard-config", "monoguard": "preset.yaml"}`,
+	"node_modules/@acme/monoguard-config/preset.yaml": `rules:
+  circularDependencies: error
+  versionConflicts: warn
+thresholds:
+  healthScore: 80
+`,
+}
+
+func TestResolve(t *testing.T) {
+	root := writeConfigFiles(t, presetWorkspace)
+	file := filepath.Join(root, FileName)
+	base := filepath.Join(root, "config", "base.yaml")
+	preset := filepath.Join(root, "node_modules", "@acme", "monoguard-config", "preset.yaml")
+
+	r, err := Resolve(file)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	if want := []string{base, preset, file}; !reflect.DeepEqual(r.Files, want) {
+		t.Errorf("Files = %v, want %v", r.Files, want)
+	}
+	if _, ok := r.Values["extends"]; ok {
+		t.Error("resolved configuration still has extends")
+	}
+
+	wantValues := map[string]interface{}{
+		"workspaces": []interface{}{"apps/*", "libs/*"},
+		"exclude":    []interface{}{"@mono/fixture", "@mono/demo"},
+		"layers": []interface{}{
+			map[string]interface{}{"name": "apps", "pattern": "apps/**", "canDependOn": []interface{}{"libs"}},
+			map[string]interface{}{"name": "libs", "pattern": "packages/**"},
+			map[string]interface{}{"name": "tools", "pattern": "tools/**", "canDependOn": []interface{}{"libs"}},
+		},
+		"rules": map[string]interface{}{
+			"circularDependencies": "error",
+			"boundaryViolations":   "warn",
+			"versionConflicts":     "warn",
+		},
+		"thresholds": map[string]interface{}{"healthScore": 80},
+	}
+	if !reflect.DeepEqual(r.Values, wantValues) {
+		t.Errorf("Values = %#v\nwant %#v", r.Values, wantValues)
+	}
+
+	origins := map[string]string{
+		"workspaces":                 base,
+		"workspaces[1]":              base,
+		"exclude[0]":                 base,
+		"exclude[1]":                 file,
+		"layers[0].pattern":          base,
+		"layers[1].pattern":          file,
+		"layers[2].name":             file,
+		"rules.circularDependencies": preset,
+		"rules.boundaryViolations":   file,
+		"thresholds.healthScore":     preset,
+	}
+	for path, want := range origins {
+		if got := r.Origin(path); got != want {
+			t.Errorf("Origin(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestResolveYAML(t *testing.T) {
+	root := writeConfigFiles(t, presetWorkspace)
+	r, err := Resolve(filepath.Join(root, FileName))
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	text, err := r.YAML()
+	if err != nil {
+		t.Fatalf("YAML() error = %v", err)
+	}
+	for _, want := range []string{
+		"workspaces: # from config/base.yaml\n",
+		"  - '@mono/demo' # from .monoguard.yaml\n",
+		"    pattern: packages/** # from .monoguard.yaml\n",
+		"  circularDependencies: error # from node_modules/@acme/monoguard-config/preset.yaml\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("YAML() missing %q\n%s", want, text)
+		}
+	}
+	if strings.Index(text, "workspaces:") > strings.Index(text, "rules:") {
+		t.Errorf("YAML() does not list workspaces before rules\n%s", text)
+	}
+}
+
+func TestResolvePackagePresets(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		extends string
+		want    string // preset file relative to the workspace
+	}{
+		{
+			name:    "package .monoguard.yaml",
+			files:   map[string]string{"node_modules/monoguard-preset/.monoguard.yaml": "rules: {}\n"},
+			extends: "monoguard-preset",
+			want:    "node_modules/monoguard-preset/.monoguard.yaml",
+		},
+		{
+			name:    "file within a package",
+			files:   map[string]string{"node_modules/@acme/presets/strict.yaml": "rules: {}\n"},
+			extends: "@acme/presets/strict.yaml",
+			want:    "node_modules/@acme/presets/strict.yaml",
+		},
+		{
+			name:    "node_modules of a parent directory",
+			files:   map[string]string{"../node_modules/monoguard-preset/.monoguard.yaml": "rules: {}\n"},
+			extends: "monoguard-preset",
+			want:    "../node_modules/monoguard-preset/.monoguard.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := map[string]string{"repo/" + FileName: "extends: \"" + tt.extends + "\"\n"}
+			for name, content := range tt.files {
+				files[filepath.ToSlash(filepath.Join("repo", name))] = content
+			}
+			root := writeConfigFiles(t, files)
+
+			r, err := Resolve(filepath.Join(root, "repo", FileName))
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			want := filepath.Join(root, "repo", filepath.FromSlash(tt.want))
+			if r.Files[0] != want {
+				t.Errorf("preset = %s, want %s", r.Files[0], want)
+			}
+		})
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name:  "missing file",
+			files: map[string]string{FileName: "extends: ./missing.yaml\n"},
+			want:  `preset "./missing.yaml" not found`,
+		},
+		{
+			name:  "missing package",
+			files: map[string]string{FileName: "extends: \"@acme/missing\"\n"},
+			want:  `preset package "@acme/missing" not found in node_modules`,
+		},
+		{
+			name: "cycle",
+			files: map[string]string{
+				FileName: "extends: ./a.yaml\n",
+				"a.yaml": "extends: ./b.yaml\n",
+				"b.yaml": "extends: ./a.yaml\n",
+			},
+			want: "configuration extends itself: ",
+		},
+		{
+			name: "invalid preset",
+			files: map[string]string{
+				FileName:    "extends: ./base.yaml\n",
+				"base.yaml": "rules:\n  circularDependencies: eror\n",
+			},
+			want: `base.yaml:2:25: rules.circularDependencies: must be one of error, warn, off`,
+		},
+		{
+			name:  "unknown inherited layer",
+			files: map[string]string{FileName: "layers:\n  - name: apps\n    pattern: apps/**\n    canDependOn: [libs]\n"},
+			want:  `layers[0].canDependOn[0]: unknown layer "libs"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeConfigFiles(t, tt.files)
+			_, err := Resolve(filepath.Join(root, FileName))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Resolve() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestResolveInheritedLayers verifies canDependOn may name preset layers
+func TestResolveInheritedLayers(t *testing.T) {
+	root := writeConfigFiles(t, map[string]string{
+		FileName:    "extends: ./base.yaml\nlayers:\n  - name: apps\n    pattern: apps/**\n    canDependOn: [libs]\n",
+		"base.yaml": "layers:\n  - name: libs\n    pattern: libs/**\n",
+	})
+
+	r, err := Resolve(filepath.Join(root, FileName))
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if layers := r.Values["layers"].([]interface{}); len(layers) != 2 {
+		t.Errorf("layers = %v, want libs and apps", layers)
+	}
+}
@@ -0,0 +1,91 @@
+// Package config provides configuration management using Viper
+package config
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+	"github.com/spf13/viper"
+)
+
+// overrideKeys are the settings a configuration file below the workspace
+// root may set
+var overrideKeys = map[string]bool{"exclude": true, "rules": true, "thresholds": true}
+
+// Overrides reads the .monoguard.yaml files below the workspace root. Each
+// file sets the rules, exclusions and thresholds used by check for the
+// packages in its directory; the nearest file takes precedence. Files may
+// extend presets, whose other settings are ignored. Other settings of the
+// files themselves are ignored too, such as in a full configuration of a
+// fixture or example, and returned as warnings.
+func Overrides(snap *workspace.Snapshot) ([]types.PackageOverride, []string, error) {
+	var files []string
+	for rel := range snap.Files {
+		if path.Base(rel) == FileName && path.Dir(rel) != "." {
+			files = append(files, rel)
+		}
+	}
+	sort.Strings(files)
+
+	var overrides []types.PackageOverride
+	var warnings []string
+	for _, rel := range files {
+		file := filepath.Join(snap.Root, filepath.FromSlash(rel))
+		resolved, err := Resolve(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		if keys := rootOnlyKeys(resolved, file); len(keys) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s: ignoring %s (package configurations only set rules, exclude and thresholds)",
+				file, strings.Join(keys, ", ")))
+		}
+
+		v := viper.New()
+		if err := v.MergeConfigMap(resolved.Values); err != nil {
+			return nil, nil, err
+		}
+		var cfg Config
+		if err := v.Unmarshal(&cfg); err != nil {
+			return nil, nil, fmt.Errorf("failed to read config %s: %w", file, err)
+		}
+		override := types.PackageOverride{
+			Path:    path.Dir(rel),
+			Exclude: cfg.Exclude,
+			Rules: &types.RulesConfig{
+				CircularDependencies: types.RuleSeverity(cfg.Rules.CircularDependencies),
+				BoundaryViolations:   types.RuleSeverity(cfg.Rules.BoundaryViolations),
+				VersionConflicts:     types.RuleSeverity(cfg.Rules.VersionConflicts),
+				Plugins:              types.RuleSeverity(cfg.Rules.Plugins),
+				CustomRules:          types.RuleSeverity(cfg.Rules.CustomRules),
+			},
+		}
+		// A threshold of 0 disables the inherited one, so only set thresholds apply
+		if _, ok := resolved.Origins["thresholds.healthScore"]; ok {
+			override.Thresholds = &types.ThresholdsConfig{HealthScore: cfg.Thresholds.HealthScore}
+		}
+		overrides = append(overrides, override)
+	}
+	return overrides, warnings, nil
+}
+
+// rootOnlyKeys returns the sorted settings of file itself that are not read
+// below the workspace root
+func rootOnlyKeys(r *Resolved, file string) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for p, origin := range r.Origins {
+		key, _, _ := strings.Cut(p, ".")
+		key, _, _ = strings.Cut(key, "[")
+		if origin == file && !overrideKeys[key] && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+func TestOverrides(t *testing.T) {
+	root := writeConfigFiles(t, map[string]string{
+		"package.json":               `{"name": "root", "private": true}`,
+		FileName:                     "rules:\n  circularDependencies: warn\n",
+		"shared.yaml":                "layers:\n  - name: apps\n    pattern: apps/**\nrules:\n  versionConflicts: warn\n",
+		"apps/web/package.json":      `{"name": "@mono/web"}`,
+		"apps/web/" + FileName:       "extends: ../../shared.yaml\nrules:\n  boundaryViolations: off\nexclude: [\"@mono/legacy-*\"]\nthresholds:\n  healthScore: 50\n",
+		"apps/" + FileName:           "rules:\n  circularDependencies: off\nthresholds:\n  healthScore: 0\n",
+		"libs/ui/package.json":       `{"name": "@mono/ui"}`,
+		"node_modules/x/" + FileName: "rules:\n  circularDependencies: off\n",
+	})
+	snap, err := workspace.Scan(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	overrides, warnings, err := Overrides(snap)
+	if err != nil || len(warnings) > 0 {
+		t.Fatalf("Overrides() warnings = %v, error = %v", warnings, err)
+	}
+	if len(overrides) != 2 {
+		t.Fatalf("Overrides() = %+v, want apps and apps/web", overrides)
+	}
+	if o := overrides[0]; o.Path != "apps" || o.Rules.CircularDependencies != types.RuleSeverityOff {
+		t.Errorf("overrides[0] = %+v", o)
+	}
+	// A threshold of 0 is set, and disables the inherited threshold
+	if o := overrides[0]; o.Thresholds == nil || o.Thresholds.HealthScore != 0 {
+		t.Errorf("overrides[0].Thresholds = %+v, want healthScore 0", o.Thresholds)
+	}
+	o := overrides[1]
+	if o.Path != "apps/web" || strings.Join(o.Exclude, ",") != "@mono/legacy-*" {
+		t.Errorf("overrides[1] = %+v", o)
+	}
+	want := types.RulesConfig{BoundaryViolations: types.RuleSeverityOff, VersionConflicts: types.RuleSeverityWarn}
+	if *o.Rules != want {
+		t.Errorf("overrides[1].Rules = %+v, want %+v", *o.Rules, want)
+	}
+	if o.Thresholds == nil || o.Thresholds.HealthScore != 50 {
+		t.Errorf("overrides[1].Thresholds = %+v, want healthScore 50", o.Thresholds)
+	}
+}
+
+func TestOverridesRootOnlySettings(t *testing.T) {
+	root := writeConfigFiles(t, map[string]string{
+		"package.json": `{"name": "root", "private": true}`,
+		"examples/demo/" + FileName: "workspaces: [\"packages/*\"]\nlayers:\n  - name: web\n    pattern: apps/web\n" +
+			"output:\n  format: json\nrules:\n  circularDependencies: warn\n",
+	})
+	snap, err := workspace.Scan(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	overrides, warnings, err := Overrides(snap)
+	if err != nil {
+		t.Fatalf("Overrides() error = %v", err)
+	}
+	if len(overrides) != 1 || overrides[0].Rules.CircularDependencies != types.RuleSeverityWarn {
+		t.Errorf("Overrides() = %+v, want the rules of examples/demo", overrides)
+	}
+	want := filepath.Join(root, "examples", "demo", FileName) + ": ignoring layers, output, workspaces"
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], want) {
+		t.Errorf("warnings = %q, want %q", warnings, want)
+	}
+}
@@ -0,0 +1,140 @@
+// Package config provides configuration management using Viper
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+)
+
+// FileName is the default configuration file name. The scanner defines it,
+// since it collects the configuration files below the workspace root and
+// cannot import this package.
+const FileName = workspace.ConfigFileName
+
+// SchemaURL is where the configuration JSON Schema is published
+const SchemaURL = "https://raw.githubusercontent.com/j620656786206/MonoGuard/main/apps/cli/pkg/config/monoguard.schema.json"
+
+// Render returns the configuration as commented YAML suitable for .monoguard.yaml
+func Render(cfg *Config) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# yaml-language-server: $schema=%s\n", SchemaURL)
+	fmt.Fprintf(&b, "# MonoGuard configuration\n")
+	fmt.Fprintf(&b, "# Generated by `monoguard init`. Review the detected values before committing.\n")
+	fmt.Fprintln(&b)
+
+	fmt.Fprintf(&b, "# Workspace package globs (from pnpm-workspace.yaml or package.json \"workspaces\")\n")
+	writeList(&b, "workspaces", cfg.Workspaces)
+	fmt.Fprintln(&b)
+
+	fmt.Fprintf(&b, "# Packages left out of the analysis (exact name, glob, or regex:<pattern>)\n")
+	writeList(&b, "exclude", cfg.Exclude)
+	fmt.Fprintln(&b)
+
+	fmt.Fprintf(&b, "# Architecture layers matched against package paths. A package belongs to the\n")
+	fmt.Fprintf(&b, "# first matching layer and may only depend on its own layer and canDependOn.\n")
+	if len(cfg.Layers) == 0 {
+		fmt.Fprintf(&b, "layers: []\n")
+		fmt.Fprintf(&b, "# layers:\n")
+		fmt.Fprintf(&b, "#   - name: apps\n")
+		fmt.Fprintf(&b, "#     pattern: \"apps/**\"\n")
+		fmt.Fprintf(&b, "#     canDependOn: [libs]\n")
+		fmt.Fprintf(&b, "#   - name: libs\n")
+		fmt.Fprintf(&b, "#     pattern: \"libs/**\"\n")
+	} else {
+		fmt.Fprintf(&b, "layers:\n")
+		for _, layer := range cfg.Layers {
+			fmt.Fprintf(&b, "  - name: %q\n", layer.Name)
+			fmt.Fprintf(&b, "    pattern: %q\n", layer.Pattern)
+			fmt.Fprintf(&b, "    canDependOn: %s\n", flowList(layer.CanDependOn))
+		}
+	}
+	fmt.Fprintln(&b)
+
+	fmt.Fprintf(&b, "# Rule severities used by `monoguard check`: error | warn | off\n")
+	fmt.Fprintf(&b, "rules:\n")
+	fmt.Fprintf(&b, "  circularDependencies: %s\n", orDefault(cfg.Rules.CircularDependencies, "error"))
+	fmt.Fprintf(&b, "  boundaryViolations: %s\n", orDefault(cfg.Rules.BoundaryViolations, "error"))
+	if cfg.Rules.VersionConflicts != "" {
+		fmt.Fprintf(&b, "  versionConflicts: %s\n", cfg.Rules.VersionConflicts)
+	} else {
+		fmt.Fprintf(&b, "  # versionConflicts: off\n")
+	}
+	fmt.Fprintln(&b)
+
+	fmt.Fprintf(&b, "thresholds:\n")
+	fmt.Fprintf(&b, "  # Fail `monoguard check` below this health score (0 disables)\n")
+	fmt.Fprintf(&b, "  healthScore: %d\n", cfg.Thresholds.HealthScore)
+	fmt.Fprintln(&b)
+
+	fmt.Fprintf(&b, "# Relative weight of each health score factor; unset factors keep the default\n")
+	defaults := HealthWeights{}.resolve()
+	prefix := "# "
+	if !cfg.Health.Weights.isZero() {
+		prefix = ""
+	}
+	fmt.Fprintf(&b, "%shealth:\n", prefix)
+	fmt.Fprintf(&b, "%s  weights:\n", prefix)
+	for _, w := range []struct {
+		name  string
+		value *float64
+		def   float64
+	}{
+		{"circular", cfg.Health.Weights.Circular, defaults.Circular},
+		{"conflict", cfg.Health.Weights.Conflict, defaults.Conflict},
+		{"depth", cfg.Health.Weights.Depth, defaults.Depth},
+		{"coupling", cfg.Health.Weights.Coupling, defaults.Coupling},
+		{"plugins", cfg.Health.Weights.Plugins, defaults.Plugins},
+	} {
+		if w.value != nil {
+			fmt.Fprintf(&b, "    %s: %g\n", w.name, *w.value)
+		} else {
+			fmt.Fprintf(&b, "#     %s: %.2f\n", w.name, w.def)
+		}
+	}
+	fmt.Fprintln(&b)
+
+	fmt.Fprintf(&b, "# Output defaults; command line flags take precedence\n")
+	if cfg.Output.Format == "" && !cfg.Output.Verbose {
+		fmt.Fprintf(&b, "# output:\n")
+		fmt.Fprintf(&b, "#   format: text\n")
+		fmt.Fprintf(&b, "#   verbose: false\n")
+	} else {
+		fmt.Fprintf(&b, "output:\n")
+		fmt.Fprintf(&b, "  format: %s\n", orDefault(cfg.Output.Format, "text"))
+		fmt.Fprintf(&b, "  verbose: %t\n", cfg.Output.Verbose)
+	}
+
+	return b.String()
+}
+
+// writeList writes a YAML block sequence, or an empty flow sequence
+func writeList(b *strings.Builder, key string, values []string) {
+	if len(values) == 0 {
+		fmt.Fprintf(b, "%s: []\n", key)
+		return
+	}
+	fmt.Fprintf(b, "%s:\n", key)
+	for _, v := range values {
+		fmt.Fprintf(b, "  - %q\n", v)
+	}
+}
+
+// flowList formats values as a YAML flow sequence
+func flowList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// orDefault returns value, or def when value is empty
+func orDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
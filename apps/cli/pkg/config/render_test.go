@@ -0,0 +1,80 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestRenderRoundTrip verifies the rendered YAML loads back into the same Config
+func TestRenderRoundTrip(t *testing.T) {
+	circular, coupling := 0.6, 0.0
+	want := &Config{
+		Workspaces: []string{"apps/*", "packages/*"},
+		Exclude:    []string{"@mono/example", "regex:^@mono/fixture-"},
+		Layers: []Layer{
+			{Name: "apps", Pattern: "apps/**", CanDependOn: []string{"packages"}},
+			{Name: "packages", Pattern: "packages/**", CanDependOn: []string{}},
+		},
+		Rules: Rules{
+			CircularDependencies: "error",
+			BoundaryViolations:   "warn",
+			VersionConflicts:     "off",
+		},
+		Thresholds: Thresholds{HealthScore: 70},
+		Health:     Health{Weights: HealthWeights{Circular: &circular, Coupling: &coupling}},
+		Output:     Output{Format: "json"},
+	}
+
+	configPath := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(configPath, []byte(Render(want)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigFile(configPath)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatalf("ReadInConfig() error = %v\n%s", err, Render(want))
+	}
+	got, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip mismatch:\ngot  %+v\nwant %+v", got, want)
+	}
+	if issues := Validate([]byte(Render(want))); len(issues) > 0 {
+		t.Errorf("rendered config is invalid: %v", issues)
+	}
+}
+
+func TestRenderComments(t *testing.T) {
+	out := Render(&Config{})
+
+	wantContains := []string{
+		"# MonoGuard configuration",
+		"workspaces: []",
+		"exclude: []",
+		"layers: []",
+		"#   - name: apps",
+		"circularDependencies: error",
+		"healthScore: 0",
+		"# versionConflicts: off",
+		"#     circular: 0.40",
+		"#   format: text",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(out, want) {
+			t.Errorf("Render() missing %q\n%s", want, out)
+		}
+	}
+	if !strings.HasPrefix(out, "# yaml-language-server: $schema="+SchemaURL+"\n") {
+		t.Errorf("Render() does not start with the schema comment\n%s", out)
+	}
+}
@@ -0,0 +1,472 @@
+// Package config provides configuration management using Viper
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/rules"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed monoguard.schema.json
+var schemaJSON []byte
+
+// schema is the subset of JSON Schema used by monoguard.schema.json
+type schema struct {
+	Type                 string             `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Required             []string           `json:"required"`
+	Items                *schema            `json:"items"`
+	Enum                 []string           `json:"enum"`
+	AnyOf                []*schema          `json:"anyOf"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	MinLength            int                `json:"minLength"`
+}
+
+// rootSchema is the parsed configuration schema
+var rootSchema = func() *schema {
+	var s schema
+	if err := json.Unmarshal(schemaJSON, &s); err != nil {
+		panic(fmt.Sprintf("invalid embedded config schema: %v", err))
+	}
+	return &s
+}()
+
+// Schema returns the JSON Schema of the configuration file
+func Schema() []byte {
+	return schemaJSON
+}
+
+// Issue is a problem found in a configuration file
+type Issue struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Path    string `json:"path"` // Key path, e.g. "layers[1].name"; empty for the whole file
+	Message string `json:"message"`
+}
+
+// String formats the issue without its position
+func (i Issue) String() string {
+	if i.Path == "" {
+		return i.Message
+	}
+	return i.Path + ": " + i.Message
+}
+
+// ValidationError lists the problems found in a configuration file
+type ValidationError struct {
+	File   string
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid configuration in %s:", e.File)
+	for _, issue := range e.Issues {
+		fmt.Fprintf(&b, "\n  %s:%d:%d: %s", e.File, issue.Line, issue.Column, issue)
+	}
+	return b.String()
+}
+
+// ValidateFile validates a configuration file. Problems are returned as a
+// *ValidationError.
+func ValidateFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	if issues := Validate(data); len(issues) > 0 {
+		return &ValidationError{File: path, Issues: issues}
+	}
+	return nil
+}
+
+// Validate checks configuration YAML against the schema and what the schema
+// cannot express: layer references, exclusion regexes, plugin names and
+// timeouts, and health weights. Both kinds of issues are reported in one
+// round, sorted by position.
+func Validate(data []byte) []Issue {
+	root, issues := checkSchema(data)
+	if root != nil {
+		issues = append(issues, checkReferences(root, nil)...)
+	}
+	sortIssues(issues)
+	return issues
+}
+
+// checkSchema parses configuration YAML and checks it against the schema.
+// The returned node is nil for an empty file.
+func checkSchema(data []byte) (*yaml.Node, []Issue) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, []Issue{syntaxIssue(err)}
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	root := doc.Content[0]
+	var issues []Issue
+	validateNode(root, rootSchema, "", &issues)
+	sortIssues(issues)
+	return root, issues
+}
+
+// sortIssues sorts issues by position
+func sortIssues(issues []Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Column < issues[j].Column
+	})
+}
+
+// ============================================================
+// Schema validation
+// ============================================================
+
+// validateNode checks a YAML node against a schema
+func validateNode(n *yaml.Node, s *schema, path string, issues *[]Issue) {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	report := func(format string, args ...interface{}) {
+		*issues = append(*issues, Issue{Line: n.Line, Column: n.Column, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.AnyOf) > 0 {
+		var kinds []string
+		for _, alt := range s.AnyOf {
+			var altIssues []Issue
+			validateNode(n, alt, path, &altIssues)
+			if len(altIssues) == 0 {
+				return
+			}
+			kinds = append(kinds, typeNames[alt.Type])
+		}
+		report("must be %s (got %s)", strings.Join(kinds, " or "), describe(n))
+		return
+	}
+
+	if len(s.Enum) > 0 {
+		if n.Kind != yaml.ScalarNode || !contains(s.Enum, n.Value) {
+			report("must be one of %s (got %s)", strings.Join(s.Enum, ", "), describe(n))
+		}
+		return
+	}
+
+	switch s.Type {
+	case "object":
+		if isNull(n) {
+			return
+		}
+		if n.Kind != yaml.MappingNode {
+			report("must be a mapping (got %s)", describe(n))
+			return
+		}
+		seen := map[string]bool{}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			keyPath := joinPath(path, key.Value)
+			seen[key.Value] = true
+			prop, ok := s.Properties[key.Value]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					*issues = append(*issues, Issue{Line: key.Line, Column: key.Column, Path: keyPath, Message: unknownKey(key.Value, s)})
+				}
+				continue
+			}
+			validateNode(value, prop, keyPath, issues)
+		}
+		for _, name := range s.Required {
+			if !seen[name] {
+				report("missing required key %q", name)
+			}
+		}
+	case "array":
+		if isNull(n) {
+			return
+		}
+		if n.Kind != yaml.SequenceNode {
+			report("must be a list (got %s)", describe(n))
+			return
+		}
+		if s.Items != nil {
+			for i, item := range n.Content {
+				validateNode(item, s.Items, fmt.Sprintf("%s[%d]", path, i), issues)
+			}
+		}
+	case "string":
+		if n.Kind != yaml.ScalarNode || n.Tag != "!!str" {
+			report("must be a string (got %s)", describe(n))
+		} else if len(n.Value) < s.MinLength {
+			report("must not be empty")
+		}
+	case "boolean":
+		if n.Kind != yaml.ScalarNode || n.Tag != "!!bool" {
+			report("must be true or false (got %s)", describe(n))
+		}
+	case "integer", "number":
+		if n.Kind != yaml.ScalarNode || !(n.Tag == "!!int" || (s.Type == "number" && n.Tag == "!!float")) {
+			report("must be %s (got %s)", typeNames[s.Type], describe(n))
+			return
+		}
+		v, err := strconv.ParseFloat(strings.ReplaceAll(n.Value, "_", ""), 64)
+		if err != nil {
+			report("must be a number (got %s)", describe(n))
+			return
+		}
+		if s.Minimum != nil && v < *s.Minimum {
+			report("must be at least %g (got %s)", *s.Minimum, n.Value)
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			report("must be at most %g (got %s)", *s.Maximum, n.Value)
+		}
+	}
+}
+
+// typeNames names schema types for error messages
+var typeNames = map[string]string{
+	"object":  "a mapping",
+	"array":   "a list",
+	"string":  "a string",
+	"boolean": "true or false",
+	"integer": "an integer",
+	"number":  "a number",
+}
+
+// unknownKey describes an unknown key, suggesting the closest known key
+// within a third of the key's length, or a known key it abbreviates
+func unknownKey(key string, s *schema) string {
+	best, bestDistance := "", max(len(key)/3, 2)+1
+	for name := range s.Properties {
+		d := editDistance(strings.ToLower(key), strings.ToLower(name))
+		if strings.HasPrefix(strings.ToLower(name), strings.ToLower(key)) {
+			d = 1
+		}
+		if d < bestDistance || (d == bestDistance && name < best) {
+			best, bestDistance = name, d
+		}
+	}
+	if best == "" {
+		return "unknown key"
+	}
+	return fmt.Sprintf("unknown key (did you mean %q?)", best)
+}
+
+// ============================================================
+// Reference checks
+// ============================================================
+
+// checkReferences checks a configuration for problems the schema cannot
+// express. Nodes that do not match the schema are skipped, since the schema
+// reports them. inherited holds the names of layers defined by extended
+// presets.
+func checkReferences(root *yaml.Node, inherited map[string]bool) []Issue {
+	var issues []Issue
+	report := func(n *yaml.Node, path, format string, args ...interface{}) {
+		issues = append(issues, Issue{Line: n.Line, Column: n.Column, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// Layer names are unique and canDependOn names a layer
+	layers := items(lookup(root, "layers"))
+	names := map[string]bool{}
+	for i, layer := range layers {
+		if name := str(lookup(layer, "name")); name != nil {
+			if names[name.Value] {
+				report(name, fmt.Sprintf("layers[%d].name", i), "duplicate layer name %q", name.Value)
+			}
+			names[name.Value] = true
+		}
+	}
+	for i, layer := range layers {
+		for j, dep := range items(lookup(layer, "canDependOn")) {
+			if dep = str(dep); dep != nil && !names[dep.Value] && !inherited[dep.Value] {
+				report(dep, fmt.Sprintf("layers[%d].canDependOn[%d]", i, j), "unknown layer %q", dep.Value)
+			}
+		}
+	}
+
+	// regex: exclusions compile
+	for i, pattern := range items(lookup(root, "exclude")) {
+		if pattern = str(pattern); pattern == nil {
+			continue
+		}
+		if expr, ok := strings.CutPrefix(pattern.Value, "regex:"); ok {
+			if _, err := regexp.Compile(expr); err != nil {
+				report(pattern, fmt.Sprintf("exclude[%d]", i), "invalid regular expression: %v", err)
+			}
+		}
+	}
+
+	// Plugin names are unique and timeouts are positive durations
+	names = map[string]bool{}
+	for i, plugin := range items(lookup(root, "plugins")) {
+		if name := str(lookup(plugin, "name")); name != nil {
+			if names[name.Value] {
+				report(name, fmt.Sprintf("plugins[%d].name", i), "duplicate plugin name %q", name.Value)
+			}
+			names[name.Value] = true
+		}
+		if timeout := str(lookup(plugin, "timeout")); timeout != nil {
+			if d, err := time.ParseDuration(timeout.Value); err != nil || d <= 0 {
+				report(timeout, fmt.Sprintf("plugins[%d].timeout", i), "invalid duration %q (e.g. 30s, 2m)", timeout.Value)
+			}
+		}
+	}
+
+	// Custom rule names are unique and forbid expressions compile
+	names = map[string]bool{}
+	for i, rule := range items(lookup(root, "customRules")) {
+		if name := str(lookup(rule, "name")); name != nil {
+			if names[name.Value] {
+				report(name, fmt.Sprintf("customRules[%d].name", i), "duplicate rule name %q", name.Value)
+			}
+			names[name.Value] = true
+		}
+		// An unknown scope is reported by the schema
+		scope, forbid := str(lookup(rule, "scope")), str(lookup(rule, "forbid"))
+		if scope == nil || forbid == nil {
+			continue
+		}
+		var exprErr *rules.Error
+		if _, err := rules.Compile(forbid.Value, types.CustomRuleScope(scope.Value)); errors.As(err, &exprErr) {
+			report(forbid, fmt.Sprintf("customRules[%d].forbid", i), "invalid expression: %v", err)
+		}
+	}
+
+	// At least one health factor keeps a weight
+	if weights := lookup(lookup(root, "health"), "weights"); weights != nil {
+		var hw HealthWeights
+		if err := weights.Decode(&hw); err == nil {
+			if w := hw.resolve(); w.Circular+w.Conflict+w.Depth+w.Coupling == 0 {
+				report(weights, "health.weights", "at least one weight must be greater than 0")
+			}
+		}
+	}
+	return issues
+}
+
+// items returns the elements of a sequence node, or nil for other nodes
+func items(n *yaml.Node) []*yaml.Node {
+	if n == nil || n.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return n.Content
+}
+
+// str returns n if it is a string node, or nil otherwise
+func str(n *yaml.Node) *yaml.Node {
+	if n == nil || n.ShortTag() != "!!str" {
+		return nil
+	}
+	return n
+}
+
+// ============================================================
+// Helpers
+// ============================================================
+
+// lookup returns the value of a key in a mapping node, or nil
+func lookup(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value != key {
+			continue
+		}
+		if v := n.Content[i+1]; v.Kind == yaml.AliasNode {
+			return v.Alias
+		}
+		return n.Content[i+1]
+	}
+	return nil
+}
+
+// yamlLine extracts the line number from a YAML syntax error
+var yamlLine = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// syntaxIssue converts a YAML syntax error into an issue
+func syntaxIssue(err error) Issue {
+	msg := err.Error()
+	if m := yamlLine.FindStringSubmatch(msg); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return Issue{Line: line, Column: 1, Message: "invalid YAML: " + strings.TrimPrefix(msg, m[0])}
+	}
+	return Issue{Line: 1, Column: 1, Message: "invalid YAML: " + strings.TrimPrefix(msg, "yaml: ")}
+}
+
+// describe names the kind of a node for error messages
+func describe(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+	switch n.Tag {
+	case "!!null":
+		return "nothing"
+	case "!!str":
+		return strconv.Quote(n.Value)
+	}
+	return n.Value
+}
+
+// isNull reports whether a node is an empty value
+func isNull(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
+
+// joinPath appends a key to a key path
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// contains reports whether values includes value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
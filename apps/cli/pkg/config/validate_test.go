@@ -0,0 +1,242 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string // "line:column: issue"
+	}{
+		{
+			name: "valid",
+			content: `workspaces: ["packages/*"]
+exclude: ["regex:^@mono/fixture-"]
+layers:
+  - name: apps
+    pattern: "apps/**"
+    canDependOn: [libs]
+  - name: libs
+    pattern: "libs/**"
+owners:
+  - pattern: "/apps/"
+    owners: ["@org/web"]
+  - pattern: "libs/legacy"
+    owners: []
+plugins:
+  - name: licenses
+    command: ./tools/check-licenses
+    args: ["--strict"]
+    timeout: 10s
+    options:
+      allowed: [MIT, Apache-2.0]
+customRules:
+  - name: apps-are-leaves
+    description: Packages under apps/ must not be depended on
+    scope: dependency
+    forbid: 'to.path matches "apps/*"'
+  - name: max-dependencies
+    scope: package
+    forbid: len(dependencies) > 15
+    severity: warn
+rules:
+  circularDependencies: error
+  versionConflicts: warn
+  plugins: warn
+  customRules: error
+thresholds:
+  healthScore: 70
+health:
+  weights:
+    circular: 1
+    depth: 0.5
+output:
+  format: json
+  verbose: true
+`,
+		},
+		{
+			name:    "empty file",
+			content: "",
+		},
+		{
+			name: "unknown keys",
+			content: `rulez:
+  circularDependencies: error
+rules:
+  circularDependency: error
+thresholds:
+  foo: 1
+`,
+			want: []string{
+				`1:1: rulez: unknown key (did you mean "rules"?)`,
+				`4:3: rules.circularDependency: unknown key (did you mean "circularDependencies"?)`,
+				`6:3: thresholds.foo: unknown key`,
+			},
+		},
+		{
+			name: "bad values",
+			content: `workspaces: packages/*
+rules:
+  boundaryViolations: erorr
+thresholds:
+  healthScore: 120
+health:
+  weights:
+    depth: -1
+output:
+  verbose: yes please
+layers:
+  - name: apps
+  - pattern: 7
+`,
+			want: []string{
+				`1:13: workspaces: must be a list (got "packages/*")`,
+				`3:23: rules.boundaryViolations: must be one of error, warn, off (got "erorr")`,
+				`5:16: thresholds.healthScore: must be at most 100 (got 120)`,
+				`8:12: health.weights.depth: must be at least 0 (got -1)`,
+				`10:12: output.verbose: must be true or false (got "yes please")`,
+				`12:5: layers[0]: missing required key "pattern"`,
+				`13:5: layers[1]: missing required key "name"`,
+				`13:14: layers[1].pattern: must be a string (got 7)`,
+			},
+		},
+		{
+			name: "references",
+			content: `exclude: ["regex:("]
+layers:
+  - name: apps
+    pattern: "apps/**"
+    canDependOn: [lib]
+  - name: apps
+    pattern: "libs/**"
+health:
+  weights: {circular: 0, conflict: 0, depth: 0, coupling: 0}
+plugins:
+  - name: lint
+    command: lint
+    timeout: soon
+  - name: lint
+    command: lint
+    timeout: 0s
+customRules:
+  - name: deps
+    scope: package
+    forbid: len(dependencies) >
+  - name: deps
+    scope: workspace
+    forbid: graph.size > 1
+`,
+			want: []string{
+				"1:11: exclude[0]: invalid regular expression: error parsing regexp: missing closing ): `(`",
+				`5:19: layers[0].canDependOn[0]: unknown layer "lib"`,
+				`6:11: layers[1].name: duplicate layer name "apps"`,
+				`9:12: health.weights: at least one weight must be greater than 0`,
+				`13:14: plugins[0].timeout: invalid duration "soon" (e.g. 30s, 2m)`,
+				`14:11: plugins[1].name: duplicate plugin name "lint"`,
+				`16:14: plugins[1].timeout: invalid duration "0s" (e.g. 30s, 2m)`,
+				`20:13: customRules[0].forbid: invalid expression: column 20: unexpected end of expression`,
+				`21:11: customRules[1].name: duplicate rule name "deps"`,
+				`23:13: customRules[1].forbid: invalid expression: column 7: unknown attribute "size" (expected one of cycles, edges, packages, workspaceType)`,
+			},
+		},
+		{
+			name: "schema and references",
+			content: `layers:
+  - name: apps
+    pattern: "apps/**"
+    canDependOn: [lib, 7]
+    tier: 1
+  - name: [libs]
+plugins: lint
+exclude: ["regex:(", {regex: x}]
+`,
+			want: []string{
+				`4:19: layers[0].canDependOn[0]: unknown layer "lib"`,
+				`4:24: layers[0].canDependOn[1]: must be a string (got 7)`,
+				`5:5: layers[0].tier: unknown key`,
+				`6:5: layers[1]: missing required key "pattern"`,
+				`6:11: layers[1].name: must be a string (got a list)`,
+				`7:10: plugins: must be a list (got "lint")`,
+				"8:11: exclude[0]: invalid regular expression: error parsing regexp: missing closing ): `(`",
+				`8:22: exclude[1]: must be a string (got a mapping)`,
+			},
+		},
+		{
+			name:    "syntax error",
+			content: "rules:\n  circularDependencies: error\n bad: [\n",
+			want:    []string{"2:1: invalid YAML: did not find expected key"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range Validate([]byte(tt.content)) {
+				got = append(got, formatIssue(issue))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() =\n  %s\nwant\n  %s", strings.Join(got, "\n  "), strings.Join(tt.want, "\n  "))
+			}
+		})
+	}
+}
+
+// TestValidateFile verifies the error lists every issue with its position
+func TestValidateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("rules:\n  circular: error\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := ValidateFile(path)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("ValidateFile() error = %v, want *ValidationError", err)
+	}
+	want := "invalid configuration in " + path + ":\n  " + path + `:2:3: rules.circular: unknown key (did you mean "circularDependencies"?)`
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	if err := ValidateFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("ValidateFile() of a missing file returned no error")
+	}
+}
+
+// TestSchemaCoversConfig verifies every Config field is in the schema
+func TestSchemaCoversConfig(t *testing.T) {
+	var s struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(Schema(), &s); err != nil {
+		t.Fatalf("schema is not JSON: %v", err)
+	}
+
+	var keys []string
+	for _, field := range reflect.VisibleFields(reflect.TypeOf(Config{})) {
+		key := field.Tag.Get("mapstructure")
+		if _, ok := s.Properties[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	if len(keys) > 0 {
+		t.Errorf("schema is missing config keys %v", keys)
+	}
+}
+
+// formatIssue renders an issue as "line:column: issue"
+func formatIssue(i Issue) string {
+	return fmt.Sprintf("%d:%d: %s", i.Line, i.Column, i)
+}
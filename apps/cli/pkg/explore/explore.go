@@ -0,0 +1,250 @@
+// Package explore implements the interactive terminal explorer for
+// analysis results.
+package explore
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// Default size used until the terminal reports its size
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+var (
+	headerStyle = lipgloss.NewStyle().Bold(true).Reverse(true)
+	footerStyle = lipgloss.NewStyle().Faint(true)
+	cursorStyle = lipgloss.NewStyle().Reverse(true)
+)
+
+// Model is the explorer state. It implements tea.Model.
+type Model struct {
+	explorer *explorer
+	stack    []*page // Pages opened so far; the last one is shown
+	width    int
+	height   int
+}
+
+// page is one screen of the explorer. Lines are built for the current
+// width so that text can be wrapped.
+type page struct {
+	title  string
+	build  func(width int) []line
+	lines  []line
+	cursor int // Selected line; only lines with open are selectable
+	offset int // First visible line
+}
+
+// line is a line of a page. Selecting a line with open opens another page.
+type line struct {
+	text string
+	open func() *page
+}
+
+// New returns an explorer that starts at the list of circular
+// dependencies, sorted by priority
+func New(result *types.AnalysisResult) *Model {
+	m := &Model{explorer: newExplorer(result), width: defaultWidth, height: defaultHeight}
+	m.push(m.explorer.cyclesPage())
+	return m
+}
+
+// Init implements tea.Model
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		for _, p := range m.stack {
+			p.lines = p.build(m.contentWidth())
+			p.cursor = min(p.cursor, max(len(p.lines)-1, 0))
+		}
+		m.scrollToCursor()
+	case tea.KeyMsg:
+		return m, m.handleKey(msg.String())
+	}
+	return m, nil
+}
+
+// handleKey applies a key press and returns tea.Quit to exit
+func (m *Model) handleKey(key string) tea.Cmd {
+	p := m.current()
+	switch key {
+	case "q", "ctrl+c":
+		return tea.Quit
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "pgup", "b":
+		m.page(-1)
+	case "pgdown", " ", "f":
+		m.page(1)
+	case "home":
+		p.offset = 0
+		p.cursor = p.firstSelectable(0, 1)
+	case "end":
+		p.offset = max(len(p.lines)-m.bodyHeight(), 0)
+		p.cursor = p.firstSelectable(len(p.lines)-1, -1)
+	case "enter", "right", "l":
+		if p.selectable(p.cursor) {
+			m.push(p.lines[p.cursor].open())
+		}
+	case "esc", "left", "h", "backspace":
+		if len(m.stack) > 1 {
+			m.stack = m.stack[:len(m.stack)-1]
+		}
+	case "c":
+		m.stack = m.stack[:1]
+	case "p":
+		m.push(m.explorer.packagesPage())
+	}
+	return nil
+}
+
+// View implements tea.Model
+func (m *Model) View() string {
+	p := m.current()
+	var titles []string
+	for _, s := range m.stack {
+		titles = append(titles, s.title)
+	}
+	header := " MonoGuard › " + strings.Join(titles, " › ")
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(pad(header, m.width)))
+	b.WriteString("\n")
+
+	body := m.bodyHeight()
+	for i := p.offset; i < p.offset+body; i++ {
+		text := ""
+		if i < len(p.lines) {
+			text = p.lines[i].text
+		}
+		prefix := "  "
+		if i == p.cursor && p.selectable(i) {
+			prefix = "> "
+			b.WriteString(cursorStyle.Render(pad(prefix+text, m.width)))
+		} else {
+			b.WriteString(ansi.Truncate(prefix+text, m.width, "…"))
+		}
+		b.WriteString("\n")
+	}
+
+	position := ""
+	if len(p.lines) > body {
+		position = fmt.Sprintf("  %d-%d/%d", p.offset+1, min(p.offset+body, len(p.lines)), len(p.lines))
+	}
+	b.WriteString(footerStyle.Render(ansi.Truncate(
+		" ↑↓ move · enter open · esc back · p packages · c cycles · q quit"+position, m.width, "…")))
+	return b.String()
+}
+
+// current returns the shown page
+func (m *Model) current() *page {
+	return m.stack[len(m.stack)-1]
+}
+
+// push builds and shows a page
+func (m *Model) push(p *page) {
+	p.lines = p.build(m.contentWidth())
+	p.cursor = p.firstSelectable(0, 1)
+	m.stack = append(m.stack, p)
+}
+
+// move selects the next selectable line in direction dir, or scrolls
+// pages without selectable lines
+func (m *Model) move(dir int) {
+	p := m.current()
+	if !p.selectable(p.cursor) {
+		p.offset = clamp(p.offset+dir, 0, max(len(p.lines)-m.bodyHeight(), 0))
+		return
+	}
+	if next := p.firstSelectable(p.cursor+dir, dir); p.selectable(next) {
+		p.cursor = next
+	}
+	m.scrollToCursor()
+}
+
+// page scrolls by one screen and selects the first selectable line shown
+func (m *Model) page(dir int) {
+	p := m.current()
+	body := m.bodyHeight()
+	p.offset = clamp(p.offset+dir*body, 0, max(len(p.lines)-body, 0))
+	if p.selectable(p.cursor) {
+		if next := p.firstSelectable(p.offset, 1); p.selectable(next) && next < p.offset+body {
+			p.cursor = next
+		}
+	}
+}
+
+// scrollToCursor adjusts the offset so the selected line is visible. The
+// lines before the first selectable line stay visible as long as possible.
+func (m *Model) scrollToCursor() {
+	p := m.current()
+	body := m.bodyHeight()
+	if !p.selectable(p.cursor) {
+		p.offset = clamp(p.offset, 0, max(len(p.lines)-body, 0))
+		return
+	}
+	if p.cursor == p.firstSelectable(0, 1) {
+		p.offset = 0
+	}
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+body {
+		p.offset = p.cursor - body + 1
+	}
+}
+
+// bodyHeight is the number of page lines shown between header and footer
+func (m *Model) bodyHeight() int {
+	return max(m.height-2, 1)
+}
+
+// contentWidth is the width available to page lines
+func (m *Model) contentWidth() int {
+	return max(m.width-2, 20)
+}
+
+// selectable reports whether line i opens a page
+func (p *page) selectable(i int) bool {
+	return i >= 0 && i < len(p.lines) && p.lines[i].open != nil
+}
+
+// firstSelectable returns the first selectable line from i in direction
+// dir, or -1 if there is none
+func (p *page) firstSelectable(i, dir int) int {
+	for ; i >= 0 && i < len(p.lines); i += dir {
+		if p.selectable(i) {
+			return i
+		}
+	}
+	return -1
+}
+
+// pad truncates or pads s with spaces to width cells
+func pad(s string, width int) string {
+	s = ansi.Truncate(s, width, "…")
+	if w := ansi.StringWidth(s); w < width {
+		s += strings.Repeat(" ", width-w)
+	}
+	return s
+}
+
+// clamp limits v to [lo, hi]
+func clamp(v, lo, hi int) int {
+	return max(lo, min(v, hi))
+}
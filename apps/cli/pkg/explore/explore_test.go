@@ -0,0 +1,193 @@
+package explore
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// sampleResult returns a low priority cycle app ⇄ ui and a high priority
+// cycle ui → core → ui with a root cause, import trace and fix guide
+func sampleResult() *types.AnalysisResult {
+	g := types.NewDependencyGraph("/repo", types.WorkspaceTypePnpm)
+	for _, name := range []string{"@mono/app", "@mono/ui", "@mono/core", "@mono/docs"} {
+		g.Nodes[name] = types.NewPackageNode(name, "1.0.0", "packages/"+strings.TrimPrefix(name, "@mono/"))
+	}
+	g.Edges = []*types.DependencyEdge{
+		{From: "@mono/app", To: "@mono/ui", Type: types.DependencyTypeProduction},
+		{From: "@mono/ui", To: "@mono/app", Type: types.DependencyTypeDevelopment},
+		{From: "@mono/ui", To: "@mono/core", Type: types.DependencyTypeProduction},
+		{From: "@mono/core", To: "@mono/ui", Type: types.DependencyTypeProduction},
+	}
+
+	return &types.AnalysisResult{
+		HealthScore: 60,
+		Packages:    4,
+		Graph:       g,
+		CircularDependencies: []*types.CircularDependencyInfo{
+			{
+				Cycle:         []string{"@mono/app", "@mono/ui", "@mono/app"},
+				Severity:      types.CircularSeverityInfo,
+				PriorityScore: 2.5,
+			},
+			{
+				Cycle:         []string{"@mono/ui", "@mono/core", "@mono/ui"},
+				Severity:      types.CircularSeverityWarning,
+				PriorityScore: 8,
+				RootCause: &types.RootCauseAnalysis{
+					OriginatingPackage: "@mono/core",
+					Confidence:         80,
+					Explanation:        "core should not import ui",
+					CriticalEdge:       &types.RootCauseEdge{From: "@mono/core", To: "@mono/ui", Type: types.DependencyTypeProduction},
+				},
+				ImportTraces: []types.ImportTrace{
+					{FromPackage: "@mono/core", ToPackage: "@mono/ui", FilePath: "packages/core/src/theme.ts", LineNumber: 3, Statement: "import { theme } from '@mono/ui'"},
+				},
+				FixStrategies: []types.FixStrategy{
+					{
+						Type:        types.FixStrategyExtractModule,
+						Name:        "Extract Shared Module",
+						Description: "Move theme into a new package",
+						Suitability: 8,
+						Effort:      types.EffortMedium,
+						Recommended: true,
+						Pros:        []string{"Clean boundaries"},
+						Guide: &types.FixGuide{
+							Title: "Extract the theme",
+							Steps: []types.FixStep{
+								{Number: 1, Title: "Create the package", Command: &types.CommandStep{Command: "mkdir packages/theme"}},
+								{Number: 2, Title: "Update imports", CodeAfter: &types.CodeSnippet{Code: "import { theme } from '@mono/theme'"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// send applies key presses and returns the view without styles
+func send(t *testing.T, m *Model, keys ...string) string {
+	t.Helper()
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		m.Update(msg)
+	}
+	return ansi.Strip(m.View())
+}
+
+func assertContains(t *testing.T, view string, want ...string) {
+	t.Helper()
+	for _, w := range want {
+		if !strings.Contains(view, w) {
+			t.Errorf("view missing %q\n%s", w, view)
+		}
+	}
+}
+
+func TestCyclesSortedByPriority(t *testing.T) {
+	view := send(t, New(sampleResult()))
+
+	high := strings.Index(view, "@mono/ui → @mono/core → @mono/ui")
+	low := strings.Index(view, "@mono/app → @mono/ui → @mono/app")
+	if high < 0 || low < 0 || high > low {
+		t.Errorf("cycles not sorted by priority\n%s", view)
+	}
+	assertContains(t, view, "Health score 60 · 4 packages · 2 circular dependencies", ">   8.0  warning")
+}
+
+func TestCycleDrillDown(t *testing.T) {
+	m := New(sampleResult())
+
+	view := send(t, m, "enter")
+	assertContains(t, view,
+		"MonoGuard › Cycles › Cycle 1",
+		"Originates in @mono/core (80% confidence)",
+		"Break @mono/core → @mono/ui (production)",
+		"packages/core/src/theme.ts:3  import { theme } from '@mono/ui'",
+		">   Extract Shared Module · suitability 8/10 · medium effort  recommended",
+	)
+
+	view = send(t, m, "enter")
+	assertContains(t, view,
+		"Cycles › Cycle 1 › Extract Shared Module",
+		"- Clean boundaries",
+		"1. Create the package",
+		"$ mkdir packages/theme",
+		"import { theme } from '@mono/theme'",
+	)
+
+	view = send(t, m, "esc")
+	assertContains(t, view, "MonoGuard › Cycles › Cycle 1 ")
+}
+
+func TestNeighbourNavigation(t *testing.T) {
+	m := New(sampleResult())
+
+	// Cycle 1 → second selectable line is the first package, @mono/ui
+	view := send(t, m, "enter", "down", "enter")
+	assertContains(t, view,
+		"Cycle 1 › @mono/ui",
+		"Path: packages/ui",
+		"Depends on (2)",
+		"@mono/app  in cycle [development]",
+		"Depended on by (2)",
+		"In cycles (2)",
+	)
+
+	// Follow the first dependency
+	view = send(t, m, "enter")
+	assertContains(t, view, "@mono/ui › @mono/app", "Depends on (1)")
+
+	view = send(t, m, "c", "p")
+	assertContains(t, view, "Cycles › Packages", "4 packages", "> @mono/app  in cycle", "@mono/docs")
+}
+
+func TestWindowResizeWraps(t *testing.T) {
+	m := New(sampleResult())
+	m.Update(tea.WindowSizeMsg{Width: 30, Height: 10})
+
+	view := send(t, m)
+	lines := strings.Split(view, "\n")
+	if len(lines) != 10 {
+		t.Errorf("view has %d lines, want 10", len(lines))
+	}
+	for _, l := range lines {
+		if w := ansi.StringWidth(l); w > 30 {
+			t.Errorf("line %q is %d cells wide, want at most 30", l, w)
+		}
+	}
+	assertContains(t, view, "Health score 60 · 4")
+}
+
+func TestQuit(t *testing.T) {
+	m := New(sampleResult())
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if cmd == nil {
+		t.Fatal("q returned no command")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("q did not quit")
+	}
+}
+
+func TestNoCycles(t *testing.T) {
+	result := sampleResult()
+	result.CircularDependencies = nil
+
+	view := send(t, New(result))
+	assertContains(t, view, "No circular dependencies found.", "Press p to browse the packages.")
+}
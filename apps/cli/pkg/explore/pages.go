@@ -0,0 +1,373 @@
+package explore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// explorer holds the analysis result and the indexes the pages use
+type explorer struct {
+	result   *types.AnalysisResult
+	cycles   []*types.CircularDependencyInfo // Highest priority first
+	packages []string                        // Sorted names
+	deps     map[string][]neighbour          // Direct dependencies by package
+	users    map[string][]neighbour          // Direct dependents by package
+	inCycles map[string][]int                // Indexes into cycles by package
+}
+
+// neighbour is a package connected to another by a direct dependency
+type neighbour struct {
+	name  string
+	kinds []types.DependencyType
+}
+
+// newExplorer indexes an analysis result
+func newExplorer(result *types.AnalysisResult) *explorer {
+	e := &explorer{
+		result:   result,
+		deps:     map[string][]neighbour{},
+		users:    map[string][]neighbour{},
+		inCycles: map[string][]int{},
+	}
+
+	e.cycles = append(e.cycles, result.CircularDependencies...)
+	sort.SliceStable(e.cycles, func(i, j int) bool {
+		return e.cycles[i].PriorityScore > e.cycles[j].PriorityScore
+	})
+	for i, c := range e.cycles {
+		for _, name := range uniqueMembers(c) {
+			e.inCycles[name] = append(e.inCycles[name], i)
+		}
+	}
+
+	if g := result.Graph; g != nil {
+		for name := range g.Nodes {
+			e.packages = append(e.packages, name)
+		}
+		sort.Strings(e.packages)
+		for _, edge := range g.Edges {
+			e.deps[edge.From] = addNeighbour(e.deps[edge.From], edge.To, edge.Type)
+			e.users[edge.To] = addNeighbour(e.users[edge.To], edge.From, edge.Type)
+		}
+		for _, m := range []map[string][]neighbour{e.deps, e.users} {
+			for _, list := range m {
+				sort.Slice(list, func(i, j int) bool { return list[i].name < list[j].name })
+			}
+		}
+	}
+	return e
+}
+
+// ============================================================
+// Pages
+// ============================================================
+
+// cyclesPage lists the circular dependencies by priority
+func (e *explorer) cyclesPage() *page {
+	return &page{title: "Cycles", build: func(width int) []line {
+		summary := fmt.Sprintf("Health score %d · %d packages · %d circular dependencies",
+			e.result.HealthScore, e.result.Packages, len(e.cycles))
+		lines := []line{{text: summary}, {}}
+		if len(e.cycles) == 0 {
+			lines = append(lines, line{text: "No circular dependencies found."})
+			if len(e.packages) > 0 {
+				lines = append(lines, line{text: "Press p to browse the packages."})
+			}
+			return lines
+		}
+		for i, c := range e.cycles {
+			i := i
+			lines = append(lines, line{
+				text: fmt.Sprintf("%5.1f  %-8s %s", c.PriorityScore, c.Severity, cycleName(c)),
+				open: func() *page { return e.cyclePage(i) },
+			})
+		}
+		return lines
+	}}
+}
+
+// cyclePage shows the analysis of one circular dependency
+func (e *explorer) cyclePage(i int) *page {
+	c := e.cycles[i]
+	return &page{title: fmt.Sprintf("Cycle %d", i+1), build: func(width int) []line {
+		lines := wrap(cycleName(c), "", width)
+		lines = append(lines, line{})
+		facts := fmt.Sprintf("Severity %s · Priority %.1f · Depth %d", c.Severity, c.PriorityScore, c.Depth)
+		if c.RefactoringComplexity != nil {
+			facts += fmt.Sprintf(" · Complexity %d/10 (%s)", c.RefactoringComplexity.Score, c.RefactoringComplexity.EstimatedTime)
+		}
+		lines = append(lines, wrap(facts, "", width)...)
+		if c.ImpactAssessment != nil {
+			a := c.ImpactAssessment
+			lines = append(lines, wrap(fmt.Sprintf("Risk %s · %d packages affected (%s)",
+				a.RiskLevel, a.TotalAffected, a.AffectedPercentageDisplay), "", width)...)
+		}
+		if c.Impact != "" {
+			lines = append(lines, wrap(c.Impact, "", width)...)
+		}
+
+		if rc := c.RootCause; rc != nil {
+			lines = append(lines, line{}, line{text: "Root cause"})
+			lines = append(lines, wrap(fmt.Sprintf("Originates in %s (%d%% confidence)",
+				rc.OriginatingPackage, rc.Confidence), "  ", width)...)
+			lines = append(lines, wrap(rc.Explanation, "  ", width)...)
+			if edge := rc.CriticalEdge; edge != nil {
+				lines = append(lines, wrap(fmt.Sprintf("Break %s → %s (%s)", edge.From, edge.To, edge.Type), "  ", width)...)
+			}
+			for _, edge := range rc.Chain {
+				mark := ""
+				if edge.Critical {
+					mark = "  critical"
+				}
+				lines = append(lines, line{text: fmt.Sprintf("    %s → %s%s", edge.From, edge.To, mark)})
+			}
+		}
+
+		if len(c.ImportTraces) > 0 {
+			lines = append(lines, line{}, line{text: fmt.Sprintf("Import traces (%d)", len(c.ImportTraces))})
+			for _, trace := range c.ImportTraces {
+				lines = append(lines, line{text: fmt.Sprintf("  %s → %s", trace.FromPackage, trace.ToPackage)})
+				lines = append(lines, line{text: fmt.Sprintf("    %s:%d  %s", trace.FilePath, trace.LineNumber, trace.Statement)})
+			}
+		}
+
+		if len(c.FixStrategies) > 0 {
+			lines = append(lines, line{}, line{text: "Fix strategies"})
+			for j, s := range c.FixStrategies {
+				j := j
+				mark := ""
+				if s.Recommended {
+					mark = "  recommended"
+				}
+				lines = append(lines, line{
+					text: fmt.Sprintf("  %s · suitability %d/10 · %s effort%s", s.Name, s.Suitability, s.Effort, mark),
+					open: func() *page { return e.strategyPage(i, j) },
+				})
+			}
+		}
+
+		lines = append(lines, line{}, line{text: "Packages"})
+		for _, name := range uniqueMembers(c) {
+			lines = append(lines, e.packageLine("  ", name))
+		}
+		return lines
+	}}
+}
+
+// strategyPage shows a fix strategy and its step-by-step guide
+func (e *explorer) strategyPage(i, j int) *page {
+	s := e.cycles[i].FixStrategies[j]
+	return &page{title: s.Name, build: func(width int) []line {
+		lines := wrap(s.Description, "", width)
+		lines = append(lines, line{})
+		lines = append(lines, wrap(fmt.Sprintf("Suitability %d/10 · %s effort · Targets %s",
+			s.Suitability, s.Effort, strings.Join(s.TargetPackages, ", ")), "", width)...)
+		if s.NewPackageName != "" {
+			lines = append(lines, line{text: "New package: " + s.NewPackageName})
+		}
+		lines = append(lines, list("Pros", s.Pros, width)...)
+		lines = append(lines, list("Cons", s.Cons, width)...)
+
+		g := s.Guide
+		if g == nil {
+			return lines
+		}
+		lines = append(lines, line{}, line{text: g.Title})
+		lines = append(lines, wrap(g.Summary, "  ", width)...)
+		if g.EstimatedTime != "" {
+			lines = append(lines, line{text: "  Estimated time: " + g.EstimatedTime})
+		}
+		lines = append(lines, steps("Steps", g.Steps, width)...)
+		lines = append(lines, steps("Verification", g.Verification, width)...)
+		if r := g.Rollback; r != nil {
+			lines = append(lines, line{}, line{text: "Rollback"})
+			for _, c := range r.GitCommands {
+				lines = append(lines, line{text: "  $ " + c})
+			}
+			for _, step := range r.ManualSteps {
+				lines = append(lines, wrap("- "+step, "  ", width)...)
+			}
+			if r.Warning != "" {
+				lines = append(lines, wrap("Warning: "+r.Warning, "  ", width)...)
+			}
+		}
+		return lines
+	}}
+}
+
+// packagePage shows a package and its direct neighbours
+func (e *explorer) packagePage(name string) *page {
+	return &page{title: name, build: func(width int) []line {
+		var lines []line
+		if e.result.Graph != nil {
+			if n := e.result.Graph.Nodes[name]; n != nil {
+				lines = append(lines, line{text: "Path: " + n.Path})
+				if n.Version != "" {
+					lines = append(lines, line{text: "Version: " + n.Version})
+				}
+				if n.Excluded {
+					lines = append(lines, line{text: "Excluded from analysis"})
+				}
+			}
+		}
+		lines = append(lines, e.neighbourLines("Depends on", e.deps[name])...)
+		lines = append(lines, e.neighbourLines("Depended on by", e.users[name])...)
+
+		if indexes := e.inCycles[name]; len(indexes) > 0 {
+			lines = append(lines, line{}, line{text: fmt.Sprintf("In cycles (%d)", len(indexes))})
+			for _, i := range indexes {
+				i := i
+				lines = append(lines, line{
+					text: "  " + cycleName(e.cycles[i]),
+					open: func() *page { return e.cyclePage(i) },
+				})
+			}
+		}
+		return lines
+	}}
+}
+
+// packagesPage lists every workspace package
+func (e *explorer) packagesPage() *page {
+	return &page{title: "Packages", build: func(width int) []line {
+		if len(e.packages) == 0 {
+			return []line{{text: "The analysis result has no dependency graph."}}
+		}
+		lines := []line{{text: fmt.Sprintf("%d packages", len(e.packages))}, {}}
+		for _, name := range e.packages {
+			lines = append(lines, e.packageLine("", name))
+		}
+		return lines
+	}}
+}
+
+// ============================================================
+// Helpers
+// ============================================================
+
+// packageLine is a selectable line that opens a package
+func (e *explorer) packageLine(indent, name string) line {
+	text := indent + name
+	if len(e.inCycles[name]) > 0 {
+		text += "  in cycle"
+	}
+	return line{text: text, open: func() *page { return e.packagePage(name) }}
+}
+
+// neighbourLines lists neighbours under a heading
+func (e *explorer) neighbourLines(heading string, neighbours []neighbour) []line {
+	lines := []line{{}, {text: fmt.Sprintf("%s (%d)", heading, len(neighbours))}}
+	for _, n := range neighbours {
+		l := e.packageLine("  ", n.name)
+		var labels []string
+		for _, t := range n.kinds {
+			if t != types.DependencyTypeProduction {
+				labels = append(labels, string(t))
+			}
+		}
+		if len(labels) > 0 {
+			l.text += " [" + strings.Join(labels, ", ") + "]"
+		}
+		lines = append(lines, l)
+	}
+	return lines
+}
+
+// steps renders the steps of a fix guide
+func steps(heading string, list []types.FixStep, width int) []line {
+	if len(list) == 0 {
+		return nil
+	}
+	lines := []line{{}, {text: heading}}
+	for _, s := range list {
+		lines = append(lines, wrap(fmt.Sprintf("%d. %s", s.Number, s.Title), "  ", width)...)
+		lines = append(lines, wrap(s.Description, "     ", width)...)
+		if s.FilePath != "" {
+			lines = append(lines, line{text: "     File: " + s.FilePath})
+		}
+		lines = append(lines, code("Before", s.CodeBefore)...)
+		lines = append(lines, code("After", s.CodeAfter)...)
+		if c := s.Command; c != nil {
+			cmd := "     $ " + c.Command
+			if c.WorkingDirectory != "" {
+				cmd += "  (in " + c.WorkingDirectory + ")"
+			}
+			lines = append(lines, line{text: cmd})
+		}
+		if s.ExpectedOutcome != "" {
+			lines = append(lines, wrap("Expected: "+s.ExpectedOutcome, "     ", width)...)
+		}
+	}
+	return lines
+}
+
+// code renders a code snippet verbatim; long lines are truncated on screen
+func code(label string, snippet *types.CodeSnippet) []line {
+	if snippet == nil || snippet.Code == "" {
+		return nil
+	}
+	lines := []line{{text: "     " + label + ":"}}
+	for _, l := range strings.Split(strings.TrimRight(snippet.Code, "\n"), "\n") {
+		lines = append(lines, line{text: "       " + strings.ReplaceAll(l, "\t", "  ")})
+	}
+	return lines
+}
+
+// list renders a bulleted list under a heading
+func list(heading string, items []string, width int) []line {
+	if len(items) == 0 {
+		return nil
+	}
+	lines := []line{{}, {text: heading}}
+	for _, item := range items {
+		lines = append(lines, wrap("- "+item, "  ", width)...)
+	}
+	return lines
+}
+
+// wrap word-wraps text to width with every line indented
+func wrap(text, indent string, width int) []line {
+	if text == "" {
+		return nil
+	}
+	var lines []line
+	wrapped := ansi.Wordwrap(text, max(width-ansi.StringWidth(indent), 10), "")
+	for _, l := range strings.Split(wrapped, "\n") {
+		lines = append(lines, line{text: indent + l})
+	}
+	return lines
+}
+
+// cycleName renders the packages of a cycle as a chain
+func cycleName(c *types.CircularDependencyInfo) string {
+	return strings.Join(c.Cycle, " → ")
+}
+
+// uniqueMembers returns the packages of a cycle without the closing repeat
+func uniqueMembers(c *types.CircularDependencyInfo) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, name := range c.Cycle {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// addNeighbour adds a dependency type to a neighbour, adding the neighbour
+// if needed
+func addNeighbour(list []neighbour, name string, t types.DependencyType) []neighbour {
+	for i := range list {
+		if list[i].name == name {
+			list[i].kinds = append(list[i].kinds, t)
+			return list
+		}
+	}
+	return append(list, neighbour{name: name, kinds: []types.DependencyType{t}})
+}
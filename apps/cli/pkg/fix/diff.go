@@ -0,0 +1,179 @@
+// Package fix turns engine fix strategies into file changes
+package fix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each hunk
+const diffContext = 3
+
+// maxDiffCells bounds the LCS table; larger inputs fall back to a full replace
+const maxDiffCells = 4 << 20
+
+// diffOp is a single line of an edit script: ' ' keep, '-' delete, '+' insert
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// UnifiedDiff returns a unified diff between before and after for path.
+// A created file is diffed against /dev/null. Returns "" when nothing changed.
+func UnifiedDiff(path, before, after string, created bool) string {
+	if before == after && !created {
+		return ""
+	}
+
+	ops := editScript(splitLines(before), splitLines(after))
+
+	var b strings.Builder
+	if created {
+		fmt.Fprintf(&b, "--- /dev/null\n")
+	} else {
+		fmt.Fprintf(&b, "--- a/%s\n", path)
+	}
+	fmt.Fprintf(&b, "+++ b/%s\n", path)
+
+	// Line numbers (0-based) of each op in the old and new file
+	oldLine := make([]int, len(ops)+1)
+	newLine := make([]int, len(ops)+1)
+	for i, op := range ops {
+		oldLine[i+1], newLine[i+1] = oldLine[i], newLine[i]
+		if op.kind != '+' {
+			oldLine[i+1]++
+		}
+		if op.kind != '-' {
+			newLine[i+1]++
+		}
+	}
+
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		start := max(0, i-diffContext)
+		end := i
+		for {
+			for end < len(ops) && ops[end].kind != ' ' {
+				end++
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' {
+				next++
+			}
+			if next == len(ops) || next-end > 2*diffContext {
+				break
+			}
+			end = next
+		}
+		end = min(len(ops), end+diffContext)
+
+		oldCount := oldLine[end] - oldLine[start]
+		newCount := newLine[end] - newLine[start]
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n",
+			hunkRange(oldLine[start], oldCount), hunkRange(newLine[start], newCount))
+		for _, op := range ops[start:end] {
+			b.WriteByte(op.kind)
+			b.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				b.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = end
+	}
+
+	return b.String()
+}
+
+// hunkRange formats a hunk range; empty ranges point at the line before
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// splitLines splits s into lines that keep their trailing newline
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// editScript computes a line edit script from a to b using the longest common
+// subsequence of the region between their common prefix and suffix
+func editScript(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+	n, m := len(midA), len(midB)
+	if (n+1)*(m+1) > maxDiffCells {
+		for _, line := range midA {
+			ops = append(ops, diffOp{'-', line})
+		}
+		for _, line := range midB {
+			ops = append(ops, diffOp{'+', line})
+		}
+	} else {
+		// lcs[i][j] is the LCS length of midA[i:] and midB[j:]
+		lcs := make([][]int, n+1)
+		for i := range lcs {
+			lcs[i] = make([]int, m+1)
+		}
+		for i := n - 1; i >= 0; i-- {
+			for j := m - 1; j >= 0; j-- {
+				if midA[i] == midB[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+
+		i, j := 0, 0
+		for i < n || j < m {
+			switch {
+			case i < n && j < m && midA[i] == midB[j]:
+				ops = append(ops, diffOp{' ', midA[i]})
+				i++
+				j++
+			case i < n && (j == m || lcs[i+1][j] >= lcs[i][j+1]):
+				ops = append(ops, diffOp{'-', midA[i]})
+				i++
+			default:
+				ops = append(ops, diffOp{'+', midB[j]})
+				j++
+			}
+		}
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
@@ -0,0 +1,94 @@
+package fix
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		before  string
+		after   string
+		created bool
+		want    string
+	}{
+		{
+			name:   "unchanged",
+			before: "a\nb\n",
+			after:  "a\nb\n",
+			want:   "",
+		},
+		{
+			name:   "single line replaced",
+			before: "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			after:  "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			want: "--- a/f.txt\n+++ b/f.txt\n@@ -2,7 +2,7 @@\n" +
+				" 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name:    "created file",
+			after:   "x\ny\n",
+			created: true,
+			want:    "--- /dev/null\n+++ b/f.txt\n@@ -0,0 +1,2 @@\n+x\n+y\n",
+		},
+		{
+			name:   "missing trailing newline",
+			before: "a\n",
+			after:  "a\nb",
+			want:   "--- a/f.txt\n+++ b/f.txt\n@@ -1 +1,2 @@\n a\n+b\n\\ No newline at end of file\n",
+		},
+		{
+			name:   "distant changes produce separate hunks",
+			before: "a\n1\n2\n3\n4\n5\n6\n7\n8\nb\n",
+			after:  "A\n1\n2\n3\n4\n5\n6\n7\n8\nB\n",
+			want: "--- a/f.txt\n+++ b/f.txt\n" +
+				"@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n" +
+				"@@ -7,4 +7,4 @@\n 6\n 7\n 8\n-b\n+B\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UnifiedDiff("f.txt", tt.before, tt.after, tt.created)
+			if got != tt.want {
+				t.Errorf("UnifiedDiff() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestUnifiedDiffAppliesWithPatch verifies the output is accepted by patch tools
+func TestUnifiedDiffAppliesWithPatch(t *testing.T) {
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git not available")
+	}
+
+	before := "import { a } from '@mono/a';\n\nexport const b = 1;\nexport const c = 2;\n"
+	after := "import { a } from '@mono/shared';\n\nexport const b = 1;\nexport const c = 3;\nexport const d = 4;\n"
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.ts"), []byte(before), 0644); err != nil {
+		t.Fatal(err)
+	}
+	patch := UnifiedDiff("index.ts", before, after, false)
+
+	cmd := exec.Command(gitPath, "apply", "--unsafe-paths", "-")
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(patch)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git apply failed: %v\n%s\nPatch:\n%s", err, out, patch)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "index.ts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != after {
+		t.Errorf("patched content =\n%s\nwant:\n%s", got, after)
+	}
+}
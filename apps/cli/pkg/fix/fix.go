@@ -0,0 +1,292 @@
+// Package fix turns engine fix strategies into file changes
+package fix
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// maxImportLines is how far past the traced line a multi-line import is searched
+const maxImportLines = 20
+
+// Change is the new content of a single file
+type Change struct {
+	Path    string `json:"path"`    // Slash-separated, relative to the workspace root
+	Created bool   `json:"created"` // File does not exist yet
+	Before  string `json:"-"`
+	After   string `json:"-"`
+}
+
+// Diff returns the unified diff of the change
+func (c *Change) Diff() string {
+	return UnifiedDiff(c.Path, c.Before, c.After, c.Created)
+}
+
+// Plan is the set of file changes that apply a fix strategy to one cycle
+type Plan struct {
+	Root     string
+	Cycle    *types.CircularDependencyInfo
+	Strategy *types.FixStrategy
+	Changes  []*Change
+	// NextSteps lists the work that cannot be automated (e.g. moving code)
+	NextSteps []string
+}
+
+// SelectStrategy returns the strategy of the given type for a cycle.
+// An empty type selects extract-module when available, since it is the
+// strategy whose changes remove the cycle edges, then the recommended one.
+func SelectStrategy(cycle *types.CircularDependencyInfo, strategyType string) (*types.FixStrategy, error) {
+	if len(cycle.FixStrategies) == 0 {
+		return nil, errors.New("no fix strategies available for this cycle")
+	}
+
+	if strategyType == "" {
+		for i := range cycle.FixStrategies {
+			if cycle.FixStrategies[i].Type == types.FixStrategyExtractModule {
+				return &cycle.FixStrategies[i], nil
+			}
+		}
+		for i := range cycle.FixStrategies {
+			if cycle.FixStrategies[i].Recommended {
+				return &cycle.FixStrategies[i], nil
+			}
+		}
+		return &cycle.FixStrategies[0], nil
+	}
+
+	available := make([]string, 0, len(cycle.FixStrategies))
+	for i := range cycle.FixStrategies {
+		if string(cycle.FixStrategies[i].Type) == strategyType {
+			return &cycle.FixStrategies[i], nil
+		}
+		available = append(available, string(cycle.FixStrategies[i].Type))
+	}
+	return nil, fmt.Errorf("strategy %q not available for this cycle (available: %s)",
+		strategyType, strings.Join(available, ", "))
+}
+
+// NewPlan builds the file changes for applying strategy to cycle in the
+// workspace at root. Files are read from disk but nothing is written.
+//
+// The plan consists of:
+//   - new files scaffolded from the strategy guide (e.g. the extracted package)
+//   - package.json dependency edits from the before/after explanation
+//   - import statement rewrites at the traced source locations
+func NewPlan(root string, cycle *types.CircularDependencyInfo, strategy *types.FixStrategy) (*Plan, error) {
+	p := &Plan{
+		Root:     root,
+		Cycle:    cycle,
+		Strategy: strategy,
+	}
+	changes := map[string]*Change{}
+
+	if strategy.Guide != nil {
+		for _, step := range strategy.Guide.Steps {
+			if err := p.scaffold(changes, step); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if explanation := strategy.BeforeAfterExplanation; explanation != nil {
+		for _, diff := range explanation.PackageJsonDiffs {
+			if len(diff.DependenciesToAdd) == 0 && len(diff.DependenciesToRemove) == 0 {
+				continue
+			}
+			if err := p.edit(changes, diff.FilePath, func(content string) (string, error) {
+				return editPackageJSON(content, diff)
+			}); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, diff := range explanation.ImportDiffs {
+			if diff.LineNumber <= 0 || len(diff.ImportsToRemove) == 0 || len(diff.ImportsToAdd) == 0 {
+				// Estimated diffs have no source location to rewrite
+				continue
+			}
+			if err := p.edit(changes, diff.FilePath, func(content string) (string, error) {
+				return rewriteImport(content, diff)
+			}); err != nil {
+				return nil, err
+			}
+			p.addMoveStep(diff)
+		}
+	}
+
+	for _, c := range changes {
+		if c.Before != c.After || c.Created {
+			p.Changes = append(p.Changes, c)
+		}
+	}
+	if len(p.Changes) == 0 {
+		return nil, fmt.Errorf("strategy %q has no changes that can be applied automatically; follow its guide instead",
+			strategy.Type)
+	}
+	sort.Slice(p.Changes, func(i, j int) bool { return p.Changes[i].Path < p.Changes[j].Path })
+
+	if strategy.Guide != nil {
+		for _, step := range strategy.Guide.Steps {
+			if step.Command != nil && step.FilePath == "" && !strings.HasPrefix(step.Command.Command, "mkdir") {
+				p.NextSteps = append(p.NextSteps, fmt.Sprintf("Run `%s`", step.Command.Command))
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// Diff returns the unified diff of all changes
+func (p *Plan) Diff() string {
+	var b strings.Builder
+	for _, c := range p.Changes {
+		b.WriteString(c.Diff())
+	}
+	return b.String()
+}
+
+// Apply writes all changes to disk, creating directories as needed
+func (p *Plan) Apply() error {
+	for _, c := range p.Changes {
+		path := filepath.Join(p.Root, filepath.FromSlash(c.Path))
+		mode := os.FileMode(0644)
+		if info, err := os.Stat(path); err == nil {
+			mode = info.Mode().Perm()
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(c.After), mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scaffold records a new file for guide steps that create one. Steps with
+// placeholder paths or for files that already exist are left to the user.
+func (p *Plan) scaffold(changes map[string]*Change, step types.FixStep) error {
+	if step.FilePath == "" || step.CodeAfter == nil || step.CodeBefore != nil ||
+		strings.Contains(step.FilePath, "<") {
+		return nil
+	}
+	if _, ok := changes[step.FilePath]; ok {
+		return nil
+	}
+
+	path, err := p.resolve(step.FilePath)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	content := step.CodeAfter.Code
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	changes[step.FilePath] = &Change{Path: step.FilePath, Created: true, After: content}
+	return nil
+}
+
+// edit applies fn to the pending content of a file, reading it on first use
+func (p *Plan) edit(changes map[string]*Change, relPath string, fn func(string) (string, error)) error {
+	c, ok := changes[relPath]
+	if !ok {
+		path, err := p.resolve(relPath)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		c = &Change{Path: relPath, Before: string(data), After: string(data)}
+		changes[relPath] = c
+	}
+
+	after, err := fn(c.After)
+	if err != nil {
+		return err
+	}
+	c.After = after
+	return nil
+}
+
+// resolve returns the absolute path of a workspace-relative path, rejecting
+// paths that escape the workspace root
+func (p *Plan) resolve(relPath string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(relPath))
+	if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("refusing to modify %s outside the workspace", relPath)
+	}
+	return filepath.Join(p.Root, clean), nil
+}
+
+// addMoveStep records the manual code move implied by an extract-module import rewrite
+func (p *Plan) addMoveStep(diff types.ImportDiff) {
+	removed, added := diff.ImportsToRemove[0], diff.ImportsToAdd[0]
+	if removed.FromPackage == added.FromPackage {
+		return
+	}
+
+	what := "the imported code"
+	if len(removed.ImportedNames) > 0 {
+		what = strings.Join(removed.ImportedNames, ", ")
+	}
+	step := fmt.Sprintf("Move %s from %s to %s", what, removed.FromPackage, added.FromPackage)
+	for _, existing := range p.NextSteps {
+		if existing == step {
+			return
+		}
+	}
+	p.NextSteps = append(p.NextSteps, step)
+}
+
+// rewriteImport rewrites the import statement at the diff's line.
+// When the import moves to another package only the module specifier is
+// replaced, keeping local names intact; otherwise the statement is replaced.
+func rewriteImport(content string, diff types.ImportDiff) (string, error) {
+	lines := strings.SplitAfter(content, "\n")
+	idx := diff.LineNumber - 1
+	if idx >= len(lines) {
+		return "", fmt.Errorf("%s:%d: line not found", diff.FilePath, diff.LineNumber)
+	}
+
+	removed, added := diff.ImportsToRemove[0], diff.ImportsToAdd[0]
+
+	if removed.FromPackage != added.FromPackage {
+		// The specifier of a multi-line import is on a later line
+		for i := idx; i < len(lines) && i <= idx+maxImportLines; i++ {
+			for _, quote := range []string{"'", `"`, "`"} {
+				old := quote + removed.FromPackage + quote
+				if strings.Contains(lines[i], old) {
+					lines[i] = strings.Replace(lines[i], old, quote+added.FromPackage+quote, 1)
+					return strings.Join(lines, ""), nil
+				}
+			}
+		}
+		return "", fmt.Errorf("%s:%d: no import of %s found", diff.FilePath, diff.LineNumber, removed.FromPackage)
+	}
+
+	line := lines[idx]
+	statement := strings.TrimSuffix(strings.TrimSpace(removed.Statement), ";")
+	pos := strings.Index(line, statement)
+	if statement == "" || pos < 0 {
+		return "", fmt.Errorf("%s:%d: import statement %q not found", diff.FilePath, diff.LineNumber, removed.Statement)
+	}
+	rest := strings.TrimPrefix(line[pos+len(statement):], ";")
+	line = line[:pos] + strings.TrimSuffix(added.Statement, ";") + ";" + rest
+
+	lines[idx] = line
+	return strings.Join(lines, ""), nil
+}
@@ -0,0 +1,284 @@
+package fix
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// writeFiles creates files under root from a map of relative path to content.
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// cycleWorkspace is a pnpm workspace where @mono/a and @mono/b depend on each other.
+var cycleWorkspace = map[string]string{
+	"package.json":            `{"name": "root", "private": true}`,
+	"pnpm-workspace.yaml":     "packages:\n  - 'packages/*'\n",
+	"packages/a/package.json": "{\n  \"name\": \"@mono/a\",\n  \"dependencies\": {\n    \"@mono/b\": \"workspace:*\"\n  }\n}\n",
+	"packages/a/src/index.ts": "import { b } from '@mono/b';\nexport const a = b;\n",
+	"packages/b/package.json": "{\n  \"name\": \"@mono/b\",\n  \"dependencies\": {\n    \"@mono/a\": \"workspace:*\"\n  }\n}\n",
+	"packages/b/src/index.ts": "import { a } from '@mono/a';\nexport const b = 1;\n",
+}
+
+// analyzeCycle analyzes the workspace at root and returns its only cycle.
+func analyzeCycle(t *testing.T, root string) *types.CircularDependencyInfo {
+	t.Helper()
+	result, err := analysis.AnalyzePath(root, nil)
+	if err != nil {
+		t.Fatalf("AnalyzePath() error = %v", err)
+	}
+	if len(result.CircularDependencies) != 1 {
+		t.Fatalf("circular dependencies = %d, want 1", len(result.CircularDependencies))
+	}
+	return result.CircularDependencies[0]
+}
+
+func TestSelectStrategy(t *testing.T) {
+	cycle := &types.CircularDependencyInfo{
+		FixStrategies: []types.FixStrategy{
+			{Type: types.FixStrategyDependencyInject, Recommended: true},
+			{Type: types.FixStrategyExtractModule},
+			{Type: types.FixStrategyBoundaryRefactor},
+		},
+	}
+
+	got, err := SelectStrategy(cycle, "")
+	if err != nil || got.Type != types.FixStrategyExtractModule {
+		t.Errorf("SelectStrategy(\"\") = %v, %v; want extract-module", got, err)
+	}
+
+	got, err = SelectStrategy(cycle, "boundary-refactoring")
+	if err != nil || got.Type != types.FixStrategyBoundaryRefactor {
+		t.Errorf("SelectStrategy(boundary-refactoring) = %v, %v", got, err)
+	}
+
+	_, err = SelectStrategy(cycle, "rewrite-everything")
+	if err == nil || !strings.Contains(err.Error(), "extract-module") {
+		t.Errorf("SelectStrategy(unknown) error = %v, want list of available strategies", err)
+	}
+
+	noExtract := &types.CircularDependencyInfo{
+		FixStrategies: []types.FixStrategy{
+			{Type: types.FixStrategyBoundaryRefactor},
+			{Type: types.FixStrategyDependencyInject, Recommended: true},
+		},
+	}
+	got, err = SelectStrategy(noExtract, "")
+	if err != nil || got.Type != types.FixStrategyDependencyInject {
+		t.Errorf("SelectStrategy(\"\") without extract-module = %v, %v; want recommended", got, err)
+	}
+
+	if _, err := SelectStrategy(&types.CircularDependencyInfo{}, ""); err == nil {
+		t.Error("SelectStrategy() should fail without strategies")
+	}
+}
+
+func TestNewPlan_ExtractModule(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, cycleWorkspace)
+	cycle := analyzeCycle(t, root)
+
+	strategy, err := SelectStrategy(cycle, string(types.FixStrategyExtractModule))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := NewPlan(root, cycle, strategy)
+	if err != nil {
+		t.Fatalf("NewPlan() error = %v", err)
+	}
+
+	newPkgDir := "packages/" + extractShortName(strategy.NewPackageName)
+	wantPaths := []string{
+		"packages/a/package.json",
+		"packages/a/src/index.ts",
+		"packages/b/package.json",
+		"packages/b/src/index.ts",
+		newPkgDir + "/package.json",
+		newPkgDir + "/src/index.ts",
+	}
+	changes := map[string]*Change{}
+	for _, c := range plan.Changes {
+		changes[c.Path] = c
+	}
+	for _, path := range wantPaths {
+		if _, ok := changes[path]; !ok {
+			t.Errorf("plan missing change for %s (changes: %v)", path, plan.Changes)
+		}
+	}
+
+	if c := changes["packages/a/src/index.ts"]; c != nil {
+		want := "import { b } from '" + strategy.NewPackageName + "';\nexport const a = b;\n"
+		if c.After != want {
+			t.Errorf("rewritten import =\n%s\nwant:\n%s", c.After, want)
+		}
+	}
+	if c := changes["packages/a/package.json"]; c != nil {
+		if strings.Contains(c.After, `"@mono/b"`) || !strings.Contains(c.After, strategy.NewPackageName) {
+			t.Errorf("package.json should swap @mono/b for %s:\n%s", strategy.NewPackageName, c.After)
+		}
+	}
+	if c := changes[newPkgDir+"/package.json"]; c != nil && !c.Created {
+		t.Error("new package.json should be marked as created")
+	}
+
+	diff := plan.Diff()
+	for _, want := range []string{
+		"--- a/packages/a/src/index.ts",
+		"-import { b } from '@mono/b';",
+		"--- /dev/null\n+++ b/" + newPkgDir + "/package.json",
+	} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("diff missing %q\n%s", want, diff)
+		}
+	}
+
+	// Planning must not touch the workspace
+	if _, err := os.Stat(filepath.Join(root, newPkgDir)); !os.IsNotExist(err) {
+		t.Error("NewPlan() should not write files")
+	}
+	if len(plan.NextSteps) == 0 {
+		t.Error("plan should list manual next steps")
+	}
+}
+
+func TestPlanApply_ResolvesCycle(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, cycleWorkspace)
+	cycle := analyzeCycle(t, root)
+
+	strategy, err := SelectStrategy(cycle, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := NewPlan(root, cycle, strategy)
+	if err != nil {
+		t.Fatalf("NewPlan() error = %v", err)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	for _, c := range plan.Changes {
+		got, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(c.Path)))
+		if err != nil {
+			t.Fatalf("reading %s: %v", c.Path, err)
+		}
+		if string(got) != c.After {
+			t.Errorf("%s content mismatch after apply", c.Path)
+		}
+	}
+
+	result, err := analysis.AnalyzePath(root, nil)
+	if err != nil {
+		t.Fatalf("AnalyzePath() after apply error = %v", err)
+	}
+	if len(result.CircularDependencies) != 0 {
+		t.Errorf("circular dependencies after apply = %d, want 0", len(result.CircularDependencies))
+	}
+}
+
+func TestNewPlan_NoAutomaticChanges(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, cycleWorkspace)
+	cycle := analyzeCycle(t, root)
+
+	strategy, err := SelectStrategy(cycle, string(types.FixStrategyBoundaryRefactor))
+	if err != nil {
+		t.Skipf("boundary-refactoring not generated for this cycle: %v", err)
+	}
+	if _, err := NewPlan(root, cycle, strategy); err == nil {
+		t.Error("NewPlan() should fail for a strategy without automatic changes")
+	}
+}
+
+func TestRewriteImport(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		diff    types.ImportDiff
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "specifier swap keeps local names",
+			content: "import utils, { x as y } from \"@mono/b\";\nconsole.log(y);\n",
+			diff: types.ImportDiff{
+				LineNumber:      1,
+				ImportsToRemove: []types.ImportChange{{FromPackage: "@mono/b"}},
+				ImportsToAdd:    []types.ImportChange{{FromPackage: "@mono/shared"}},
+			},
+			want: "import utils, { x as y } from \"@mono/shared\";\nconsole.log(y);\n",
+		},
+		{
+			name:    "multi-line import",
+			content: "import {\n  a,\n  b,\n} from '@mono/b';\n",
+			diff: types.ImportDiff{
+				LineNumber:      1,
+				ImportsToRemove: []types.ImportChange{{FromPackage: "@mono/b"}},
+				ImportsToAdd:    []types.ImportChange{{FromPackage: "@mono/shared"}},
+			},
+			want: "import {\n  a,\n  b,\n} from '@mono/shared';\n",
+		},
+		{
+			name:    "same package replaces statement",
+			content: "import { b } from '@mono/b';\n",
+			diff: types.ImportDiff{
+				LineNumber:      1,
+				ImportsToRemove: []types.ImportChange{{Statement: "import { b } from '@mono/b';", FromPackage: "@mono/b"}},
+				ImportsToAdd:    []types.ImportChange{{Statement: "import type { BHandler } from '@mono/b';", FromPackage: "@mono/b"}},
+			},
+			want: "import type { BHandler } from '@mono/b';\n",
+		},
+		{
+			name:    "stale location",
+			content: "export const a = 1;\n",
+			diff: types.ImportDiff{
+				FilePath:        "packages/a/src/index.ts",
+				LineNumber:      1,
+				ImportsToRemove: []types.ImportChange{{FromPackage: "@mono/b"}},
+				ImportsToAdd:    []types.ImportChange{{FromPackage: "@mono/shared"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rewriteImport(tt.content, tt.diff)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rewriteImport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("rewriteImport() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanResolveRejectsEscapingPaths(t *testing.T) {
+	p := &Plan{Root: t.TempDir()}
+	for _, path := range []string{"../outside.json", "/etc/passwd"} {
+		if _, err := p.resolve(path); err == nil {
+			t.Errorf("resolve(%q) should fail", path)
+		}
+	}
+}
+
+// extractShortName returns the last segment of a package name.
+func extractShortName(pkgName string) string {
+	return pkgName[strings.LastIndex(pkgName, "/")+1:]
+}
@@ -0,0 +1,190 @@
+// Package fix turns engine fix strategies into file changes
+package fix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// dependencyFields are the package.json fields that declare dependencies
+var dependencyFields = []string{"dependencies", "devDependencies", "peerDependencies", "optionalDependencies"}
+
+// member is a key/value pair of a JSON object, kept in document order
+type member struct {
+	key   string
+	value json.RawMessage
+}
+
+// object is a JSON object that preserves key order when re-encoded
+type object []member
+
+// editPackageJSON applies a dependency diff to package.json content.
+// Key order and indentation are preserved; other fields are left untouched.
+func editPackageJSON(content string, diff types.PackageJsonDiff) (string, error) {
+	root, err := parseObject([]byte(content))
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", diff.FilePath, err)
+	}
+
+	for _, dep := range diff.DependenciesToRemove {
+		fields := dependencyFields
+		if dep.DependencyType != "" {
+			fields = append([]string{dep.DependencyType}, dependencyFields...)
+		}
+		for _, field := range fields {
+			removed, err := root.editField(field, false, func(deps object) object {
+				return deps.without(dep.Name)
+			})
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", diff.FilePath, err)
+			}
+			if removed {
+				break
+			}
+		}
+	}
+
+	for _, dep := range diff.DependenciesToAdd {
+		field := dep.DependencyType
+		if field == "" {
+			field = "dependencies"
+		}
+		version, _ := json.Marshal(dep.Version)
+		if _, err := root.editField(field, true, func(deps object) object {
+			return deps.with(dep.Name, version)
+		}); err != nil {
+			return "", fmt.Errorf("%s: %w", diff.FilePath, err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, root.encode(), "", detectIndent(content)); err != nil {
+		return "", err
+	}
+	if strings.HasSuffix(content, "\n") {
+		out.WriteByte('\n')
+	}
+	return out.String(), nil
+}
+
+// editField rewrites the object stored in field. It reports whether the field
+// changed; a missing field is only created when create is set.
+func (o *object) editField(field string, create bool, edit func(object) object) (bool, error) {
+	for i, m := range *o {
+		if m.key != field {
+			continue
+		}
+		deps, err := parseObject(m.value)
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", field, err)
+		}
+		edited := edit(deps)
+		if len(edited) == len(deps) && !create {
+			return false, nil
+		}
+		(*o)[i].value = edited.encode()
+		return true, nil
+	}
+
+	if !create {
+		return false, nil
+	}
+	*o = append(*o, member{key: field, value: edit(object{}).encode()})
+	return true, nil
+}
+
+// without returns the object with key removed
+func (o object) without(key string) object {
+	out := make(object, 0, len(o))
+	for _, m := range o {
+		if m.key != key {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// with returns the object with key set to value. New keys are inserted in
+// alphabetical position when the existing keys are sorted, otherwise appended.
+func (o object) with(key string, value json.RawMessage) object {
+	for i, m := range o {
+		if m.key == key {
+			out := append(object{}, o...)
+			out[i].value = value
+			return out
+		}
+	}
+
+	sorted := sort.SliceIsSorted(o, func(i, j int) bool { return o[i].key < o[j].key })
+	pos := len(o)
+	if sorted {
+		pos = sort.Search(len(o), func(i int) bool { return o[i].key > key })
+	}
+	out := make(object, 0, len(o)+1)
+	out = append(out, o[:pos]...)
+	out = append(out, member{key: key, value: value})
+	return append(out, o[pos:]...)
+}
+
+// encode returns the compact JSON encoding of the object
+func (o object) encode() []byte {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(m.key)
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(m.value)
+	}
+	b.WriteByte('}')
+	return b.Bytes()
+}
+
+// parseObject decodes a JSON object, keeping member order
+func parseObject(data []byte) (object, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+
+	obj := object{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		obj = append(obj, member{key: key, value: value})
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// detectIndent returns the indentation of the first indented line, or two spaces
+func detectIndent(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed != "" && len(trimmed) < len(line) {
+			return line[:len(line)-len(trimmed)]
+		}
+	}
+	return "  "
+}
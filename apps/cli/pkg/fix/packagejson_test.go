@@ -0,0 +1,109 @@
+package fix
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+func TestEditPackageJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		diff    types.PackageJsonDiff
+		want    string
+	}{
+		{
+			name: "replace cycle dependency keeping order",
+			content: `{
+  "name": "@mono/a",
+  "version": "1.0.0",
+  "dependencies": {
+    "@mono/b": "workspace:*",
+    "lodash": "^4.17.21"
+  }
+}
+`,
+			diff: types.PackageJsonDiff{
+				DependenciesToAdd:    []types.DependencyChange{{Name: "@mono/shared", Version: "workspace:*", DependencyType: "dependencies"}},
+				DependenciesToRemove: []types.DependencyChange{{Name: "@mono/b", DependencyType: "dependencies"}},
+			},
+			want: `{
+  "name": "@mono/a",
+  "version": "1.0.0",
+  "dependencies": {
+    "@mono/shared": "workspace:*",
+    "lodash": "^4.17.21"
+  }
+}
+`,
+		},
+		{
+			name:    "create dependencies block with detected indent",
+			content: "{\n    \"name\": \"@mono/a\"\n}",
+			diff: types.PackageJsonDiff{
+				DependenciesToAdd: []types.DependencyChange{{Name: "@mono/shared", Version: "workspace:*"}},
+			},
+			want: "{\n    \"name\": \"@mono/a\",\n    \"dependencies\": {\n        \"@mono/shared\": \"workspace:*\"\n    }\n}",
+		},
+		{
+			name: "remove falls back to other dependency fields",
+			content: `{
+  "name": "@mono/a",
+  "devDependencies": {
+    "@mono/b": "workspace:*"
+  }
+}
+`,
+			diff: types.PackageJsonDiff{
+				DependenciesToRemove: []types.DependencyChange{{Name: "@mono/b", DependencyType: "dependencies"}},
+			},
+			want: `{
+  "name": "@mono/a",
+  "devDependencies": {}
+}
+`,
+		},
+		{
+			name: "unsorted dependencies append new entry",
+			content: `{
+  "dependencies": {
+    "zod": "^3.0.0",
+    "axios": "^1.0.0"
+  }
+}
+`,
+			diff: types.PackageJsonDiff{
+				DependenciesToAdd: []types.DependencyChange{{Name: "@mono/shared", Version: "workspace:*", DependencyType: "dependencies"}},
+			},
+			want: `{
+  "dependencies": {
+    "zod": "^3.0.0",
+    "axios": "^1.0.0",
+    "@mono/shared": "workspace:*"
+  }
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := editPackageJSON(tt.content, tt.diff)
+			if err != nil {
+				t.Fatalf("editPackageJSON() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("editPackageJSON() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditPackageJSON_Invalid(t *testing.T) {
+	_, err := editPackageJSON("[1, 2]", types.PackageJsonDiff{FilePath: "packages/a/package.json"})
+	if err == nil || !strings.Contains(err.Error(), "packages/a/package.json") {
+		t.Errorf("editPackageJSON() error = %v, want error naming the file", err)
+	}
+}
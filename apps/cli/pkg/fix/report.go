@@ -0,0 +1,31 @@
+// Package fix turns engine fix strategies into file changes
+package fix
+
+import "github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+
+// Report describes a fix run for output formatting
+type Report struct {
+	Path         string                `json:"path"`
+	Cycle        []string              `json:"cycle"`
+	Strategy     types.FixStrategyType `json:"strategy"`
+	StrategyName string                `json:"strategyName"`
+	DryRun       bool                  `json:"dryRun"`
+	Applied      bool                  `json:"applied"`
+	Changes      []*Change             `json:"changes"`
+	Diff         string                `json:"diff,omitempty"`
+	NextSteps    []string              `json:"nextSteps,omitempty"`
+	// Resolved reports whether the cycle is gone when re-analyzing after apply
+	Resolved *bool `json:"resolved,omitempty"`
+}
+
+// NewReport creates a report for a plan
+func NewReport(path string, p *Plan) *Report {
+	return &Report{
+		Path:         path,
+		Cycle:        p.Cycle.Cycle,
+		Strategy:     p.Strategy.Type,
+		StrategyName: p.Strategy.Name,
+		Changes:      p.Changes,
+		NextSteps:    p.NextSteps,
+	}
+}
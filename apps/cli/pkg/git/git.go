@@ -0,0 +1,118 @@
+// Package git runs git commands against a local repository.
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Repo is a git repository with a working tree
+type Repo struct {
+	Root string // Absolute path of the top-level directory
+}
+
+// Open finds the repository containing dir
+func Open(dir string) (*Repo, error) {
+	out, err := run(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("%s is not inside a git repository: %w", dir, err)
+	}
+	return &Repo{Root: out}, nil
+}
+
+// RelPath returns the path of dir relative to the repository root
+func (r *Repo) RelPath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	rel, err := filepath.Rel(r.Root, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%s is outside the repository %s", dir, r.Root)
+	}
+	return rel, nil
+}
+
+// ResolveCommit returns the commit hash a ref points to
+func (r *Repo) ResolveCommit(ref string) (string, error) {
+	out, err := run(r.Root, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("unknown git ref %q", ref)
+	}
+	return out, nil
+}
+
+// Worktree checks out ref into a new temporary worktree. The returned
+// function removes the worktree again.
+func (r *Repo) Worktree(ref string) (string, func() error, error) {
+	commit, err := r.ResolveCommit(ref)
+	if err != nil {
+		return "", nil, err
+	}
+	dir, err := os.MkdirTemp("", "monoguard-worktree-")
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := run(r.Root, "worktree", "add", "--detach", "--quiet", dir, commit); err != nil {
+		os.RemoveAll(dir)
+		return "", nil, fmt.Errorf("failed to check out %s: %w", ref, err)
+	}
+	remove := func() error {
+		_, err := run(r.Root, "worktree", "remove", "--force", dir)
+		os.RemoveAll(dir)
+		return err
+	}
+	return dir, remove, nil
+}
+
+// ChangedFiles returns the files under dir that changed since ref: files
+// that differ between the merge base of ref and HEAD and the working tree,
+// and untracked files. Paths are relative to dir and use forward slashes.
+func (r *Repo) ChangedFiles(dir, ref string) ([]string, error) {
+	commit, err := r.ResolveCommit(ref)
+	if err != nil {
+		return nil, err
+	}
+	base, err := run(r.Root, "merge-base", commit, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("%s and HEAD have no common ancestor: %w", ref, err)
+	}
+	changed, err := run(dir, "diff", "--name-only", "--relative", "-z", base)
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := run(dir, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, f := range strings.Split(changed+"\x00"+untracked, "\x00") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// run runs git in dir and returns its trimmed stdout
+func run(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,123 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// initRepo creates a repository with one commit containing file
+func initRepo(t *testing.T, file, content string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	p := filepath.Join(dir, filepath.FromSlash(file))
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "initial"},
+	} {
+		if _, err := run(dir, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestOpen(t *testing.T) {
+	dir := initRepo(t, "ws/package.json", "{}")
+
+	repo, err := Open(filepath.Join(dir, "ws"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	rel, err := repo.RelPath(filepath.Join(dir, "ws"))
+	if err != nil || rel != "ws" {
+		t.Errorf("RelPath() = %q, %v, want ws", rel, err)
+	}
+
+	if _, err := Open(t.TempDir()); err == nil {
+		t.Error("Open() should fail outside a repository")
+	}
+}
+
+func TestWorktree(t *testing.T) {
+	dir := initRepo(t, "package.json", `{"name": "root"}`)
+	repo, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Uncommitted changes are not part of the worktree
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name": "changed"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wt, remove, err := repo.Worktree("HEAD")
+	if err != nil {
+		t.Fatalf("Worktree() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(wt, "package.json"))
+	if err != nil || string(data) != `{"name": "root"}` {
+		t.Errorf("worktree package.json = %q, %v", data, err)
+	}
+
+	if err := remove(); err != nil {
+		t.Errorf("remove() error = %v", err)
+	}
+	if _, err := os.Stat(wt); !os.IsNotExist(err) {
+		t.Errorf("worktree %s not removed", wt)
+	}
+}
+
+func TestWorktree_UnknownRef(t *testing.T) {
+	repo, err := Open(initRepo(t, "package.json", "{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := repo.Worktree("no-such-branch"); err == nil {
+		t.Error("Worktree() should fail for an unknown ref")
+	}
+}
+
+func TestChangedFiles(t *testing.T) {
+	dir := initRepo(t, "ws/packages/a/index.ts", "export const a = 1;\n")
+	repo, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"ws/packages/a/index.ts": "export const a = 2;\n", // Modified
+		"ws/packages/b/new.ts":   "export const b = 1;\n", // Untracked
+		"outside.txt":            "not in the workspace\n",
+	}
+	for rel, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := repo.ChangedFiles(filepath.Join(dir, "ws"), "HEAD")
+	if err != nil {
+		t.Fatalf("ChangedFiles() error = %v", err)
+	}
+	want := []string{"packages/a/index.ts", "packages/b/new.ts"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ChangedFiles() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,307 @@
+// Package graph selects part of a workspace dependency graph for export.
+package graph
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// Colors shared by the exports
+const (
+	cycleColor    = "#dc2626"
+	excludedColor = "#9ca3af"
+)
+
+// ========================================
+// Graphviz DOT
+// ========================================
+
+// WriteDOT writes the view as a Graphviz digraph. Cycle edges are red,
+// excluded packages are dashed and grey, dev dependencies are dashed and
+// peer dependencies dotted.
+func WriteDOT(w io.Writer, v *View) error {
+	var b strings.Builder
+	b.WriteString("digraph monoguard {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=rounded, fontname=\"Helvetica\"];\n")
+	b.WriteString("  edge [fontname=\"Helvetica\"];\n")
+
+	nodes := map[string]Node{}
+	for _, n := range v.Nodes {
+		nodes[n.Name] = n
+	}
+	if len(v.Clusters) > 0 {
+		for i, c := range v.Clusters {
+			fmt.Fprintf(&b, "\n  subgraph cluster_%d {\n    label=%s;\n    style=dashed;\n", i, dotQuote(c.Dir))
+			for _, name := range c.Packages {
+				fmt.Fprintf(&b, "    %s;\n", dotNode(nodes[name], v.Focus))
+			}
+			b.WriteString("  }\n")
+		}
+	} else {
+		b.WriteString("\n")
+		for _, n := range v.Nodes {
+			fmt.Fprintf(&b, "  %s;\n", dotNode(n, v.Focus))
+		}
+	}
+
+	if len(v.Edges) > 0 {
+		b.WriteString("\n")
+	}
+	for _, e := range v.Edges {
+		var attrs []string
+		switch e.Type {
+		case types.DependencyTypeDevelopment:
+			attrs = append(attrs, "style=dashed")
+		case types.DependencyTypePeer:
+			attrs = append(attrs, "style=dotted")
+		}
+		if e.InCycle {
+			attrs = append(attrs, "color="+dotQuote(cycleColor), "penwidth=2")
+		}
+		fmt.Fprintf(&b, "  %s -> %s", dotQuote(e.From), dotQuote(e.To))
+		if len(attrs) > 0 {
+			fmt.Fprintf(&b, " [%s]", strings.Join(attrs, ", "))
+		}
+		b.WriteString(";\n")
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotNode returns a node statement with its style attributes
+func dotNode(n Node, focus string) string {
+	var attrs []string
+	styles := []string{"rounded"}
+	if n.Excluded {
+		styles = append(styles, "dashed")
+		attrs = append(attrs, "color="+dotQuote(excludedColor), "fontcolor="+dotQuote(excludedColor))
+	}
+	if n.InCycle && !n.Excluded {
+		attrs = append(attrs, "color="+dotQuote(cycleColor))
+	}
+	if n.Name == focus {
+		styles = append(styles, "bold")
+	}
+	if len(styles) > 1 {
+		attrs = append([]string{"style=" + dotQuote(strings.Join(styles, ","))}, attrs...)
+	}
+	if len(attrs) == 0 {
+		return dotQuote(n.Name)
+	}
+	return fmt.Sprintf("%s [%s]", dotQuote(n.Name), strings.Join(attrs, ", "))
+}
+
+// dotQuote returns s as a quoted DOT ID
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// ========================================
+// Mermaid flowchart
+// ========================================
+
+// WriteMermaid writes the view as a Mermaid flowchart. Package names are
+// not valid Mermaid IDs, so nodes get generated IDs and quoted labels.
+func WriteMermaid(w io.Writer, v *View) error {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	ids := map[string]string{}
+	for i, n := range v.Nodes {
+		ids[n.Name] = "n" + strconv.Itoa(i)
+	}
+	nodes := map[string]Node{}
+	for _, n := range v.Nodes {
+		nodes[n.Name] = n
+	}
+
+	if len(v.Clusters) > 0 {
+		for i, c := range v.Clusters {
+			fmt.Fprintf(&b, "  subgraph c%d[%s]\n", i, mermaidLabel(c.Dir))
+			for _, name := range c.Packages {
+				fmt.Fprintf(&b, "    %s[%s]\n", ids[name], mermaidLabel(name))
+			}
+			b.WriteString("  end\n")
+		}
+	} else {
+		for _, n := range v.Nodes {
+			fmt.Fprintf(&b, "  %s[%s]\n", ids[n.Name], mermaidLabel(n.Name))
+		}
+	}
+
+	var cycleLinks []string
+	for i, e := range v.Edges {
+		arrow := "-->"
+		switch e.Type {
+		case types.DependencyTypeDevelopment:
+			arrow = "-.->"
+		case types.DependencyTypePeer:
+			arrow = "-. peer .->"
+		}
+		fmt.Fprintf(&b, "  %s %s %s\n", ids[e.From], arrow, ids[e.To])
+		if e.InCycle {
+			cycleLinks = append(cycleLinks, strconv.Itoa(i))
+		}
+	}
+	if len(cycleLinks) > 0 {
+		fmt.Fprintf(&b, "  linkStyle %s stroke:%s,stroke-width:2px\n", strings.Join(cycleLinks, ","), cycleColor)
+	}
+
+	var excluded, inCycle []string
+	for _, n := range v.Nodes {
+		switch {
+		case n.Excluded:
+			excluded = append(excluded, ids[n.Name])
+		case n.InCycle:
+			inCycle = append(inCycle, ids[n.Name])
+		}
+	}
+	if len(inCycle) > 0 {
+		fmt.Fprintf(&b, "  classDef cycle stroke:%s,stroke-width:2px\n", cycleColor)
+		fmt.Fprintf(&b, "  class %s cycle\n", strings.Join(inCycle, ","))
+	}
+	if len(excluded) > 0 {
+		fmt.Fprintf(&b, "  classDef excluded stroke:%s,color:%s,stroke-dasharray:4 3\n", excludedColor, excludedColor)
+		fmt.Fprintf(&b, "  class %s excluded\n", strings.Join(excluded, ","))
+	}
+	if v.Focus != "" {
+		b.WriteString("  classDef focus stroke-width:3px\n")
+		fmt.Fprintf(&b, "  class %s focus\n", ids[v.Focus])
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mermaidLabel quotes a label, replacing quotes Mermaid cannot escape
+func mermaidLabel(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
+
+// ========================================
+// GraphML
+// ========================================
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID    string        `xml:"id,attr"`
+	Data  []graphMLData `xml:"data"`
+	Graph *graphMLGraph `xml:"graph,omitempty"` // Nested graph of a cluster
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// graphMLKeys declares the node and edge attributes
+var graphMLKeys = []graphMLKey{
+	{ID: "name", For: "node", AttrName: "name", AttrType: "string"},
+	{ID: "path", For: "node", AttrName: "path", AttrType: "string"},
+	{ID: "excluded", For: "node", AttrName: "excluded", AttrType: "boolean"},
+	{ID: "inCycle", For: "node", AttrName: "inCycle", AttrType: "boolean"},
+	{ID: "focus", For: "node", AttrName: "focus", AttrType: "boolean"},
+	{ID: "type", For: "edge", AttrName: "type", AttrType: "string"},
+	{ID: "edgeInCycle", For: "edge", AttrName: "inCycle", AttrType: "boolean"},
+}
+
+// WriteGraphML writes the view as GraphML. Packages are identified by name;
+// clusters become nested graphs inside a node per directory.
+func WriteGraphML(w io.Writer, v *View) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys:  graphMLKeys,
+		Graph: graphMLGraph{ID: "monoguard", EdgeDefault: "directed"},
+	}
+
+	nodes := map[string]Node{}
+	for _, n := range v.Nodes {
+		nodes[n.Name] = n
+	}
+	if len(v.Clusters) > 0 {
+		for i, c := range v.Clusters {
+			id := "cluster" + strconv.Itoa(i)
+			sub := &graphMLGraph{ID: id + ":", EdgeDefault: "directed"}
+			for _, name := range c.Packages {
+				sub.Nodes = append(sub.Nodes, graphMLPackage(nodes[name], v.Focus))
+			}
+			doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+				ID:    id,
+				Data:  []graphMLData{{Key: "path", Value: c.Dir}},
+				Graph: sub,
+			})
+		}
+	} else {
+		for _, n := range v.Nodes {
+			doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLPackage(n, v.Focus))
+		}
+	}
+
+	for i, e := range v.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			ID:     "e" + strconv.Itoa(i),
+			Source: e.From,
+			Target: e.To,
+			Data: []graphMLData{
+				{Key: "type", Value: string(e.Type)},
+				{Key: "edgeInCycle", Value: strconv.FormatBool(e.InCycle)},
+			},
+		})
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s%s\n", xml.Header, out)
+	return err
+}
+
+// graphMLPackage returns the GraphML node of a package
+func graphMLPackage(n Node, focus string) graphMLNode {
+	return graphMLNode{
+		ID: n.Name,
+		Data: []graphMLData{
+			{Key: "name", Value: n.Name},
+			{Key: "path", Value: n.Path},
+			{Key: "excluded", Value: strconv.FormatBool(n.Excluded)},
+			{Key: "inCycle", Value: strconv.FormatBool(n.InCycle)},
+			{Key: "focus", Value: strconv.FormatBool(n.Name == focus)},
+		},
+	}
+}
@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func buildView(t *testing.T, opts Options) *View {
+	t.Helper()
+	v, err := Build(sampleResult(), opts)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	return v
+}
+
+func TestWriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDOT(&buf, buildView(t, Options{Focus: "@mono/app"})); err != nil {
+		t.Fatalf("WriteDOT() error = %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"digraph monoguard {",
+		`"@mono/app" -> "@mono/ui";`,
+		`"@mono/app" -> "@mono/test" [style=dashed];`,
+		`"@mono/ui" -> "@mono/utils" [color="#dc2626", penwidth=2];`,
+		`"@mono/utils" -> "@mono/ui" [style=dotted, color="#dc2626", penwidth=2];`,
+		`"@mono/legacy" [style="rounded,dashed", color="#9ca3af", fontcolor="#9ca3af"];`,
+		`"@mono/app" [style="rounded,bold"];`,
+		`"@mono/ui" [color="#dc2626"];`,
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("DOT output missing %q\n%s", want, output)
+		}
+	}
+	if !strings.HasSuffix(output, "}\n") {
+		t.Errorf("DOT output not closed\n%s", output)
+	}
+}
+
+func TestWriteDOT_Clusters(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDOT(&buf, buildView(t, Options{ClusterDirs: true})); err != nil {
+		t.Fatalf("WriteDOT() error = %v", err)
+	}
+	output := buf.String()
+	if strings.Count(output, "subgraph cluster_") != 3 || !strings.Contains(output, `label="packages";`) {
+		t.Errorf("expected three clusters\n%s", output)
+	}
+}
+
+func TestWriteMermaid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteMermaid(&buf, buildView(t, Options{ClusterDirs: true})); err != nil {
+		t.Fatalf("WriteMermaid() error = %v", err)
+	}
+
+	// Nodes are numbered in name order: app, legacy, test, ui, utils
+	output := buf.String()
+	wantContains := []string{
+		"flowchart LR",
+		`subgraph c1["packages"]`,
+		`n3["@mono/ui"]`,
+		"n0 --> n3",
+		"n0 -.-> n2",
+		"n4 -. peer .-> n3",
+		"linkStyle 3,4 stroke:#dc2626,stroke-width:2px",
+		"class n3,n4 cycle",
+		"class n1 excluded",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("Mermaid output missing %q\n%s", want, output)
+		}
+	}
+}
+
+func TestWriteGraphML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGraphML(&buf, buildView(t, Options{})); err != nil {
+		t.Fatalf("WriteGraphML() error = %v", err)
+	}
+
+	var doc graphML
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, buf.String())
+	}
+	if len(doc.Graph.Nodes) != 5 || len(doc.Graph.Edges) != 5 {
+		t.Fatalf("nodes = %d, edges = %d", len(doc.Graph.Nodes), len(doc.Graph.Edges))
+	}
+	if !strings.Contains(buf.String(), `<data key="excluded">true</data>`) ||
+		!strings.Contains(buf.String(), `<data key="edgeInCycle">true</data>`) {
+		t.Errorf("missing highlight data\n%s", buf.String())
+	}
+}
+
+func TestWriteGraphML_Clusters(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGraphML(&buf, buildView(t, Options{ClusterDirs: true})); err != nil {
+		t.Fatalf("WriteGraphML() error = %v", err)
+	}
+	var doc graphML
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+	if len(doc.Graph.Nodes) != 3 || doc.Graph.Nodes[1].Graph == nil || len(doc.Graph.Nodes[1].Graph.Nodes) != 2 {
+		t.Errorf("expected nested graphs per directory\n%s", buf.String())
+	}
+}
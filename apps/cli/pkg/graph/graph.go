@@ -0,0 +1,189 @@
+// Package graph selects part of a workspace dependency graph for export.
+package graph
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// Options selects which packages and dependencies to export
+type Options struct {
+	Focus       string // Only export packages within Depth of this package; "" exports all
+	Depth       int    // Maximum distance from Focus in either direction; 0 means unlimited
+	HideDev     bool   // Drop devDependencies edges
+	HidePeer    bool   // Drop peerDependencies edges
+	ClusterDirs bool   // Group packages by their parent directory
+}
+
+// View is the selected part of a dependency graph
+type View struct {
+	Nodes    []Node    `json:"nodes"` // Sorted by name
+	Edges    []Edge    `json:"edges"` // Sorted by from, then to
+	Clusters []Cluster `json:"clusters,omitempty"`
+	Focus    string    `json:"focus,omitempty"`
+}
+
+// Node is a workspace package
+type Node struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Excluded bool   `json:"excluded,omitempty"` // Excluded from analysis in .monoguard.yaml
+	InCycle  bool   `json:"inCycle,omitempty"`
+}
+
+// Edge is a dependency between workspace packages
+type Edge struct {
+	From    string               `json:"from"`
+	To      string               `json:"to"`
+	Type    types.DependencyType `json:"type"`
+	InCycle bool                 `json:"inCycle,omitempty"` // Part of a detected circular dependency
+}
+
+// Cluster groups the packages of one directory
+type Cluster struct {
+	Dir      string   `json:"dir"`
+	Packages []string `json:"packages"`
+}
+
+// Build selects the part of the analyzed graph described by opts and marks
+// the edges and packages of detected circular dependencies
+func Build(result *types.AnalysisResult, opts Options) (*View, error) {
+	g := result.Graph
+	if g == nil {
+		return nil, fmt.Errorf("analysis result has no dependency graph")
+	}
+	if opts.Focus != "" {
+		if _, ok := g.Nodes[opts.Focus]; !ok {
+			return nil, fmt.Errorf("package %q not found in workspace", opts.Focus)
+		}
+	}
+	if opts.Depth < 0 {
+		return nil, fmt.Errorf("depth must not be negative")
+	}
+
+	cycleEdges := map[[2]string]bool{}
+	cyclePackages := map[string]bool{}
+	for _, cycle := range result.CircularDependencies {
+		for i := 0; i+1 < len(cycle.Cycle); i++ {
+			cycleEdges[[2]string{cycle.Cycle[i], cycle.Cycle[i+1]}] = true
+			cyclePackages[cycle.Cycle[i]] = true
+		}
+	}
+
+	var edges []Edge
+	seen := map[[3]string]bool{}
+	for _, e := range g.Edges {
+		if (opts.HideDev && e.Type == types.DependencyTypeDevelopment) ||
+			(opts.HidePeer && e.Type == types.DependencyTypePeer) {
+			continue
+		}
+		if _, ok := g.Nodes[e.From]; !ok {
+			continue
+		}
+		if _, ok := g.Nodes[e.To]; !ok {
+			continue
+		}
+		key := [3]string{e.From, e.To, string(e.Type)}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		edges = append(edges, Edge{
+			From:    e.From,
+			To:      e.To,
+			Type:    e.Type,
+			InCycle: cycleEdges[[2]string{e.From, e.To}],
+		})
+	}
+
+	include := func(string) bool { return true }
+	if opts.Focus != "" {
+		reach := neighborhood(opts.Focus, edges, opts.Depth)
+		include = func(name string) bool { return reach[name] }
+	}
+
+	view := &View{Nodes: []Node{}, Edges: []Edge{}, Focus: opts.Focus}
+	for name, node := range g.Nodes {
+		if include(name) {
+			view.Nodes = append(view.Nodes, Node{
+				Name:     name,
+				Path:     node.Path,
+				Excluded: node.Excluded,
+				InCycle:  cyclePackages[name],
+			})
+		}
+	}
+	sort.Slice(view.Nodes, func(i, j int) bool { return view.Nodes[i].Name < view.Nodes[j].Name })
+
+	for _, e := range edges {
+		if include(e.From) && include(e.To) {
+			view.Edges = append(view.Edges, e)
+		}
+	}
+	sort.SliceStable(view.Edges, func(i, j int) bool {
+		a, b := view.Edges[i], view.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+
+	if opts.ClusterDirs {
+		view.Clusters = clusters(view.Nodes)
+	}
+	return view, nil
+}
+
+// neighborhood returns the packages within depth of focus, following edges
+// in both directions (dependencies and dependents). depth 0 is unlimited.
+func neighborhood(focus string, edges []Edge, depth int) map[string]bool {
+	out := map[string][]string{}
+	in := map[string][]string{}
+	for _, e := range edges {
+		out[e.From] = append(out[e.From], e.To)
+		in[e.To] = append(in[e.To], e.From)
+	}
+
+	reach := map[string]bool{focus: true}
+	for _, adjacent := range []map[string][]string{out, in} {
+		frontier := []string{focus}
+		visited := map[string]bool{focus: true}
+		for d := 1; len(frontier) > 0 && (depth == 0 || d <= depth); d++ {
+			var next []string
+			for _, name := range frontier {
+				for _, n := range adjacent[name] {
+					if !visited[n] {
+						visited[n] = true
+						reach[n] = true
+						next = append(next, n)
+					}
+				}
+			}
+			frontier = next
+		}
+	}
+	return reach
+}
+
+// clusters groups nodes by the parent directory of their path
+func clusters(nodes []Node) []Cluster {
+	byDir := map[string][]string{}
+	for _, n := range nodes {
+		dir := path.Dir(n.Path)
+		byDir[dir] = append(byDir[dir], n.Name)
+	}
+	dirs := make([]string, 0, len(byDir))
+	for dir := range byDir {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	out := make([]Cluster, 0, len(dirs))
+	for _, dir := range dirs {
+		out = append(out, Cluster{Dir: dir, Packages: byDir[dir]})
+	}
+	return out
+}
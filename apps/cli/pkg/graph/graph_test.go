@@ -0,0 +1,161 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// sampleResult returns a chain app → ui → utils with a dev edge app → test,
+// a cycle ui ⇄ utils and an excluded legacy package depending on app
+func sampleResult() *types.AnalysisResult {
+	g := types.NewDependencyGraph("/repo", types.WorkspaceTypePnpm)
+	for name, dir := range map[string]string{
+		"@mono/app":    "apps/app",
+		"@mono/ui":     "packages/ui",
+		"@mono/utils":  "packages/utils",
+		"@mono/test":   "tools/test",
+		"@mono/legacy": "apps/legacy",
+	} {
+		g.Nodes[name] = types.NewPackageNode(name, "1.0.0", dir)
+	}
+	g.Nodes["@mono/legacy"].Excluded = true
+	g.Edges = []*types.DependencyEdge{
+		{From: "@mono/app", To: "@mono/ui", Type: types.DependencyTypeProduction},
+		{From: "@mono/app", To: "@mono/test", Type: types.DependencyTypeDevelopment},
+		{From: "@mono/ui", To: "@mono/utils", Type: types.DependencyTypeProduction},
+		{From: "@mono/utils", To: "@mono/ui", Type: types.DependencyTypePeer},
+		{From: "@mono/legacy", To: "@mono/app", Type: types.DependencyTypeProduction},
+	}
+	return &types.AnalysisResult{
+		Graph: g,
+		CircularDependencies: []*types.CircularDependencyInfo{
+			{Cycle: []string{"@mono/ui", "@mono/utils", "@mono/ui"}},
+		},
+	}
+}
+
+func nodeNames(v *View) string {
+	names := make([]string, len(v.Nodes))
+	for i, n := range v.Nodes {
+		names[i] = n.Name
+	}
+	return strings.Join(names, ",")
+}
+
+func TestBuild(t *testing.T) {
+	v, err := Build(sampleResult(), Options{})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if got := nodeNames(v); got != "@mono/app,@mono/legacy,@mono/test,@mono/ui,@mono/utils" {
+		t.Errorf("nodes = %s", got)
+	}
+	if len(v.Edges) != 5 {
+		t.Fatalf("edges = %d, want 5", len(v.Edges))
+	}
+
+	for _, n := range v.Nodes {
+		wantCycle := n.Name == "@mono/ui" || n.Name == "@mono/utils"
+		if n.InCycle != wantCycle {
+			t.Errorf("%s InCycle = %v, want %v", n.Name, n.InCycle, wantCycle)
+		}
+		if n.Excluded != (n.Name == "@mono/legacy") {
+			t.Errorf("%s Excluded = %v", n.Name, n.Excluded)
+		}
+	}
+	for _, e := range v.Edges {
+		wantCycle := (e.From == "@mono/ui" && e.To == "@mono/utils") || (e.From == "@mono/utils" && e.To == "@mono/ui")
+		if e.InCycle != wantCycle {
+			t.Errorf("%s → %s InCycle = %v, want %v", e.From, e.To, e.InCycle, wantCycle)
+		}
+	}
+	if v.Clusters != nil {
+		t.Errorf("clusters = %v, want none without ClusterDirs", v.Clusters)
+	}
+}
+
+func TestBuild_HideEdges(t *testing.T) {
+	v, err := Build(sampleResult(), Options{HideDev: true, HidePeer: true})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	for _, e := range v.Edges {
+		if e.Type == types.DependencyTypeDevelopment || e.Type == types.DependencyTypePeer {
+			t.Errorf("edge %s → %s (%s) should be hidden", e.From, e.To, e.Type)
+		}
+	}
+	if len(v.Edges) != 3 {
+		t.Errorf("edges = %d, want 3", len(v.Edges))
+	}
+	// Packages stay even when all their edges are hidden
+	if len(v.Nodes) != 5 {
+		t.Errorf("nodes = %d, want 5", len(v.Nodes))
+	}
+}
+
+func TestBuild_Focus(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  Options
+		nodes string
+	}{
+		{"unlimited", Options{Focus: "@mono/ui"}, "@mono/app,@mono/legacy,@mono/ui,@mono/utils"},
+		{"depth 1", Options{Focus: "@mono/ui", Depth: 1}, "@mono/app,@mono/ui,@mono/utils"},
+		{"depth 1 without dev", Options{Focus: "@mono/app", Depth: 1, HideDev: true}, "@mono/app,@mono/legacy,@mono/ui"},
+		{"leaf", Options{Focus: "@mono/test", Depth: 1}, "@mono/app,@mono/test"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := Build(sampleResult(), tt.opts)
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if got := nodeNames(v); got != tt.nodes {
+				t.Errorf("nodes = %s, want %s", got, tt.nodes)
+			}
+			for _, e := range v.Edges {
+				if !strings.Contains(tt.nodes, e.From) || !strings.Contains(tt.nodes, e.To) {
+					t.Errorf("edge %s → %s leaves the selection", e.From, e.To)
+				}
+			}
+			if v.Focus != tt.opts.Focus {
+				t.Errorf("Focus = %q", v.Focus)
+			}
+		})
+	}
+}
+
+func TestBuild_Clusters(t *testing.T) {
+	v, err := Build(sampleResult(), Options{ClusterDirs: true})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	want := []Cluster{
+		{Dir: "apps", Packages: []string{"@mono/app", "@mono/legacy"}},
+		{Dir: "packages", Packages: []string{"@mono/ui", "@mono/utils"}},
+		{Dir: "tools", Packages: []string{"@mono/test"}},
+	}
+	if len(v.Clusters) != len(want) {
+		t.Fatalf("clusters = %+v, want %+v", v.Clusters, want)
+	}
+	for i, c := range want {
+		got := v.Clusters[i]
+		if got.Dir != c.Dir || strings.Join(got.Packages, ",") != strings.Join(c.Packages, ",") {
+			t.Errorf("clusters[%d] = %+v, want %+v", i, got, c)
+		}
+	}
+}
+
+func TestBuild_Errors(t *testing.T) {
+	if _, err := Build(sampleResult(), Options{Focus: "@mono/missing"}); err == nil {
+		t.Error("Build() should fail for an unknown focus package")
+	}
+	if _, err := Build(sampleResult(), Options{Focus: "@mono/ui", Depth: -1}); err == nil {
+		t.Error("Build() should fail for a negative depth")
+	}
+	if _, err := Build(&types.AnalysisResult{}, Options{}); err == nil {
+		t.Error("Build() should fail without a graph")
+	}
+}
@@ -0,0 +1,206 @@
+// Package graph selects part of a workspace dependency graph for export.
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// MaxPaths is the number of paths listed when PathOptions.Limit is 0. The
+// number of simple paths grows exponentially with the graph, so it is never
+// unbounded.
+const MaxPaths = 1000
+
+// maxExpansions caps the partial paths Explain extends before it gives up on
+// finding further paths
+const maxExpansions = 10000
+
+// PathOptions selects which dependency paths to list
+type PathOptions struct {
+	Limit    int  // Maximum number of paths, shortest first; 0 lists up to MaxPaths
+	HideDev  bool // Ignore devDependencies
+	HidePeer bool // Ignore peerDependencies
+}
+
+// Explanation lists the dependency paths from one package to another
+type Explanation struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Paths     []Path `json:"paths"`     // Shortest first
+	Truncated bool   `json:"truncated"` // More paths exist than the limit
+}
+
+// Path is a chain of direct dependencies
+type Path struct {
+	Hops []Hop `json:"hops"`
+}
+
+// Hop is a direct dependency of one package on another
+type Hop struct {
+	From         string              `json:"from"`
+	To           string              `json:"to"`
+	Declarations []Declaration       `json:"declarations"`      // package.json entries behind the dependency
+	Imports      []types.ImportTrace `json:"imports,omitempty"` // Import statements behind the dependency
+}
+
+// Declaration is a dependency entry in package.json
+type Declaration struct {
+	Type         types.DependencyType `json:"type"`
+	VersionRange string               `json:"versionRange"`
+}
+
+// Explain lists the simple dependency paths from one package to another,
+// shortest first. Paths of equal length are ordered by package names. The
+// search stops at the path limit or after extending maxExpansions partial
+// paths, whichever comes first; the result is then marked truncated but
+// always includes a shortest path if there is one.
+func Explain(g *types.DependencyGraph, from, to string, opts PathOptions) (*Explanation, error) {
+	if g == nil {
+		return nil, fmt.Errorf("analysis result has no dependency graph")
+	}
+	for _, name := range []string{from, to} {
+		if _, ok := g.Nodes[name]; !ok {
+			return nil, fmt.Errorf("package %q not found in workspace", name)
+		}
+	}
+	if from == to {
+		return nil, fmt.Errorf("from and to are the same package")
+	}
+	if opts.Limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative")
+	}
+	limit := opts.Limit
+	if limit == 0 {
+		limit = MaxPaths
+	}
+
+	declarations := map[[2]string][]Declaration{}
+	out := map[string][]string{}
+	in := map[string][]string{}
+	for _, e := range g.Edges {
+		if (opts.HideDev && e.Type == types.DependencyTypeDevelopment) ||
+			(opts.HidePeer && e.Type == types.DependencyTypePeer) {
+			continue
+		}
+		key := [2]string{e.From, e.To}
+		if _, ok := declarations[key]; !ok {
+			out[e.From] = append(out[e.From], e.To)
+			in[e.To] = append(in[e.To], e.From)
+		}
+		declarations[key] = append(declarations[key], Declaration{Type: e.Type, VersionRange: e.VersionRange})
+	}
+	for _, next := range out {
+		sort.Strings(next)
+	}
+
+	// Only packages that can reach to are worth expanding
+	reaches := map[string]bool{to: true}
+	queue := []string{to}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, dependent := range in[name] {
+			if !reaches[dependent] {
+				reaches[dependent] = true
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	ex := &Explanation{From: from, To: to, Paths: []Path{}}
+	// Breadth-first over partial paths yields paths in order of length
+	partial := [][]string{{from}}
+	for expansions := 0; len(partial) > 0; expansions++ {
+		if expansions == maxExpansions {
+			ex.Truncated = true
+			if len(ex.Paths) == 0 {
+				ex.Paths = append(ex.Paths, toPath(shortestPath(out, from, to), declarations))
+			}
+			return ex, nil
+		}
+		p := partial[0]
+		partial = partial[1:]
+		for _, next := range out[p[len(p)-1]] {
+			if !reaches[next] || contains(p, next) {
+				continue
+			}
+			extended := append(append([]string{}, p...), next)
+			if next != to {
+				partial = append(partial, extended)
+				continue
+			}
+			if len(ex.Paths) == limit {
+				ex.Truncated = true
+				return ex, nil
+			}
+			ex.Paths = append(ex.Paths, toPath(extended, declarations))
+		}
+	}
+	return ex, nil
+}
+
+// shortestPath returns a shortest package sequence from one package to
+// another, which must be reachable
+func shortestPath(out map[string][]string, from, to string) []string {
+	previous := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 && previous[to] == "" {
+		name := queue[0]
+		queue = queue[1:]
+		for _, next := range out[name] {
+			if _, ok := previous[next]; !ok {
+				previous[next] = name
+				queue = append(queue, next)
+			}
+		}
+	}
+	names := []string{to}
+	for name := to; name != from; {
+		name = previous[name]
+		names = append([]string{name}, names...)
+	}
+	return names
+}
+
+// TraceImports sets the import statements of every hop using trace, which
+// is called once per dependency
+func (ex *Explanation) TraceImports(trace func(from, to string) []types.ImportTrace) {
+	cache := map[[2]string][]types.ImportTrace{}
+	for i := range ex.Paths {
+		for j := range ex.Paths[i].Hops {
+			hop := &ex.Paths[i].Hops[j]
+			key := [2]string{hop.From, hop.To}
+			imports, ok := cache[key]
+			if !ok {
+				imports = trace(hop.From, hop.To)
+				cache[key] = imports
+			}
+			hop.Imports = imports
+		}
+	}
+}
+
+// toPath builds the hops of a package sequence
+func toPath(names []string, declarations map[[2]string][]Declaration) Path {
+	p := Path{Hops: make([]Hop, 0, len(names)-1)}
+	for i := 0; i+1 < len(names); i++ {
+		p.Hops = append(p.Hops, Hop{
+			From:         names[i],
+			To:           names[i+1],
+			Declarations: declarations[[2]string{names[i], names[i+1]}],
+		})
+	}
+	return p
+}
+
+// contains reports whether names includes name
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
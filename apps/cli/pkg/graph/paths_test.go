@@ -0,0 +1,195 @@
+package graph
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// diamondGraph returns app → ui → core and app → api → core, a dev
+// dependency app → core, and ui declared both as dependency and peer of app
+func diamondGraph() *types.DependencyGraph {
+	g := types.NewDependencyGraph("/repo", types.WorkspaceTypePnpm)
+	for _, name := range []string{"@mono/app", "@mono/ui", "@mono/api", "@mono/core", "@mono/docs"} {
+		g.Nodes[name] = types.NewPackageNode(name, "1.0.0", "packages/"+strings.TrimPrefix(name, "@mono/"))
+	}
+	g.Edges = []*types.DependencyEdge{
+		{From: "@mono/app", To: "@mono/ui", Type: types.DependencyTypeProduction, VersionRange: "workspace:*"},
+		{From: "@mono/app", To: "@mono/ui", Type: types.DependencyTypePeer, VersionRange: "^1.0.0"},
+		{From: "@mono/app", To: "@mono/api", Type: types.DependencyTypeProduction, VersionRange: "workspace:*"},
+		{From: "@mono/app", To: "@mono/core", Type: types.DependencyTypeDevelopment, VersionRange: "workspace:*"},
+		{From: "@mono/ui", To: "@mono/core", Type: types.DependencyTypeProduction, VersionRange: "^1.0.0"},
+		{From: "@mono/api", To: "@mono/core", Type: types.DependencyTypeProduction, VersionRange: "^1.0.0"},
+		{From: "@mono/core", To: "@mono/ui", Type: types.DependencyTypeDevelopment, VersionRange: "workspace:*"},
+	}
+	return g
+}
+
+// pathNames returns each path as "a > b > c"
+func pathNames(ex *Explanation) []string {
+	var out []string
+	for _, p := range ex.Paths {
+		names := []string{p.Hops[0].From}
+		for _, h := range p.Hops {
+			names = append(names, h.To)
+		}
+		out = append(out, strings.Join(names, " > "))
+	}
+	return out
+}
+
+func TestExplain(t *testing.T) {
+	ex, err := Explain(diamondGraph(), "@mono/app", "@mono/core", PathOptions{})
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+
+	want := []string{
+		"@mono/app > @mono/core",
+		"@mono/app > @mono/api > @mono/core",
+		"@mono/app > @mono/ui > @mono/core",
+	}
+	if got := pathNames(ex); !reflect.DeepEqual(got, want) {
+		t.Errorf("paths = %v, want %v", got, want)
+	}
+	if ex.Truncated {
+		t.Error("Truncated = true, want false")
+	}
+
+	hop := ex.Paths[2].Hops[0]
+	wantDecl := []Declaration{
+		{Type: types.DependencyTypeProduction, VersionRange: "workspace:*"},
+		{Type: types.DependencyTypePeer, VersionRange: "^1.0.0"},
+	}
+	if !reflect.DeepEqual(hop.Declarations, wantDecl) {
+		t.Errorf("app → ui declarations = %+v, want %+v", hop.Declarations, wantDecl)
+	}
+}
+
+func TestExplain_Options(t *testing.T) {
+	ex, err := Explain(diamondGraph(), "@mono/app", "@mono/core", PathOptions{Limit: 2, HideDev: true})
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	want := []string{
+		"@mono/app > @mono/api > @mono/core",
+		"@mono/app > @mono/ui > @mono/core",
+	}
+	if got := pathNames(ex); !reflect.DeepEqual(got, want) {
+		t.Errorf("paths = %v, want %v", got, want)
+	}
+	if ex.Truncated {
+		t.Error("Truncated = true, want false with exactly Limit paths")
+	}
+
+	ex, err = Explain(diamondGraph(), "@mono/app", "@mono/core", PathOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if len(ex.Paths) != 1 || !ex.Truncated {
+		t.Errorf("Explain(Limit 1) = %d paths, truncated %v", len(ex.Paths), ex.Truncated)
+	}
+}
+
+// layeredGraph returns a source, layers of width packages each depending on
+// every package of the next layer, and a target: width^layers paths
+func layeredGraph(layers, width int) *types.DependencyGraph {
+	g := types.NewDependencyGraph("/repo", types.WorkspaceTypePnpm)
+	previous := []string{"source"}
+	for l := 0; l <= layers; l++ {
+		current := []string{"target"}
+		if l < layers {
+			current = nil
+			for i := 0; i < width; i++ {
+				current = append(current, fmt.Sprintf("l%02d-%d", l, i))
+			}
+		}
+		for _, to := range current {
+			for _, from := range previous {
+				g.Edges = append(g.Edges, &types.DependencyEdge{From: from, To: to, Type: types.DependencyTypeProduction})
+			}
+		}
+		previous = current
+	}
+	for _, e := range g.Edges {
+		for _, name := range []string{e.From, e.To} {
+			g.Nodes[name] = types.NewPackageNode(name, "1.0.0", name)
+		}
+	}
+	return g
+}
+
+func TestExplain_Bounded(t *testing.T) {
+	// 2^12 paths: without a limit only MaxPaths are listed
+	ex, err := Explain(layeredGraph(12, 2), "source", "target", PathOptions{})
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if len(ex.Paths) != MaxPaths || !ex.Truncated {
+		t.Errorf("Explain() = %d paths, truncated %v, want %d truncated", len(ex.Paths), ex.Truncated, MaxPaths)
+	}
+
+	// 4^30 paths: the search gives up before any path is complete but still
+	// returns a shortest path
+	ex, err = Explain(layeredGraph(30, 4), "source", "target", PathOptions{Limit: 5})
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if len(ex.Paths) != 1 || !ex.Truncated {
+		t.Fatalf("Explain() = %d paths, truncated %v, want 1 truncated", len(ex.Paths), ex.Truncated)
+	}
+	if hops := ex.Paths[0].Hops; len(hops) != 31 || hops[0].From != "source" || hops[30].To != "target" {
+		t.Errorf("path = %v, want a 31-hop path from source to target", pathNames(ex))
+	}
+}
+
+func TestExplain_NoPath(t *testing.T) {
+	ex, err := Explain(diamondGraph(), "@mono/core", "@mono/api", PathOptions{})
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if len(ex.Paths) != 0 {
+		t.Errorf("paths = %v, want none", pathNames(ex))
+	}
+}
+
+func TestExplain_Errors(t *testing.T) {
+	tests := []struct {
+		from, to string
+		opts     PathOptions
+		want     string
+	}{
+		{"@mono/app", "@mono/missing", PathOptions{}, "not found"},
+		{"@mono/app", "@mono/app", PathOptions{}, "same package"},
+		{"@mono/app", "@mono/core", PathOptions{Limit: -1}, "negative"},
+	}
+	for _, tt := range tests {
+		_, err := Explain(diamondGraph(), tt.from, tt.to, tt.opts)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Explain(%s, %s) error = %v, want %q", tt.from, tt.to, err, tt.want)
+		}
+	}
+}
+
+func TestExplain_TraceImports(t *testing.T) {
+	ex, err := Explain(diamondGraph(), "@mono/app", "@mono/core", PathOptions{})
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+
+	calls := 0
+	ex.TraceImports(func(from, to string) []types.ImportTrace {
+		calls++
+		return []types.ImportTrace{{FromPackage: from, ToPackage: to, FilePath: "src/index.ts", LineNumber: 1}}
+	})
+	// app → core, app → api, api → core, app → ui, ui → core
+	if calls != 5 {
+		t.Errorf("trace called %d times, want once per dependency (5)", calls)
+	}
+	if imp := ex.Paths[1].Hops[1].Imports; len(imp) != 1 || imp[0].FromPackage != "@mono/api" {
+		t.Errorf("api → core imports = %+v", imp)
+	}
+}
@@ -0,0 +1,101 @@
+// Package history records compact summaries of analysis runs and computes
+// health trends from them, for teams that track architecture health locally.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// DefaultFile is the history file, relative to the workspace root
+const DefaultFile = ".monoguard/history.jsonl"
+
+// Record summarizes one analysis run. Records are stored one per line.
+type Record struct {
+	Timestamp string `json:"timestamp"`        // RFC 3339, UTC
+	Commit    string `json:"commit,omitempty"` // Git HEAD of the analyzed workspace, if any
+
+	HealthScore int                   `json:"healthScore"`
+	Rating      types.HealthRating    `json:"rating,omitempty"`
+	Breakdown   *types.ScoreBreakdown `json:"breakdown,omitempty"`
+
+	Packages           int `json:"packages"`
+	Cycles             int `json:"cycles"`
+	Conflicts          int `json:"conflicts"`
+	BoundaryViolations int `json:"boundaryViolations"`
+}
+
+// FromResult summarizes an analysis result taken at the given time and commit
+func FromResult(result *types.AnalysisResult, commit string, at time.Time) Record {
+	r := Record{
+		Timestamp:          at.UTC().Format(time.RFC3339),
+		Commit:             commit,
+		HealthScore:        result.HealthScore,
+		Packages:           result.Packages,
+		Cycles:             len(result.CircularDependencies),
+		Conflicts:          len(result.VersionConflicts),
+		BoundaryViolations: len(result.BoundaryViolations),
+	}
+	if details := result.HealthScoreDetails; details != nil {
+		r.Rating = details.Rating
+		r.Breakdown = details.Breakdown
+	}
+	return r
+}
+
+// Append adds a record to the history file, creating it and its directory
+// if needed
+func Append(path string, r Record) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads the records of a history file in the order they were appended.
+// A missing file has no records; blank lines are skipped.
+func Load(path string) ([]Record, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal(text, &r); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid history record: %w", path, line, err)
+		}
+		records = append(records, r)
+	}
+	return records, scanner.Err()
+}
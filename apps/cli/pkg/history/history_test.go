@@ -0,0 +1,78 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+func TestFromResult(t *testing.T) {
+	result := &types.AnalysisResult{
+		HealthScore: 72,
+		HealthScoreDetails: &types.HealthScoreResult{
+			Overall:   72,
+			Rating:    types.HealthRatingGood,
+			Breakdown: &types.ScoreBreakdown{CircularScore: 60, ConflictScore: 80, DepthScore: 90, CouplingScore: 70},
+		},
+		Packages:             12,
+		CircularDependencies: []*types.CircularDependencyInfo{{}, {}},
+		VersionConflicts:     []*types.VersionConflictInfo{{}},
+	}
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	r := FromResult(result, "abc123", at)
+	if r.Timestamp != "2026-10-01T10:00:00Z" || r.Commit != "abc123" {
+		t.Errorf("Timestamp, Commit = %q, %q", r.Timestamp, r.Commit)
+	}
+	if r.HealthScore != 72 || r.Rating != types.HealthRatingGood || r.Breakdown.CircularScore != 60 {
+		t.Errorf("health = %d %s %+v", r.HealthScore, r.Rating, r.Breakdown)
+	}
+	if r.Packages != 12 || r.Cycles != 2 || r.Conflicts != 1 || r.BoundaryViolations != 0 {
+		t.Errorf("counts = %+v", r)
+	}
+
+	if r := FromResult(&types.AnalysisResult{HealthScore: 100}, "", at); r.Breakdown != nil || r.Rating != "" {
+		t.Errorf("record without health details = %+v", r)
+	}
+}
+
+func TestAppendLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".monoguard", "history.jsonl")
+
+	records, err := Load(path)
+	if err != nil || records != nil {
+		t.Fatalf("Load() of a missing file = %v, %v; want nil, nil", records, err)
+	}
+
+	for _, score := range []int{70, 75} {
+		if err := Append(path, Record{Timestamp: "2026-10-01T00:00:00Z", HealthScore: score}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 2 {
+		t.Errorf("history has %d lines, want 2:\n%s", lines, data)
+	}
+
+	records, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(records) != 2 || records[0].HealthScore != 70 || records[1].HealthScore != 75 {
+		t.Errorf("Load() = %+v, want scores 70, 75", records)
+	}
+
+	if err := os.WriteFile(path, append(data, []byte("\n{broken\n")...), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "history.jsonl:4") {
+		t.Errorf("Load() error = %v, want error at line 4", err)
+	}
+}
@@ -0,0 +1,90 @@
+package history
+
+import "github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+
+// Trend is the development of the health metrics over a window of records
+type Trend struct {
+	Records    int      `json:"records"` // Records in the window
+	From       string   `json:"from,omitempty"`
+	To         string   `json:"to,omitempty"`
+	FromCommit string   `json:"fromCommit,omitempty"`
+	ToCommit   string   `json:"toCommit,omitempty"`
+	Metrics    []Metric `json:"metrics"`
+}
+
+// Metric is one tracked value over time
+type Metric struct {
+	Name           string `json:"name"`
+	Values         []int  `json:"values"`     // Oldest first
+	Change         int    `json:"change"`     // Last value minus the first one
+	LastChange     int    `json:"lastChange"` // Last value minus the previous one
+	HigherIsBetter bool   `json:"higherIsBetter"`
+	Regressed      bool   `json:"regressed"` // The last run is worse than the previous one
+}
+
+// metricDefinitions are the tracked metrics in display order. Health
+// scores are better when higher, issue counts when lower.
+var metricDefinitions = []struct {
+	name           string
+	higherIsBetter bool
+	value          func(r Record) int
+}{
+	{"Health Score", true, func(r Record) int { return r.HealthScore }},
+	{"Circular Dependencies", true, func(r Record) int { return breakdown(r).CircularScore }},
+	{"Version Conflicts", true, func(r Record) int { return breakdown(r).ConflictScore }},
+	{"Dependency Depth", true, func(r Record) int { return breakdown(r).DepthScore }},
+	{"Package Coupling", true, func(r Record) int { return breakdown(r).CouplingScore }},
+	{"Cycles", false, func(r Record) int { return r.Cycles }},
+	{"Conflicts", false, func(r Record) int { return r.Conflicts }},
+	{"Boundary Violations", false, func(r Record) int { return r.BoundaryViolations }},
+}
+
+// NewTrend computes the trend of the last records, or of all records when
+// last is not positive. A metric regressed when the last run is worse than
+// the run before it.
+func NewTrend(records []Record, last int) *Trend {
+	if last > 0 && len(records) > last {
+		records = records[len(records)-last:]
+	}
+	t := &Trend{Records: len(records), Metrics: []Metric{}}
+	if len(records) == 0 {
+		return t
+	}
+	first, latest := records[0], records[len(records)-1]
+	t.From, t.To = first.Timestamp, latest.Timestamp
+	t.FromCommit, t.ToCommit = first.Commit, latest.Commit
+
+	for _, def := range metricDefinitions {
+		m := Metric{Name: def.name, HigherIsBetter: def.higherIsBetter}
+		for _, r := range records {
+			m.Values = append(m.Values, def.value(r))
+		}
+		n := len(m.Values)
+		m.Change = m.Values[n-1] - m.Values[0]
+		if n > 1 {
+			m.LastChange = m.Values[n-1] - m.Values[n-2]
+		}
+		m.Regressed = (def.higherIsBetter && m.LastChange < 0) || (!def.higherIsBetter && m.LastChange > 0)
+		t.Metrics = append(t.Metrics, m)
+	}
+	return t
+}
+
+// Regressed reports whether any metric regressed in the last run
+func (t *Trend) Regressed() bool {
+	for _, m := range t.Metrics {
+		if m.Regressed {
+			return true
+		}
+	}
+	return false
+}
+
+// breakdown returns the score breakdown of a record, or zero scores for
+// records without one
+func breakdown(r Record) types.ScoreBreakdown {
+	if r.Breakdown == nil {
+		return types.ScoreBreakdown{}
+	}
+	return *r.Breakdown
+}
@@ -0,0 +1,85 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// record creates a record with a health score, circular score and cycle count
+func record(ts string, score, circular, cycles int) Record {
+	return Record{
+		Timestamp:   ts,
+		Commit:      "c-" + ts,
+		HealthScore: score,
+		Breakdown:   &types.ScoreBreakdown{CircularScore: circular},
+		Cycles:      cycles,
+	}
+}
+
+func TestNewTrend(t *testing.T) {
+	records := []Record{
+		record("1", 50, 40, 3),
+		record("2", 70, 80, 1),
+		record("3", 80, 100, 0),
+		record("4", 75, 90, 1),
+	}
+
+	trend := NewTrend(records, 3)
+	if trend.Records != 3 || trend.From != "2" || trend.To != "4" || trend.FromCommit != "c-2" || trend.ToCommit != "c-4" {
+		t.Errorf("window = %d runs %s (%s) to %s (%s)", trend.Records, trend.From, trend.FromCommit, trend.To, trend.ToCommit)
+	}
+
+	metrics := map[string]Metric{}
+	for _, m := range trend.Metrics {
+		metrics[m.Name] = m
+	}
+	tests := []struct {
+		name       string
+		values     []int
+		change     int
+		lastChange int
+		regressed  bool
+	}{
+		{"Health Score", []int{70, 80, 75}, 5, -5, true},
+		{"Circular Dependencies", []int{80, 100, 90}, 10, -10, true},
+		{"Version Conflicts", []int{0, 0, 0}, 0, 0, false},
+		{"Cycles", []int{1, 0, 1}, 0, 1, true},
+		{"Boundary Violations", []int{0, 0, 0}, 0, 0, false},
+	}
+	for _, tt := range tests {
+		m, ok := metrics[tt.name]
+		if !ok {
+			t.Errorf("metric %q missing", tt.name)
+			continue
+		}
+		if len(m.Values) != len(tt.values) || m.Values[0] != tt.values[0] || m.Values[2] != tt.values[2] {
+			t.Errorf("%s values = %v, want %v", tt.name, m.Values, tt.values)
+		}
+		if m.Change != tt.change || m.LastChange != tt.lastChange || m.Regressed != tt.regressed {
+			t.Errorf("%s = change %d, last %d, regressed %v; want %d, %d, %v",
+				tt.name, m.Change, m.LastChange, m.Regressed, tt.change, tt.lastChange, tt.regressed)
+		}
+	}
+	if !trend.Regressed() {
+		t.Error("Regressed() = false, want true")
+	}
+
+	if all := NewTrend(records, 0); all.Records != 4 || all.From != "1" {
+		t.Errorf("NewTrend(records, 0) = %d runs from %s, want all 4", all.Records, all.From)
+	}
+	if NewTrend(records[:3], 0).Regressed() {
+		t.Error("improving runs should not regress")
+	}
+}
+
+func TestNewTrend_Empty(t *testing.T) {
+	trend := NewTrend(nil, 20)
+	if trend.Records != 0 || len(trend.Metrics) != 0 || trend.Regressed() {
+		t.Errorf("NewTrend(nil) = %+v", trend)
+	}
+	single := NewTrend([]Record{{HealthScore: 80}}, 20)
+	if single.Regressed() || single.Metrics[0].Change != 0 {
+		t.Errorf("single record trend = %+v", single.Metrics[0])
+	}
+}
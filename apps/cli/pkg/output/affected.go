@@ -0,0 +1,34 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/affected"
+)
+
+// writeAffectedText renders the affected packages in build order
+func writeAffectedText(w io.Writer, r *affected.Result) {
+	since := ""
+	if r.Base != "" {
+		since = " since " + r.Base
+	}
+	fmt.Fprintf(w, "📦 Affected Packages: %d (%d changed files%s)\n", len(r.Packages), r.ChangedFiles, since)
+	if len(r.Packages) == 0 {
+		fmt.Fprintf(w, "\n✅ No packages affected\n")
+	} else {
+		fmt.Fprintln(w)
+		for i, p := range r.Packages {
+			if p.Changed {
+				fmt.Fprintf(w, "   %d. %s (changed)\n", i+1, p.Name)
+			} else {
+				fmt.Fprintf(w, "   %d. %s (depends on %s)\n", i+1, p.Name, p.Via)
+			}
+		}
+	}
+
+	if len(r.UnmatchedFiles) > 0 {
+		fmt.Fprintf(w, "\n%d changed files are outside every package\n", len(r.UnmatchedFiles))
+	}
+}
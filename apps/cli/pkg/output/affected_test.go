@@ -0,0 +1,47 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/affected"
+)
+
+func TestFormatterText_Affected(t *testing.T) {
+	r := &affected.Result{
+		Base:         "origin/main",
+		ChangedFiles: 3,
+		Packages: []affected.Package{
+			{Name: "@mono/core", Path: "packages/core", Changed: true},
+			{Name: "@mono/app", Path: "apps/app", Via: "@mono/core", Distance: 1},
+		},
+		UnmatchedFiles: []string{"README.md"},
+	}
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, r); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"📦 Affected Packages: 2 (3 changed files since origin/main)",
+		"   1. @mono/core (changed)\n   2. @mono/app (depends on @mono/core)",
+		"1 changed files are outside every package",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatterText_AffectedNone(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, &affected.Result{}); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "✅ No packages affected") {
+		t.Errorf("unexpected output\n%s", buf.String())
+	}
+}
@@ -0,0 +1,112 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// writeAnalysisText renders an AnalysisResult as a human-readable report
+func writeAnalysisText(w io.Writer, r *types.AnalysisResult) {
+	fmt.Fprintf(w, "🔍 MonoGuard Analysis\n")
+	if r.Graph != nil {
+		fmt.Fprintf(w, "   Path: %s\n", r.Graph.RootPath)
+		fmt.Fprintf(w, "   Workspace: %s\n", r.Graph.WorkspaceType)
+	}
+	if r.ExcludedPackages > 0 {
+		fmt.Fprintf(w, "   Packages: %d (%d excluded)\n", r.Packages, r.ExcludedPackages)
+	} else {
+		fmt.Fprintf(w, "   Packages: %d\n", r.Packages)
+	}
+	if r.HealthScoreDetails != nil {
+		fmt.Fprintf(w, "   Health Score: %d/100 (%s)\n", r.HealthScore, r.HealthScoreDetails.Rating)
+	} else {
+		fmt.Fprintf(w, "   Health Score: %d/100\n", r.HealthScore)
+	}
+
+	fmt.Fprintln(w)
+	if len(r.CircularDependencies) == 0 {
+		fmt.Fprintf(w, "✅ No circular dependencies\n")
+	} else {
+		fmt.Fprintf(w, "🔄 Circular Dependencies (%d)\n", len(r.CircularDependencies))
+		for _, cycle := range r.CircularDependencies {
+			fmt.Fprintf(w, "   [%s] %s\n", cycle.Severity, strings.Join(cycle.Cycle, " → "))
+			if len(cycle.Owners) > 0 {
+				fmt.Fprintf(w, "      Owners: %s\n", strings.Join(cycle.Owners, " "))
+			}
+			if cycle.QuickFix != nil {
+				fmt.Fprintf(w, "      Fix: %s (%s effort, %s)\n",
+					cycle.QuickFix.StrategyName, cycle.QuickFix.Effort, cycle.QuickFix.EstimatedTime)
+			}
+			for _, trace := range cycle.ImportTraces {
+				fmt.Fprintf(w, "      %s:%d %s\n", trace.FilePath, trace.LineNumber, trace.Statement)
+			}
+		}
+	}
+
+	fmt.Fprintln(w)
+	if len(r.VersionConflicts) == 0 {
+		fmt.Fprintf(w, "✅ No version conflicts\n")
+	} else {
+		fmt.Fprintf(w, "⚠️  Version Conflicts (%d)\n", len(r.VersionConflicts))
+		for _, conflict := range r.VersionConflicts {
+			versions := make([]string, 0, len(conflict.ConflictingVersions))
+			for _, v := range conflict.ConflictingVersions {
+				versions = append(versions, fmt.Sprintf("%s (%s)", v.Version, strings.Join(v.Packages, ", ")))
+			}
+			fmt.Fprintf(w, "   [%s] %s: %s\n", conflict.Severity, conflict.PackageName, strings.Join(versions, " vs "))
+			if len(conflict.Owners) > 0 {
+				fmt.Fprintf(w, "      Owners: %s\n", strings.Join(conflict.Owners, " "))
+			}
+		}
+	}
+
+	if len(r.BoundaryViolations) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "🚧 Boundary Violations (%d)\n", len(r.BoundaryViolations))
+		for _, v := range r.BoundaryViolations {
+			fmt.Fprintf(w, "   %s\n", v.Message)
+		}
+	}
+
+	if len(r.PluginFindings) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "🧩 Plugin Findings (%d)\n", len(r.PluginFindings))
+		for _, f := range r.PluginFindings {
+			fmt.Fprintf(w, "   [%s] %s\n", f.Severity, pluginMessage(f))
+			if loc := location(f.File, f.Line); loc != "" {
+				fmt.Fprintf(w, "      %s\n", loc)
+			} else if f.Package != "" {
+				fmt.Fprintf(w, "      %s\n", f.Package)
+			}
+		}
+	}
+	if len(r.PluginErrors) > 0 {
+		fmt.Fprintln(w)
+		for _, e := range r.PluginErrors {
+			fmt.Fprintf(w, "❌ Plugin %s failed: %s\n", e.Plugin, e.Message)
+		}
+	}
+
+	if len(r.CustomRuleViolations) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "📏 Custom Rule Violations (%d)\n", len(r.CustomRuleViolations))
+		for _, v := range r.CustomRuleViolations {
+			fmt.Fprintf(w, "   [%s] %s\n", v.Severity, customRuleMessage(v))
+		}
+	}
+
+	if r.Ownership != nil && len(r.Ownership.CrossTeamEdges) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "👥 Cross-team dependencies: %d (see monoguard owners)\n", len(r.Ownership.CrossTeamEdges))
+	}
+
+	if r.FixSummary != nil && r.FixSummary.TotalCircularDependencies > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "🔧 Estimated fix time: %s (%d quick wins)\n",
+			r.FixSummary.TotalEstimatedFixTime, r.FixSummary.QuickWinsCount)
+	}
+}
@@ -0,0 +1,154 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// sampleAnalysisResult returns a result with one cycle and one version conflict.
+func sampleAnalysisResult() *types.AnalysisResult {
+	graph := types.NewDependencyGraph("/repo", types.WorkspaceTypePnpm)
+	return &types.AnalysisResult{
+		HealthScore: 72,
+		HealthScoreDetails: &types.HealthScoreResult{
+			Overall: 72,
+			Rating:  types.HealthRatingGood,
+		},
+		Packages: 3,
+		Graph:    graph,
+		CircularDependencies: []*types.CircularDependencyInfo{
+			{
+				Cycle:    []string{"@mono/a", "@mono/b", "@mono/a"},
+				Type:     types.CircularTypeDirect,
+				Severity: types.CircularSeverityWarning,
+				QuickFix: &types.QuickFixSummary{
+					StrategyName:  "Extract Shared Module",
+					Effort:        types.EffortMedium,
+					EstimatedTime: "30-60 minutes",
+				},
+				ImportTraces: []types.ImportTrace{
+					{FilePath: "packages/a/src/index.ts", LineNumber: 1, Statement: "import { b } from '@mono/b'"},
+				},
+			},
+		},
+		VersionConflicts: []*types.VersionConflictInfo{
+			{
+				PackageName: "lodash",
+				Severity:    types.ConflictSeverityCritical,
+				ConflictingVersions: []*types.ConflictingVersion{
+					{Version: "^3.10.0", Packages: []string{"@mono/b"}},
+					{Version: "^4.17.21", Packages: []string{"@mono/a"}},
+				},
+			},
+		},
+		BoundaryViolations: []*types.BoundaryViolation{
+			{
+				From:    "@mono/a",
+				To:      "@mono/b",
+				Message: `@mono/a (layer "libs") must not depend on @mono/b (layer "apps")`,
+			},
+		},
+	}
+}
+
+func TestFormatterText_AnalysisResult(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, sampleAnalysisResult()); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"MonoGuard Analysis",
+		"Path: /repo",
+		"Workspace: pnpm",
+		"Packages: 3",
+		"Health Score: 72/100 (good)",
+		"Circular Dependencies (1)",
+		"@mono/a → @mono/b → @mono/a",
+		"Fix: Extract Shared Module",
+		"packages/a/src/index.ts:1",
+		"Version Conflicts (1)",
+		"lodash: ^3.10.0 (@mono/b) vs ^4.17.21 (@mono/a)",
+		"Boundary Violations (1)",
+		`@mono/a (layer "libs") must not depend on @mono/b (layer "apps")`,
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q\nOutput:\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatterText_PluginResults(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, addPluginResults(sampleGraphResult())); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"🧩 Plugin Findings (2)",
+		"[error] deps/no-lodash: lodash is banned\n      packages/a/src/index.ts:3",
+		"[info] docs/readme: add a README\n      @mono/b",
+		"❌ Plugin slow failed: timed out after 30s",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q\nOutput:\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatterText_CustomRuleViolations(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, addCustomRuleViolations(sampleGraphResult())); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"📏 Custom Rule Violations (2)",
+		"[error] apps-are-leaves: Apps must not be depended on (@mono/a → @mono/b)",
+		"[warn] small: workspace",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q\nOutput:\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatterText_CleanAnalysisResult(t *testing.T) {
+	var buf bytes.Buffer
+	result := &types.AnalysisResult{HealthScore: 100, Packages: 2}
+	if err := NewFormatter("text").PrintTo(&buf, result); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"No circular dependencies", "No version conflicts"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q\nOutput:\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatterJSON_AnalysisResult(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("json").PrintTo(&buf, sampleAnalysisResult()); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	var decoded types.AnalysisResult
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	if decoded.HealthScore != 72 || len(decoded.CircularDependencies) != 1 {
+		t.Errorf("decoded result mismatch: %+v", decoded)
+	}
+}
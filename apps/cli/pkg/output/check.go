@@ -0,0 +1,64 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// writeCheckText renders a CheckResult as a human-readable report
+func writeCheckText(w io.Writer, r *types.CheckResult) {
+	if r.Passed {
+		fmt.Fprintf(w, "✅ MonoGuard Check: PASSED\n")
+	} else {
+		fmt.Fprintf(w, "❌ MonoGuard Check: FAILED\n")
+	}
+	fmt.Fprintf(w, "   Health Score: %d/100\n", r.HealthScore)
+	fmt.Fprintf(w, "   Errors: %d, Warnings: %d\n", len(r.Errors), len(r.Warnings))
+	if r.Baseline != nil {
+		fmt.Fprintf(w, "   Baseline: %d known issues ignored (%s)\n", r.Baseline.Known, r.Baseline.File)
+	}
+
+	if len(r.Errors) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Errors:\n")
+		for _, e := range r.Errors {
+			fmt.Fprintf(w, "   [%s] %s\n", e.Code, e.Message)
+			if loc := location(e.File, e.Line); loc != "" {
+				fmt.Fprintf(w, "      at %s\n", loc)
+			}
+		}
+	}
+
+	if len(r.Warnings) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Warnings:\n")
+		for _, warning := range r.Warnings {
+			fmt.Fprintf(w, "   [%s] %s\n", warning.Code, warning.Message)
+			if loc := location(warning.File, warning.Line); loc != "" {
+				fmt.Fprintf(w, "      at %s\n", loc)
+			}
+		}
+	}
+
+	if r.Baseline != nil && len(r.Baseline.Fixed) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Fixed since baseline (run \"monoguard baseline\" to remove them):\n")
+		for _, fixed := range r.Baseline.Fixed {
+			fmt.Fprintf(w, "   ✓ %s\n", fixed)
+		}
+	}
+}
+
+// location formats a file/line pair, omitting unknown parts
+func location(file string, line int) string {
+	if file == "" {
+		return ""
+	}
+	if line > 0 {
+		return fmt.Sprintf("%s:%d", file, line)
+	}
+	return file
+}
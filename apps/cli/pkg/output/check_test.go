@@ -0,0 +1,136 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// sampleCheckResult returns a failing result with one error and one warning.
+func sampleCheckResult() *types.CheckResult {
+	return &types.CheckResult{
+		Passed:      false,
+		HealthScore: 64,
+		Errors: []types.ValidationError{
+			{
+				Code:    types.CheckCodeCircularDetected,
+				Message: "Circular dependency found: @mono/a -> @mono/b -> @mono/a",
+				File:    "packages/a/src/index.ts",
+				Line:    3,
+			},
+		},
+		Warnings: []types.ValidationWarning{
+			{
+				Code:    types.CheckCodeBoundaryViolation,
+				Message: `@mono/ui (layer "libs") must not depend on @mono/web (layer "apps")`,
+				File:    "packages/ui/package.json",
+			},
+		},
+	}
+}
+
+func TestFormatterText_CheckResult(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, sampleCheckResult()); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"MonoGuard Check: FAILED",
+		"Health Score: 64/100",
+		"Errors: 1, Warnings: 1",
+		"[CIRCULAR_DETECTED] Circular dependency found",
+		"at packages/a/src/index.ts:3",
+		"[BOUNDARY_VIOLATION]",
+		"at packages/ui/package.json",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("text output missing %q\nOutput:\n%s", want, output)
+		}
+	}
+}
+
+// TestFormatterText_CheckWarningLine verifies warnings keep their line, e.g.
+// for a cycle whose rule is set to warn
+func TestFormatterText_CheckWarningLine(t *testing.T) {
+	check := types.NewCheckResult(80)
+	check.Warnings = []types.ValidationWarning{{
+		Code:    types.CheckCodeCircularDetected,
+		Message: "Circular dependency found: @mono/a -> @mono/b -> @mono/a",
+		File:    "packages/a/src/index.ts",
+		Line:    7,
+	}}
+
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, check); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "at packages/a/src/index.ts:7") {
+		t.Errorf("text output missing the warning line\nOutput:\n%s", buf.String())
+	}
+
+	run := decodeSARIF(t, check).Runs[0]
+	if region := run.Results[0].Locations[0].PhysicalLocation.Region; region == nil || region.StartLine != 7 {
+		t.Errorf("SARIF warning region = %+v, want line 7", region)
+	}
+}
+
+func TestFormatterText_CheckResultPassed(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, types.NewCheckResult(100)); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "MonoGuard Check: PASSED") {
+		t.Errorf("text output should report PASSED: %q", output)
+	}
+	if strings.Contains(output, "Errors:\n") {
+		t.Errorf("text output should not list errors when passed: %q", output)
+	}
+}
+
+func TestFormatterText_CheckResultBaseline(t *testing.T) {
+	r := types.NewCheckResult(80)
+	r.Baseline = &types.BaselineStatus{
+		File:  ".monoguard-baseline.json",
+		Known: 2,
+		Fixed: []string{"version conflict lodash (^4.17.0 vs ^4.18.0)"},
+	}
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, r); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"Baseline: 2 known issues ignored (.monoguard-baseline.json)",
+		"Fixed since baseline",
+		"   ✓ version conflict lodash (^4.17.0 vs ^4.18.0)",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("text output missing %q\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatterJSON_CheckResult(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("json").PrintTo(&buf, sampleCheckResult()); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	var parsed types.CheckResult
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if parsed.Passed || len(parsed.Errors) != 1 || parsed.Errors[0].Line != 3 {
+		t.Errorf("unexpected round-trip result: %+v", parsed)
+	}
+}
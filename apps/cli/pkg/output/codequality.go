@@ -0,0 +1,71 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// codeQualitySeverities maps finding levels to GitLab Code Quality severities
+var codeQualitySeverities = map[string]string{
+	"error":   "critical",
+	"warning": "major",
+	"note":    "info",
+}
+
+type codeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    codeQualityLocation `json:"location"`
+}
+
+type codeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines codeQualityLines `json:"lines"`
+}
+
+type codeQualityLines struct {
+	Begin int `json:"begin"`
+}
+
+// writeCodeQuality renders findings as a GitLab Code Quality report.
+// Every issue needs a location, so findings without a file are reported
+// against the root package.json. Data without findings produces an empty report.
+func writeCodeQuality(w io.Writer, data interface{}) error {
+	issues := []codeQualityIssue{}
+
+	if set, ok := collectFindings(data); ok {
+		for _, f := range set.findings {
+			file, line := f.file, f.line
+			if file == "" {
+				file = "package.json"
+			}
+			if line < 1 {
+				line = 1
+			}
+			sum := md5.Sum([]byte(f.rule + "\x00" + f.pkg + "\x00" + f.message + "\x00" + file))
+			issues = append(issues, codeQualityIssue{
+				Description: f.message,
+				CheckName:   f.rule,
+				Fingerprint: hex.EncodeToString(sum[:]),
+				Severity:    codeQualitySeverities[f.level],
+				Location: codeQualityLocation{
+					Path:  file,
+					Lines: codeQualityLines{Begin: line},
+				},
+			})
+		}
+	}
+
+	b, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(w, string(b))
+	return nil
+}
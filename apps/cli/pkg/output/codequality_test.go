@@ -0,0 +1,49 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestFormatterCodeQuality_AnalysisResult(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("gitlab-codequality").PrintTo(&buf, sampleGraphResult()); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	var issues []codeQualityIssue
+	if err := json.Unmarshal(buf.Bytes(), &issues); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if len(issues) != 5 {
+		t.Fatalf("issues = %d, want 5", len(issues))
+	}
+
+	first := issues[0]
+	if first.CheckName != ruleCircularDependency || first.Severity != "major" ||
+		first.Location.Path != "packages/a/src/index.ts" || first.Location.Lines.Begin != 1 {
+		t.Errorf("first issue = %+v", first)
+	}
+	if issues[2].Severity != "critical" || issues[2].Location.Lines.Begin != 1 {
+		t.Errorf("version conflict issue = %+v, want critical at line 1", issues[2])
+	}
+
+	seen := map[string]bool{}
+	for _, issue := range issues {
+		if len(issue.Fingerprint) != 32 || seen[issue.Fingerprint] {
+			t.Errorf("fingerprint %q should be a unique MD5 hex digest", issue.Fingerprint)
+		}
+		seen[issue.Fingerprint] = true
+	}
+}
+
+func TestFormatterCodeQuality_NoFindings(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("gitlab-codequality").PrintTo(&buf, sampleFixReport()); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	if buf.String() != "[]\n" {
+		t.Errorf("output = %q, want an empty array", buf.String())
+	}
+}
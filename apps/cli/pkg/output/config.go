@@ -0,0 +1,23 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+)
+
+// writeResolvedText renders a resolved configuration as annotated YAML
+func writeResolvedText(w io.Writer, r *config.Resolved) {
+	fmt.Fprintf(w, "# Resolved from %s\n", r.File)
+	for _, file := range r.Files[:len(r.Files)-1] {
+		fmt.Fprintf(w, "#   extends %s\n", file)
+	}
+	text, err := r.YAML()
+	if err != nil {
+		fmt.Fprintf(w, "# %v\n", err)
+		return
+	}
+	fmt.Fprint(w, text)
+}
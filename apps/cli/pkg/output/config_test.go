@@ -0,0 +1,44 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+)
+
+func TestFormatterText_Resolved(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	file := filepath.Join(dir, config.FileName)
+	if err := os.WriteFile(base, []byte("exclude: [\"@mono/fixture\"]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte("extends: ./base.yaml\nthresholds:\n  healthScore: 70\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	resolved, err := config.Resolve(file)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, resolved); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{
+		"# Resolved from " + file,
+		"#   extends " + base,
+		"- '@mono/fixture' # from base.yaml",
+		"healthScore: 70 # from .monoguard.yaml",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("text output missing %q\nOutput:\n%s", want, output)
+		}
+	}
+}
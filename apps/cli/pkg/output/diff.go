@@ -0,0 +1,153 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+)
+
+// writeDiffText renders the changes between two analyses
+func writeDiffText(w io.Writer, d *analysis.Diff) {
+	fmt.Fprintf(w, "📊 MonoGuard Diff: %s → %s\n", d.Base, d.Head)
+	fmt.Fprintf(w, "   Health Score: %d → %d (%s)\n", d.HealthScore.Base, d.HealthScore.Head, signed(d.HealthScore.Delta))
+	for _, f := range d.Factors {
+		if f.Delta != 0 {
+			fmt.Fprintf(w, "      %s: %d → %d (%s, %s overall)\n",
+				f.Name, f.Base, f.Head, signed(f.Delta), signed(f.WeightedDelta))
+		}
+	}
+
+	if d.Unchanged() {
+		fmt.Fprintf(w, "\n✅ No changes\n")
+		return
+	}
+
+	if len(d.NewCycles) > 0 {
+		fmt.Fprintf(w, "\n🔄 New Circular Dependencies (%d)\n", len(d.NewCycles))
+		for _, c := range d.NewCycles {
+			fmt.Fprintf(w, "   + [%s] %s\n", c.Severity, strings.Join(c.Cycle, " → "))
+		}
+	}
+	if len(d.ChangedCycles) > 0 {
+		fmt.Fprintf(w, "\n🔀 Changed Circular Dependencies (%d)\n", len(d.ChangedCycles))
+		for _, c := range d.ChangedCycles {
+			fmt.Fprintf(w, "   ~ %s\n     → %s\n", strings.Join(c.Before, " → "), strings.Join(c.After, " → "))
+		}
+	}
+	if len(d.ResolvedCycles) > 0 {
+		fmt.Fprintf(w, "\n✅ Resolved Circular Dependencies (%d)\n", len(d.ResolvedCycles))
+		for _, c := range d.ResolvedCycles {
+			fmt.Fprintf(w, "   - %s\n", strings.Join(c.Cycle, " → "))
+		}
+	}
+
+	if len(d.NewConflicts) > 0 {
+		fmt.Fprintf(w, "\n⚠️  New Version Conflicts (%d)\n", len(d.NewConflicts))
+		for _, c := range d.NewConflicts {
+			fmt.Fprintf(w, "   + %s: %s\n", c.PackageName, strings.Join(c.Versions, " vs "))
+		}
+	}
+	if len(d.ResolvedConflicts) > 0 {
+		fmt.Fprintf(w, "\n✅ Resolved Version Conflicts (%d)\n", len(d.ResolvedConflicts))
+		for _, c := range d.ResolvedConflicts {
+			fmt.Fprintf(w, "   - %s: %s\n", c.PackageName, strings.Join(c.Versions, " vs "))
+		}
+	}
+
+	if len(d.AddedEdges) > 0 || len(d.RemovedEdges) > 0 {
+		fmt.Fprintf(w, "\n🔗 Dependencies (+%d, -%d)\n", len(d.AddedEdges), len(d.RemovedEdges))
+		for _, e := range d.AddedEdges {
+			fmt.Fprintf(w, "   + %s → %s (%s)\n", e.From, e.To, e.Type)
+		}
+		for _, e := range d.RemovedEdges {
+			fmt.Fprintf(w, "   - %s → %s (%s)\n", e.From, e.To, e.Type)
+		}
+	}
+
+	fmt.Fprintln(w)
+	if d.Regressed() {
+		fmt.Fprintf(w, "❌ Regressions found\n")
+	} else {
+		fmt.Fprintf(w, "✅ No regressions\n")
+	}
+}
+
+// RenderDiffMarkdown renders the changes between two analyses as Markdown
+// for a PR comment
+func RenderDiffMarkdown(d *analysis.Diff) string {
+	var b strings.Builder
+	status := "✅ No regressions"
+	if d.Regressed() {
+		status = "❌ Regressions found"
+	}
+	fmt.Fprintf(&b, "## 📊 MonoGuard Diff\n\n%s · `%s` → `%s`\n\n", status, d.Base, d.Head)
+
+	fmt.Fprintf(&b, "| Metric | Base | Head | Change |\n|---|---:|---:|---:|\n")
+	fmt.Fprintf(&b, "| **Health Score** | %d | %d | %s |\n", d.HealthScore.Base, d.HealthScore.Head, signed(d.HealthScore.Delta))
+	for _, f := range d.Factors {
+		fmt.Fprintf(&b, "| %s | %d | %d | %s |\n", escapeTableCell(f.Name), f.Base, f.Head, signed(f.Delta))
+	}
+
+	section := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n### %s (%d)\n\n", title, len(items))
+		for _, item := range items {
+			fmt.Fprintf(&b, "- %s\n", item)
+		}
+	}
+
+	var items []string
+	for _, c := range d.NewCycles {
+		items = append(items, fmt.Sprintf("**[%s]** `%s`", c.Severity, strings.Join(c.Cycle, " → ")))
+	}
+	section("🔄 New Circular Dependencies", items)
+
+	items = nil
+	for _, c := range d.ChangedCycles {
+		items = append(items, fmt.Sprintf("`%s` → `%s`", strings.Join(c.Before, " → "), strings.Join(c.After, " → ")))
+	}
+	section("🔀 Changed Circular Dependencies", items)
+
+	items = nil
+	for _, c := range d.ResolvedCycles {
+		items = append(items, fmt.Sprintf("`%s`", strings.Join(c.Cycle, " → ")))
+	}
+	section("✅ Resolved Circular Dependencies", items)
+
+	items = nil
+	for _, c := range d.NewConflicts {
+		items = append(items, fmt.Sprintf("`%s`: %s", c.PackageName, codeList(c.Versions)))
+	}
+	section("⚠️ New Version Conflicts", items)
+
+	items = nil
+	for _, c := range d.ResolvedConflicts {
+		items = append(items, fmt.Sprintf("`%s`: %s", c.PackageName, codeList(c.Versions)))
+	}
+	section("✅ Resolved Version Conflicts", items)
+
+	items = nil
+	for _, e := range d.AddedEdges {
+		items = append(items, fmt.Sprintf("➕ `%s` → `%s` (%s)", e.From, e.To, e.Type))
+	}
+	for _, e := range d.RemovedEdges {
+		items = append(items, fmt.Sprintf("➖ `%s` → `%s` (%s)", e.From, e.To, e.Type))
+	}
+	section("🔗 Dependency Changes", items)
+
+	b.WriteString("\n<sub>Generated by MonoGuard</sub>\n")
+	return b.String()
+}
+
+// signed formats a change with an explicit sign, or ±0
+func signed(n int) string {
+	if n == 0 {
+		return "±0"
+	}
+	return fmt.Sprintf("%+d", n)
+}
@@ -0,0 +1,111 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+func sampleDiff() *analysis.Diff {
+	return &analysis.Diff{
+		Base:        "main",
+		Head:        "working tree",
+		HealthScore: analysis.ScoreChange{Base: 90, Head: 75, Delta: -15},
+		Factors: []analysis.FactorChange{
+			{Name: "Circular Dependencies", ScoreChange: analysis.ScoreChange{Base: 100, Head: 60, Delta: -40}, WeightedDelta: -12},
+			{Name: "Version Consistency", ScoreChange: analysis.ScoreChange{Base: 80, Head: 80}},
+		},
+		NewCycles: []analysis.DiffCycle{
+			{ID: "a→b", Cycle: []string{"@mono/a", "@mono/b", "@mono/a"}, Severity: types.CircularSeverityWarning},
+		},
+		ResolvedConflicts: []analysis.ConflictSummary{
+			{PackageName: "lodash", Versions: []string{"^4.17.0", "^4.18.0"}},
+		},
+		AddedEdges: []analysis.EdgeSummary{
+			{From: "@mono/b", To: "@mono/a", Type: types.DependencyTypeProduction},
+		},
+	}
+}
+
+func TestFormatterText_Diff(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, sampleDiff()); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"📊 MonoGuard Diff: main → working tree",
+		"Health Score: 90 → 75 (-15)",
+		"Circular Dependencies: 100 → 60 (-40, -12 overall)",
+		"+ [warning] @mono/a → @mono/b → @mono/a",
+		"- lodash: ^4.17.0 vs ^4.18.0",
+		"🔗 Dependencies (+1, -0)",
+		"+ @mono/b → @mono/a (production)",
+		"❌ Regressions found",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Version Consistency") {
+		t.Errorf("unchanged factor should be omitted\n%s", output)
+	}
+}
+
+func TestFormatterText_DiffUnchanged(t *testing.T) {
+	d := &analysis.Diff{
+		Base:        "a.json",
+		Head:        "b.json",
+		HealthScore: analysis.ScoreChange{Base: 100, Head: 100},
+	}
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, d); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "(±0)") || !strings.Contains(output, "✅ No changes") {
+		t.Errorf("unexpected output\n%s", output)
+	}
+	if strings.Contains(output, "regressions") {
+		t.Errorf("unchanged diff should not report regressions\n%s", output)
+	}
+}
+
+func TestRenderDiffMarkdown(t *testing.T) {
+	md := RenderDiffMarkdown(sampleDiff())
+
+	wantContains := []string{
+		"## 📊 MonoGuard Diff",
+		"❌ Regressions found · `main` → `working tree`",
+		"| **Health Score** | 90 | 75 | -15 |",
+		"| Version Consistency | 80 | 80 | ±0 |",
+		"### 🔄 New Circular Dependencies (1)",
+		"- **[warning]** `@mono/a → @mono/b → @mono/a`",
+		"### ✅ Resolved Version Conflicts (1)",
+		"- ➕ `@mono/b` → `@mono/a` (production)",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "New Version Conflicts") {
+		t.Errorf("empty sections should be omitted\n%s", md)
+	}
+}
+
+func TestFormatterMarkdown_Diff(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("markdown").PrintTo(&buf, sampleDiff()); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	if buf.String() != RenderDiffMarkdown(sampleDiff()) {
+		t.Errorf("markdown format should render the diff report\n%s", buf.String())
+	}
+}
@@ -0,0 +1,29 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+)
+
+// writeCheckupText renders the workspace diagnostics grouped by file
+func writeCheckupText(w io.Writer, c *analysis.Checkup) {
+	fmt.Fprintf(w, "🩺 MonoGuard Doctor: %d packages in %s workspace\n", c.Packages, c.WorkspaceType)
+	if len(c.Diagnostics) == 0 {
+		fmt.Fprintf(w, "\n✅ No workspace problems found\n")
+		return
+	}
+
+	fmt.Fprintln(w)
+	file := ""
+	for _, d := range c.Diagnostics {
+		if d.File != file {
+			file = d.File
+			fmt.Fprintf(w, "   %s\n", file)
+		}
+		fmt.Fprintf(w, "      [%s] %s\n", d.Kind, d.Message)
+	}
+	fmt.Fprintf(w, "\n❌ %d workspace problems found\n", len(c.Diagnostics))
+}
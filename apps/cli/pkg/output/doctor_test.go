@@ -0,0 +1,50 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+func TestFormatterText_Checkup(t *testing.T) {
+	c := &analysis.Checkup{
+		WorkspaceType: types.WorkspaceTypePnpm,
+		Packages:      2,
+		Diagnostics: []types.Diagnostic{
+			{File: "packages/c/package.json", Kind: types.DiagnosticMissingName, Message: "package.json has no name"},
+			{File: "packages/c/package.json", Kind: types.DiagnosticDuplicateName, Message: "duplicate"},
+			{File: "pnpm-workspace.yaml", Kind: types.DiagnosticUnmatchedPattern, Message: `workspace pattern "libs/*" matches no package`},
+		},
+	}
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, c); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"🩺 MonoGuard Doctor: 2 packages in pnpm workspace",
+		"   packages/c/package.json\n      [missing-name] package.json has no name\n      [duplicate-name] duplicate\n",
+		"   pnpm-workspace.yaml\n      [unmatched-pattern]",
+		"❌ 3 workspace problems found",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatterText_CheckupClean(t *testing.T) {
+	var buf bytes.Buffer
+	c := &analysis.Checkup{WorkspaceType: types.WorkspaceTypeNpm, Packages: 3, Diagnostics: []types.Diagnostic{}}
+	if err := NewFormatter("text").PrintTo(&buf, c); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "✅ No workspace problems found") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
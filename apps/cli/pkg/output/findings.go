@@ -0,0 +1,273 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/watch"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// workspaceCase names findings that belong to the workspace rather than a package
+const workspaceCase = "(workspace)"
+
+// finding is a single issue in the shape shared by the CI formats
+// (junit, github, gitlab-codequality)
+type finding struct {
+	rule    string // SARIF rule ID, e.g. circular-dependency
+	level   string // error, warning or note
+	pkg     string // Workspace package, or "" for the workspace
+	message string
+	file    string // Relative to the workspace root; "" if unknown
+	line    int    // 1-based; 0 if unknown
+}
+
+// findingSet is the findings of a result together with the packages and
+// rules that were evaluated, so formats can also report what passed
+type findingSet struct {
+	packages []string
+	rules    []string
+	findings []finding
+}
+
+// collectFindings extracts findings from analysis, check and watch results.
+// It returns false for data that has no findings (e.g. a fix report).
+func collectFindings(data interface{}) (*findingSet, bool) {
+	switch v := data.(type) {
+	case *types.AnalysisResult:
+		return analysisFindings(v), true
+	case *types.CheckResult:
+		return checkFindings(v), true
+	case *watch.Event:
+		return watchFindings(v), true
+	}
+	return nil, false
+}
+
+// analysisFindings reports every cycle, version conflict and boundary violation
+// once per involved package, located at that package's import or package.json,
+// followed by plugin findings and failures and custom rule violations
+func analysisFindings(r *types.AnalysisResult) *findingSet {
+	set := &findingSet{
+		packages: analyzedPackages(r.Graph),
+		rules:    []string{ruleCircularDependency, ruleVersionConflict, ruleBoundaryViolation},
+	}
+
+	for _, cycle := range r.CircularDependencies {
+		message := fmt.Sprintf("Circular dependency: %s", strings.Join(cycle.Cycle, " → "))
+		seen := map[string]bool{}
+		for _, pkg := range cycle.Cycle {
+			if seen[pkg] {
+				continue
+			}
+			seen[pkg] = true
+
+			f := finding{
+				rule:    ruleCircularDependency,
+				level:   severityLevel(string(cycle.Severity)),
+				pkg:     pkg,
+				message: message,
+				file:    packageJSONFile(r.Graph, pkg),
+			}
+			for _, trace := range cycle.ImportTraces {
+				if trace.FromPackage == pkg {
+					f.file, f.line = trace.FilePath, trace.LineNumber
+					break
+				}
+			}
+			set.findings = append(set.findings, f)
+		}
+	}
+
+	for _, conflict := range r.VersionConflicts {
+		for _, version := range conflict.ConflictingVersions {
+			var others []string
+			for _, other := range conflict.ConflictingVersions {
+				if other != version {
+					others = append(others, other.Version)
+				}
+			}
+			for _, pkg := range version.Packages {
+				set.findings = append(set.findings, finding{
+					rule:  ruleVersionConflict,
+					level: severityLevel(string(conflict.Severity)),
+					pkg:   pkg,
+					message: fmt.Sprintf("Version conflict for %s: %s uses %s, other packages use %s",
+						conflict.PackageName, pkg, version.Version, strings.Join(others, ", ")),
+					file: packageJSONFile(r.Graph, pkg),
+				})
+			}
+		}
+	}
+
+	for _, v := range r.BoundaryViolations {
+		set.findings = append(set.findings, finding{
+			rule:    ruleBoundaryViolation,
+			level:   "error",
+			pkg:     v.From,
+			message: v.Message,
+			file:    packageJSONFile(r.Graph, v.From),
+		})
+	}
+
+	for _, f := range r.PluginFindings {
+		set.findings = append(set.findings, finding{
+			rule:    rulePluginFinding,
+			level:   pluginLevel(f.Severity),
+			pkg:     f.Package,
+			message: pluginMessage(f),
+			file:    pluginFindingFile(r.Graph, f),
+			line:    f.Line,
+		})
+	}
+	for _, e := range r.PluginErrors {
+		set.findings = append(set.findings, finding{
+			rule:    rulePluginFailed,
+			level:   "warning",
+			message: fmt.Sprintf("Plugin %s failed: %s", e.Plugin, e.Message),
+			file:    config.FileName,
+		})
+	}
+	// Plugin rules are only listed when plugins ran or failed
+	if len(r.PluginFindings) > 0 || len(r.PluginErrors) > 0 {
+		set.rules = append(set.rules, rulePluginFinding)
+	}
+	if len(r.PluginErrors) > 0 {
+		set.rules = append(set.rules, rulePluginFailed)
+	}
+
+	for _, v := range r.CustomRuleViolations {
+		set.findings = append(set.findings, finding{
+			rule:    ruleCustomRule,
+			level:   customRuleLevel(v.Severity),
+			pkg:     v.Package,
+			message: customRuleMessage(v),
+			file:    customRuleFile(r.Graph, v),
+		})
+	}
+	// Custom rules are only listed when they are violated
+	if len(r.CustomRuleViolations) > 0 {
+		set.rules = append(set.rules, ruleCustomRule)
+	}
+
+	return set
+}
+
+// checkFindings reports check errors and warnings
+func checkFindings(r *types.CheckResult) *findingSet {
+	set := &findingSet{
+		packages: r.Packages,
+		rules:    []string{ruleCircularDependency, ruleBoundaryViolation, ruleLowHealthScore},
+	}
+	for _, e := range r.Errors {
+		set.findings = append(set.findings, checkFinding(e.Code, "error", e.Message, e.Package, e.File, e.Line))
+	}
+	for _, w := range r.Warnings {
+		set.findings = append(set.findings, checkFinding(w.Code, "warning", w.Message, w.Package, w.File, w.Line))
+	}
+	// The version conflict, plugin and custom rules are off or absent unless
+	// configured, so they are only listed when they reported something
+	for _, rule := range []string{ruleVersionConflict, rulePluginFinding, rulePluginFailed, ruleCustomRule} {
+		for _, f := range set.findings {
+			if f.rule == rule {
+				set.rules = append(set.rules, rule)
+				break
+			}
+		}
+	}
+	return set
+}
+
+// checkFinding maps a check code to its rule
+func checkFinding(code, level, message, pkg, file string, line int) finding {
+	rule, ok := checkCodeRules[code]
+	if !ok {
+		rule = strings.ToLower(strings.ReplaceAll(code, "_", "-"))
+	}
+	return finding{rule: rule, level: level, pkg: pkg, message: message, file: file, line: line}
+}
+
+// watchFindings reports the cycles and conflicts that appeared in a watch event
+func watchFindings(e *watch.Event) *findingSet {
+	set := &findingSet{rules: []string{ruleCircularDependency, ruleVersionConflict}}
+	if e.Delta == nil {
+		return set
+	}
+	for _, cycle := range e.NewCycles {
+		set.findings = append(set.findings, finding{
+			rule:    ruleCircularDependency,
+			level:   "error",
+			message: fmt.Sprintf("New circular dependency: %s", strings.Join(cycle, " → ")),
+		})
+	}
+	for _, c := range e.NewConflicts {
+		set.findings = append(set.findings, finding{
+			rule:    ruleVersionConflict,
+			level:   "warning",
+			message: fmt.Sprintf("New version conflict for %s: %s", c.PackageName, strings.Join(c.Versions, " vs ")),
+		})
+	}
+	return set
+}
+
+// analyzedPackages returns the sorted names of the non-excluded packages
+func analyzedPackages(graph *types.DependencyGraph) []string {
+	if graph == nil {
+		return nil
+	}
+	names := make([]string, 0, len(graph.Nodes))
+	for name, node := range graph.Nodes {
+		if !node.Excluded {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// pluginMessage prefixes the message of a plugin finding with its plugin and rule
+func pluginMessage(f *types.PluginFinding) string {
+	return fmt.Sprintf("%s/%s: %s", f.Plugin, f.Rule, f.Message)
+}
+
+// pluginFindingFile returns the file of a plugin finding, falling back to the
+// package.json of its package and then the root package.json
+func pluginFindingFile(graph *types.DependencyGraph, f *types.PluginFinding) string {
+	if f.File != "" {
+		return f.File
+	}
+	if file := packageJSONFile(graph, f.Package); file != "" {
+		return file
+	}
+	return "package.json"
+}
+
+// customRuleMessage prefixes the message of a custom rule violation with its rule
+func customRuleMessage(v *types.CustomRuleViolation) string {
+	return fmt.Sprintf("%s: %s", v.Rule, v.Message)
+}
+
+// customRuleFile returns the package.json of the violating package, or the
+// root package.json for workspace rules
+func customRuleFile(graph *types.DependencyGraph, v *types.CustomRuleViolation) string {
+	if file := packageJSONFile(graph, v.Package); file != "" {
+		return file
+	}
+	return "package.json"
+}
+
+// packageJSONFile returns the relative package.json path of a package, or ""
+func packageJSONFile(graph *types.DependencyGraph, pkg string) string {
+	if graph == nil {
+		return ""
+	}
+	node, ok := graph.Nodes[pkg]
+	if !ok {
+		return ""
+	}
+	return path.Join(node.Path, "package.json")
+}
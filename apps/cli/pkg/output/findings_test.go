@@ -0,0 +1,167 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/fix"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/watch"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// sampleGraphResult returns sampleAnalysisResult with package nodes in the graph
+// and the import trace attributed to @mono/a.
+func sampleGraphResult() *types.AnalysisResult {
+	result := sampleAnalysisResult()
+	result.Graph.Nodes["@mono/a"] = &types.PackageNode{Name: "@mono/a", Path: "packages/a"}
+	result.Graph.Nodes["@mono/b"] = &types.PackageNode{Name: "@mono/b", Path: "packages/b"}
+	result.Graph.Nodes["@mono/c"] = &types.PackageNode{Name: "@mono/c", Path: "packages/c"}
+	result.Graph.Nodes["@mono/x"] = &types.PackageNode{Name: "@mono/x", Path: "packages/x", Excluded: true}
+	result.CircularDependencies[0].ImportTraces[0].FromPackage = "@mono/a"
+	return result
+}
+
+func TestCollectFindings_AnalysisResult(t *testing.T) {
+	set, ok := collectFindings(sampleGraphResult())
+	if !ok {
+		t.Fatal("collectFindings() should support analysis results")
+	}
+
+	if want := []string{"@mono/a", "@mono/b", "@mono/c"}; len(set.packages) != 3 ||
+		set.packages[0] != want[0] || set.packages[2] != want[2] {
+		t.Errorf("packages = %v, want %v", set.packages, want)
+	}
+
+	want := []finding{
+		{rule: ruleCircularDependency, level: "warning", pkg: "@mono/a", file: "packages/a/src/index.ts", line: 1},
+		{rule: ruleCircularDependency, level: "warning", pkg: "@mono/b", file: "packages/b/package.json"},
+		{rule: ruleVersionConflict, level: "error", pkg: "@mono/b", file: "packages/b/package.json"},
+		{rule: ruleVersionConflict, level: "error", pkg: "@mono/a", file: "packages/a/package.json"},
+		{rule: ruleBoundaryViolation, level: "error", pkg: "@mono/a", file: "packages/a/package.json"},
+	}
+	if len(set.findings) != len(want) {
+		t.Fatalf("findings = %d, want %d: %+v", len(set.findings), len(want), set.findings)
+	}
+	for i, w := range want {
+		got := set.findings[i]
+		got.message = ""
+		if got != w {
+			t.Errorf("findings[%d] = %+v, want %+v", i, got, w)
+		}
+	}
+	if msg := set.findings[2].message; msg != "Version conflict for lodash: @mono/b uses ^3.10.0, other packages use ^4.17.21" {
+		t.Errorf("version conflict message = %q", msg)
+	}
+}
+
+// addPluginResults adds a finding located at a file, a finding of a package
+// without a file and a failed plugin to result
+func addPluginResults(result *types.AnalysisResult) *types.AnalysisResult {
+	result.PluginFindings = []*types.PluginFinding{
+		{Plugin: "deps", Rule: "no-lodash", Severity: types.PluginSeverityError, Message: "lodash is banned",
+			Package: "@mono/a", File: "packages/a/src/index.ts", Line: 3},
+		{Plugin: "docs", Rule: "readme", Severity: types.PluginSeverityInfo, Message: "add a README", Package: "@mono/b"},
+	}
+	result.PluginErrors = []*types.PluginError{{Plugin: "slow", Message: "timed out after 30s"}}
+	return result
+}
+
+func TestCollectFindings_PluginResults(t *testing.T) {
+	set, _ := collectFindings(addPluginResults(sampleGraphResult()))
+
+	want := []finding{
+		{rule: rulePluginFinding, level: "error", pkg: "@mono/a", message: "deps/no-lodash: lodash is banned",
+			file: "packages/a/src/index.ts", line: 3},
+		{rule: rulePluginFinding, level: "note", pkg: "@mono/b", message: "docs/readme: add a README",
+			file: "packages/b/package.json"},
+		{rule: rulePluginFailed, level: "warning", message: "Plugin slow failed: timed out after 30s", file: ".monoguard.yaml"},
+	}
+	got := set.findings[len(set.findings)-len(want):]
+	for i, w := range want {
+		if got[i] != w {
+			t.Errorf("findings[%d] = %+v, want %+v", i, got[i], w)
+		}
+	}
+	if n := len(set.rules); n < 2 || set.rules[n-2] != rulePluginFinding || set.rules[n-1] != rulePluginFailed {
+		t.Errorf("rules = %v, want the plugin rules last", set.rules)
+	}
+
+	set, _ = collectFindings(sampleGraphResult())
+	for _, rule := range set.rules {
+		if rule == rulePluginFinding || rule == rulePluginFailed {
+			t.Errorf("rules = %v, want no plugin rules without plugins", set.rules)
+		}
+	}
+}
+
+// addCustomRuleViolations adds a dependency violation and a workspace
+// violation to result
+func addCustomRuleViolations(result *types.AnalysisResult) *types.AnalysisResult {
+	result.CustomRuleViolations = []*types.CustomRuleViolation{
+		{Rule: "apps-are-leaves", Severity: types.RuleSeverityError, Message: "Apps must not be depended on (@mono/a → @mono/b)",
+			Package: "@mono/a", Dependency: "@mono/b"},
+		{Rule: "small", Severity: types.RuleSeverityWarn, Message: "workspace"},
+	}
+	return result
+}
+
+func TestCollectFindings_CustomRuleViolations(t *testing.T) {
+	set, _ := collectFindings(addCustomRuleViolations(sampleGraphResult()))
+
+	want := []finding{
+		{rule: ruleCustomRule, level: "error", pkg: "@mono/a", message: "apps-are-leaves: Apps must not be depended on (@mono/a → @mono/b)",
+			file: "packages/a/package.json"},
+		{rule: ruleCustomRule, level: "warning", message: "small: workspace", file: "package.json"},
+	}
+	got := set.findings[len(set.findings)-len(want):]
+	for i, w := range want {
+		if got[i] != w {
+			t.Errorf("findings[%d] = %+v, want %+v", i, got[i], w)
+		}
+	}
+	if n := len(set.rules); set.rules[n-1] != ruleCustomRule {
+		t.Errorf("rules = %v, want the custom rule last", set.rules)
+	}
+
+	set, _ = collectFindings(sampleGraphResult())
+	for _, rule := range set.rules {
+		if rule == ruleCustomRule {
+			t.Errorf("rules = %v, want no custom rule without violations", set.rules)
+		}
+	}
+}
+
+func TestCollectFindings_CheckResult(t *testing.T) {
+	check := sampleCheckResult()
+	check.Packages = []string{"@mono/a", "@mono/ui"}
+	check.Errors[0].Package = "@mono/a"
+
+	set, ok := collectFindings(check)
+	if !ok {
+		t.Fatal("collectFindings() should support check results")
+	}
+	if len(set.findings) != 2 {
+		t.Fatalf("findings = %d, want 2", len(set.findings))
+	}
+	if f := set.findings[0]; f.rule != ruleCircularDependency || f.level != "error" || f.pkg != "@mono/a" || f.line != 3 {
+		t.Errorf("error finding = %+v", f)
+	}
+	if f := set.findings[1]; f.rule != ruleBoundaryViolation || f.level != "warning" {
+		t.Errorf("warning finding = %+v", f)
+	}
+}
+
+func TestCollectFindings_WatchEventAndUnsupported(t *testing.T) {
+	event := &watch.Event{Delta: &analysis.Delta{
+		NewCycles:      [][]string{{"@mono/a", "@mono/b", "@mono/a"}},
+		ResolvedCycles: [][]string{{"@mono/c", "@mono/d", "@mono/c"}},
+	}}
+	set, ok := collectFindings(event)
+	if !ok || len(set.findings) != 1 || set.findings[0].rule != ruleCircularDependency {
+		t.Errorf("collectFindings(watch event) = %+v, %v; want the new cycle only", set, ok)
+	}
+
+	if _, ok := collectFindings(&fix.Report{}); ok {
+		t.Error("collectFindings() should not support fix reports")
+	}
+}
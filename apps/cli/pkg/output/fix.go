@@ -0,0 +1,63 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/fix"
+)
+
+// writeFixText renders a fix Report as a human-readable report.
+// In dry-run mode the unified diff is printed so it can be piped to `git apply`.
+func writeFixText(w io.Writer, r *fix.Report) {
+	switch {
+	case r.Applied:
+		fmt.Fprintf(w, "🔧 MonoGuard Fix: applied %s\n", r.StrategyName)
+	case r.DryRun:
+		fmt.Fprintf(w, "🔧 MonoGuard Fix (dry run): %s\n", r.StrategyName)
+	default:
+		fmt.Fprintf(w, "🔧 MonoGuard Fix: %s\n", r.StrategyName)
+	}
+	fmt.Fprintf(w, "   Path: %s\n", r.Path)
+	fmt.Fprintf(w, "   Cycle: %s\n", strings.Join(r.Cycle, " → "))
+	fmt.Fprintf(w, "   Strategy: %s\n", r.Strategy)
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Files (%d):\n", len(r.Changes))
+	for _, c := range r.Changes {
+		if c.Created {
+			fmt.Fprintf(w, "   + %s\n", c.Path)
+		} else {
+			fmt.Fprintf(w, "   ~ %s\n", c.Path)
+		}
+	}
+
+	if r.DryRun && r.Diff != "" {
+		fmt.Fprintln(w)
+		fmt.Fprint(w, r.Diff)
+	}
+
+	if r.Resolved != nil {
+		fmt.Fprintln(w)
+		if *r.Resolved {
+			fmt.Fprintf(w, "✅ Re-analysis: cycle resolved\n")
+		} else {
+			fmt.Fprintf(w, "⚠️  Re-analysis: cycle still present\n")
+		}
+	}
+
+	if len(r.NextSteps) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Next steps:\n")
+		for i, step := range r.NextSteps {
+			fmt.Fprintf(w, "   %d. %s\n", i+1, step)
+		}
+	}
+
+	if !r.Applied && !r.DryRun {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Run with --dry-run to preview the diff or --apply to write the changes.\n")
+	}
+}
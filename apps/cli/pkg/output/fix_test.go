@@ -0,0 +1,89 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/fix"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// sampleFixReport returns a dry-run report with one modified and one created file.
+func sampleFixReport() *fix.Report {
+	return &fix.Report{
+		Path:         "/repo",
+		Cycle:        []string{"@mono/a", "@mono/b", "@mono/a"},
+		Strategy:     types.FixStrategyExtractModule,
+		StrategyName: "Extract Shared Module",
+		DryRun:       true,
+		Changes: []*fix.Change{
+			{Path: "packages/a/src/index.ts"},
+			{Path: "packages/shared/package.json", Created: true},
+		},
+		Diff:      "--- a/packages/a/src/index.ts\n+++ b/packages/a/src/index.ts\n",
+		NextSteps: []string{"Move b from @mono/b to @mono/shared"},
+	}
+}
+
+func TestFormatterText_FixReport(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, sampleFixReport()); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"MonoGuard Fix (dry run): Extract Shared Module",
+		"Cycle: @mono/a → @mono/b → @mono/a",
+		"~ packages/a/src/index.ts",
+		"+ packages/shared/package.json",
+		"--- a/packages/a/src/index.ts",
+		"1. Move b from @mono/b to @mono/shared",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("text output missing %q\nOutput:\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatterText_FixReportApplied(t *testing.T) {
+	report := sampleFixReport()
+	report.DryRun = false
+	report.Applied = true
+	report.Diff = ""
+	resolved := true
+	report.Resolved = &resolved
+
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, report); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"applied Extract Shared Module", "cycle resolved"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("text output missing %q\nOutput:\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatterJSON_FixReport(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("json").PrintTo(&buf, sampleFixReport()); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if parsed["dryRun"] != true || parsed["strategy"] != "extract-module" {
+		t.Errorf("unexpected JSON: %v", parsed)
+	}
+	if _, ok := parsed["resolved"]; ok {
+		t.Error("resolved should be omitted when the fix was not applied")
+	}
+}
@@ -0,0 +1,46 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// githubCommands maps finding levels to GitHub Actions workflow commands
+var githubCommands = map[string]string{
+	"error":   "error",
+	"warning": "warning",
+	"note":    "notice",
+}
+
+// writeGitHub renders findings as GitHub Actions workflow commands, which the
+// runner turns into inline annotations, followed by the text report.
+// File paths are relative to the workspace root, so annotations land on the
+// right lines when the workspace is the repository root.
+func writeGitHub(w io.Writer, data interface{}) {
+	if set, ok := collectFindings(data); ok {
+		for _, f := range set.findings {
+			var props []string
+			if f.file != "" {
+				props = append(props, "file="+escapeGitHubProperty(f.file))
+				if f.line > 0 {
+					props = append(props, fmt.Sprintf("line=%d", f.line))
+				}
+			}
+			props = append(props, "title="+escapeGitHubProperty("MonoGuard "+f.rule))
+			fmt.Fprintf(w, "::%s %s::%s\n", githubCommands[f.level], strings.Join(props, ","), escapeGitHubData(f.message))
+		}
+	}
+	writeText(w, data)
+}
+
+// escapeGitHubData escapes a workflow command message
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeGitHubProperty escapes a workflow command property value
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFormatterGitHub_CheckResult(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("github").PrintTo(&buf, sampleCheckResult()); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+	wantPrefixes := []string{
+		"::error file=packages/a/src/index.ts,line=3,title=MonoGuard circular-dependency::Circular dependency found",
+		"::warning file=packages/ui/package.json,title=MonoGuard boundary-violation::@mono/ui",
+	}
+	for i, want := range wantPrefixes {
+		if !strings.HasPrefix(lines[i], want) {
+			t.Errorf("line %d = %q, want prefix %q", i, lines[i], want)
+		}
+	}
+	if !strings.Contains(buf.String(), "MonoGuard Check: FAILED") {
+		t.Errorf("annotations should be followed by the text report:\n%s", buf.String())
+	}
+}
+
+func TestFormatterGitHub_TextFallback(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("github").PrintTo(&buf, "✅ No circular dependencies to fix"); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	if buf.String() != "✅ No circular dependencies to fix\n" {
+		t.Errorf("output = %q, want the text output", buf.String())
+	}
+}
+
+func TestEscapeGitHub(t *testing.T) {
+	if got := escapeGitHubData("50% done\nnext"); got != "50%25 done%0Anext" {
+		t.Errorf("escapeGitHubData() = %q", got)
+	}
+	if got := escapeGitHubProperty("a:b,c"); got != "a%3Ab%2Cc" {
+		t.Errorf("escapeGitHubProperty() = %q", got)
+	}
+}
@@ -0,0 +1,115 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/graph"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// writeGraphExport renders a graph view as DOT, Mermaid or GraphML
+func writeGraphExport(w io.Writer, format string, data interface{}) error {
+	v, ok := data.(*graph.View)
+	if !ok {
+		return fmt.Errorf("%s format is only supported by the graph command", format)
+	}
+	switch format {
+	case "dot":
+		return graph.WriteDOT(w, v)
+	case "mermaid":
+		return graph.WriteMermaid(w, v)
+	default:
+		return graph.WriteGraphML(w, v)
+	}
+}
+
+// writeGraphText renders a graph view as an adjacency list
+func writeGraphText(w io.Writer, v *graph.View) {
+	fmt.Fprintf(w, "🕸️  Dependency Graph (%d packages, %d dependencies)\n", len(v.Nodes), len(v.Edges))
+	if v.Focus != "" {
+		fmt.Fprintf(w, "   Focus: %s\n", v.Focus)
+	}
+
+	deps := map[string][]graph.Edge{}
+	for _, e := range v.Edges {
+		deps[e.From] = append(deps[e.From], e)
+	}
+
+	byName := map[string]graph.Node{}
+	for _, n := range v.Nodes {
+		byName[n.Name] = n
+	}
+	groups := v.Clusters
+	if len(groups) == 0 {
+		all := graph.Cluster{}
+		for _, n := range v.Nodes {
+			all.Packages = append(all.Packages, n.Name)
+		}
+		groups = []graph.Cluster{all}
+	}
+
+	for _, group := range groups {
+		indent := "   "
+		if group.Dir != "" {
+			fmt.Fprintf(w, "\n   📁 %s/\n", group.Dir)
+			indent = "      "
+		} else {
+			fmt.Fprintln(w)
+		}
+		for _, name := range group.Packages {
+			n := byName[name]
+			var marks []string
+			if n.Excluded {
+				marks = append(marks, "excluded")
+			}
+			if n.InCycle {
+				marks = append(marks, "in cycle")
+			}
+			if len(marks) > 0 {
+				fmt.Fprintf(w, "%s%s (%s)\n", indent, name, strings.Join(marks, ", "))
+			} else {
+				fmt.Fprintf(w, "%s%s\n", indent, name)
+			}
+			for _, e := range deps[name] {
+				line := fmt.Sprintf("%s  → %s", indent, e.To)
+				if e.Type != types.DependencyTypeProduction {
+					line += fmt.Sprintf(" [%s]", e.Type)
+				}
+				if e.InCycle {
+					line += " 🔄"
+				}
+				fmt.Fprintln(w, line)
+			}
+		}
+	}
+}
+
+// writeExplanationText renders the dependency paths between two packages
+func writeExplanationText(w io.Writer, ex *graph.Explanation) {
+	if len(ex.Paths) == 0 {
+		fmt.Fprintf(w, "✅ %s does not depend on %s\n", ex.From, ex.To)
+		return
+	}
+
+	more := ""
+	if ex.Truncated {
+		more = ", more not shown"
+	}
+	fmt.Fprintf(w, "🔍 Why %s depends on %s: %d paths%s\n", ex.From, ex.To, len(ex.Paths), more)
+	for i, p := range ex.Paths {
+		fmt.Fprintf(w, "\nPath %d (%d hops)\n", i+1, len(p.Hops))
+		for _, h := range p.Hops {
+			decls := make([]string, len(h.Declarations))
+			for j, d := range h.Declarations {
+				decls[j] = fmt.Sprintf("%s %s", d.Type, d.VersionRange)
+			}
+			fmt.Fprintf(w, "   %s → %s [%s]\n", h.From, h.To, strings.Join(decls, ", "))
+			for _, imp := range h.Imports {
+				fmt.Fprintf(w, "      %s  %s\n", location(imp.FilePath, imp.LineNumber), imp.Statement)
+			}
+		}
+	}
+}
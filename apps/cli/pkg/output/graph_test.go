@@ -0,0 +1,134 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/graph"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+func sampleGraphView() *graph.View {
+	return &graph.View{
+		Nodes: []graph.Node{
+			{Name: "@mono/a", Path: "packages/a", InCycle: true},
+			{Name: "@mono/b", Path: "packages/b", InCycle: true},
+			{Name: "@mono/x", Path: "tools/x", Excluded: true},
+		},
+		Edges: []graph.Edge{
+			{From: "@mono/a", To: "@mono/b", Type: types.DependencyTypeProduction, InCycle: true},
+			{From: "@mono/b", To: "@mono/a", Type: types.DependencyTypeProduction, InCycle: true},
+			{From: "@mono/x", To: "@mono/a", Type: types.DependencyTypeDevelopment},
+		},
+	}
+}
+
+func TestFormatterText_GraphView(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, sampleGraphView()); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"Dependency Graph (3 packages, 3 dependencies)",
+		"   @mono/a (in cycle)\n     → @mono/b 🔄",
+		"   @mono/x (excluded)\n     → @mono/a [development]",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("text output missing %q\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatterText_GraphViewClusters(t *testing.T) {
+	v := sampleGraphView()
+	v.Clusters = []graph.Cluster{
+		{Dir: "packages", Packages: []string{"@mono/a", "@mono/b"}},
+		{Dir: "tools", Packages: []string{"@mono/x"}},
+	}
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, v); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "📁 tools/\n      @mono/x (excluded)") {
+		t.Errorf("clustered text output\n%s", buf.String())
+	}
+}
+
+func TestFormatterGraphExports(t *testing.T) {
+	tests := map[string]string{
+		"dot":     "digraph monoguard {",
+		"mermaid": "flowchart LR",
+		"graphml": "<graphml",
+	}
+	for format, want := range tests {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewFormatter(format).PrintTo(&buf, sampleGraphView()); err != nil {
+				t.Fatalf("PrintTo() error = %v", err)
+			}
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("%s output missing %q\n%s", format, want, buf.String())
+			}
+
+			// Only graph views can be exported
+			if err := NewFormatter(format).PrintTo(&bytes.Buffer{}, sampleAnalysisResult()); err == nil {
+				t.Errorf("%s format should reject analysis results", format)
+			}
+		})
+	}
+}
+
+func TestFormatterText_Explanation(t *testing.T) {
+	ex := &graph.Explanation{
+		From: "@mono/app",
+		To:   "@mono/core",
+		Paths: []graph.Path{{Hops: []graph.Hop{
+			{
+				From:         "@mono/app",
+				To:           "@mono/ui",
+				Declarations: []graph.Declaration{{Type: types.DependencyTypeProduction, VersionRange: "workspace:*"}},
+				Imports: []types.ImportTrace{
+					{FilePath: "apps/app/src/main.ts", LineNumber: 3, Statement: "import { Button } from '@mono/ui';"},
+				},
+			},
+			{
+				From:         "@mono/ui",
+				To:           "@mono/core",
+				Declarations: []graph.Declaration{{Type: types.DependencyTypePeer, VersionRange: "^1.0.0"}},
+			},
+		}}},
+		Truncated: true,
+	}
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, ex); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"🔍 Why @mono/app depends on @mono/core: 1 paths, more not shown",
+		"Path 1 (2 hops)",
+		"   @mono/app → @mono/ui [production workspace:*]\n      apps/app/src/main.ts:3  import { Button } from '@mono/ui';",
+		"   @mono/ui → @mono/core [peer ^1.0.0]",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatterText_ExplanationNoPath(t *testing.T) {
+	var buf bytes.Buffer
+	ex := &graph.Explanation{From: "@mono/core", To: "@mono/app"}
+	if err := NewFormatter("text").PrintTo(&buf, ex); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "✅ @mono/core does not depend on @mono/app") {
+		t.Errorf("unexpected output\n%s", buf.String())
+	}
+}
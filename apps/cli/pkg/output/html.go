@@ -0,0 +1,201 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+//go:embed templates/report.html.tmpl
+var reportTemplateText string
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"join":         strings.Join,
+	"percent":      func(f float64) int { return int(math.Round(f * 100)) },
+	"severityRank": severityRank,
+	"healthClass":  healthClass,
+	"packageCount": packageCount,
+}).Parse(reportTemplateText))
+
+// htmlReport is the data of the HTML report template
+type htmlReport struct {
+	Generated string
+	Result    *types.AnalysisResult
+	Cycles    []htmlCycle
+}
+
+// htmlCycle is a cycle card
+type htmlCycle struct {
+	*types.CircularDependencyInfo
+	Number     int
+	Strategies []htmlStrategy
+}
+
+// htmlStrategy is a fix strategy with its before/after diagrams laid out
+type htmlStrategy struct {
+	*types.FixStrategy
+	Before *svgDiagram
+	After  *svgDiagram
+}
+
+// WriteHTML renders an analysis result as a single self-contained HTML page
+// with inline CSS, JavaScript and SVG, so it can be shared without the CLI
+// or network access
+func WriteHTML(w io.Writer, r *types.AnalysisResult) error {
+	report := htmlReport{
+		Generated: time.Now().Format("2006-01-02 15:04 MST"),
+		Result:    r,
+	}
+	for i, cycle := range r.CircularDependencies {
+		c := htmlCycle{CircularDependencyInfo: cycle, Number: i + 1}
+		for j := range cycle.FixStrategies {
+			s := htmlStrategy{FixStrategy: &cycle.FixStrategies[j]}
+			if ba := s.BeforeAfterExplanation; ba != nil {
+				s.Before = layoutDiagram(ba.CurrentState)
+				s.After = layoutDiagram(ba.ProposedState)
+			}
+			c.Strategies = append(c.Strategies, s)
+		}
+		report.Cycles = append(report.Cycles, c)
+	}
+	return reportTemplate.Execute(w, report)
+}
+
+// severityRank orders severities for sorting, most severe first
+func severityRank(severity string) int {
+	switch severity {
+	case "critical":
+		return 3
+	case "warning":
+		return 2
+	case "info":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// packageCount returns the number of workspace packages involved in a conflict
+func packageCount(c *types.VersionConflictInfo) int {
+	n := 0
+	for _, v := range c.ConflictingVersions {
+		n += len(v.Packages)
+	}
+	return n
+}
+
+// healthClass returns the CSS class for a score
+func healthClass(score int) string {
+	switch {
+	case score >= 85:
+		return "good"
+	case score >= 50:
+		return "fair"
+	default:
+		return "poor"
+	}
+}
+
+// ========================================
+// State diagram layout
+// ========================================
+
+// Diagram geometry, in SVG user units
+const (
+	diagramNodeRadius  = 9
+	diagramLabelMargin = 150 // Room for labels left and right of the circle
+	diagramMinRadius   = 70
+	diagramNodeSpacing = 46 // Arc length between neighboring nodes
+)
+
+// svgDiagram is a state diagram with nodes placed on a circle
+type svgDiagram struct {
+	Width, Height int
+	Nodes         []svgNode
+	Edges         []svgEdge
+	Resolved      bool
+}
+
+type svgNode struct {
+	X, Y   float64
+	Label  string
+	Class  string // cycle, affected, new or unchanged
+	Anchor string // text-anchor of the label
+	LabelX float64
+	LabelY float64
+}
+
+type svgEdge struct {
+	X1, Y1, X2, Y2 float64
+	Class          string // cycle, removed, new or unchanged
+	Title          string
+}
+
+// layoutDiagram places the nodes of a state diagram evenly on a circle,
+// starting at the top, and shortens edges so arrows end at the node border
+func layoutDiagram(d *types.StateDiagram) *svgDiagram {
+	if d == nil || len(d.Nodes) == 0 {
+		return nil
+	}
+
+	n := len(d.Nodes)
+	radius := math.Max(diagramMinRadius, float64(n*diagramNodeSpacing)/(2*math.Pi))
+	cx := radius + diagramLabelMargin
+	cy := radius + 2*diagramNodeRadius + 14
+	out := &svgDiagram{
+		Width:    int(2 * cx),
+		Height:   int(2 * cy),
+		Resolved: d.CycleResolved,
+	}
+
+	pos := map[string][2]float64{}
+	for i, node := range d.Nodes {
+		angle := 2*math.Pi*float64(i)/float64(n) - math.Pi/2
+		x, y := cx+radius*math.Cos(angle), cy+radius*math.Sin(angle)
+		pos[node.ID] = [2]float64{x, y}
+
+		label := node.Label
+		if label == "" {
+			label = node.ID
+		}
+		sn := svgNode{X: x, Y: y, Label: label, Class: string(node.NodeType), Anchor: "middle", LabelX: x}
+		switch {
+		case math.Cos(angle) > 0.3:
+			sn.Anchor, sn.LabelX, sn.LabelY = "start", x+diagramNodeRadius+6, y+4
+		case math.Cos(angle) < -0.3:
+			sn.Anchor, sn.LabelX, sn.LabelY = "end", x-diagramNodeRadius-6, y+4
+		case math.Sin(angle) < 0:
+			sn.LabelY = y - diagramNodeRadius - 6
+		default:
+			sn.LabelY = y + diagramNodeRadius + 16
+		}
+		out.Nodes = append(out.Nodes, sn)
+	}
+
+	for _, edge := range d.Edges {
+		from, ok1 := pos[edge.From]
+		to, ok2 := pos[edge.To]
+		if !ok1 || !ok2 || edge.From == edge.To {
+			continue
+		}
+		dx, dy := to[0]-from[0], to[1]-from[1]
+		length := math.Hypot(dx, dy)
+		ux, uy := dx/length, dy/length
+		gap := float64(diagramNodeRadius + 3)
+		out.Edges = append(out.Edges, svgEdge{
+			X1:    from[0] + ux*gap,
+			Y1:    from[1] + uy*gap,
+			X2:    to[0] - ux*gap,
+			Y2:    to[1] - uy*gap,
+			Class: string(edge.EdgeType),
+			Title: edge.From + " → " + edge.To,
+		})
+	}
+	return out
+}
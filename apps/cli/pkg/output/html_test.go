@@ -0,0 +1,114 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// htmlResult returns the markdown sample result with a fix strategy that has
+// a guide and before/after diagrams
+func htmlResult() *types.AnalysisResult {
+	result := markdownResult()
+	result.VersionConflicts[0].Resolution = "Align on ^4.17.21"
+	result.CircularDependencies[0].FixStrategies = []types.FixStrategy{
+		{
+			Type:        types.FixStrategyExtractModule,
+			Name:        "Extract Shared Module",
+			Description: "Move shared code to a new package",
+			Suitability: 8,
+			Effort:      types.EffortMedium,
+			Recommended: true,
+			Guide: &types.FixGuide{
+				Title: "Extract <shared> code",
+				Steps: []types.FixStep{
+					{Number: 1, Title: "Create the package", Command: &types.CommandStep{Command: "mkdir packages/shared"}},
+				},
+			},
+			BeforeAfterExplanation: &types.BeforeAfterExplanation{
+				CurrentState: &types.StateDiagram{
+					Nodes: []types.DiagramNode{
+						{ID: "@mono/a", Label: "@mono/a", NodeType: types.NodeTypeCycle},
+						{ID: "@mono/b", Label: "@mono/b", NodeType: types.NodeTypeCycle},
+					},
+					Edges: []types.DiagramEdge{
+						{From: "@mono/a", To: "@mono/b", EdgeType: types.EdgeTypeCycle},
+						{From: "@mono/b", To: "@mono/a", EdgeType: types.EdgeTypeCycle},
+					},
+				},
+				ProposedState: &types.StateDiagram{
+					Nodes: []types.DiagramNode{
+						{ID: "@mono/a", Label: "@mono/a", NodeType: types.NodeTypeAffected},
+						{ID: "@mono/b", Label: "@mono/b", NodeType: types.NodeTypeAffected},
+						{ID: "@mono/shared", Label: "@mono/shared", NodeType: types.NodeTypeNew},
+					},
+					Edges: []types.DiagramEdge{
+						{From: "@mono/a", To: "@mono/shared", EdgeType: types.EdgeTypeNew},
+						{From: "@mono/b", To: "@mono/a", EdgeType: types.EdgeTypeRemoved},
+					},
+					CycleResolved: true,
+				},
+			},
+		},
+	}
+	return result
+}
+
+func TestWriteHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, htmlResult()); err != nil {
+		t.Fatalf("WriteHTML() error = %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"<!DOCTYPE html>",
+		`<div class="value fair">72</div>`,
+		"Circular Dependencies</strong><span>80/100 · weight 40% · +32</span>",
+		`<table class="sortable">`,
+		`<td data-sort="3"><span class="badge critical">critical</span></td>`,
+		"Align on ^4.17.21",
+		`<span class="cycle-path">@mono/a → @mono/b → @mono/a</span>`,
+		"<details open>",
+		"Extract &lt;shared&gt; code",
+		"$ mkdir packages/shared",
+		"cycle resolved",
+		`class="edge-removed"`,
+		`class="node-new"`,
+		"@mono/shared</text>",
+		"must not depend on",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("HTML report missing %q", want)
+		}
+	}
+
+	// Self-contained: nothing is loaded over the network
+	for _, external := range []string{"<link", "src=\"http", "src='http", "@import"} {
+		if strings.Contains(output, external) {
+			t.Errorf("HTML report references external resource %q", external)
+		}
+	}
+}
+
+func TestLayoutDiagram(t *testing.T) {
+	if layoutDiagram(nil) != nil || layoutDiagram(&types.StateDiagram{}) != nil {
+		t.Error("layoutDiagram() should return nil for empty diagrams")
+	}
+
+	d := layoutDiagram(htmlResult().CircularDependencies[0].FixStrategies[0].BeforeAfterExplanation.ProposedState)
+	if len(d.Nodes) != 3 || len(d.Edges) != 2 || !d.Resolved {
+		t.Fatalf("layoutDiagram() = %d nodes, %d edges, resolved %v", len(d.Nodes), len(d.Edges), d.Resolved)
+	}
+	for _, n := range d.Nodes {
+		if n.X < 0 || n.Y < 0 || n.X > float64(d.Width) || n.Y > float64(d.Height) {
+			t.Errorf("node %s at (%.0f, %.0f) outside %dx%d", n.Label, n.X, n.Y, d.Width, d.Height)
+		}
+	}
+	if d.Nodes[0].Anchor != "middle" || d.Nodes[0].LabelY >= d.Nodes[0].Y {
+		t.Errorf("top node label should be centered above it: %+v", d.Nodes[0])
+	}
+}
@@ -0,0 +1,37 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+)
+
+// writeInitText renders an init result as a human-readable summary
+func writeInitText(w io.Writer, r *config.InitResult) {
+	fmt.Fprintf(w, "🚀 MonoGuard Init\n")
+	fmt.Fprintf(w, "   %s %s\n", capitalize(r.Status), r.Path)
+	fmt.Fprintf(w, "   Workspace: %s\n", r.WorkspaceType)
+	fmt.Fprintf(w, "   Workspaces: %s\n", joinOrNone(r.Workspaces))
+	fmt.Fprintf(w, "   Packages: %d\n", len(r.Packages))
+	fmt.Fprintf(w, "   Excluded: %s\n", joinOrNone(r.Exclude))
+
+	if len(r.Layers) == 0 {
+		fmt.Fprintf(w, "   Layers: none detected\n")
+	} else {
+		fmt.Fprintf(w, "   Layers:\n")
+		for _, layer := range r.Layers {
+			fmt.Fprintf(w, "      %s (%s) → %s\n", layer.Name, layer.Pattern, joinOrNone(layer.CanDependOn))
+		}
+	}
+}
+
+// joinOrNone joins values with commas, or returns "none"
+func joinOrNone(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
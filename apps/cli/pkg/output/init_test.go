@@ -0,0 +1,49 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+func TestFormatterText_InitResult(t *testing.T) {
+	result := &config.InitResult{
+		Status: "created",
+		Path:   "/repo/.monoguard.yaml",
+		Detection: &config.Detection{
+			WorkspaceType: types.WorkspaceTypePnpm,
+			Workspaces:    []string{"apps/*", "libs/*"},
+			Packages:      []string{"apps/web", "libs/ui"},
+			Exclude:       []string{},
+			Layers: []config.Layer{
+				{Name: "apps", Pattern: "apps/**", CanDependOn: []string{"libs"}},
+				{Name: "libs", Pattern: "libs/**"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, result); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"MonoGuard Init",
+		"Created /repo/.monoguard.yaml",
+		"Workspace: pnpm",
+		"Workspaces: apps/*, libs/*",
+		"Packages: 2",
+		"Excluded: none",
+		"apps (apps/**) → libs",
+		"libs (libs/**) → none",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("text output missing %q\nOutput:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Type    string `xml:"type,attr"`
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// writeJUnit renders findings as a JUnit XML report with one test suite per
+// rule and one test case per package. Errors and warnings fail the test
+// case; notes are attached as system-out. Data without findings produces an
+// empty report.
+func writeJUnit(w io.Writer, data interface{}) error {
+	report := junitTestSuites{Name: "monoguard", Suites: []junitTestSuite{}}
+
+	if set, ok := collectFindings(data); ok {
+		for _, rule := range set.rules {
+			suite := junitSuite(rule, set)
+			report.Tests += suite.Tests
+			report.Failures += suite.Failures
+			report.Suites = append(report.Suites, suite)
+		}
+	}
+
+	b, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(w, xml.Header)
+	fmt.Fprintln(w, string(b))
+	return nil
+}
+
+// junitSuite builds the test suite of one rule
+func junitSuite(rule string, set *findingSet) junitTestSuite {
+	byPackage := map[string][]finding{}
+	for _, f := range set.findings {
+		if f.rule == rule {
+			byPackage[f.caseName()] = append(byPackage[f.caseName()], f)
+		}
+	}
+
+	// Packages that were checked, then any others with findings
+	names := []string{}
+	if rule == ruleLowHealthScore || rule == rulePluginFailed {
+		names = append(names, workspaceCase)
+	} else {
+		names = append(names, set.packages...)
+	}
+	listed := map[string]bool{}
+	for _, name := range names {
+		listed[name] = true
+	}
+	for _, f := range set.findings {
+		if f.rule == rule && !listed[f.caseName()] {
+			listed[f.caseName()] = true
+			names = append(names, f.caseName())
+		}
+	}
+
+	suite := junitTestSuite{Name: rule, TestCases: []junitTestCase{}}
+	for _, name := range names {
+		tc := junitTestCase{ClassName: rule, Name: name}
+
+		var failures, notes []string
+		level, message := "warning", ""
+		for _, f := range byPackage[name] {
+			line := f.message
+			if loc := location(f.file, f.line); loc != "" {
+				line = fmt.Sprintf("%s: %s", loc, f.message)
+			}
+			if f.level == "note" {
+				notes = append(notes, line)
+				continue
+			}
+			if len(failures) == 0 {
+				message = f.message
+			}
+			failures = append(failures, line)
+			if f.level == "error" {
+				level = "error"
+			}
+		}
+		if len(failures) > 0 {
+			tc.Failure = &junitFailure{
+				Type:    level,
+				Message: message,
+				Body:    strings.Join(failures, "\n"),
+			}
+			suite.Failures++
+		}
+		tc.SystemOut = strings.Join(notes, "\n")
+
+		suite.TestCases = append(suite.TestCases, tc)
+		suite.Tests++
+	}
+	return suite
+}
+
+// caseName returns the test case a finding belongs to
+func (f finding) caseName() string {
+	if f.pkg == "" {
+		return workspaceCase
+	}
+	return f.pkg
+}
@@ -0,0 +1,85 @@
+package output
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestFormatterJUnit_AnalysisResult(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("junit").PrintTo(&buf, sampleGraphResult()); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "<?xml") {
+		t.Errorf("output should start with an XML header:\n%s", buf.String())
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, buf.String())
+	}
+
+	// 3 rules x 3 non-excluded packages
+	if report.Tests != 9 || report.Failures != 5 {
+		t.Errorf("tests = %d, failures = %d; want 9, 5", report.Tests, report.Failures)
+	}
+	if len(report.Suites) != 3 || report.Suites[0].Name != ruleCircularDependency {
+		t.Fatalf("suites = %+v", report.Suites)
+	}
+
+	cycles := report.Suites[0]
+	if cycles.Tests != 3 || cycles.Failures != 2 {
+		t.Errorf("circular-dependency suite: tests = %d, failures = %d; want 3, 2", cycles.Tests, cycles.Failures)
+	}
+	tc := cycles.TestCases[0]
+	if tc.Name != "@mono/a" || tc.Failure == nil || tc.Failure.Type != "warning" {
+		t.Fatalf("first test case = %+v", tc)
+	}
+	if !strings.Contains(tc.Failure.Body, "packages/a/src/index.ts:1: Circular dependency") {
+		t.Errorf("failure body should include the location: %q", tc.Failure.Body)
+	}
+	if cycles.TestCases[2].Name != "@mono/c" || cycles.TestCases[2].Failure != nil {
+		t.Errorf("@mono/c should pass: %+v", cycles.TestCases[2])
+	}
+}
+
+func TestFormatterJUnit_CheckResult(t *testing.T) {
+	check := sampleCheckResult()
+	check.Errors = append(check.Errors, sampleCheckResult().Errors[0])
+	check.Errors[1].Code = "LOW_HEALTH_SCORE"
+	check.Errors[1].File = ""
+	check.Errors[1].Message = "Health score 64 is below threshold 80"
+
+	var buf bytes.Buffer
+	if err := NewFormatter("junit").PrintTo(&buf, check); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	var report junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, buf.String())
+	}
+
+	health := report.Suites[2]
+	if health.Name != ruleLowHealthScore || len(health.TestCases) != 1 {
+		t.Fatalf("low-health-score suite = %+v", health)
+	}
+	if tc := health.TestCases[0]; tc.Name != workspaceCase || tc.Failure == nil || tc.Failure.Type != "error" {
+		t.Errorf("workspace test case = %+v", tc)
+	}
+}
+
+func TestFormatterJUnit_NoFindings(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("junit").PrintTo(&buf, sampleFixReport()); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	var report junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, buf.String())
+	}
+	if report.Tests != 0 || len(report.Suites) != 0 {
+		t.Errorf("report = %+v, want an empty report", report)
+	}
+}
@@ -0,0 +1,427 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// GitHubCommentLimit is the maximum length of a GitHub issue or PR comment
+const GitHubCommentLimit = 65536
+
+// markdownSection is a titled list of blocks that can be dropped one by one
+// when the report has to fit a size limit
+type markdownSection struct {
+	title  string
+	header string // Written once before the first block (e.g. a table header)
+	noun   string // Names the blocks in the truncation notice, e.g. "version conflicts"
+	blocks []markdownBlock
+}
+
+// markdownBlock is one item of a section. compact is a shorter rendering
+// used when full does not fit; empty means the block cannot be shortened.
+type markdownBlock struct {
+	full    string
+	compact string
+}
+
+// short returns the compact rendering if there is one
+func (b markdownBlock) short() string {
+	if b.compact != "" {
+		return b.compact
+	}
+	return b.full
+}
+
+// writeMarkdown renders analysis results and diffs as Markdown and other
+// data as a fenced text block
+func writeMarkdown(w io.Writer, data interface{}, maxLength int) {
+	switch v := data.(type) {
+	case *types.AnalysisResult:
+		fmt.Fprint(w, RenderMarkdown(v, maxLength))
+		return
+	case *analysis.Diff:
+		fmt.Fprint(w, RenderDiffMarkdown(v))
+		return
+	}
+	var b strings.Builder
+	writeText(&b, data)
+	fmt.Fprintf(w, "```\n%s```\n", b.String())
+}
+
+// RenderMarkdown renders an analysis result as GitHub-flavored Markdown for
+// PR descriptions and comments: a summary table, the health score breakdown,
+// a collapsible section per cycle, version conflicts and boundary violations.
+//
+// A positive maxLength bounds the output in bytes. The summary is kept
+// unless it alone exceeds the limit, in which case the rows that do not fit
+// are cut; cycles that do not fit in full are listed on one line, and
+// findings that do not fit at all are left out with a notice saying how many
+// were.
+func RenderMarkdown(r *types.AnalysisResult, maxLength int) string {
+	var b strings.Builder
+	writeMarkdownSummary(&b, r)
+	summary := b.String()
+
+	sections := []markdownSection{
+		markdownBreakdown(r),
+		markdownCycles(r),
+		markdownConflicts(r),
+		markdownViolations(r),
+		markdownPluginFindings(r),
+		markdownCustomRuleViolations(r),
+	}
+	footer := "\n<sub>Generated by MonoGuard</sub>\n"
+
+	if maxLength <= 0 {
+		for _, s := range sections {
+			if len(s.blocks) == 0 {
+				continue
+			}
+			b.WriteString(s.title)
+			b.WriteString(s.header)
+			for _, block := range s.blocks {
+				b.WriteString(block.full)
+			}
+		}
+		b.WriteString(footer)
+		return b.String()
+	}
+
+	// Reserve room for the footer and the longest possible notice, which is
+	// the one listing every block as omitted
+	omitted := make([]int, len(sections))
+	for i, s := range sections {
+		omitted[i] = len(s.blocks)
+	}
+	reserved := len(footer) + len(truncationNotice(sections, omitted, true))
+	summaryCut := len(summary) > maxLength-reserved
+	if summaryCut {
+		summary = cutLines(summary, maxLength-reserved)
+		b.Reset()
+		b.WriteString(summary)
+	}
+	budget := maxLength - len(summary) - reserved
+
+	// First fit as many blocks as possible in their short form, taking one
+	// block from each section in turn so that a long list of cycles does not
+	// crowd out the other sections, then expand them in order while there
+	// is room
+	included := make([][]string, len(sections))
+	done := make([]bool, len(sections))
+	for more := true; more; {
+		more = false
+		for i, s := range sections {
+			j := len(included[i])
+			if done[i] || j == len(s.blocks) {
+				continue
+			}
+			text := s.blocks[j].short()
+			if j == 0 {
+				text = s.title + s.header + text
+			}
+			if len(text) > budget {
+				done[i] = true
+				continue
+			}
+			included[i] = append(included[i], s.blocks[j].short())
+			budget -= len(text)
+			omitted[i]--
+			more = true
+		}
+	}
+	for i, s := range sections {
+		for j := range included[i] {
+			block := s.blocks[j]
+			if grow := len(block.full) - len(block.short()); grow > 0 && grow <= budget {
+				included[i][j] = block.full
+				budget -= grow
+			}
+		}
+	}
+
+	for i, s := range sections {
+		if len(included[i]) == 0 {
+			continue
+		}
+		b.WriteString(s.title)
+		b.WriteString(s.header)
+		for _, text := range included[i] {
+			b.WriteString(text)
+		}
+	}
+
+	b.WriteString(truncationNotice(sections, omitted, summaryCut))
+	b.WriteString(footer)
+	return clip(b.String(), maxLength)
+}
+
+// cutLines returns the longest prefix of text that ends with a newline and
+// is at most limit bytes long
+func cutLines(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	if limit <= 0 {
+		return ""
+	}
+	return text[:strings.LastIndex(text[:limit], "\n")+1]
+}
+
+// clip cuts text to at most limit bytes without splitting a UTF-8 sequence.
+// It only applies when the limit is too small for the notice and footer.
+func clip(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	for limit > 0 && !utf8.RuneStart(text[limit]) {
+		limit--
+	}
+	return text[:limit]
+}
+
+// truncationNotice describes the omitted blocks and summary rows, or returns
+// "" if nothing was omitted
+func truncationNotice(sections []markdownSection, omitted []int, summaryCut bool) string {
+	var parts []string
+	if summaryCut {
+		parts = append(parts, "part of the summary")
+	}
+	for i, s := range sections {
+		if omitted[i] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", omitted[i], s.noun))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n> [!NOTE]\n> Report truncated to fit the size limit: %s not shown. "+
+		"Run `monoguard analyze` for the full report.\n", strings.Join(parts, ", "))
+}
+
+// writeMarkdownSummary writes the title and the summary table
+func writeMarkdownSummary(b *strings.Builder, r *types.AnalysisResult) {
+	fmt.Fprintf(b, "## 🔍 MonoGuard Report\n\n")
+	fmt.Fprintf(b, "| Metric | Value |\n|---|---|\n")
+
+	if r.HealthScoreDetails != nil {
+		fmt.Fprintf(b, "| Health Score | %s **%d/100** (%s) |\n",
+			healthEmoji(r.HealthScore), r.HealthScore, r.HealthScoreDetails.Rating)
+	} else {
+		fmt.Fprintf(b, "| Health Score | %s **%d/100** |\n", healthEmoji(r.HealthScore), r.HealthScore)
+	}
+	if r.Graph != nil {
+		fmt.Fprintf(b, "| Workspace | %s |\n", r.Graph.WorkspaceType)
+	}
+	if r.ExcludedPackages > 0 {
+		fmt.Fprintf(b, "| Packages | %d (%d excluded) |\n", r.Packages, r.ExcludedPackages)
+	} else {
+		fmt.Fprintf(b, "| Packages | %d |\n", r.Packages)
+	}
+	fmt.Fprintf(b, "| Circular Dependencies | %d |\n", len(r.CircularDependencies))
+	fmt.Fprintf(b, "| Version Conflicts | %d |\n", len(r.VersionConflicts))
+	if len(r.BoundaryViolations) > 0 {
+		fmt.Fprintf(b, "| Boundary Violations | %d |\n", len(r.BoundaryViolations))
+	}
+	if len(r.PluginFindings) > 0 {
+		fmt.Fprintf(b, "| Plugin Findings | %d |\n", len(r.PluginFindings))
+	}
+	if len(r.PluginErrors) > 0 {
+		failed := make([]string, len(r.PluginErrors))
+		for i, e := range r.PluginErrors {
+			failed[i] = e.Plugin
+		}
+		fmt.Fprintf(b, "| Failed Plugins | ❌ %s |\n", codeList(failed))
+	}
+	if len(r.CustomRuleViolations) > 0 {
+		fmt.Fprintf(b, "| Custom Rule Violations | %d |\n", len(r.CustomRuleViolations))
+	}
+	if r.FixSummary != nil && r.FixSummary.TotalCircularDependencies > 0 {
+		fmt.Fprintf(b, "| Estimated Fix Time | %s (%d quick wins) |\n",
+			r.FixSummary.TotalEstimatedFixTime, r.FixSummary.QuickWinsCount)
+	}
+}
+
+// markdownBreakdown renders the health score factors as a table
+func markdownBreakdown(r *types.AnalysisResult) markdownSection {
+	s := markdownSection{
+		title:  "\n### 📊 Health Score Breakdown\n\n",
+		header: "| Factor | Score | Weight | Contribution |\n|---|---:|---:|---:|\n",
+		noun:   "health score factors",
+	}
+	if r.HealthScoreDetails == nil {
+		return s
+	}
+	for _, f := range r.HealthScoreDetails.Factors {
+		s.blocks = append(s.blocks, markdownBlock{full: fmt.Sprintf("| %s | %d | %.0f%% | %d |\n",
+			escapeTableCell(f.Name), f.Score, f.Weight*100, f.WeightedScore)})
+	}
+	return s
+}
+
+// markdownCycles renders each cycle as a collapsible section
+func markdownCycles(r *types.AnalysisResult) markdownSection {
+	s := markdownSection{
+		title: fmt.Sprintf("\n### 🔄 Circular Dependencies (%d)\n\n", len(r.CircularDependencies)),
+		noun:  "circular dependencies",
+	}
+	for _, cycle := range r.CircularDependencies {
+		s.blocks = append(s.blocks, markdownBlock{
+			full:    markdownCycle(cycle),
+			compact: fmt.Sprintf("- **[%s]** `%s`\n", cycle.Severity, strings.Join(cycle.Cycle, " → ")),
+		})
+	}
+	return s
+}
+
+// markdownCycle renders one cycle with its quick fix, root cause, import
+// traces and ripple effect
+func markdownCycle(cycle *types.CircularDependencyInfo) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<details>\n<summary><b>[%s]</b> <code>%s</code></summary>\n\n",
+		cycle.Severity, escapeHTML(strings.Join(cycle.Cycle, " → ")))
+
+	if qf := cycle.QuickFix; qf != nil {
+		fmt.Fprintf(&b, "**Quick fix:** %s", qf.StrategyName)
+		if qf.Summary != "" {
+			fmt.Fprintf(&b, " — %s", qf.Summary)
+		}
+		fmt.Fprintf(&b, " (%s effort, %s)\n\n", qf.Effort, qf.EstimatedTime)
+	}
+
+	if rc := cycle.RootCause; rc != nil {
+		fmt.Fprintf(&b, "**Root cause** (%d%% confidence): %s\n\n", rc.Confidence, rc.Explanation)
+		if rc.CriticalEdge != nil {
+			fmt.Fprintf(&b, "- Breaking point: `%s` → `%s` (%s)\n\n",
+				rc.CriticalEdge.From, rc.CriticalEdge.To, rc.CriticalEdge.Type)
+		}
+	}
+
+	if len(cycle.ImportTraces) > 0 {
+		fmt.Fprintf(&b, "**Imports**\n\n")
+		for _, trace := range cycle.ImportTraces {
+			fmt.Fprintf(&b, "- `%s:%d` `%s`\n", trace.FilePath, trace.LineNumber, strings.TrimSpace(trace.Statement))
+		}
+		fmt.Fprintln(&b)
+	}
+
+	if ia := cycle.ImpactAssessment; ia != nil {
+		fmt.Fprintf(&b, "**Impact:** %s risk, %d packages affected (%s)",
+			ia.RiskLevel, ia.TotalAffected, ia.AffectedPercentageDisplay)
+		if ia.RiskExplanation != "" {
+			fmt.Fprintf(&b, " — %s", ia.RiskExplanation)
+		}
+		fmt.Fprintf(&b, "\n\n")
+		if ia.RippleEffect != nil && len(ia.RippleEffect.Layers) > 0 {
+			fmt.Fprintf(&b, "| Distance | Packages |\n|---:|---|\n")
+			for _, layer := range ia.RippleEffect.Layers {
+				fmt.Fprintf(&b, "| %d | %s |\n", layer.Distance, escapeTableCell(codeList(layer.Packages)))
+			}
+			fmt.Fprintln(&b)
+		}
+	}
+
+	fmt.Fprintf(&b, "</details>\n\n")
+	return b.String()
+}
+
+// markdownConflicts renders version conflicts as a table
+func markdownConflicts(r *types.AnalysisResult) markdownSection {
+	s := markdownSection{
+		title:  fmt.Sprintf("\n### ⚠️ Version Conflicts (%d)\n\n", len(r.VersionConflicts)),
+		header: "| Package | Severity | Versions |\n|---|---|---|\n",
+		noun:   "version conflicts",
+	}
+	for _, conflict := range r.VersionConflicts {
+		versions := make([]string, 0, len(conflict.ConflictingVersions))
+		for _, v := range conflict.ConflictingVersions {
+			versions = append(versions, fmt.Sprintf("`%s` (%s)", v.Version, strings.Join(v.Packages, ", ")))
+		}
+		s.blocks = append(s.blocks, markdownBlock{full: fmt.Sprintf("| `%s` | %s | %s |\n",
+			conflict.PackageName, conflict.Severity, escapeTableCell(strings.Join(versions, ", ")))})
+	}
+	return s
+}
+
+// markdownViolations renders boundary violations as a list
+func markdownViolations(r *types.AnalysisResult) markdownSection {
+	s := markdownSection{
+		title: fmt.Sprintf("\n### 🚧 Boundary Violations (%d)\n\n", len(r.BoundaryViolations)),
+		noun:  "boundary violations",
+	}
+	for _, v := range r.BoundaryViolations {
+		s.blocks = append(s.blocks, markdownBlock{full: fmt.Sprintf("- %s\n", v.Message)})
+	}
+	return s
+}
+
+// markdownPluginFindings renders plugin findings as a table
+func markdownPluginFindings(r *types.AnalysisResult) markdownSection {
+	s := markdownSection{
+		title:  fmt.Sprintf("\n### 🧩 Plugin Findings (%d)\n\n", len(r.PluginFindings)),
+		header: "| Rule | Severity | Location | Message |\n|---|---|---|---|\n",
+		noun:   "plugin findings",
+	}
+	for _, f := range r.PluginFindings {
+		loc := location(f.File, f.Line)
+		if loc == "" {
+			loc = f.Package
+		}
+		if loc != "" {
+			loc = "`" + loc + "`"
+		}
+		s.blocks = append(s.blocks, markdownBlock{full: fmt.Sprintf("| `%s/%s` | %s | %s | %s |\n",
+			f.Plugin, f.Rule, f.Severity, escapeTableCell(loc), escapeTableCell(f.Message))})
+	}
+	return s
+}
+
+// markdownCustomRuleViolations renders custom rule violations as a table
+func markdownCustomRuleViolations(r *types.AnalysisResult) markdownSection {
+	s := markdownSection{
+		title:  fmt.Sprintf("\n### 📏 Custom Rule Violations (%d)\n\n", len(r.CustomRuleViolations)),
+		header: "| Rule | Severity | Message |\n|---|---|---|\n",
+		noun:   "custom rule violations",
+	}
+	for _, v := range r.CustomRuleViolations {
+		s.blocks = append(s.blocks, markdownBlock{full: fmt.Sprintf("| `%s` | %s | %s |\n",
+			v.Rule, v.Severity, escapeTableCell(v.Message))})
+	}
+	return s
+}
+
+// healthEmoji returns a traffic light for a health score
+func healthEmoji(score int) string {
+	switch {
+	case score >= 85:
+		return "🟢"
+	case score >= 50:
+		return "🟡"
+	default:
+		return "🔴"
+	}
+}
+
+// codeList formats names as comma-separated code spans
+func codeList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "`" + name + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// escapeTableCell escapes pipes, which would otherwise end a table cell
+func escapeTableCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// escapeHTML escapes text placed inside HTML tags such as <summary>
+func escapeHTML(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
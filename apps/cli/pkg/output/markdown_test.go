@@ -0,0 +1,212 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// markdownResult returns the sample result with root cause, impact and
+// health score factors filled in
+func markdownResult() *types.AnalysisResult {
+	result := sampleAnalysisResult()
+	result.HealthScoreDetails.Factors = []*types.HealthFactor{
+		{Name: "Circular Dependencies", Score: 80, Weight: 0.4, WeightedScore: 32},
+	}
+	cycle := result.CircularDependencies[0]
+	cycle.RootCause = &types.RootCauseAnalysis{
+		OriginatingPackage: "@mono/a",
+		Confidence:         85,
+		Explanation:        "@mono/a imports @mono/b for a shared helper",
+		CriticalEdge:       &types.RootCauseEdge{From: "@mono/a", To: "@mono/b", Type: types.DependencyTypeProduction},
+	}
+	cycle.ImpactAssessment = &types.ImpactAssessment{
+		TotalAffected:             3,
+		AffectedPercentageDisplay: "100%",
+		RiskLevel:                 types.RiskLevelHigh,
+		RippleEffect: &types.RippleEffect{
+			Layers: []types.RippleLayer{
+				{Distance: 0, Packages: []string{"@mono/a", "@mono/b"}, Count: 2},
+				{Distance: 1, Packages: []string{"@mono/c"}, Count: 1},
+			},
+			TotalLayers: 2,
+		},
+	}
+	return result
+}
+
+func TestFormatterMarkdown_AnalysisResult(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("markdown").PrintTo(&buf, markdownResult()); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"## 🔍 MonoGuard Report",
+		"| Health Score | 🟡 **72/100** (good) |",
+		"| Workspace | pnpm |",
+		"| Circular Dependencies | 1 |",
+		"| Circular Dependencies | 80 | 40% | 32 |",
+		"<summary><b>[warning]</b> <code>@mono/a → @mono/b → @mono/a</code></summary>",
+		"**Quick fix:** Extract Shared Module (medium effort, 30-60 minutes)",
+		"**Root cause** (85% confidence): @mono/a imports @mono/b for a shared helper",
+		"Breaking point: `@mono/a` → `@mono/b` (production)",
+		"- `packages/a/src/index.ts:1` `import { b } from '@mono/b'`",
+		"**Impact:** high risk, 3 packages affected (100%)",
+		"| 1 | `@mono/c` |",
+		"</details>",
+		"| `lodash` | critical | `^3.10.0` (@mono/b), `^4.17.21` (@mono/a) |",
+		`- @mono/a (layer "libs") must not depend on @mono/b (layer "apps")`,
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("markdown output missing %q\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "truncated") {
+		t.Errorf("unbounded output should not be truncated\n%s", output)
+	}
+}
+
+func TestRenderMarkdown_PluginResults(t *testing.T) {
+	output := RenderMarkdown(addPluginResults(markdownResult()), 0)
+	wantContains := []string{
+		"| Plugin Findings | 2 |",
+		"| Failed Plugins | ❌ `slow` |",
+		"### 🧩 Plugin Findings (2)",
+		"| `deps/no-lodash` | error | `packages/a/src/index.ts:3` | lodash is banned |",
+		"| `docs/readme` | info | `@mono/b` | add a README |",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("markdown output missing %q\n%s", want, output)
+		}
+	}
+}
+
+func TestRenderMarkdown_CustomRuleViolations(t *testing.T) {
+	output := RenderMarkdown(addCustomRuleViolations(markdownResult()), 0)
+	wantContains := []string{
+		"| Custom Rule Violations | 2 |",
+		"### 📏 Custom Rule Violations (2)",
+		"| `apps-are-leaves` | error | Apps must not be depended on (@mono/a → @mono/b) |",
+		"| `small` | warn | workspace |",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("markdown output missing %q\n%s", want, output)
+		}
+	}
+}
+
+func TestRenderMarkdown_NoIssues(t *testing.T) {
+	result := &types.AnalysisResult{HealthScore: 100, Packages: 2}
+	output := RenderMarkdown(result, 0)
+
+	if !strings.Contains(output, "| Health Score | 🟢 **100/100** |") {
+		t.Errorf("missing health score\n%s", output)
+	}
+	for _, heading := range []string{"###", "<details>"} {
+		if strings.Contains(output, heading) {
+			t.Errorf("output without issues should have no %q sections\n%s", heading, output)
+		}
+	}
+}
+
+func TestRenderMarkdown_MaxLength(t *testing.T) {
+	result := markdownResult()
+	for i := 0; i < 50; i++ {
+		cycle := *result.CircularDependencies[0]
+		cycle.Cycle = []string{fmt.Sprintf("@mono/p%d", i), "@mono/b", fmt.Sprintf("@mono/p%d", i)}
+		result.CircularDependencies = append(result.CircularDependencies, &cycle)
+	}
+	full := RenderMarkdown(result, 0)
+
+	for _, limit := range []int{len(full) / 2, 2000, 800} {
+		output := RenderMarkdown(result, limit)
+		if len(output) > limit {
+			t.Errorf("RenderMarkdown(%d) length = %d", limit, len(output))
+		}
+		if !strings.Contains(output, "| Health Score |") {
+			t.Errorf("RenderMarkdown(%d) dropped the summary", limit)
+		}
+		if strings.Count(output, "<details>") != strings.Count(output, "</details>") {
+			t.Errorf("RenderMarkdown(%d) cut a collapsible section", limit)
+		}
+	}
+
+	// Cycles that do not fit in full fall back to a single line
+	output := RenderMarkdown(result, len(full)/2)
+	if !strings.Contains(output, "- **[warning]** `@mono/p49 → @mono/b → @mono/p49`") {
+		t.Errorf("expected compact cycle lines\n%s", output)
+	}
+	if strings.Contains(output, "truncated") {
+		t.Errorf("nothing should be omitted when every cycle fits on one line\n%s", output)
+	}
+
+	// Findings that do not fit at all are counted in a notice, and the
+	// short sections after the cycles are kept
+	output = RenderMarkdown(result, 2000)
+	if !strings.Contains(output, "circular dependencies not shown") {
+		t.Errorf("missing truncation notice\n%s", output)
+	}
+	if !strings.Contains(output, "| `lodash` |") {
+		t.Errorf("version conflicts should not be crowded out by cycles\n%s", output)
+	}
+
+	// Everything fits: no notice
+	if got := RenderMarkdown(result, len(full)*2); got != full {
+		t.Error("output within the limit should match the unbounded output")
+	}
+}
+
+// TestRenderMarkdown_SummaryOverLimit verifies the summary is cut too when it
+// alone exceeds the limit
+func TestRenderMarkdown_SummaryOverLimit(t *testing.T) {
+	result := markdownResult()
+	for i := 0; i < 100; i++ {
+		result.PluginErrors = append(result.PluginErrors,
+			&types.PluginError{Plugin: fmt.Sprintf("plugin-with-a-long-name-%d", i), Message: "boom"})
+	}
+	var b strings.Builder
+	writeMarkdownSummary(&b, result)
+	if len(b.String()) < 2000 {
+		t.Fatalf("summary length = %d, want a summary over the limit", len(b.String()))
+	}
+
+	output := RenderMarkdown(result, 1000)
+	if len(output) > 1000 {
+		t.Errorf("RenderMarkdown(1000) length = %d", len(output))
+	}
+	for _, want := range []string{"| Health Score |", "part of the summary", "<sub>Generated by MonoGuard</sub>"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Failed Plugins") {
+		t.Errorf("the row that does not fit should be cut, not split\n%s", output)
+	}
+
+	// A limit below the notice and footer is still honored
+	for _, limit := range []int{1, 50, 200} {
+		if output := RenderMarkdown(result, limit); len(output) > limit || !utf8.ValidString(output) {
+			t.Errorf("RenderMarkdown(%d) = %q, want valid UTF-8 of at most %d bytes", limit, output, limit)
+		}
+	}
+}
+
+func TestFormatterMarkdown_OtherData(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("markdown").PrintTo(&buf, sampleCheckResult()); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	output := buf.String()
+	if !strings.HasPrefix(output, "```\n") || !strings.HasSuffix(output, "```\n") {
+		t.Errorf("non-analysis data should be a fenced text block\n%s", output)
+	}
+}
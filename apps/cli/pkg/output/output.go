@@ -8,11 +8,21 @@ import (
 	"os"
 	"reflect"
 	"strings"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/affected"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/fix"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/graph"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/history"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/watch"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
 )
 
 // Formatter handles output formatting
 type Formatter struct {
-	Format string // "text" or "json"
+	Format    string // "text", "json", "markdown", "sarif", "junit", "github", "gitlab-codequality", "dot", "mermaid" or "graphml"
+	MaxLength int    // Upper bound in bytes for markdown output; 0 means unlimited
 }
 
 // NewFormatter creates a new output formatter
@@ -32,44 +42,87 @@ func (f *Formatter) Print(data interface{}) error {
 
 // PrintTo outputs data in the configured format to the given writer
 func (f *Formatter) PrintTo(w io.Writer, data interface{}) error {
-	if f.Format == "json" {
+	switch f.Format {
+	case "json":
 		b, err := json.MarshalIndent(data, "", "  ")
 		if err != nil {
 			return err
 		}
 		fmt.Fprintln(w, string(b))
-	} else {
-		// For text format, provide structured output
-		switch v := data.(type) {
-		case string:
-			fmt.Fprintln(w, v)
-		case map[string]interface{}:
-			for key, val := range v {
-				fmt.Fprintf(w, "%s: %v\n", capitalize(key), val)
-			}
-		case map[string]string:
-			for key, val := range v {
-				fmt.Fprintf(w, "%s: %s\n", capitalize(key), val)
-			}
-		default:
-			// Use reflection for structs
-			val := reflect.ValueOf(data)
-			if val.Kind() == reflect.Ptr {
-				val = val.Elem()
-			}
-			if val.Kind() == reflect.Struct {
-				typ := val.Type()
-				for i := 0; i < val.NumField(); i++ {
-					field := typ.Field(i)
-					value := val.Field(i)
-					fmt.Fprintf(w, "%s: %v\n", field.Name, value.Interface())
-				}
-			} else {
-				fmt.Fprintf(w, "%+v\n", data)
+	case "markdown":
+		writeMarkdown(w, data, f.MaxLength)
+	case "sarif":
+		return writeSARIF(w, data)
+	case "junit":
+		return writeJUnit(w, data)
+	case "github":
+		writeGitHub(w, data)
+	case "gitlab-codequality":
+		return writeCodeQuality(w, data)
+	case "dot", "mermaid", "graphml":
+		return writeGraphExport(w, f.Format, data)
+	default:
+		writeText(w, data)
+	}
+	return nil
+}
+
+// writeText renders data as human-readable text
+func writeText(w io.Writer, data interface{}) {
+	switch v := data.(type) {
+	case string:
+		fmt.Fprintln(w, v)
+	case *types.AnalysisResult:
+		writeAnalysisText(w, v)
+	case *types.CheckResult:
+		writeCheckText(w, v)
+	case *fix.Report:
+		writeFixText(w, v)
+	case *config.InitResult:
+		writeInitText(w, v)
+	case *config.Resolved:
+		writeResolvedText(w, v)
+	case *watch.Event:
+		writeWatchText(w, v)
+	case *graph.View:
+		writeGraphText(w, v)
+	case *graph.Explanation:
+		writeExplanationText(w, v)
+	case *analysis.Diff:
+		writeDiffText(w, v)
+	case *affected.Result:
+		writeAffectedText(w, v)
+	case *history.Trend:
+		writeTrendText(w, v)
+	case *analysis.Checkup:
+		writeCheckupText(w, v)
+	case *types.Ownership:
+		writeOwnershipText(w, v)
+	case map[string]interface{}:
+		for key, val := range v {
+			fmt.Fprintf(w, "%s: %v\n", capitalize(key), val)
+		}
+	case map[string]string:
+		for key, val := range v {
+			fmt.Fprintf(w, "%s: %s\n", capitalize(key), val)
+		}
+	default:
+		// Use reflection for structs
+		val := reflect.ValueOf(data)
+		if val.Kind() == reflect.Ptr {
+			val = val.Elem()
+		}
+		if val.Kind() == reflect.Struct {
+			typ := val.Type()
+			for i := 0; i < val.NumField(); i++ {
+				field := typ.Field(i)
+				value := val.Field(i)
+				fmt.Fprintf(w, "%s: %v\n", field.Name, value.Interface())
 			}
+		} else {
+			fmt.Fprintf(w, "%+v\n", data)
 		}
 	}
-	return nil
 }
 
 // capitalize returns the string with first letter capitalized
@@ -0,0 +1,61 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// writeOwnershipText renders the cross-team dependencies and the issues of
+// each team
+func writeOwnershipText(w io.Writer, o *types.Ownership) {
+	fmt.Fprintf(w, "👥 MonoGuard Ownership: %d teams\n", len(o.Teams))
+
+	fmt.Fprintln(w)
+	if len(o.CrossTeamEdges) == 0 {
+		fmt.Fprintf(w, "✅ No cross-team dependencies\n")
+	} else {
+		fmt.Fprintf(w, "🔗 Cross-Team Dependencies (%d)\n", len(o.CrossTeamEdges))
+		for _, e := range o.CrossTeamEdges {
+			fmt.Fprintf(w, "   %s (%s) → %s (%s)\n", e.From, ownerList(e.FromOwners), e.To, ownerList(e.ToOwners))
+		}
+	}
+
+	for _, team := range o.Teams {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "%s: %d packages, %d incoming, %d outgoing\n",
+			team.Owner, len(team.Packages), team.IncomingEdges, team.OutgoingEdges)
+		fmt.Fprintf(w, "   Packages: %s\n", strings.Join(team.Packages, ", "))
+		if len(team.Cycles) > 0 {
+			fmt.Fprintf(w, "   🔄 Cycles (%d)\n", len(team.Cycles))
+			for _, cycle := range team.Cycles {
+				fmt.Fprintf(w, "      %s\n", cycle)
+			}
+		}
+		if len(team.VersionConflicts) > 0 {
+			fmt.Fprintf(w, "   ⚠️  Version conflicts: %s\n", strings.Join(team.VersionConflicts, ", "))
+		}
+		if len(team.BoundaryViolations) > 0 {
+			fmt.Fprintf(w, "   🚧 Boundary violations (%d)\n", len(team.BoundaryViolations))
+			for _, v := range team.BoundaryViolations {
+				fmt.Fprintf(w, "      %s\n", v)
+			}
+		}
+	}
+
+	if len(o.Unowned) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "❔ Unowned packages (%d): %s\n", len(o.Unowned), strings.Join(o.Unowned, ", "))
+	}
+}
+
+// ownerList joins owners, or returns "unowned" for none
+func ownerList(owners []string) string {
+	if len(owners) == 0 {
+		return "unowned"
+	}
+	return strings.Join(owners, " ")
+}
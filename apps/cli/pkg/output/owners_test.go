@@ -0,0 +1,61 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+func TestFormatterText_Ownership(t *testing.T) {
+	o := &types.Ownership{
+		CrossTeamEdges: []*types.CrossTeamEdge{
+			{From: "@mono/web", To: "@mono/ui", FromOwners: []string{"@org/web"}, ToOwners: []string{"@org/design"}},
+			{From: "@mono/web", To: "@mono/old", FromOwners: []string{"@org/web"}},
+		},
+		Teams: []*types.TeamReport{
+			{Owner: "@org/design", Packages: []string{"@mono/ui"}, IncomingEdges: 1},
+			{
+				Owner:              "@org/web",
+				Packages:           []string{"@mono/api", "@mono/web"},
+				Cycles:             []string{"@mono/api → @mono/web → @mono/api"},
+				VersionConflicts:   []string{"react"},
+				BoundaryViolations: []string{"@mono/web → @mono/ui"},
+				OutgoingEdges:      2,
+			},
+		},
+		Unowned: []string{"@mono/old"},
+	}
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, o); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"👥 MonoGuard Ownership: 2 teams",
+		"🔗 Cross-Team Dependencies (2)\n   @mono/web (@org/web) → @mono/ui (@org/design)\n   @mono/web (@org/web) → @mono/old (unowned)\n",
+		"@org/design: 1 packages, 1 incoming, 0 outgoing\n   Packages: @mono/ui\n",
+		"@org/web: 2 packages, 0 incoming, 2 outgoing\n   Packages: @mono/api, @mono/web\n   🔄 Cycles (1)\n      @mono/api → @mono/web → @mono/api\n",
+		"⚠️  Version conflicts: react",
+		"🚧 Boundary violations (1)\n      @mono/web → @mono/ui\n",
+		"❔ Unowned packages (1): @mono/old",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatterText_OwnershipNoCrossTeamEdges(t *testing.T) {
+	var buf bytes.Buffer
+	o := &types.Ownership{CrossTeamEdges: []*types.CrossTeamEdge{}, Teams: []*types.TeamReport{}}
+	if err := NewFormatter("text").PrintTo(&buf, o); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "✅ No cross-team dependencies") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
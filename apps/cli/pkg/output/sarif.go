@@ -0,0 +1,395 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// SARIF 2.1.0 constants
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifSrcRoot = "%SRCROOT%"
+	toolInfoURI  = "https://github.com/j620656786206/MonoGuard"
+)
+
+// SARIF rule IDs
+const (
+	ruleCircularDependency = "circular-dependency"
+	ruleVersionConflict    = "version-conflict"
+	ruleBoundaryViolation  = "boundary-violation"
+	ruleLowHealthScore     = "low-health-score"
+	rulePluginFinding      = "plugin-finding"
+	rulePluginFailed       = "plugin-failed"
+	ruleCustomRule         = "custom-rule"
+)
+
+// sarifRules describes every rule MonoGuard can report, in rule index order
+var sarifRules = []sarifRule{
+	{
+		ID:                   ruleCircularDependency,
+		Name:                 "CircularDependency",
+		ShortDescription:     sarifMessage{Text: "Circular dependency between workspace packages"},
+		FullDescription:      sarifMessage{Text: "Workspace packages depend on each other in a cycle, which couples their builds and releases. Run `monoguard fix` for fix strategies."},
+		DefaultConfiguration: sarifConfiguration{Level: "error"},
+	},
+	{
+		ID:                   ruleVersionConflict,
+		Name:                 "VersionConflict",
+		ShortDescription:     sarifMessage{Text: "External dependency declared with conflicting versions"},
+		FullDescription:      sarifMessage{Text: "Workspace packages declare different versions of the same external dependency, which can duplicate it in bundles or break at runtime."},
+		DefaultConfiguration: sarifConfiguration{Level: "warning"},
+	},
+	{
+		ID:                   ruleBoundaryViolation,
+		Name:                 "BoundaryViolation",
+		ShortDescription:     sarifMessage{Text: "Dependency crosses a disallowed layer boundary"},
+		FullDescription:      sarifMessage{Text: "A package depends on a package in a layer its own layer may not depend on, as configured under `layers` in .monoguard.yaml."},
+		DefaultConfiguration: sarifConfiguration{Level: "error"},
+	},
+	{
+		ID:                   ruleLowHealthScore,
+		Name:                 "LowHealthScore",
+		ShortDescription:     sarifMessage{Text: "Health score below the configured threshold"},
+		FullDescription:      sarifMessage{Text: "The workspace health score is below `thresholds.healthScore` in .monoguard.yaml or the --threshold flag."},
+		DefaultConfiguration: sarifConfiguration{Level: "error"},
+	},
+	{
+		ID:                   rulePluginFinding,
+		Name:                 "PluginFinding",
+		ShortDescription:     sarifMessage{Text: "Issue reported by a rule plugin"},
+		FullDescription:      sarifMessage{Text: "A plugin configured under `plugins` in .monoguard.yaml reported an issue. The message starts with the plugin name and its rule ID."},
+		DefaultConfiguration: sarifConfiguration{Level: "warning"},
+	},
+	{
+		ID:                   rulePluginFailed,
+		Name:                 "PluginFailed",
+		ShortDescription:     sarifMessage{Text: "Rule plugin failed"},
+		FullDescription:      sarifMessage{Text: "A plugin configured under `plugins` in .monoguard.yaml exited with an error, timed out or wrote invalid output, so its rules were not checked."},
+		DefaultConfiguration: sarifConfiguration{Level: "warning"},
+	},
+	{
+		ID:                   ruleCustomRule,
+		Name:                 "CustomRuleViolation",
+		ShortDescription:     sarifMessage{Text: "Violation of a custom rule"},
+		FullDescription:      sarifMessage{Text: "A package, dependency or the workspace matched the forbid expression of a rule configured under `customRules` in .monoguard.yaml. The message starts with the rule name."},
+		DefaultConfiguration: sarifConfiguration{Level: "error"},
+	},
+}
+
+// checkCodeRules maps check codes to SARIF rule IDs
+var checkCodeRules = map[string]string{
+	types.CheckCodeCircularDetected:    ruleCircularDependency,
+	types.CheckCodeBoundaryViolation:   ruleBoundaryViolation,
+	types.CheckCodeVersionConflict:     ruleVersionConflict,
+	types.CheckCodeLowHealthScore:      ruleLowHealthScore,
+	types.CheckCodePluginFinding:       rulePluginFinding,
+	types.CheckCodePluginFailed:        rulePluginFailed,
+	types.CheckCodeCustomRuleViolation: ruleCustomRule,
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                   `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactLoc `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult               `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	FullDescription      sarifMessage       `json:"fullDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           *int              `json:"ruleIndex,omitempty"` // Unset for rules not in sarifRules
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations,omitempty"`
+	RelatedLocations    []sarifLocation   `json:"relatedLocations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+type sarifLocation struct {
+	ID               int                   `json:"id,omitempty"`
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	Message          *sarifMessage         `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLoc `json:"artifactLocation"`
+	Region           *sarifRegion     `json:"region,omitempty"`
+}
+
+type sarifArtifactLoc struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// writeSARIF renders analysis or check results as a SARIF 2.1.0 log
+func writeSARIF(w io.Writer, data interface{}) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "MonoGuard",
+			InformationURI: toolInfoURI,
+			Rules:          sarifRules,
+		}},
+		Results: []sarifResult{},
+	}
+
+	switch v := data.(type) {
+	case *types.AnalysisResult:
+		run.Results = analysisSARIFResults(v)
+		if v.Graph != nil && v.Graph.RootPath != "" {
+			run.OriginalURIBaseIDs = map[string]sarifArtifactLoc{
+				sarifSrcRoot: {URI: rootURI(v.Graph.RootPath)},
+			}
+		}
+	case *types.CheckResult:
+		run.Results = checkSARIFResults(v)
+	default:
+		return fmt.Errorf("sarif format is only supported for analysis and check results")
+	}
+
+	b, err := json.MarshalIndent(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(w, string(b))
+	return nil
+}
+
+// analysisSARIFResults maps cycles, version conflicts, boundary violations,
+// plugin findings and custom rule violations to results
+func analysisSARIFResults(r *types.AnalysisResult) []sarifResult {
+	results := []sarifResult{}
+
+	for _, cycle := range r.CircularDependencies {
+		result := newSARIFResult(ruleCircularDependency, severityLevel(string(cycle.Severity)),
+			fmt.Sprintf("Circular dependency: %s", strings.Join(cycle.Cycle, " → ")))
+		for i, trace := range cycle.ImportTraces {
+			loc := sarifFileLocation(trace.FilePath, trace.LineNumber)
+			if i == 0 {
+				result.Locations = []sarifLocation{loc}
+				continue
+			}
+			loc.ID = i
+			loc.Message = &sarifMessage{Text: fmt.Sprintf("%s imports %s", trace.FromPackage, trace.ToPackage)}
+			result.RelatedLocations = append(result.RelatedLocations, loc)
+		}
+		if len(result.Locations) == 0 && len(cycle.Cycle) > 0 {
+			result.Locations = packageLocations(r.Graph, cycle.Cycle[:1])
+		}
+		result.PartialFingerprints = map[string]string{
+			"monoguardCycle/v1": strings.ReplaceAll(analysis.CycleKey(cycle.Cycle), "\x00", ","),
+		}
+		results = append(results, result)
+	}
+
+	for _, conflict := range r.VersionConflicts {
+		versions := make([]string, 0, len(conflict.ConflictingVersions))
+		var pkgs []string
+		for _, v := range conflict.ConflictingVersions {
+			versions = append(versions, fmt.Sprintf("%s (%s)", v.Version, strings.Join(v.Packages, ", ")))
+			pkgs = append(pkgs, v.Packages...)
+		}
+		result := newSARIFResult(ruleVersionConflict, severityLevel(string(conflict.Severity)),
+			fmt.Sprintf("Version conflict for %s: %s", conflict.PackageName, strings.Join(versions, " vs ")))
+		if locs := packageLocations(r.Graph, pkgs); len(locs) > 0 {
+			result.Locations = locs[:1]
+			for i, loc := range locs[1:] {
+				loc.ID = i + 1
+				result.RelatedLocations = append(result.RelatedLocations, loc)
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, v := range r.BoundaryViolations {
+		result := newSARIFResult(ruleBoundaryViolation, "error", v.Message)
+		result.Locations = packageLocations(r.Graph, []string{v.From})
+		results = append(results, result)
+	}
+
+	for _, f := range r.PluginFindings {
+		result := newSARIFResult(rulePluginFinding, pluginLevel(f.Severity), pluginMessage(f))
+		result.Locations = []sarifLocation{sarifFileLocation(pluginFindingFile(r.Graph, f), f.Line)}
+		results = append(results, result)
+	}
+	for _, e := range r.PluginErrors {
+		result := newSARIFResult(rulePluginFailed, "warning", fmt.Sprintf("Plugin %s failed: %s", e.Plugin, e.Message))
+		result.Locations = []sarifLocation{sarifFileLocation(config.FileName, 0)}
+		results = append(results, result)
+	}
+
+	for _, v := range r.CustomRuleViolations {
+		result := newSARIFResult(ruleCustomRule, customRuleLevel(v.Severity), customRuleMessage(v))
+		result.Locations = []sarifLocation{sarifFileLocation(customRuleFile(r.Graph, v), 0)}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// checkSARIFResults maps check errors and warnings to results
+func checkSARIFResults(r *types.CheckResult) []sarifResult {
+	results := []sarifResult{}
+	for _, e := range r.Errors {
+		results = append(results, checkSARIFResult(e.Code, "error", e.Message, e.File, e.Line))
+	}
+	for _, w := range r.Warnings {
+		results = append(results, checkSARIFResult(w.Code, "warning", w.Message, w.File, w.Line))
+	}
+	return results
+}
+
+// checkSARIFResult builds one result for a check finding. Findings without a
+// file, such as a low health score, are reported against the root package.json.
+func checkSARIFResult(code, level, message, file string, line int) sarifResult {
+	ruleID, ok := checkCodeRules[code]
+	if !ok {
+		ruleID = strings.ToLower(strings.ReplaceAll(code, "_", "-"))
+	}
+	result := newSARIFResult(ruleID, level, message)
+	if file == "" {
+		file = "package.json"
+	}
+	result.Locations = []sarifLocation{sarifFileLocation(file, line)}
+	return result
+}
+
+// newSARIFResult creates a result for a rule. The rule index is left out for
+// rules that are not described in the log.
+func newSARIFResult(ruleID, level, message string) sarifResult {
+	result := sarifResult{
+		RuleID:  ruleID,
+		Level:   level,
+		Message: sarifMessage{Text: message},
+	}
+	for i, rule := range sarifRules {
+		if rule.ID == ruleID {
+			index := i
+			result.RuleIndex = &index
+			break
+		}
+	}
+	return result
+}
+
+// rootURI returns the file URI of the workspace root directory, with the
+// trailing slash SARIF requires for base URIs
+func rootURI(root string) string {
+	p := strings.TrimSuffix(filepath.ToSlash(root), "/") + "/"
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p // Windows drive letter
+	}
+	u := url.URL{Scheme: "file", Path: p}
+	return u.String()
+}
+
+// sarifFileLocation returns a location relative to the workspace root.
+// Line 0 means the location covers the whole file.
+func sarifFileLocation(file string, line int) sarifLocation {
+	loc := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLoc{URI: file, URIBaseID: sarifSrcRoot},
+	}}
+	if line > 0 {
+		loc.PhysicalLocation.Region = &sarifRegion{StartLine: line}
+	}
+	return loc
+}
+
+// packageLocations returns the package.json locations of the given packages,
+// skipping packages that are not in the graph and duplicates
+func packageLocations(graph *types.DependencyGraph, pkgs []string) []sarifLocation {
+	if graph == nil {
+		return nil
+	}
+	var locs []sarifLocation
+	seen := map[string]bool{}
+	for _, name := range pkgs {
+		node, ok := graph.Nodes[name]
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		locs = append(locs, sarifFileLocation(path.Join(node.Path, "package.json"), 0))
+	}
+	return locs
+}
+
+// severityLevel maps engine severities (critical, warning, info) to SARIF levels
+func severityLevel(severity string) string {
+	switch severity {
+	case "critical":
+		return "error"
+	case "info":
+		return "note"
+	default:
+		return "warning"
+	}
+}
+
+// customRuleLevel maps custom rule severities (error, warn) to SARIF levels
+func customRuleLevel(severity types.RuleSeverity) string {
+	if severity == types.RuleSeverityWarn {
+		return "warning"
+	}
+	return "error"
+}
+
+// pluginLevel maps plugin severities (error, warning, info) to SARIF levels
+func pluginLevel(severity types.PluginSeverity) string {
+	switch severity {
+	case types.PluginSeverityError:
+		return "error"
+	case types.PluginSeverityInfo:
+		return "note"
+	default:
+		return "warning"
+	}
+}
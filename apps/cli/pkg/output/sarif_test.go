@@ -0,0 +1,224 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// decodeSARIF prints data as SARIF and decodes the resulting log
+func decodeSARIF(t *testing.T, data interface{}) sarifLog {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := NewFormatter("sarif").PrintTo(&buf, data); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("version = %q, runs = %d; want 2.1.0 with one run", log.Version, len(log.Runs))
+	}
+	return log
+}
+
+func TestFormatterSARIF_AnalysisResult(t *testing.T) {
+	result := sampleAnalysisResult()
+	result.Graph.Nodes["@mono/a"] = &types.PackageNode{Name: "@mono/a", Path: "packages/a"}
+	result.Graph.Nodes["@mono/b"] = &types.PackageNode{Name: "@mono/b", Path: "packages/b"}
+
+	log := decodeSARIF(t, result)
+	run := log.Runs[0]
+
+	if run.Tool.Driver.Name != "MonoGuard" || len(run.Tool.Driver.Rules) != len(sarifRules) {
+		t.Errorf("driver = %+v, want MonoGuard with all rules", run.Tool.Driver)
+	}
+	if got := run.OriginalURIBaseIDs[sarifSrcRoot].URI; got != "file:///repo/" {
+		t.Errorf("%s = %q, want file:///repo/", sarifSrcRoot, got)
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("results = %d, want 3", len(run.Results))
+	}
+
+	tests := []struct {
+		ruleID string
+		level  string
+		uri    string
+		line   int
+	}{
+		{ruleCircularDependency, "warning", "packages/a/src/index.ts", 1},
+		{ruleVersionConflict, "error", "packages/b/package.json", 0},
+		{ruleBoundaryViolation, "error", "packages/a/package.json", 0},
+	}
+	for i, tt := range tests {
+		r := run.Results[i]
+		if r.RuleID != tt.ruleID || r.Level != tt.level {
+			t.Errorf("result %d = %s/%s, want %s/%s", i, r.RuleID, r.Level, tt.ruleID, tt.level)
+		}
+		if r.RuleIndex == nil || run.Tool.Driver.Rules[*r.RuleIndex].ID != r.RuleID {
+			t.Errorf("result %d ruleIndex %v does not point at %s", i, r.RuleIndex, r.RuleID)
+		}
+		if len(r.Locations) != 1 {
+			t.Fatalf("result %d locations = %d, want 1", i, len(r.Locations))
+		}
+		loc := r.Locations[0].PhysicalLocation
+		if loc.ArtifactLocation.URI != tt.uri || loc.ArtifactLocation.URIBaseID != sarifSrcRoot {
+			t.Errorf("result %d location = %+v, want %s", i, loc.ArtifactLocation, tt.uri)
+		}
+		line := 0
+		if loc.Region != nil {
+			line = loc.Region.StartLine
+		}
+		if line != tt.line {
+			t.Errorf("result %d startLine = %d, want %d", i, line, tt.line)
+		}
+	}
+
+	if fp := run.Results[0].PartialFingerprints["monoguardCycle/v1"]; fp != "@mono/a,@mono/b" {
+		t.Errorf("cycle fingerprint = %q", fp)
+	}
+	if related := run.Results[1].RelatedLocations; len(related) != 1 ||
+		related[0].PhysicalLocation.ArtifactLocation.URI != "packages/a/package.json" {
+		t.Errorf("version conflict relatedLocations = %+v", related)
+	}
+}
+
+func TestFormatterSARIF_PluginResults(t *testing.T) {
+	result := addPluginResults(sampleGraphResult())
+	run := decodeSARIF(t, result).Runs[0]
+
+	tests := []struct {
+		ruleID string
+		level  string
+		uri    string
+		line   int
+	}{
+		{rulePluginFinding, "error", "packages/a/src/index.ts", 3},
+		{rulePluginFinding, "note", "packages/b/package.json", 0},
+		{rulePluginFailed, "warning", ".monoguard.yaml", 0},
+	}
+	results := run.Results[len(run.Results)-len(tests):]
+	for i, tt := range tests {
+		r := results[i]
+		if r.RuleID != tt.ruleID || r.Level != tt.level || r.RuleIndex == nil {
+			t.Errorf("result %d = %s/%s (index %v), want %s/%s", i, r.RuleID, r.Level, r.RuleIndex, tt.ruleID, tt.level)
+			continue
+		}
+		loc := r.Locations[0].PhysicalLocation
+		line := 0
+		if loc.Region != nil {
+			line = loc.Region.StartLine
+		}
+		if loc.ArtifactLocation.URI != tt.uri || line != tt.line {
+			t.Errorf("result %d location = %s:%d, want %s:%d", i, loc.ArtifactLocation.URI, line, tt.uri, tt.line)
+		}
+	}
+	if got := results[0].Message.Text; got != "deps/no-lodash: lodash is banned" {
+		t.Errorf("message = %q", got)
+	}
+}
+
+func TestFormatterSARIF_CustomRuleViolations(t *testing.T) {
+	run := decodeSARIF(t, addCustomRuleViolations(sampleGraphResult())).Runs[0]
+
+	tests := []struct {
+		level   string
+		uri     string
+		message string
+	}{
+		{"error", "packages/a/package.json", "apps-are-leaves: Apps must not be depended on (@mono/a → @mono/b)"},
+		{"warning", "package.json", "small: workspace"},
+	}
+	results := run.Results[len(run.Results)-len(tests):]
+	for i, tt := range tests {
+		r := results[i]
+		if r.RuleID != ruleCustomRule || r.Level != tt.level || r.RuleIndex == nil {
+			t.Errorf("result %d = %s/%s (index %v), want %s/%s", i, r.RuleID, r.Level, r.RuleIndex, ruleCustomRule, tt.level)
+			continue
+		}
+		if uri := r.Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != tt.uri {
+			t.Errorf("result %d location = %s, want %s", i, uri, tt.uri)
+		}
+		if r.Message.Text != tt.message {
+			t.Errorf("result %d message = %q, want %q", i, r.Message.Text, tt.message)
+		}
+	}
+}
+
+func TestFormatterSARIF_CheckResult(t *testing.T) {
+	check := sampleCheckResult()
+	check.Errors = append(check.Errors, types.ValidationError{
+		Code:    types.CheckCodeLowHealthScore,
+		Message: "Health score 64 is below threshold 80",
+	})
+
+	run := decodeSARIF(t, check).Runs[0]
+	if len(run.Results) != 3 {
+		t.Fatalf("results = %d, want 3", len(run.Results))
+	}
+
+	tests := []struct {
+		ruleID string
+		level  string
+		uri    string
+	}{
+		{ruleCircularDependency, "error", "packages/a/src/index.ts"},
+		{ruleLowHealthScore, "error", "package.json"},
+		{ruleBoundaryViolation, "warning", "packages/ui/package.json"},
+	}
+	for i, tt := range tests {
+		r := run.Results[i]
+		if r.RuleID != tt.ruleID || r.Level != tt.level {
+			t.Errorf("result %d = %s/%s, want %s/%s", i, r.RuleID, r.Level, tt.ruleID, tt.level)
+		}
+		if got := r.Locations[0].PhysicalLocation.ArtifactLocation.URI; got != tt.uri {
+			t.Errorf("result %d uri = %q, want %q", i, got, tt.uri)
+		}
+	}
+	if region := run.Results[0].Locations[0].PhysicalLocation.Region; region == nil || region.StartLine != 3 {
+		t.Errorf("circular result region = %+v, want line 3", region)
+	}
+}
+
+// TestFormatterSARIF_UnknownRule verifies results of codes without a rule
+// descriptor leave out ruleIndex instead of using an invalid index
+func TestFormatterSARIF_UnknownRule(t *testing.T) {
+	check := &types.CheckResult{Errors: []types.ValidationError{{Code: "NEW_CHECK", Message: "new check failed"}}}
+
+	var buf bytes.Buffer
+	if err := NewFormatter("sarif").PrintTo(&buf, check); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "ruleIndex") {
+		t.Errorf("output contains a ruleIndex for an unknown rule:\n%s", buf.String())
+	}
+	if run := decodeSARIF(t, check).Runs[0]; run.Results[0].RuleID != "new-check" {
+		t.Errorf("ruleId = %q, want new-check", run.Results[0].RuleID)
+	}
+}
+
+func TestRootURI(t *testing.T) {
+	tests := map[string]string{
+		"/repo":            "file:///repo/",
+		"/repo/":           "file:///repo/",
+		"/my repo/mono":    "file:///my%20repo/mono/",
+		"C:/work/monorepo": "file:///C:/work/monorepo/",
+	}
+	for root, want := range tests {
+		if got := rootURI(root); got != want {
+			t.Errorf("rootURI(%q) = %q, want %q", root, got, want)
+		}
+	}
+}
+
+func TestFormatterSARIF_Unsupported(t *testing.T) {
+	var buf bytes.Buffer
+	err := NewFormatter("sarif").PrintTo(&buf, "✅ No circular dependencies to fix")
+	if err == nil || !strings.Contains(err.Error(), "sarif") {
+		t.Errorf("PrintTo() error = %v, want unsupported sarif error", err)
+	}
+}
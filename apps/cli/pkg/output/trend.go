@@ -0,0 +1,73 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/history"
+)
+
+// sparkBars are the sparkline characters from lowest to highest
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// writeTrendText renders each metric as a sparkline with its change over
+// the window and in the last run
+func writeTrendText(w io.Writer, t *history.Trend) {
+	if t.Records == 0 {
+		fmt.Fprintf(w, "📈 MonoGuard Trend: no runs recorded yet\n")
+		fmt.Fprintf(w, "   Record runs with \"monoguard analyze --record\"\n")
+		return
+	}
+	fmt.Fprintf(w, "📈 MonoGuard Trend: %d runs\n", t.Records)
+	fmt.Fprintf(w, "   %s → %s\n\n", runLabel(t.From, t.FromCommit), runLabel(t.To, t.ToCommit))
+
+	for _, m := range t.Metrics {
+		first, last := m.Values[0], m.Values[len(m.Values)-1]
+		mark := ""
+		if m.Regressed {
+			mark = "  ⚠️ regressed"
+		}
+		fmt.Fprintf(w, "   %-22s %s  %3d → %3d (%s, last run %s)%s\n",
+			m.Name, Sparkline(m.Values), first, last, signed(m.Change), signed(m.LastChange), mark)
+	}
+
+	fmt.Fprintln(w)
+	if t.Regressed() {
+		fmt.Fprintf(w, "❌ Regressions in the last run\n")
+	} else {
+		fmt.Fprintf(w, "✅ No regressions\n")
+	}
+}
+
+// runLabel names a recorded run by its timestamp and short commit
+func runLabel(timestamp, commit string) string {
+	if len(commit) > 7 {
+		commit = commit[:7]
+	}
+	if commit == "" {
+		return timestamp
+	}
+	return fmt.Sprintf("%s (%s)", timestamp, commit)
+}
+
+// Sparkline renders values as bars scaled between their minimum and maximum.
+// Constant values are drawn at mid height.
+func Sparkline(values []int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	bars := make([]rune, len(values))
+	for i, v := range values {
+		if hi == lo {
+			bars[i] = sparkBars[len(sparkBars)/2-1]
+			continue
+		}
+		bars[i] = sparkBars[(v-lo)*(len(sparkBars)-1)/(hi-lo)]
+	}
+	return string(bars)
+}
@@ -0,0 +1,62 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/history"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   string
+	}{
+		{nil, ""},
+		{[]int{0, 7}, "▁█"},
+		{[]int{10, 20, 30, 40, 50, 60, 70, 80}, "▁▂▃▄▅▆▇█"},
+		{[]int{5, 5, 5}, "▄▄▄"},
+	}
+	for _, tt := range tests {
+		if got := Sparkline(tt.values); got != tt.want {
+			t.Errorf("Sparkline(%v) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestFormatterText_Trend(t *testing.T) {
+	trend := history.NewTrend([]history.Record{
+		{Timestamp: "2026-10-01T00:00:00Z", Commit: "0123456789abcdef", HealthScore: 70, Cycles: 2},
+		{Timestamp: "2026-10-02T00:00:00Z", HealthScore: 80, Cycles: 1},
+		{Timestamp: "2026-10-03T00:00:00Z", Commit: "fedcba9876543210", HealthScore: 75, Cycles: 1},
+	}, 0)
+
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, trend); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	output := buf.String()
+	wantContains := []string{
+		"📈 MonoGuard Trend: 3 runs",
+		"2026-10-01T00:00:00Z (0123456) → 2026-10-03T00:00:00Z (fedcba9)",
+		"Health Score           ▁█▄   70 →  75 (+5, last run -5)  ⚠️ regressed",
+		"Cycles                 █▁▁    2 →   1 (-1, last run ±0)\n",
+		"❌ Regressions in the last run",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatterText_TrendEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, history.NewTrend(nil, 0)); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "no runs recorded yet") {
+		t.Errorf("output = %q, want empty history notice", buf.String())
+	}
+}
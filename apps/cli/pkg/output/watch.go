@@ -0,0 +1,53 @@
+// Package output provides formatted output utilities
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/watch"
+)
+
+// maxWatchFiles is how many changed files are listed per watch event
+const maxWatchFiles = 3
+
+// writeWatchText renders a watch event as a short status block
+func writeWatchText(w io.Writer, e *watch.Event) {
+	stamp := e.Time
+	if t, err := time.Parse(time.RFC3339, e.Time); err == nil {
+		stamp = t.Format("15:04:05")
+	}
+
+	switch {
+	case len(e.Files) == 0:
+		fmt.Fprintf(w, "👀 [%s] Watching %d packages\n", stamp, e.Packages)
+	case len(e.Files) > maxWatchFiles:
+		fmt.Fprintf(w, "🔁 [%s] %s (+%d more)\n", stamp,
+			strings.Join(e.Files[:maxWatchFiles], ", "), len(e.Files)-maxWatchFiles)
+	default:
+		fmt.Fprintf(w, "🔁 [%s] %s\n", stamp, strings.Join(e.Files, ", "))
+	}
+
+	if e.Error != "" {
+		fmt.Fprintf(w, "   ❌ Analysis failed: %s\n", e.Error)
+		return
+	}
+
+	if e.Delta != nil {
+		for _, cycle := range e.NewCycles {
+			fmt.Fprintf(w, "   🔄 New cycle: %s\n", strings.Join(cycle, " → "))
+		}
+		for _, cycle := range e.ResolvedCycles {
+			fmt.Fprintf(w, "   ✅ Resolved cycle: %s\n", strings.Join(cycle, " → "))
+		}
+		for _, c := range e.NewConflicts {
+			fmt.Fprintf(w, "   ⚠️  New version conflict: %s (%s)\n", c.PackageName, strings.Join(c.Versions, " vs "))
+		}
+		for _, c := range e.ResolvedConflicts {
+			fmt.Fprintf(w, "   ✅ Resolved version conflict: %s (%s)\n", c.PackageName, strings.Join(c.Versions, " vs "))
+		}
+	}
+	fmt.Fprintf(w, "   Health Score: %d/100\n", e.HealthScore)
+}
@@ -0,0 +1,62 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/watch"
+)
+
+func TestFormatterText_WatchEvent(t *testing.T) {
+	event := &watch.Event{
+		Time:        "2026-01-02T15:04:05Z",
+		Files:       []string{"packages/a/package.json", "packages/a/src/index.ts", "packages/b/src/index.ts", "packages/c/package.json"},
+		Packages:    3,
+		HealthScore: 85,
+		Delta: &analysis.Delta{
+			NewCycles:      [][]string{{"@mono/a", "@mono/b", "@mono/a"}},
+			ResolvedCycles: [][]string{{"@mono/b", "@mono/c", "@mono/b"}},
+			NewConflicts: []analysis.ConflictSummary{
+				{PackageName: "lodash", Versions: []string{"^3.10.0", "^4.17.21"}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, event); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"[15:04:05] packages/a/package.json, packages/a/src/index.ts, packages/b/src/index.ts (+1 more)",
+		"New cycle: @mono/a → @mono/b → @mono/a",
+		"Resolved cycle: @mono/b → @mono/c → @mono/b",
+		"New version conflict: lodash (^3.10.0 vs ^4.17.21)",
+		"Health Score: 85/100",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Errorf("text output missing %q\nOutput:\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatterText_WatchEventError(t *testing.T) {
+	event := &watch.Event{
+		Time:  "2026-01-02T15:04:05Z",
+		Files: []string{"package.json"},
+		Delta: &analysis.Delta{},
+		Error: "failed to parse workspace",
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatter("text").PrintTo(&buf, event); err != nil {
+		t.Fatalf("PrintTo() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "Analysis failed: failed to parse workspace") {
+		t.Errorf("text output should report the error:\n%s", buf.String())
+	}
+}
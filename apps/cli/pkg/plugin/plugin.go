@@ -0,0 +1,230 @@
+// Package plugin runs external rule plugins. A plugin is an executable that
+// reads a Request as JSON on stdin and writes a Response as JSON to stdout;
+// each plugin runs in its own process with a timeout, so a plugin that
+// crashes, hangs or writes invalid output only loses its own findings.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// ProtocolVersion is the version of the Request and Response documents
+const ProtocolVersion = 1
+
+// DefaultTimeout is how long a plugin may run unless configured otherwise
+const DefaultTimeout = 30 * time.Second
+
+// maxStderr is how much of a failed plugin's stderr is kept in its error
+const maxStderr = 512
+
+// Request is the document a plugin reads from stdin
+type Request struct {
+	Version   int                    `json:"version"` // ProtocolVersion
+	Plugin    string                 `json:"plugin"`  // Name of the plugin in the configuration
+	Root      string                 `json:"root"`    // Absolute path of the workspace root
+	Workspace *types.WorkspaceData   `json:"workspace"`
+	Graph     *types.DependencyGraph `json:"graph"`
+	Config    *types.AnalysisConfig  `json:"config"`
+	Options   map[string]interface{} `json:"options,omitempty"` // The plugin's options from .monoguard.yaml
+}
+
+// Response is the document a plugin writes to stdout
+type Response struct {
+	Findings []Finding `json:"findings"`
+}
+
+// Finding is an issue reported by a plugin
+type Finding struct {
+	Rule     string `json:"rule"`              // Plugin-defined rule ID, e.g. "no-lodash"
+	Severity string `json:"severity"`          // error, warning or info
+	Message  string `json:"message"`           // Human-readable description
+	Package  string `json:"package,omitempty"` // Workspace package the finding belongs to
+	File     string `json:"file,omitempty"`    // Relative to the workspace root
+	Line     int    `json:"line,omitempty"`    // 1-based line number in File
+}
+
+// Input is the analysis data sent to every plugin
+type Input struct {
+	Root      string
+	Workspace *types.WorkspaceData
+	Graph     *types.DependencyGraph
+	Config    *types.AnalysisConfig
+}
+
+// Plugin is a configured plugin executable
+type Plugin struct {
+	Name    string
+	Command string
+	Args    []string
+	Timeout time.Duration
+	Options map[string]interface{}
+}
+
+// FromConfig returns the plugins of the configuration with their timeouts
+// parsed
+func FromConfig(plugins []config.Plugin) ([]Plugin, error) {
+	var result []Plugin
+	for _, p := range plugins {
+		timeout := DefaultTimeout
+		if p.Timeout != "" {
+			d, err := time.ParseDuration(p.Timeout)
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("plugin %s: invalid timeout %q", p.Name, p.Timeout)
+			}
+			timeout = d
+		}
+		result = append(result, Plugin{
+			Name:    p.Name,
+			Command: p.Command,
+			Args:    p.Args,
+			Timeout: timeout,
+			Options: p.Options,
+		})
+	}
+	return result, nil
+}
+
+// RunAll runs the plugins concurrently and returns their results in
+// configuration order
+func RunAll(ctx context.Context, plugins []Plugin, input *Input) []*types.PluginResult {
+	results := make([]*types.PluginResult, len(plugins))
+	var wg sync.WaitGroup
+	for i, p := range plugins {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = p.Run(ctx, input)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// Run runs the plugin in the workspace root and returns its findings, or
+// the reason it failed
+func (p Plugin) Run(ctx context.Context, input *Input) *types.PluginResult {
+	result := &types.PluginResult{Plugin: p.Name, Findings: []*types.PluginFinding{}}
+	findings, err := p.run(ctx, input)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Findings = findings
+	return result
+}
+
+// run executes the plugin and validates its response
+func (p Plugin) run(ctx context.Context, input *Input) ([]*types.PluginFinding, error) {
+	body, err := json.Marshal(Request{
+		Version:   ProtocolVersion,
+		Plugin:    p.Name,
+		Root:      input.Root,
+		Workspace: input.Workspace,
+		Graph:     input.Graph,
+		Config:    input.Config,
+		Options:   p.Options,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, resolveCommand(input.Root, p.Command), p.Args...)
+	cmd.Dir = input.Root
+	cmd.Stdin = bytes.NewReader(body)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Do not wait for children of the plugin that keep its output open
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("timed out after %s", timeout)
+		}
+		if msg := lastLine(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%v: %s", err, msg)
+		}
+		return nil, err
+	}
+
+	var response Response
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("invalid output: %v", err)
+	}
+	findings := make([]*types.PluginFinding, 0, len(response.Findings))
+	for i, f := range response.Findings {
+		finding, err := p.finding(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid finding %d: %v", i, err)
+		}
+		findings = append(findings, finding)
+	}
+	return findings, nil
+}
+
+// finding validates a finding of the plugin's response
+func (p Plugin) finding(f Finding) (*types.PluginFinding, error) {
+	severity := types.PluginSeverity(f.Severity)
+	switch severity {
+	case types.PluginSeverityError, types.PluginSeverityWarning, types.PluginSeverityInfo:
+	default:
+		return nil, fmt.Errorf("severity must be error, warning or info (got %q)", f.Severity)
+	}
+	if f.Rule == "" {
+		return nil, fmt.Errorf("rule is required")
+	}
+	if f.Message == "" {
+		return nil, fmt.Errorf("message is required")
+	}
+	if f.Line < 0 {
+		return nil, fmt.Errorf("line must not be negative")
+	}
+	return &types.PluginFinding{
+		Plugin:   p.Name,
+		Rule:     f.Rule,
+		Severity: severity,
+		Message:  f.Message,
+		Package:  f.Package,
+		File:     filepath.ToSlash(f.File),
+		Line:     f.Line,
+	}, nil
+}
+
+// resolveCommand resolves a relative command path against root. Commands
+// without a path separator are looked up on PATH.
+func resolveCommand(root, command string) string {
+	if filepath.IsAbs(command) || !strings.ContainsAny(command, `/\`) {
+		return command
+	}
+	return filepath.Join(root, filepath.FromSlash(command))
+}
+
+// lastLine returns the last non-empty line of a plugin's stderr, shortened
+// to maxStderr bytes
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	line := strings.TrimSpace(lines[len(lines)-1])
+	if len(line) > maxStderr {
+		line = line[:maxStderr] + "…"
+	}
+	return line
+}
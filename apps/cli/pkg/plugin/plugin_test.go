@@ -0,0 +1,181 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/config"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// writeScript writes an executable shell script below root and returns its
+// path relative to root
+func writeScript(t *testing.T, root, name, body string) string {
+	t.Helper()
+	rel := "tools/" + name
+	p := filepath.Join(root, rel)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte("#!/bin/sh\n"+body), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return "./" + rel
+}
+
+// testInput returns the input of a workspace with one package
+func testInput(root string) *Input {
+	graph := types.NewDependencyGraph(root, types.WorkspaceTypePnpm)
+	graph.Nodes["@mono/a"] = types.NewPackageNode("@mono/a", "1.0.0", "packages/a")
+	return &Input{
+		Root: root,
+		Workspace: &types.WorkspaceData{
+			RootPath:      root,
+			WorkspaceType: types.WorkspaceTypePnpm,
+			Packages:      map[string]*types.PackageInfo{"@mono/a": {Name: "@mono/a", Path: "packages/a"}},
+		},
+		Graph:  graph,
+		Config: &types.AnalysisConfig{Exclude: []string{"@mono/legacy"}},
+	}
+}
+
+func TestRun(t *testing.T) {
+	root := t.TempDir()
+	command := writeScript(t, root, "lint", `cat > request.json
+echo '{"findings": [
+  {"rule": "no-lodash", "severity": "error", "message": "lodash is banned", "package": "@mono/a", "file": "packages/a/src/index.ts", "line": 2},
+  {"rule": "readme", "severity": "info", "message": "add a README"}
+]}'
+`)
+
+	p := Plugin{Name: "lint", Command: command, Args: []string{"--strict"}, Timeout: 5 * time.Second, Options: map[string]interface{}{"banned": []string{"lodash"}}}
+	result := p.Run(context.Background(), testInput(root))
+	if result.Error != "" {
+		t.Fatalf("Run() error = %s", result.Error)
+	}
+	if len(result.Findings) != 2 {
+		t.Fatalf("Findings = %+v, want 2", result.Findings)
+	}
+	want := types.PluginFinding{Plugin: "lint", Rule: "no-lodash", Severity: types.PluginSeverityError, Message: "lodash is banned",
+		Package: "@mono/a", File: "packages/a/src/index.ts", Line: 2}
+	if *result.Findings[0] != want {
+		t.Errorf("Findings[0] = %+v, want %+v", *result.Findings[0], want)
+	}
+
+	// The plugin runs in the workspace root and reads the request on stdin
+	data, err := os.ReadFile(filepath.Join(root, "request.json"))
+	if err != nil {
+		t.Fatalf("plugin did not write request.json in the workspace root: %v", err)
+	}
+	var request Request
+	if err := json.Unmarshal(data, &request); err != nil {
+		t.Fatalf("request is not JSON: %v\n%s", err, data)
+	}
+	if request.Version != ProtocolVersion || request.Plugin != "lint" || request.Root != root {
+		t.Errorf("request = %+v", request)
+	}
+	if request.Workspace == nil || request.Workspace.Packages["@mono/a"] == nil || request.Graph == nil || request.Graph.Nodes["@mono/a"] == nil {
+		t.Errorf("request workspace or graph missing @mono/a")
+	}
+	if request.Config == nil || len(request.Config.Exclude) != 1 || request.Options["banned"] == nil {
+		t.Errorf("request config = %+v, options = %+v", request.Config, request.Options)
+	}
+}
+
+func TestRunFailures(t *testing.T) {
+	root := t.TempDir()
+	tests := []struct {
+		name    string
+		script  string
+		timeout time.Duration
+		want    string
+	}{
+		{"exit status", "echo 'debug output' >&2\necho 'config missing' >&2\nexit 3\n", 0, "exit status 3: config missing"},
+		{"timeout", "exec sleep 5\n", 100 * time.Millisecond, "timed out after 100ms"},
+		{"invalid JSON", "echo 'not json'\n", 0, "invalid output:"},
+		{"invalid severity", `echo '{"findings": [{"rule": "r", "severity": "fatal", "message": "m"}]}'` + "\n", 0,
+			`invalid finding 0: severity must be error, warning or info (got "fatal")`},
+		{"missing message", `echo '{"findings": [{"rule": "r", "severity": "info"}]}'` + "\n", 0, "invalid finding 0: message is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command := writeScript(t, root, strings.ReplaceAll(tt.name, " ", "-"), tt.script)
+			start := time.Now()
+			result := Plugin{Name: "p", Command: command, Timeout: tt.timeout}.Run(context.Background(), testInput(root))
+			if !strings.HasPrefix(result.Error, tt.want) {
+				t.Errorf("Error = %q, want prefix %q", result.Error, tt.want)
+			}
+			if len(result.Findings) != 0 {
+				t.Errorf("Findings = %+v, want none", result.Findings)
+			}
+			if time.Since(start) > 3*time.Second {
+				t.Errorf("Run() took %s", time.Since(start))
+			}
+		})
+	}
+
+	result := Plugin{Name: "missing", Command: "./tools/does-not-exist"}.Run(context.Background(), testInput(root))
+	if result.Error == "" {
+		t.Error("Run() of a missing command succeeded")
+	}
+}
+
+func TestRunAll(t *testing.T) {
+	root := t.TempDir()
+	ok := writeScript(t, root, "ok", `echo '{"findings": [{"rule": "r", "severity": "warning", "message": "m"}]}'`+"\n")
+	broken := writeScript(t, root, "broken", "exit 1\n")
+
+	results := RunAll(context.Background(), []Plugin{
+		{Name: "broken", Command: broken},
+		{Name: "ok", Command: ok},
+	}, testInput(root))
+
+	if len(results) != 2 || results[0].Plugin != "broken" || results[1].Plugin != "ok" {
+		t.Fatalf("results = %+v, want broken and ok in order", results)
+	}
+	if results[0].Error == "" {
+		t.Error("broken plugin succeeded")
+	}
+	if results[1].Error != "" || len(results[1].Findings) != 1 || results[1].Findings[0].Plugin != "ok" {
+		t.Errorf("ok plugin = %+v, want one finding despite the broken plugin", results[1])
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	plugins, err := FromConfig([]config.Plugin{
+		{Name: "a", Command: "a"},
+		{Name: "b", Command: "b", Timeout: "2m"},
+	})
+	if err != nil {
+		t.Fatalf("FromConfig() error = %v", err)
+	}
+	if plugins[0].Timeout != DefaultTimeout || plugins[1].Timeout != 2*time.Minute {
+		t.Errorf("timeouts = %s, %s", plugins[0].Timeout, plugins[1].Timeout)
+	}
+
+	if _, err := FromConfig([]config.Plugin{{Name: "c", Command: "c", Timeout: "-1s"}}); err == nil {
+		t.Error("FromConfig() accepted a negative timeout")
+	}
+}
+
+func TestResolveCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"eslint", "eslint"},
+		{"/usr/bin/check", "/usr/bin/check"},
+		{"./tools/check", filepath.Join("/ws", "tools", "check")},
+		{"tools/check", filepath.Join("/ws", "tools", "check")},
+	}
+	for _, tt := range tests {
+		if got := resolveCommand("/ws", tt.command); got != tt.want {
+			t.Errorf("resolveCommand(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+// Package watch keeps a workspace analysis up to date as files change.
+package watch
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/analysis"
+	"github.com/j620656786206/MonoGuard/apps/cli/pkg/workspace"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// DefaultDebounce is how long to wait for more changes before re-analyzing
+const DefaultDebounce = 300 * time.Millisecond
+
+// Event reports one analysis run and what it changed
+type Event struct {
+	Time        string   `json:"time"`  // RFC 3339
+	Files       []string `json:"files"` // Changed files that triggered the run (empty for the initial run)
+	Packages    int      `json:"packages"`
+	HealthScore int      `json:"healthScore"`
+	*analysis.Delta
+	Error string `json:"error,omitempty"` // Analysis failed; the previous result is kept
+}
+
+// Session holds a workspace snapshot and its latest analysis.
+// Only changed files are re-read from disk between runs.
+type Session struct {
+	snap   *workspace.Snapshot
+	config *types.AnalysisConfig
+	result *types.AnalysisResult
+}
+
+// NewSession scans and analyzes the workspace at root.
+// config is optional; nil analyzes every workspace package.
+func NewSession(root string, config *types.AnalysisConfig) (*Session, error) {
+	snap, err := workspace.Scan(root)
+	if err != nil {
+		return nil, err
+	}
+	result, err := analysis.Run(snap, config)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{snap: snap, config: config, result: result}, nil
+}
+
+// Root returns the absolute workspace root
+func (s *Session) Root() string {
+	return s.snap.Root
+}
+
+// Result returns the latest successful analysis
+func (s *Session) Result() *types.AnalysisResult {
+	return s.result
+}
+
+// Initial returns an event listing every current cycle and conflict as new
+func (s *Session) Initial() *Event {
+	return s.event(nil, analysis.Compare(nil, s.result))
+}
+
+// Update re-reads the given paths (relative to the root) and re-analyzes the
+// workspace. It returns nil when none of the paths affect the analysis.
+// A change to a .gitignore file triggers a full rescan.
+func (s *Session) Update(paths []string) (*Event, error) {
+	changed := []string{}
+	rescan := needsRescan(paths)
+	for _, rel := range paths {
+		if path.Base(rel) == ".gitignore" {
+			changed = append(changed, rel)
+			continue
+		}
+		ok, err := s.snap.Update(rel)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			changed = append(changed, rel)
+		}
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	sort.Strings(changed)
+
+	if rescan {
+		snap, err := workspace.Scan(s.snap.Root)
+		if err != nil {
+			return nil, err
+		}
+		s.snap = snap
+	}
+
+	result, err := analysis.Run(s.snap, s.config)
+	if err != nil {
+		// Typically a file saved mid-edit; keep the last good result
+		e := s.event(changed, analysis.Compare(s.result, s.result))
+		e.Error = err.Error()
+		return e, nil
+	}
+
+	delta := analysis.Compare(s.result, result)
+	s.result = result
+	return s.event(changed, delta), nil
+}
+
+// needsRescan reports whether the changed paths include a .gitignore file,
+// which changes what is skipped and requires a full rescan
+func needsRescan(paths []string) bool {
+	for _, rel := range paths {
+		if path.Base(rel) == ".gitignore" {
+			return true
+		}
+	}
+	return false
+}
+
+// Watch re-analyzes the workspace whenever files change until ctx is done.
+// Changes are batched until no new change arrives for debounce; emit is
+// called with the event of every run that affected the analysis. Errors of
+// the file watcher are passed to warn, if set, without ending the session.
+func (s *Session) Watch(ctx context.Context, debounce time.Duration, emit func(*Event), warn func(error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err := s.syncWatches(watcher); err != nil {
+		return err
+	}
+
+	pending := map[string]bool{}
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			// Typically a dropped event queue; later changes are still seen
+			if warn != nil {
+				warn(err)
+			}
+
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			rel, err := filepath.Rel(s.snap.Root, ev.Name)
+			if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+				continue
+			}
+			rel = filepath.ToSlash(rel)
+			if ev.Has(fsnotify.Create) {
+				// New directories need their own watches
+				if err := s.addTree(watcher, ev.Name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+					return err
+				}
+			}
+			pending[rel] = true
+			timer.Reset(debounce)
+
+		case <-timer.C:
+			paths := make([]string, 0, len(pending))
+			for rel := range pending {
+				paths = append(paths, rel)
+			}
+			pending = map[string]bool{}
+
+			e, err := s.Update(paths)
+			if err != nil {
+				return err
+			}
+			if needsRescan(paths) {
+				// Directories may have become watched or skipped
+				if err := s.syncWatches(watcher); err != nil {
+					return err
+				}
+			}
+			if e != nil {
+				emit(e)
+			}
+		}
+	}
+}
+
+// syncWatches watches the root and every directory below it that is not
+// skipped, and stops watching directories that are skipped now
+func (s *Session) syncWatches(watcher *fsnotify.Watcher) error {
+	dirs, err := s.snap.Dirs()
+	if err != nil {
+		return err
+	}
+	want := map[string]bool{s.snap.Root: true}
+	for _, rel := range dirs {
+		want[filepath.Join(s.snap.Root, filepath.FromSlash(rel))] = true
+	}
+	for _, p := range watcher.WatchList() {
+		if !want[p] {
+			// Fails only when the directory is gone, which removes the watch too
+			_ = watcher.Remove(p)
+		}
+	}
+	for p := range want {
+		if err := watcher.Add(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// addTree watches dir and every directory below it that is not skipped
+func (s *Session) addTree(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(s.snap.Root, p)
+		if err != nil {
+			return err
+		}
+		if rel != "." && s.snap.Skipped(filepath.ToSlash(rel), true) {
+			return filepath.SkipDir
+		}
+		return watcher.Add(p)
+	})
+}
+
+// event builds an event for the current result
+func (s *Session) event(files []string, delta *analysis.Delta) *Event {
+	if files == nil {
+		files = []string{}
+	}
+	return &Event{
+		Time:        time.Now().Format(time.RFC3339),
+		Files:       files,
+		Packages:    s.result.Packages,
+		HealthScore: s.result.HealthScore,
+		Delta:       delta,
+	}
+}
@@ -0,0 +1,195 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// writeFiles creates files under root from a map of relative path to content.
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// acyclicWorkspace is a pnpm workspace where @mono/a depends on @mono/b.
+var acyclicWorkspace = map[string]string{
+	"package.json":            `{"name": "root", "private": true}`,
+	"pnpm-workspace.yaml":     "packages:\n  - 'packages/*'\n",
+	"pnpm-lock.yaml":          "",
+	"packages/a/package.json": `{"name": "@mono/a", "dependencies": {"@mono/b": "workspace:*"}}`,
+	"packages/a/src/index.ts": "import { b } from '@mono/b';\nexport const a = b;\n",
+	"packages/b/package.json": `{"name": "@mono/b"}`,
+	"packages/b/src/index.ts": "export const b = 1;\n",
+}
+
+const cyclicB = `{"name": "@mono/b", "dependencies": {"@mono/a": "workspace:*"}}`
+
+func TestSessionUpdate(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, acyclicWorkspace)
+
+	s, err := NewSession(root, nil)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	if initial := s.Initial(); initial.Packages != 2 || len(initial.NewCycles) != 0 {
+		t.Fatalf("Initial() = %+v, want 2 packages and no cycles", initial)
+	}
+
+	// Irrelevant change
+	writeFiles(t, root, map[string]string{"packages/b/README.md": "# b"})
+	e, err := s.Update([]string{"packages/b/README.md"})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if e != nil {
+		t.Errorf("Update() for an unrelated file = %+v, want nil", e)
+	}
+
+	// Introduce a cycle
+	writeFiles(t, root, map[string]string{"packages/b/package.json": cyclicB})
+	e, err = s.Update([]string{"packages/b/package.json"})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if e == nil || len(e.NewCycles) != 1 {
+		t.Fatalf("Update() = %+v, want one new cycle", e)
+	}
+	if len(e.Files) != 1 || e.Files[0] != "packages/b/package.json" {
+		t.Errorf("Files = %v, want [packages/b/package.json]", e.Files)
+	}
+	if len(s.Result().CircularDependencies) != 1 {
+		t.Error("Result() should reflect the new cycle")
+	}
+
+	// Resolve it again
+	writeFiles(t, root, map[string]string{"packages/b/package.json": acyclicWorkspace["packages/b/package.json"]})
+	e, err = s.Update([]string{"packages/b/package.json"})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if e == nil || len(e.ResolvedCycles) != 1 || len(e.NewCycles) != 0 {
+		t.Fatalf("Update() = %+v, want one resolved cycle", e)
+	}
+}
+
+func TestSessionUpdate_KeepsResultOnError(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, acyclicWorkspace)
+
+	s, err := NewSession(root, nil)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	previous := s.Result()
+
+	if err := os.Remove(filepath.Join(root, "package.json")); err != nil {
+		t.Fatal(err)
+	}
+	e, err := s.Update([]string{"package.json"})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if e == nil || e.Error == "" {
+		t.Fatalf("Update() = %+v, want an event reporting the analysis error", e)
+	}
+	if s.Result() != previous {
+		t.Error("Result() should keep the last successful analysis")
+	}
+}
+
+func TestSessionWatch(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, acyclicWorkspace)
+
+	s, err := NewSession(root, nil)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	events := make(chan *Event, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Watch(ctx, 50*time.Millisecond, func(e *Event) { events <- e }, func(err error) { t.Errorf("watcher error: %v", err) })
+	}()
+
+	// Give the watcher time to register its directories
+	time.Sleep(200 * time.Millisecond)
+	writeFiles(t, root, map[string]string{"packages/b/package.json": cyclicB})
+
+	select {
+	case e := <-events:
+		if len(e.NewCycles) != 1 {
+			t.Errorf("event = %+v, want one new cycle", e)
+		}
+	case <-ctx.Done():
+		t.Fatal("no event received after changing package.json")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Watch() error = %v", err)
+	}
+}
+
+// TestSessionSyncWatches verifies directories are watched or unwatched after
+// a .gitignore change
+func TestSessionSyncWatches(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, acyclicWorkspace)
+	writeFiles(t, root, map[string]string{".gitignore": "packages/c/\n", "packages/c/package.json": `{"name": "@mono/c"}`})
+
+	s, err := NewSession(root, nil)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+
+	watched := func(rel string) bool {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		for _, w := range watcher.WatchList() {
+			if w == p {
+				return true
+			}
+		}
+		return false
+	}
+
+	if err := s.syncWatches(watcher); err != nil {
+		t.Fatalf("syncWatches() error = %v", err)
+	}
+	if !watched("packages/a/src") || watched("packages/c") {
+		t.Fatalf("watches = %v, want packages/a/src but not the ignored packages/c", watcher.WatchList())
+	}
+
+	writeFiles(t, root, map[string]string{".gitignore": "packages/a/src/\n"})
+	if _, err := s.Update([]string{".gitignore"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := s.syncWatches(watcher); err != nil {
+		t.Fatalf("syncWatches() error = %v", err)
+	}
+	if !watched("packages/c") || watched("packages/a/src") {
+		t.Errorf("watches = %v, want packages/c but not the now ignored packages/a/src", watcher.WatchList())
+	}
+}
@@ -0,0 +1,157 @@
+// Package workspace provides local filesystem scanning for monorepo workspaces.
+// This file contains a .gitignore matcher used while walking the tree.
+package workspace
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// ignoreRule is a single compiled .gitignore pattern.
+type ignoreRule struct {
+	base    string         // Directory containing the .gitignore ("" for root)
+	negate  bool           // Pattern started with "!"
+	dirOnly bool           // Pattern ended with "/"
+	regex   *regexp.Regexp // Compiled pattern, matched against the path relative to base
+}
+
+// IgnoreMatcher evaluates .gitignore rules collected from one or more directories.
+// Rules are evaluated in the order they were added; the last matching rule wins,
+// so rules from nested .gitignore files override rules from their parents.
+type IgnoreMatcher struct {
+	rules []ignoreRule
+}
+
+// NewIgnoreMatcher creates an empty matcher that ignores nothing.
+func NewIgnoreMatcher() *IgnoreMatcher {
+	return &IgnoreMatcher{}
+}
+
+// AddPatterns parses .gitignore content and adds its rules.
+// base is the slash-separated directory (relative to the workspace root)
+// containing the .gitignore file, or "" for the root.
+func (m *IgnoreMatcher) AddPatterns(base string, content []byte) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		if rule, ok := parseIgnoreLine(base, scanner.Text()); ok {
+			m.rules = append(m.rules, rule)
+		}
+	}
+}
+
+// Match reports whether the slash-separated relative path should be ignored.
+func (m *IgnoreMatcher) Match(relPath string, isDir bool) bool {
+	ignored := false
+	for _, rule := range m.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+
+		rel := relPath
+		if rule.base != "" {
+			if !strings.HasPrefix(relPath, rule.base+"/") {
+				continue
+			}
+			rel = strings.TrimPrefix(relPath, rule.base+"/")
+		}
+
+		if rule.regex.MatchString(rel) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// parseIgnoreLine compiles a single .gitignore line.
+// Returns false for blank lines, comments, and patterns that fail to compile.
+func parseIgnoreLine(base, line string) (ignoreRule, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	rule := ignoreRule{base: base}
+
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+
+	// A pattern containing a slash (other than a trailing one) is anchored to
+	// the .gitignore directory; otherwise it matches a name at any depth.
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	if line == "" {
+		return ignoreRule{}, false
+	}
+
+	expr := globToRegex(line)
+	if !anchored {
+		expr = "(.*/)?" + expr
+	}
+
+	re, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return ignoreRule{}, false
+	}
+	rule.regex = re
+
+	return rule, true
+}
+
+// globToRegex converts a gitignore glob into a regular expression fragment.
+// Supports *, ?, ** (as a leading, trailing, or middle path segment) and [...] classes.
+func globToRegex(pattern string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				// "**/" matches zero or more directories
+				if i+2 < len(pattern) && pattern[i+2] == '/' {
+					sb.WriteString("(.*/)?")
+					i += 2
+					continue
+				}
+				sb.WriteString(".*")
+				i++
+				continue
+			}
+			sb.WriteString("[^/]*")
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,52 @@
+package workspace
+
+import "testing"
+
+func TestIgnoreMatcher_Match(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     string
+		patterns string
+		path     string
+		isDir    bool
+		want     bool
+	}{
+		{"plain name matches at root", "", "dist", "dist", true, true},
+		{"plain name matches at any depth", "", "dist", "packages/a/dist", true, true},
+		{"plain name does not match prefix", "", "dist", "distribution", true, false},
+		{"wildcard extension", "", "*.log", "packages/a/debug.log", false, true},
+		{"dir-only pattern skips files", "", "build/", "build", false, false},
+		{"dir-only pattern matches dirs", "", "build/", "apps/web/build", true, true},
+		{"anchored pattern only at base", "", "/coverage", "packages/a/coverage", true, false},
+		{"anchored pattern matches at base", "", "/coverage", "coverage", true, true},
+		{"pattern with slash is anchored", "", "examples/fixtures", "examples/fixtures", true, true},
+		{"pattern with slash not at depth", "", "examples/fixtures", "a/examples/fixtures", true, false},
+		{"leading doublestar", "", "**/fixtures", "a/b/fixtures", true, true},
+		{"trailing doublestar", "", "tmp/**", "tmp/a/b.ts", false, true},
+		{"middle doublestar", "", "a/**/z", "a/b/c/z", true, true},
+		{"negation re-includes", "", "*.ts\n!keep.ts", "keep.ts", false, false},
+		{"last match wins", "", "!keep.ts\n*.ts", "keep.ts", false, true},
+		{"comments and blank lines ignored", "", "# dist\n\n", "dist", true, false},
+		{"character class", "", "file[0-9].js", "file3.js", false, true},
+		{"nested base scopes rules", "packages/a", "generated", "packages/a/generated", true, true},
+		{"nested base does not leak", "packages/a", "generated", "packages/b/generated", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewIgnoreMatcher()
+			m.AddPatterns(tt.base, []byte(tt.patterns))
+
+			if got := m.Match(tt.path, tt.isDir); got != tt.want {
+				t.Errorf("Match(%q, %v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIgnoreMatcher_Empty(t *testing.T) {
+	m := NewIgnoreMatcher()
+	if m.Match("anything/at/all.ts", false) {
+		t.Error("empty matcher should not ignore any path")
+	}
+}
@@ -0,0 +1,325 @@
+// Package workspace provides local filesystem scanning for monorepo workspaces.
+// It collects the manifest and source files the analysis engine expects,
+// keyed by slash-separated paths relative to the workspace root.
+package workspace
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/analyzer"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/parser"
+)
+
+// MaxSourceFileSize is the largest source file read for import tracing.
+// Larger files are almost always generated bundles and are skipped.
+const MaxSourceFileSize = 1 << 20 // 1 MiB
+
+// alwaysSkippedDirs are never descended into, regardless of .gitignore.
+var alwaysSkippedDirs = map[string]bool{
+	"node_modules": true,
+	".git":         true,
+	".monoguard":   true, // Analysis cache
+}
+
+// workspaceMarkers are root-level files whose content the parser reads.
+var workspaceMarkers = map[string]bool{
+	"pnpm-workspace.yaml": true,
+}
+
+// codeownersFiles are the CODEOWNERS locations the parser reads package
+// owners from.
+var codeownersFiles = func() map[string]bool {
+	files := map[string]bool{}
+	for _, name := range parser.CodeownersFiles {
+		files[name] = true
+	}
+	return files
+}()
+
+// ConfigFileName is the MonoGuard configuration file (config.FileName).
+// Below the root it overrides the root configuration for the packages in
+// its directory.
+const ConfigFileName = ".monoguard.yaml"
+
+// lockfileMarkers are root-level lockfiles used only for workspace type
+// detection. Their presence matters, not their content, so they are
+// recorded with empty content to avoid reading large files.
+var lockfileMarkers = map[string]bool{
+	"pnpm-lock.yaml":    true,
+	"yarn.lock":         true,
+	"package-lock.json": true,
+}
+
+// Snapshot holds the files collected from a workspace on disk.
+type Snapshot struct {
+	// Root is the absolute path of the workspace root
+	Root string
+
+	// Files contains package.json files, pnpm-workspace.yaml, lockfile markers,
+	// the CODEOWNERS file and the .monoguard.yaml files below the root
+	Files map[string][]byte
+
+	// SourceFiles contains JS/TS source files used for import tracing
+	SourceFiles map[string][]byte
+
+	// CodeownersRoot is the workspace directory relative to the root of the
+	// git repository containing it, or "" at the repository root. CODEOWNERS
+	// patterns are relative to the repository root, so below it Codeowners
+	// holds the repository's CODEOWNERS file instead of Files.
+	CodeownersRoot string
+	Codeowners     []byte
+
+	// ignore holds the .gitignore rules loaded while walking, and gitignores
+	// the directories whose .gitignore was loaded
+	ignore     *IgnoreMatcher
+	gitignores map[string]bool
+}
+
+// Scan walks the directory tree rooted at root and collects workspace files.
+// node_modules, .git and .monoguard are always skipped; paths matched by
+// .gitignore files (at the root or in any nested directory) are skipped as well.
+func Scan(root string) (*Snapshot, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path %s: %w", root, err)
+	}
+
+	info, err := os.Stat(absRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("workspace path is not a directory: %s", absRoot)
+	}
+
+	snap := &Snapshot{
+		Root:        absRoot,
+		Files:       make(map[string][]byte),
+		SourceFiles: make(map[string][]byte),
+		ignore:      NewIgnoreMatcher(),
+	}
+	if repoRoot := findRepoRoot(absRoot); repoRoot != "" && repoRoot != absRoot {
+		rel, err := filepath.Rel(repoRoot, absRoot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve path %s: %w", root, err)
+		}
+		snap.CodeownersRoot = filepath.ToSlash(rel)
+		for _, name := range parser.CodeownersFiles {
+			if content, err := os.ReadFile(filepath.Join(repoRoot, filepath.FromSlash(name))); err == nil {
+				snap.Codeowners = content
+				break
+			}
+		}
+	}
+	if err := snap.walk(absRoot); err != nil {
+		return nil, fmt.Errorf("failed to scan workspace: %w", err)
+	}
+
+	return snap, nil
+}
+
+// findRepoRoot returns the closest directory at or above dir containing
+// .git (a directory, or a file in worktrees), or "" outside a repository.
+func findRepoRoot(dir string) string {
+	for {
+		if _, err := os.Lstat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Skipped reports whether the slash-separated relative path is left out of
+// the snapshot because it is always skipped or matched by .gitignore.
+func (s *Snapshot) Skipped(rel string, isDir bool) bool {
+	segments := strings.Split(rel, "/")
+	for i, segment := range segments {
+		dir := i < len(segments)-1 || isDir
+		if dir && alwaysSkippedDirs[segment] {
+			return true
+		}
+		if s.ignore != nil && s.ignore.Match(strings.Join(segments[:i+1], "/"), dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// Dirs returns the slash-separated relative paths of the directories below
+// the root that are not skipped, in lexical order. Unlike the files, the
+// directories are read from disk on each call.
+func (s *Snapshot) Dirs() ([]string, error) {
+	var dirs []string
+	err := filepath.WalkDir(s.Root, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !d.IsDir() || p == s.Root {
+			return nil
+		}
+		rel, err := filepath.Rel(s.Root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if s.Skipped(rel, true) {
+			return filepath.SkipDir
+		}
+		dirs = append(dirs, rel)
+		return nil
+	})
+	return dirs, err
+}
+
+// Update re-reads a single path (relative to the root) after it changed on
+// disk and reports whether the snapshot changed. A removed path drops its
+// files; a new directory is walked. Changes to .gitignore files are not
+// re-evaluated; callers should Scan again instead.
+func (s *Snapshot) Update(rel string) (bool, error) {
+	rel = path.Clean(filepath.ToSlash(rel))
+	if rel == "." || strings.HasPrefix(rel, "../") || path.IsAbs(rel) {
+		return false, fmt.Errorf("path %s is outside the workspace", rel)
+	}
+	absPath := filepath.Join(s.Root, filepath.FromSlash(rel))
+
+	info, err := os.Lstat(absPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s.remove(rel), nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if info.IsDir() {
+		if s.Skipped(rel, true) {
+			return false, nil
+		}
+		before := len(s.Files) + len(s.SourceFiles)
+		if err := s.walk(absPath); err != nil {
+			return false, err
+		}
+		return len(s.Files)+len(s.SourceFiles) != before, nil
+	}
+
+	oldFile, inFiles := s.Files[rel]
+	oldSource, inSources := s.SourceFiles[rel]
+	delete(s.Files, rel)
+	delete(s.SourceFiles, rel)
+
+	if info.Mode().IsRegular() && !s.Skipped(rel, false) {
+		if err := s.collect(absPath, rel, fs.FileInfoToDirEntry(info)); err != nil {
+			return false, err
+		}
+	}
+
+	newFile, nowInFiles := s.Files[rel]
+	newSource, nowInSources := s.SourceFiles[rel]
+	return inFiles != nowInFiles || inSources != nowInSources ||
+		!bytes.Equal(oldFile, newFile) || !bytes.Equal(oldSource, newSource), nil
+}
+
+// remove drops rel and everything below it, reporting whether anything was removed
+func (s *Snapshot) remove(rel string) bool {
+	removed := false
+	for _, files := range []map[string][]byte{s.Files, s.SourceFiles} {
+		for p := range files {
+			if p == rel || strings.HasPrefix(p, rel+"/") {
+				delete(files, p)
+				removed = true
+			}
+		}
+	}
+	return removed
+}
+
+// walk collects the files below dir, loading .gitignore files on the way
+func (s *Snapshot) walk(dir string) error {
+	if s.ignore == nil {
+		s.ignore = NewIgnoreMatcher()
+	}
+	if s.gitignores == nil {
+		s.gitignores = map[string]bool{}
+	}
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		rel, err := filepath.Rel(s.Root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			rel = ""
+		}
+
+		if d.IsDir() {
+			if rel != "" && (alwaysSkippedDirs[d.Name()] || s.ignore.Match(rel, true)) {
+				return filepath.SkipDir
+			}
+			// Load this directory's .gitignore before visiting its children,
+			// once: Update walks new and changed directories again
+			if s.gitignores[rel] {
+				return nil
+			}
+			if content, err := os.ReadFile(filepath.Join(p, ".gitignore")); err == nil {
+				s.ignore.AddPatterns(rel, content)
+				s.gitignores[rel] = true
+			}
+			return nil
+		}
+
+		if !d.Type().IsRegular() || s.ignore.Match(rel, false) {
+			return nil
+		}
+
+		return s.collect(p, rel, d)
+	})
+}
+
+// collect records a single file in the snapshot if it is relevant to analysis.
+func (s *Snapshot) collect(absPath, rel string, d fs.DirEntry) error {
+	name := d.Name()
+	isRoot := path.Dir(rel) == "."
+
+	switch {
+	case name == "package.json", isRoot && workspaceMarkers[name], !isRoot && name == ConfigFileName,
+		codeownersFiles[rel] && s.CodeownersRoot == "":
+		content, err := os.ReadFile(absPath)
+		if err != nil {
+			return err
+		}
+		s.Files[rel] = content
+
+	case isRoot && lockfileMarkers[name]:
+		s.Files[rel] = []byte{}
+
+	case analyzer.IsSourceFile(rel):
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.Size() > MaxSourceFileSize {
+			return nil
+		}
+		content, err := os.ReadFile(absPath)
+		if err != nil {
+			return err
+		}
+		s.SourceFiles[rel] = content
+	}
+
+	return nil
+}
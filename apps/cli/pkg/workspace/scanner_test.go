@@ -0,0 +1,324 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles creates files under root from a map of relative path to content.
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"package.json":                          `{"name": "root", "workspaces": ["packages/*"]}`,
+		"pnpm-workspace.yaml":                   "packages:\n  - 'packages/*'\n",
+		"pnpm-lock.yaml":                        "lockfileVersion: '9.0'\n",
+		".gitignore":                            "dist/\n*.generated.ts\n",
+		".monoguard.yaml":                       "rules: {}\n",
+		"packages/a/.monoguard.yaml":            "rules: {}\n",
+		"packages/a/package.json":               `{"name": "@mono/a"}`,
+		"packages/a/src/index.ts":               `import { b } from '@mono/b';`,
+		"packages/a/src/types.generated.ts":     `export type X = string;`,
+		"packages/a/dist/index.js":              `module.exports = {};`,
+		"packages/a/README.md":                  "# a",
+		"packages/b/package.json":               `{"name": "@mono/b"}`,
+		"packages/b/.gitignore":                 "fixtures\n",
+		"packages/b/src/index.js":               `export const b = 1;`,
+		"packages/b/fixtures/package.json":      `{"name": "fixture"}`,
+		"node_modules/lodash/package.json":      `{"name": "lodash"}`,
+		"packages/a/node_modules/x/index.js":    `module.exports = {};`,
+		"packages/a/nested/pnpm-workspace.yaml": "packages: []\n",
+		".github/CODEOWNERS":                    "/packages/ @org/web\n",
+		"packages/a/CODEOWNERS":                 "* @nested\n",
+	})
+
+	snap, err := Scan(root)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	if !filepath.IsAbs(snap.Root) {
+		t.Errorf("Root = %q, want absolute path", snap.Root)
+	}
+
+	wantFiles := []string{"package.json", "pnpm-workspace.yaml", "pnpm-lock.yaml", "packages/a/package.json", "packages/a/.monoguard.yaml", "packages/b/package.json", ".github/CODEOWNERS"}
+	for _, f := range wantFiles {
+		if _, ok := snap.Files[f]; !ok {
+			t.Errorf("Files missing %q", f)
+		}
+	}
+	if len(snap.Files) != len(wantFiles) {
+		t.Errorf("Files = %v, want exactly %v", keys(snap.Files), wantFiles)
+	}
+
+	if len(snap.Files["pnpm-lock.yaml"]) != 0 {
+		t.Error("lockfile should be recorded as an empty marker")
+	}
+	if !strings.Contains(string(snap.Files["packages/a/package.json"]), "@mono/a") {
+		t.Error("package.json content should be read")
+	}
+
+	wantSources := []string{"packages/a/src/index.ts", "packages/b/src/index.js"}
+	for _, f := range wantSources {
+		if _, ok := snap.SourceFiles[f]; !ok {
+			t.Errorf("SourceFiles missing %q", f)
+		}
+	}
+	if len(snap.SourceFiles) != len(wantSources) {
+		t.Errorf("SourceFiles = %v, want exactly %v", keys(snap.SourceFiles), wantSources)
+	}
+}
+
+func TestScan_SkipsLargeSourceFiles(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"package.json":        `{"name": "root"}`,
+		"bundle/vendor.js":    strings.Repeat("x", MaxSourceFileSize+1),
+		"src/small/module.ts": "export {}",
+	})
+
+	snap, err := Scan(root)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if _, ok := snap.SourceFiles["bundle/vendor.js"]; ok {
+		t.Error("oversized source file should be skipped")
+	}
+	if _, ok := snap.SourceFiles["src/small/module.ts"]; !ok {
+		t.Error("small source file should be collected")
+	}
+}
+
+func TestScan_RepositoryCodeowners(t *testing.T) {
+	repo := t.TempDir()
+	writeFiles(t, repo, map[string]string{
+		".git/HEAD":                        "ref: refs/heads/main\n",
+		".github/CODEOWNERS":               "/frontend/ @org/frontend\n",
+		"frontend/package.json":            `{"name": "root"}`,
+		"frontend/CODEOWNERS":              "* @ignored\n",
+		"frontend/packages/a/package.json": `{"name": "@mono/a"}`,
+	})
+
+	snap, err := Scan(filepath.Join(repo, "frontend"))
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if snap.CodeownersRoot != "frontend" {
+		t.Errorf("CodeownersRoot = %q, want frontend", snap.CodeownersRoot)
+	}
+	if string(snap.Codeowners) != "/frontend/ @org/frontend\n" {
+		t.Errorf("Codeowners = %q, want the repository CODEOWNERS", snap.Codeowners)
+	}
+	if _, ok := snap.Files["CODEOWNERS"]; ok {
+		t.Error("CODEOWNERS below the repository root should not be collected")
+	}
+
+	// At the repository root the workspace CODEOWNERS is used
+	snap, err = Scan(repo)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if snap.CodeownersRoot != "" || snap.Codeowners != nil {
+		t.Errorf("CodeownersRoot = %q, Codeowners = %q; want none at the repository root", snap.CodeownersRoot, snap.Codeowners)
+	}
+	if _, ok := snap.Files[".github/CODEOWNERS"]; !ok {
+		t.Error("CODEOWNERS at the repository root should be collected")
+	}
+}
+
+func TestScan_Errors(t *testing.T) {
+	t.Run("missing directory", func(t *testing.T) {
+		if _, err := Scan(filepath.Join(t.TempDir(), "missing")); err == nil {
+			t.Error("Scan() should fail for a missing directory")
+		}
+	})
+
+	t.Run("file instead of directory", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{"package.json": "{}"})
+		if _, err := Scan(filepath.Join(root, "package.json")); err == nil {
+			t.Error("Scan() should fail for a file path")
+		}
+	})
+}
+
+func TestSnapshotUpdate(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"package.json":            `{"name": "root"}`,
+		".gitignore":              "dist/\n",
+		"packages/a/package.json": `{"name": "@mono/a"}`,
+		"packages/a/src/index.ts": "export const a = 1;",
+	})
+
+	snap, err := Scan(root)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	update := func(rel string, want bool) {
+		t.Helper()
+		changed, err := snap.Update(rel)
+		if err != nil {
+			t.Fatalf("Update(%q) error = %v", rel, err)
+		}
+		if changed != want {
+			t.Errorf("Update(%q) changed = %v, want %v", rel, changed, want)
+		}
+	}
+
+	// Unchanged content
+	update("packages/a/src/index.ts", false)
+
+	// Modified file
+	writeFiles(t, root, map[string]string{"packages/a/src/index.ts": "import { b } from '@mono/b';"})
+	update("packages/a/src/index.ts", true)
+	if got := string(snap.SourceFiles["packages/a/src/index.ts"]); got != "import { b } from '@mono/b';" {
+		t.Errorf("source not refreshed: %q", got)
+	}
+
+	// Irrelevant and ignored files
+	writeFiles(t, root, map[string]string{
+		"packages/a/README.md":     "# a",
+		"packages/a/dist/index.js": "module.exports = {};",
+	})
+	update("packages/a/README.md", false)
+	update("packages/a/dist/index.js", false)
+	update("packages/a/dist", false)
+
+	// New directory
+	writeFiles(t, root, map[string]string{
+		"packages/b/package.json": `{"name": "@mono/b"}`,
+		"packages/b/src/index.ts": "export const b = 1;",
+	})
+	update("packages/b", true)
+	if _, ok := snap.Files["packages/b/package.json"]; !ok {
+		t.Error("new package.json not collected")
+	}
+	if _, ok := snap.SourceFiles["packages/b/src/index.ts"]; !ok {
+		t.Error("new source file not collected")
+	}
+
+	// Removed directory
+	if err := os.RemoveAll(filepath.Join(root, "packages", "a")); err != nil {
+		t.Fatal(err)
+	}
+	update("packages/a", true)
+	if _, ok := snap.Files["packages/a/package.json"]; ok {
+		t.Error("removed package.json still present")
+	}
+	if _, ok := snap.SourceFiles["packages/a/src/index.ts"]; ok {
+		t.Error("removed source file still present")
+	}
+
+	if _, err := snap.Update("../outside"); err == nil {
+		t.Error("Update() should reject paths outside the workspace")
+	}
+}
+
+// TestSnapshotUpdate_LoadsGitignoreOnce verifies walking a directory again
+// does not add its .gitignore rules twice
+func TestSnapshotUpdate_LoadsGitignoreOnce(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"package.json":            `{"name": "root"}`,
+		".gitignore":              "dist/\n",
+		"packages/a/.gitignore":   "tmp/\n*.log\n",
+		"packages/a/package.json": `{"name": "@mono/a"}`,
+	})
+
+	snap, err := Scan(root)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	rules := len(snap.ignore.rules)
+	for _, rel := range []string{"packages/a", "packages", "packages/a"} {
+		if _, err := snap.Update(rel); err != nil {
+			t.Fatalf("Update(%q) error = %v", rel, err)
+		}
+	}
+	if got := len(snap.ignore.rules); got != rules {
+		t.Errorf("rules after updates = %d, want %d", got, rules)
+	}
+}
+
+func TestSnapshotSkipped(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"package.json": `{"name": "root"}`,
+		".gitignore":   "dist/\n",
+	})
+
+	snap, err := Scan(root)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	tests := []struct {
+		rel   string
+		isDir bool
+		want  bool
+	}{
+		{"node_modules", true, true},
+		{"packages/a/node_modules/lodash", true, true},
+		{".git", true, true},
+		{"packages/a/dist", true, true},
+		{"packages/a/src", true, false},
+		{"packages/a/src/index.ts", false, false},
+	}
+	for _, tt := range tests {
+		if got := snap.Skipped(tt.rel, tt.isDir); got != tt.want {
+			t.Errorf("Skipped(%q, %v) = %v, want %v", tt.rel, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestSnapshotDirs(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"package.json":                       `{"name": "root"}`,
+		".gitignore":                         "dist/\n",
+		"packages/a/src/index.ts":            "export {}",
+		"packages/a/dist/index.js":           "",
+		"packages/a/node_modules/x/index.js": "",
+	})
+	if err := os.MkdirAll(filepath.Join(root, "packages", "empty"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := Scan(root)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	dirs, err := snap.Dirs()
+	if err != nil {
+		t.Fatalf("Dirs() error = %v", err)
+	}
+
+	want := "packages packages/a packages/a/src packages/empty"
+	if got := strings.Join(dirs, " "); got != want {
+		t.Errorf("Dirs() = %q, want %q", got, want)
+	}
+}
+
+func keys(m map[string][]byte) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
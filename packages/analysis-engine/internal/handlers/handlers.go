@@ -13,7 +13,7 @@ import (
 )
 
 // Version is the current version of the analysis engine.
-const Version = "0.1.0"
+const Version = analyzer.Version
 
 // GetVersion returns the analysis engine version as a Result JSON string.
 func GetVersion() string {
@@ -55,9 +55,47 @@ func GetVersion() string {
 //
 // Returns a Result JSON string with AnalysisResult (including dependency graph) or error.
 func Analyze(input string) string {
+	analysisResult, _, errResult := analyzeInput(input)
+	if errResult != nil {
+		return errResult.ToJSON()
+	}
+
+	r := result.NewSuccess(analysisResult)
+	return r.ToJSON()
+}
+
+// Check validates the workspace against the configured rules.
+//
+// Accepts the same input formats as Analyze. In the AnalysisInput format,
+// "config" may additionally carry check rules:
+//
+//	{
+//	  "files": { ... },
+//	  "config": {
+//	    "layers": [{ "name": "apps", "pattern": "apps/*", "canDependOn": ["libs"] }],
+//	    "rules": { "circularDependencies": "error", "boundaryViolations": "warn" },
+//	    "thresholds": { "healthScore": 70 }
+//	  }
+//	}
+//
+// Returns a Result JSON string with CheckResult or error.
+func Check(input string) string {
+	analysisResult, config, errResult := analyzeInput(input)
+	if errResult != nil {
+		return errResult.ToJSON()
+	}
+
+	checkResult := analyzer.NewRuleEvaluator(config).Evaluate(analysisResult)
+
+	r := result.NewSuccess(checkResult)
+	return r.ToJSON()
+}
+
+// analyzeInput parses Analyze/Check input JSON and runs the full analysis.
+// Returns the analysis result and the parsed config (may be nil), or an error Result.
+func analyzeInput(input string) (*types.AnalysisResult, *types.AnalysisConfig, *result.Result) {
 	if input == "" {
-		r := result.NewError(result.ErrInvalidInput, "Missing JSON input")
-		return r.ToJSON()
+		return nil, nil, result.NewError(result.ErrInvalidInput, "Missing JSON input")
 	}
 
 	// Parse input: try AnalysisInput format first, fallback to legacy format
@@ -75,8 +113,7 @@ func Analyze(input string) string {
 		// Format 1: Legacy flat map (keys=paths, values=contents)
 		// Note: This path also handles Format 2 parse failures gracefully
 		if err := json.Unmarshal([]byte(input), &filesInput); err != nil {
-			r := result.NewError(result.ErrInvalidInput, "Failed to parse input JSON: "+err.Error())
-			return r.ToJSON()
+			return nil, nil, result.NewError(result.ErrInvalidInput, "Failed to parse input JSON: "+err.Error())
 		}
 	}
 
@@ -99,42 +136,20 @@ func Analyze(input string) string {
 	p := parser.NewParser("/workspace")
 	workspaceData, err := p.Parse(files)
 	if err != nil {
-		r := result.NewError(result.ErrAnalysisFailed, err.Error())
-		return r.ToJSON()
+		return nil, nil, result.NewError(result.ErrAnalysisFailed, err.Error())
 	}
 
 	// Run analysis with config (Story 2.6: exclusion patterns)
 	a, err := analyzer.NewAnalyzerWithConfig(config)
 	if err != nil {
-		r := result.NewError(result.ErrInvalidInput, "Invalid exclusion pattern: "+err.Error())
-		return r.ToJSON()
+		return nil, nil, result.NewError(result.ErrInvalidInput, "Invalid analysis config: "+err.Error())
 	}
 
 	// Story 3.2: Use AnalyzeWithSources to enable import tracing when source files provided
 	analysisResult, err := a.AnalyzeWithSources(workspaceData, sourceFiles)
 	if err != nil {
-		r := result.NewError(result.ErrAnalysisFailed, err.Error())
-		return r.ToJSON()
-	}
-
-	r := result.NewSuccess(analysisResult)
-	return r.ToJSON()
-}
-
-// Check validates the workspace configuration against configured rules.
-// Returns a Result JSON string with CheckResult or error.
-func Check(input string) string {
-	if input == "" {
-		r := result.NewError("INVALID_INPUT", "Missing JSON input")
-		return r.ToJSON()
+		return nil, nil, result.NewError(result.ErrAnalysisFailed, err.Error())
 	}
 
-	// Placeholder - will be implemented in Epic 2
-	// TODO: Parse input JSON and perform actual validation
-	r := result.NewSuccess(types.CheckResult{
-		Passed:      true,
-		Errors:      []string{},
-		Placeholder: true,
-	})
-	return r.ToJSON()
+	return analysisResult, config, nil
 }
@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
 )
 
 func TestGetVersion(t *testing.T) {
@@ -240,21 +243,64 @@ func TestAnalyzeWithPnpmWorkspace(t *testing.T) {
 }
 
 func TestCheck(t *testing.T) {
+	cycleFiles := `"package.json": "{\"name\": \"root\", \"workspaces\": [\"packages/*\", \"apps/*\"]}",
+		"package-lock.json": "{}",
+		"packages/a/package.json": "{\"name\": \"a\", \"dependencies\": {\"b\": \"*\"}}",
+		"packages/b/package.json": "{\"name\": \"b\", \"dependencies\": {\"a\": \"*\"}}",
+		"apps/web/package.json": "{\"name\": \"web\", \"dependencies\": {\"a\": \"*\"}}"`
+	cleanFiles := `"package.json": "{\"name\": \"root\", \"workspaces\": [\"packages/*\", \"apps/*\"]}",
+		"package-lock.json": "{}",
+		"packages/a/package.json": "{\"name\": \"a\", \"dependencies\": {\"web\": \"*\"}}",
+		"apps/web/package.json": "{\"name\": \"web\"}"`
+
 	tests := []struct {
-		name      string
-		input     string
-		wantError bool
-		errorCode string
+		name         string
+		input        string
+		wantError    bool
+		errorCode    string
+		wantPassed   bool
+		wantErrors   []string // Expected ValidationError codes
+		wantWarnings []string // Expected ValidationWarning codes
 	}{
 		{
-			name:      "valid empty JSON input",
-			input:     "{}",
-			wantError: false,
+			name:       "legacy format with cycle fails",
+			input:      `{` + cycleFiles + `}`,
+			wantPassed: false,
+			wantErrors: []string{"CIRCULAR_DETECTED"},
+		},
+		{
+			name:       "clean workspace passes",
+			input:      `{"files": {` + cleanFiles + `}}`,
+			wantPassed: true,
+		},
+		{
+			name:         "circular rule as warning passes",
+			input:        `{"files": {` + cycleFiles + `}, "config": {"rules": {"circularDependencies": "warn"}}}`,
+			wantPassed:   true,
+			wantWarnings: []string{"CIRCULAR_DETECTED"},
+		},
+		{
+			name:       "circular rule off passes",
+			input:      `{"files": {` + cycleFiles + `}, "config": {"rules": {"circularDependencies": "off"}}}`,
+			wantPassed: true,
+		},
+		{
+			name:       "boundary violation fails",
+			input:      `{"files": {` + cleanFiles + `}, "config": {"layers": [{"name": "apps", "pattern": "apps/*", "canDependOn": ["packages"]}, {"name": "packages", "pattern": "packages/*"}]}}`,
+			wantPassed: false,
+			wantErrors: []string{"BOUNDARY_VIOLATION"},
+		},
+		{
+			name:       "health score threshold fails",
+			input:      `{"files": {` + cycleFiles + `}, "config": {"rules": {"circularDependencies": "off"}, "thresholds": {"healthScore": 100}}}`,
+			wantPassed: false,
+			wantErrors: []string{"LOW_HEALTH_SCORE"},
 		},
 		{
-			name:      "valid config JSON",
-			input:     `{"rules": []}`,
-			wantError: false,
+			name:      "empty object returns error",
+			input:     "{}",
+			wantError: true,
+			errorCode: "ANALYSIS_FAILED",
 		},
 		{
 			name:      "empty string input returns error",
@@ -268,41 +314,50 @@ func TestCheck(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			result := Check(tt.input)
 
-			var parsed map[string]interface{}
+			var parsed struct {
+				Data  *types.CheckResult `json:"data"`
+				Error *struct {
+					Code string `json:"code"`
+				} `json:"error"`
+			}
 			if err := json.Unmarshal([]byte(result), &parsed); err != nil {
 				t.Fatalf("Failed to parse JSON result: %v", err)
 			}
 
 			if tt.wantError {
-				if parsed["error"] == nil {
-					t.Error("Expected error but got success")
-					return
-				}
-				errObj := parsed["error"].(map[string]interface{})
-				if errObj["code"] != tt.errorCode {
-					t.Errorf("error code = %v, want %v", errObj["code"], tt.errorCode)
+				if parsed.Error == nil {
+					t.Fatal("Expected error but got success")
 				}
-			} else {
-				if parsed["error"] != nil {
-					t.Errorf("Unexpected error: %v", parsed["error"])
-					return
+				if parsed.Error.Code != tt.errorCode {
+					t.Errorf("error code = %v, want %v", parsed.Error.Code, tt.errorCode)
 				}
+				return
+			}
 
-				data, ok := parsed["data"].(map[string]interface{})
-				if !ok {
-					t.Fatal("data is not a map")
-				}
+			if parsed.Error != nil {
+				t.Fatalf("Unexpected error: %v", parsed.Error)
+			}
+			if parsed.Data == nil {
+				t.Fatal("data is nil")
+			}
 
-				// Verify CheckResult fields
-				if data["passed"] != true {
-					t.Error("Expected passed to be true")
-				}
-				if _, ok := data["errors"]; !ok {
-					t.Error("Missing errors field")
-				}
-				if data["placeholder"] != true {
-					t.Error("Expected placeholder to be true")
-				}
+			if parsed.Data.Passed != tt.wantPassed {
+				t.Errorf("passed = %v, want %v", parsed.Data.Passed, tt.wantPassed)
+			}
+
+			gotErrors := []string{}
+			for _, e := range parsed.Data.Errors {
+				gotErrors = append(gotErrors, e.Code)
+			}
+			gotWarnings := []string{}
+			for _, w := range parsed.Data.Warnings {
+				gotWarnings = append(gotWarnings, w.Code)
+			}
+			if strings.Join(gotErrors, ",") != strings.Join(tt.wantErrors, ",") {
+				t.Errorf("errors = %v, want %v", gotErrors, tt.wantErrors)
+			}
+			if strings.Join(gotWarnings, ",") != strings.Join(tt.wantWarnings, ",") {
+				t.Errorf("warnings = %v, want %v", gotWarnings, tt.wantWarnings)
 			}
 		})
 	}
@@ -12,6 +12,8 @@
 //   - Impact assessment for circular dependencies (Story 3.6)
 //   - Before/after fix explanations for circular dependencies (Story 3.7)
 //   - Integration of fix suggestions with analysis results (Story 3.8)
+//   - Layer boundary checks and check rule evaluation
+//   - Declarative custom rules
 package analyzer
 
 import (
@@ -20,10 +22,15 @@ import (
 	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
 )
 
+// Version is the current version of the analysis engine. Results cached by
+// the CLI are only reused by the same engine version.
+const Version = "0.1.0"
+
 // Analyzer orchestrates the complete workspace analysis process.
 type Analyzer struct {
 	graphBuilder *GraphBuilder
 	config       *types.AnalysisConfig
+	customRules  *CustomRuleChecker
 }
 
 // NewAnalyzer creates a new analyzer instance.
@@ -35,20 +42,29 @@ func NewAnalyzer() *Analyzer {
 }
 
 // NewAnalyzerWithConfig creates an analyzer with the specified configuration.
-// Returns an error if any exclusion regex pattern is invalid.
+// Returns an error if any exclusion regex pattern, custom rule or health
+// weight is invalid.
 func NewAnalyzerWithConfig(config *types.AnalysisConfig) (*Analyzer, error) {
 	if config == nil {
 		return NewAnalyzer(), nil
 	}
+	if err := ValidateHealthWeights(config.HealthWeights); err != nil {
+		return nil, err
+	}
 
 	graphBuilder, err := NewGraphBuilderWithExclusions(config.Exclude)
 	if err != nil {
 		return nil, err
 	}
+	customRules, err := NewCustomRuleChecker(config.CustomRules)
+	if err != nil {
+		return nil, err
+	}
 
 	return &Analyzer{
 		graphBuilder: graphBuilder,
 		config:       config,
+		customRules:  customRules,
 	}, nil
 }
 
@@ -58,92 +74,12 @@ func NewAnalyzerWithConfig(config *types.AnalysisConfig) (*Analyzer, error) {
 // Story 2.6: Excluded packages are marked in the graph but filtered from metrics.
 func (a *Analyzer) Analyze(workspace *types.WorkspaceData) (*types.AnalysisResult, error) {
 	// Build dependency graph (Story 2.2)
-	// Story 2.6: Excluded packages are marked with Excluded=true
-	graph, err := a.graphBuilder.Build(workspace)
+	graph, err := a.BuildGraph(workspace)
 	if err != nil {
 		return nil, err
 	}
 
-	// Story 2.6: Count excluded and non-excluded packages
-	excludedCount := 0
-	for _, node := range graph.Nodes {
-		if node.Excluded {
-			excludedCount++
-		}
-	}
-	packageCount := len(graph.Nodes) - excludedCount
-
-	// Story 2.6: Create filtered graph for detectors (excludes excluded packages)
-	filteredGraph := filterExcludedPackages(graph)
-
-	// Detect circular dependencies (Story 2.3)
-	// Story 2.6: Use filtered graph to exclude excluded packages
-	cycleDetector := NewCycleDetector(filteredGraph)
-	cycles := cycleDetector.DetectCycles()
-
-	// Story 3.1: Enrich cycles with root cause analysis
-	rootCauseAnalyzer := NewRootCauseAnalyzer(filteredGraph)
-	for _, cycle := range cycles {
-		cycle.RootCause = rootCauseAnalyzer.Analyze(cycle)
-	}
-
-	// Story 3.6: Calculate impact assessment for each cycle
-	// (Computed before fix strategies so BeforeAfterExplanation can use ImpactAssessment)
-	impactAnalyzer := NewImpactAnalyzer(filteredGraph, workspace)
-	for _, cycle := range cycles {
-		cycle.ImpactAssessment = impactAnalyzer.Analyze(cycle)
-	}
-
-	// Story 3.3: Generate fix strategies for cycles
-	// Story 3.4: Generate step-by-step guides for each strategy
-	// Story 3.5: Calculate refactoring complexity for cycles and strategies
-	// Story 3.7: Generate before/after explanations for each strategy
-	fixGenerator := NewFixStrategyGenerator(filteredGraph, workspace)
-	guideGenerator := NewFixGuideGenerator(workspace)
-	complexityCalc := NewComplexityCalculator(filteredGraph, workspace)
-	beforeAfterGenerator := NewBeforeAfterGenerator(filteredGraph, workspace)
-	for _, cycle := range cycles {
-		// Calculate cycle-level complexity
-		cycle.RefactoringComplexity = complexityCalc.Calculate(cycle)
-
-		// Generate fix strategies
-		strategies := fixGenerator.Generate(cycle)
-		for i := range strategies {
-			strategies[i].Guide = guideGenerator.Generate(cycle, &strategies[i])
-			// Calculate per-strategy complexity with strategy-specific adjustments (AC6)
-			strategies[i].Complexity = complexityCalc.CalculateForStrategy(cycle, strategies[i].Type)
-			// Story 3.7: Generate before/after explanation for each strategy
-			strategies[i].BeforeAfterExplanation = beforeAfterGenerator.Generate(cycle, &strategies[i])
-		}
-		cycle.FixStrategies = strategies
-	}
-
-	// Detect version conflicts (Story 2.4)
-	// Story 2.6: Use filtered graph to exclude excluded packages
-	conflictDetector := NewConflictDetector(filteredGraph)
-	conflicts := conflictDetector.DetectConflicts()
-
-	// Calculate health score (Story 2.5)
-	// Story 2.6: Use filtered graph to exclude excluded packages from metrics
-	healthCalc := NewHealthCalculator(filteredGraph, cycles, conflicts)
-	healthScore := healthCalc.Calculate()
-
-	result := &types.AnalysisResult{
-		HealthScore:          healthScore.Overall,
-		HealthScoreDetails:   healthScore,
-		Packages:             packageCount,
-		ExcludedPackages:     excludedCount,
-		Graph:                graph, // Full graph with excluded flag for visualization
-		CircularDependencies: cycles,
-		VersionConflicts:     conflicts,
-		CreatedAt:            time.Now().UTC().Format(time.RFC3339),
-	}
-
-	// Story 3.8: Enrich result with QuickFix, priority scores, and FixSummary
-	enricher := NewResultEnricher(filteredGraph, workspace)
-	result = enricher.Enrich(result)
-
-	return result, nil
+	return a.AnalyzeGraph(workspace, graph, nil), nil
 }
 
 // AnalyzeWithSources performs complete workspace analysis with optional import tracing.
@@ -155,12 +91,29 @@ func (a *Analyzer) AnalyzeWithSources(
 	sourceFiles map[string][]byte,
 ) (*types.AnalysisResult, error) {
 	// Build dependency graph (Story 2.2)
-	// Story 2.6: Excluded packages are marked with Excluded=true
-	graph, err := a.graphBuilder.Build(workspace)
+	graph, err := a.BuildGraph(workspace)
 	if err != nil {
 		return nil, err
 	}
 
+	return a.AnalyzeGraph(workspace, graph, NewImportTracer(workspace, sourceFiles)), nil
+}
+
+// BuildGraph builds the dependency graph of the workspace.
+// Story 2.6: Excluded packages are marked with Excluded=true
+func (a *Analyzer) BuildGraph(workspace *types.WorkspaceData) (*types.DependencyGraph, error) {
+	return a.graphBuilder.Build(workspace)
+}
+
+// AnalyzeGraph performs complete workspace analysis on a graph built by
+// BuildGraph, tracing the import statements of circular dependencies with
+// importTracer (nil skips import tracing). Callers that keep the graph
+// between runs use it to skip the graph build.
+func (a *Analyzer) AnalyzeGraph(
+	workspace *types.WorkspaceData,
+	graph *types.DependencyGraph,
+	importTracer *ImportTracer,
+) *types.AnalysisResult {
 	// Story 2.6: Count excluded and non-excluded packages
 	excludedCount := 0
 	for _, node := range graph.Nodes {
@@ -185,10 +138,11 @@ func (a *Analyzer) AnalyzeWithSources(
 	}
 
 	// Story 3.2: Enrich cycles with import traces
-	// Always set ImportTraces (empty slice for graceful degradation per AC6)
-	importTracer := NewImportTracer(workspace, sourceFiles)
-	for _, cycle := range cycles {
-		cycle.ImportTraces = importTracer.Trace(cycle)
+	// Always set ImportTraces when tracing (empty slice for graceful degradation per AC6)
+	if importTracer != nil {
+		for _, cycle := range cycles {
+			cycle.ImportTraces = importTracer.Trace(cycle)
+		}
 	}
 
 	// Story 3.6: Calculate impact assessment for each cycle
@@ -227,9 +181,18 @@ func (a *Analyzer) AnalyzeWithSources(
 	conflictDetector := NewConflictDetector(filteredGraph)
 	conflicts := conflictDetector.DetectConflicts()
 
+	// Check layer boundaries (only when layers are configured)
+	boundaryViolations := a.checkBoundaries(filteredGraph)
+
+	// Tag packages and issues with owners (only when CODEOWNERS or owner rules exist)
+	ownership := a.reportOwnership(workspace, graph, cycles, conflicts, boundaryViolations)
+
+	// Evaluate custom rules (after ownership, so owners are attributes)
+	customRuleViolations := a.checkCustomRules(graph, cycles)
+
 	// Calculate health score (Story 2.5)
 	// Story 2.6: Use filtered graph to exclude excluded packages from metrics
-	healthCalc := NewHealthCalculator(filteredGraph, cycles, conflicts)
+	healthCalc := NewHealthCalculatorWithWeights(filteredGraph, cycles, conflicts, a.healthWeights())
 	healthScore := healthCalc.Calculate()
 
 	result := &types.AnalysisResult{
@@ -240,24 +203,85 @@ func (a *Analyzer) AnalyzeWithSources(
 		Graph:                graph, // Full graph with excluded flag for visualization
 		CircularDependencies: cycles,
 		VersionConflicts:     conflicts,
+		BoundaryViolations:   boundaryViolations,
+		Ownership:            ownership,
+		CustomRuleViolations: customRuleViolations,
 		CreatedAt:            time.Now().UTC().Format(time.RFC3339),
 	}
 
 	// Story 3.8: Enrich result with QuickFix, priority scores, and FixSummary
 	enricher := NewResultEnricher(filteredGraph, workspace)
-	result = enricher.Enrich(result)
+	return enricher.Enrich(result)
+}
 
-	return result, nil
+// checkBoundaries returns layer boundary violations for the configured layers.
+// Returns nil when no layers are configured.
+func (a *Analyzer) checkBoundaries(graph *types.DependencyGraph) []*types.BoundaryViolation {
+	if a.config == nil || len(a.config.Layers) == 0 {
+		return nil
+	}
+	return NewBoundaryChecker(graph, a.config.Layers).Check()
+}
+
+// checkCustomRules returns the violations of the configured custom rules.
+// Returns nil when no custom rules are configured.
+func (a *Analyzer) checkCustomRules(graph *types.DependencyGraph, cycles []*types.CircularDependencyInfo) []*types.CustomRuleViolation {
+	if a.customRules == nil {
+		return nil
+	}
+	return a.customRules.Check(graph, cycles, a.config.Layers)
+}
+
+// reportOwnership tags the graph, cycles and conflicts with the owners from
+// the workspace CODEOWNERS file followed by the configured owner rules, and
+// returns the issues grouped by owner. Returns nil when there are no rules.
+func (a *Analyzer) reportOwnership(
+	workspace *types.WorkspaceData,
+	graph *types.DependencyGraph,
+	cycles []*types.CircularDependencyInfo,
+	conflicts []*types.VersionConflictInfo,
+	violations []*types.BoundaryViolation,
+) *types.Ownership {
+	var rules []types.OwnerRule
+	if workspace != nil {
+		rules = append(rules, workspace.CodeOwners...)
+	}
+	if a.config != nil {
+		rules = append(rules, a.config.Owners...)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	return NewOwnershipReporter(graph, rules).Report(cycles, conflicts, violations)
+}
+
+// healthWeights returns the configured health factor weights, or nil for the
+// defaults.
+func (a *Analyzer) healthWeights() *types.HealthWeights {
+	if a.config == nil {
+		return nil
+	}
+	return a.config.HealthWeights
 }
 
 // filterExcludedPackages creates a new graph with only non-excluded packages.
 // This is used for metrics calculation while preserving the full graph for visualization.
 func filterExcludedPackages(graph *types.DependencyGraph) *types.DependencyGraph {
+	return filterPackages(graph, func(node *types.PackageNode) bool { return !node.Excluded })
+}
+
+// filterPackages creates a new graph with only the packages keep accepts and
+// the dependencies between them.
+func filterPackages(graph *types.DependencyGraph, keep func(*types.PackageNode) bool) *types.DependencyGraph {
 	filtered := types.NewDependencyGraph(graph.RootPath, graph.WorkspaceType)
+	kept := func(name string) bool {
+		node, ok := graph.Nodes[name]
+		return ok && keep(node)
+	}
 
-	// Copy only non-excluded nodes
+	// Copy only kept nodes
 	for name, node := range graph.Nodes {
-		if !node.Excluded {
+		if keep(node) {
 			// Create a new node with filtered dependencies
 			newNode := types.NewPackageNode(node.Name, node.Version, node.Path)
 			newNode.ExternalDeps = node.ExternalDeps
@@ -265,24 +289,24 @@ func filterExcludedPackages(graph *types.DependencyGraph) *types.DependencyGraph
 			newNode.ExternalPeerDeps = node.ExternalPeerDeps
 			newNode.ExternalOptionalDeps = node.ExternalOptionalDeps
 
-			// Filter internal dependencies to exclude excluded packages
+			// Filter internal dependencies to the kept packages
 			for _, dep := range node.Dependencies {
-				if depNode, ok := graph.Nodes[dep]; ok && !depNode.Excluded {
+				if kept(dep) {
 					newNode.Dependencies = append(newNode.Dependencies, dep)
 				}
 			}
 			for _, dep := range node.DevDependencies {
-				if depNode, ok := graph.Nodes[dep]; ok && !depNode.Excluded {
+				if kept(dep) {
 					newNode.DevDependencies = append(newNode.DevDependencies, dep)
 				}
 			}
 			for _, dep := range node.PeerDependencies {
-				if depNode, ok := graph.Nodes[dep]; ok && !depNode.Excluded {
+				if kept(dep) {
 					newNode.PeerDependencies = append(newNode.PeerDependencies, dep)
 				}
 			}
 			for _, dep := range node.OptionalDependencies {
-				if depNode, ok := graph.Nodes[dep]; ok && !depNode.Excluded {
+				if kept(dep) {
 					newNode.OptionalDependencies = append(newNode.OptionalDependencies, dep)
 				}
 			}
@@ -291,11 +315,9 @@ func filterExcludedPackages(graph *types.DependencyGraph) *types.DependencyGraph
 		}
 	}
 
-	// Copy only edges between non-excluded packages
+	// Copy only edges between kept packages
 	for _, edge := range graph.Edges {
-		fromNode, fromOk := graph.Nodes[edge.From]
-		toNode, toOk := graph.Nodes[edge.To]
-		if fromOk && toOk && !fromNode.Excluded && !toNode.Excluded {
+		if kept(edge.From) && kept(edge.To) {
 			filtered.Edges = append(filtered.Edges, edge)
 		}
 	}
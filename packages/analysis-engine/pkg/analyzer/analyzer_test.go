@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
@@ -458,6 +459,18 @@ func TestNewAnalyzerWithConfigInvalidRegex(t *testing.T) {
 	}
 }
 
+// TestNewAnalyzerWithConfigNegativeWeight verifies error on a negative health weight.
+func TestNewAnalyzerWithConfigNegativeWeight(t *testing.T) {
+	config := &types.AnalysisConfig{
+		HealthWeights: &types.HealthWeights{Circular: 1, Conflict: -1},
+	}
+
+	_, err := NewAnalyzerWithConfig(config)
+	if err == nil || !strings.Contains(err.Error(), "conflict") {
+		t.Errorf("Expected error for negative conflict weight, got %v", err)
+	}
+}
+
 // TestAnalyzeWithExclusions verifies exclusion patterns are applied.
 func TestAnalyzeWithExclusions(t *testing.T) {
 	config := &types.AnalysisConfig{
@@ -1728,3 +1741,90 @@ func TestAnalyzeWithSourcesIncludesImpactAssessment(t *testing.T) {
 		t.Log("All Story 3.x enrichments (RootCause, FixStrategies, ImpactAssessment) are populated")
 	}
 }
+
+// TestAnalyzeGraph verifies analysis of a previously built graph matches
+// AnalyzeWithSources.
+func TestAnalyzeGraph(t *testing.T) {
+	workspace := &types.WorkspaceData{
+		RootPath:      "/workspace",
+		WorkspaceType: types.WorkspaceTypePnpm,
+		Packages: map[string]*types.PackageInfo{
+			"@mono/ui":  {Name: "@mono/ui", Version: "1.0.0", Path: "packages/ui", Dependencies: map[string]string{"@mono/api": "^1.0.0"}},
+			"@mono/api": {Name: "@mono/api", Version: "1.0.0", Path: "packages/api", Dependencies: map[string]string{"@mono/ui": "^1.0.0"}},
+			"@mono/old": {Name: "@mono/old", Version: "1.0.0", Path: "packages/old"},
+		},
+	}
+	sourceFiles := map[string][]byte{
+		"packages/ui/src/index.ts":  []byte(`import { api } from '@mono/api';`),
+		"packages/api/src/index.ts": []byte(`import { ui } from '@mono/ui';`),
+	}
+
+	a, err := NewAnalyzerWithConfig(&types.AnalysisConfig{Exclude: []string{"@mono/old"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	graph, err := a.BuildGraph(workspace)
+	if err != nil {
+		t.Fatalf("BuildGraph failed: %v", err)
+	}
+	if !graph.Nodes["@mono/old"].Excluded {
+		t.Error("BuildGraph should mark excluded packages")
+	}
+
+	got := a.AnalyzeGraph(workspace, graph, NewImportTracerWithImports(workspace, sourceFiles, nil))
+	want, err := a.AnalyzeWithSources(workspace, sourceFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Packages != want.Packages || got.ExcludedPackages != want.ExcludedPackages || got.HealthScore != want.HealthScore {
+		t.Errorf("AnalyzeGraph() = %d packages, %d excluded, score %d; want %d, %d, %d",
+			got.Packages, got.ExcludedPackages, got.HealthScore, want.Packages, want.ExcludedPackages, want.HealthScore)
+	}
+	if got.Graph != graph {
+		t.Error("AnalyzeGraph() should return the given graph")
+	}
+	if len(got.CircularDependencies) != 1 || len(got.CircularDependencies[0].ImportTraces) != 2 {
+		t.Errorf("AnalyzeGraph() cycles = %+v, want 1 cycle with 2 import traces", got.CircularDependencies)
+	}
+}
+
+// TestAnalyzeCustomRules verifies custom rules are compiled with the config
+// and evaluated against the analyzed packages.
+func TestAnalyzeCustomRules(t *testing.T) {
+	if _, err := NewAnalyzerWithConfig(&types.AnalysisConfig{CustomRules: []types.CustomRule{
+		{Name: "broken", Scope: types.CustomRuleScopePackage, Forbid: "len(dependencies) >"},
+	}}); err == nil || !strings.Contains(err.Error(), `custom rule "broken"`) {
+		t.Errorf("Expected error for invalid custom rule, got %v", err)
+	}
+
+	workspace := &types.WorkspaceData{
+		RootPath:      "/workspace",
+		WorkspaceType: types.WorkspaceTypePnpm,
+		Packages: map[string]*types.PackageInfo{
+			"@mono/web": {Name: "@mono/web", Version: "1.0.0", Path: "apps/web", Dependencies: map[string]string{"@mono/ui": "^1.0.0"}},
+			"@mono/ui":  {Name: "@mono/ui", Version: "1.0.0", Path: "libs/ui", Dependencies: map[string]string{"moment": "^2.29.0"}},
+			"@mono/old": {Name: "@mono/old", Version: "1.0.0", Path: "libs/old", Dependencies: map[string]string{"moment": "^1.0.0"}},
+		},
+	}
+	a, err := NewAnalyzerWithConfig(&types.AnalysisConfig{
+		Exclude: []string{"@mono/old"},
+		CustomRules: []types.CustomRule{
+			{Name: "no-moment-in-libs", Scope: types.CustomRuleScopeExternal, Forbid: `name == "moment" && package.path matches "libs/*"`},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewAnalyzerWithConfig failed: %v", err)
+	}
+	result, err := a.Analyze(workspace)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	if len(result.CustomRuleViolations) != 1 {
+		t.Fatalf("CustomRuleViolations = %+v, want 1", result.CustomRuleViolations)
+	}
+	if v := result.CustomRuleViolations[0]; v.Package != "@mono/ui" || v.Dependency != "moment" {
+		t.Errorf("CustomRuleViolations[0] = %+v, want @mono/ui → moment", v)
+	}
+}
@@ -39,7 +39,7 @@ func (bag *BeforeAfterGenerator) Generate(
 	return &types.BeforeAfterExplanation{
 		CurrentState:     bag.generateCurrentState(cycle),
 		ProposedState:    bag.generateProposedState(cycle, strategy),
-		PackageJsonDiffs: bag.generatePackageJsonDiffs(strategy),
+		PackageJsonDiffs: bag.generatePackageJsonDiffs(cycle, strategy),
 		ImportDiffs:      bag.generateImportDiffs(cycle, strategy),
 		Explanation:      bag.generateExplanation(cycle, strategy),
 		Warnings:         bag.generateWarnings(cycle, strategy),
@@ -275,7 +275,10 @@ func (bag *BeforeAfterGenerator) generateProposedStateBoundary(
 // ========================================
 
 // generatePackageJsonDiffs creates package.json change descriptions.
-func (bag *BeforeAfterGenerator) generatePackageJsonDiffs(strategy *types.FixStrategy) []types.PackageJsonDiff {
+func (bag *BeforeAfterGenerator) generatePackageJsonDiffs(
+	cycle *types.CircularDependencyInfo,
+	strategy *types.FixStrategy,
+) []types.PackageJsonDiff {
 	diffs := []types.PackageJsonDiff{}
 
 	// Guard against empty TargetPackages
@@ -286,7 +289,13 @@ func (bag *BeforeAfterGenerator) generatePackageJsonDiffs(strategy *types.FixStr
 	switch strategy.Type {
 	case types.FixStrategyExtractModule:
 		// Each target package needs to add dependency on new shared package
+		// and drop its dependency on the next package in the cycle
 		for _, pkgName := range strategy.TargetPackages {
+			toRemove := bag.cycleDependencyRemovals(cycle, pkgName)
+			summary := fmt.Sprintf("Add dependency on %s", strategy.NewPackageName)
+			for _, dep := range toRemove {
+				summary += fmt.Sprintf(", remove dependency on %s", dep.Name)
+			}
 			diffs = append(diffs, types.PackageJsonDiff{
 				PackageName: pkgName,
 				FilePath:    bag.getPackageJsonPath(pkgName),
@@ -297,8 +306,8 @@ func (bag *BeforeAfterGenerator) generatePackageJsonDiffs(strategy *types.FixStr
 						DependencyType: "dependencies",
 					},
 				},
-				DependenciesToRemove: []types.DependencyChange{},
-				Summary:              fmt.Sprintf("Add dependency on %s", strategy.NewPackageName),
+				DependenciesToRemove: toRemove,
+				Summary:              summary,
 			})
 		}
 
@@ -330,6 +339,52 @@ func (bag *BeforeAfterGenerator) generatePackageJsonDiffs(strategy *types.FixStr
 	return diffs
 }
 
+// cycleDependencyRemovals returns the cycle edges leaving pkgName as
+// package.json dependency removals.
+func (bag *BeforeAfterGenerator) cycleDependencyRemovals(
+	cycle *types.CircularDependencyInfo,
+	pkgName string,
+) []types.DependencyChange {
+	removals := []types.DependencyChange{}
+	if cycle == nil {
+		return removals
+	}
+
+	for i := 0; i < len(cycle.Cycle)-1; i++ {
+		if cycle.Cycle[i] != pkgName {
+			continue
+		}
+		removals = append(removals, types.DependencyChange{
+			Name:           cycle.Cycle[i+1],
+			DependencyType: bag.dependencyField(pkgName, cycle.Cycle[i+1]),
+		})
+	}
+	return removals
+}
+
+// dependencyField returns the package.json field declaring the from -> to edge.
+// Defaults to "dependencies" when the edge is not in the graph.
+func (bag *BeforeAfterGenerator) dependencyField(from, to string) string {
+	if bag.graph != nil {
+		for _, edge := range bag.graph.Edges {
+			if edge.From != from || edge.To != to {
+				continue
+			}
+			switch edge.Type {
+			case types.DependencyTypeDevelopment:
+				return "devDependencies"
+			case types.DependencyTypePeer:
+				return "peerDependencies"
+			case types.DependencyTypeOptional:
+				return "optionalDependencies"
+			default:
+				return "dependencies"
+			}
+		}
+	}
+	return "dependencies"
+}
+
 // getPackageJsonPath returns the path to a package's package.json.
 func (bag *BeforeAfterGenerator) getPackageJsonPath(pkgName string) string {
 	if bag.workspace != nil && bag.workspace.Packages != nil {
@@ -251,7 +251,7 @@ func TestGeneratePackageJsonDiffs_ExtractModule(t *testing.T) {
 		NewPackageName: "@mono/shared",
 	}
 
-	diffs := generator.generatePackageJsonDiffs(strategy)
+	diffs := generator.generatePackageJsonDiffs(nil, strategy)
 
 	if len(diffs) != 2 {
 		t.Errorf("Expected 2 diffs, got %d", len(diffs))
@@ -270,6 +270,64 @@ func TestGeneratePackageJsonDiffs_ExtractModule(t *testing.T) {
 	}
 }
 
+func TestGeneratePackageJsonDiffs_ExtractModuleRemovesCycleEdges(t *testing.T) {
+	graph := types.NewDependencyGraph("/root", types.WorkspaceTypePnpm)
+	graph.Edges = []*types.DependencyEdge{
+		{From: "@mono/ui", To: "@mono/api", Type: types.DependencyTypeProduction},
+		{From: "@mono/api", To: "@mono/ui", Type: types.DependencyTypeDevelopment},
+	}
+	generator := NewBeforeAfterGenerator(graph, nil)
+	cycle := &types.CircularDependencyInfo{
+		Cycle: []string{"@mono/ui", "@mono/api", "@mono/ui"},
+	}
+	strategy := &types.FixStrategy{
+		Type:           types.FixStrategyExtractModule,
+		TargetPackages: []string{"@mono/ui", "@mono/api"},
+		NewPackageName: "@mono/shared",
+	}
+
+	diffs := generator.generatePackageJsonDiffs(cycle, strategy)
+
+	if len(diffs) != 2 {
+		t.Fatalf("Expected 2 diffs, got %d", len(diffs))
+	}
+	want := map[string]types.DependencyChange{
+		"@mono/ui":  {Name: "@mono/api", DependencyType: "dependencies"},
+		"@mono/api": {Name: "@mono/ui", DependencyType: "devDependencies"},
+	}
+	for _, diff := range diffs {
+		if len(diff.DependenciesToRemove) != 1 {
+			t.Fatalf("Expected 1 dependency to remove for %s, got %d", diff.PackageName, len(diff.DependenciesToRemove))
+		}
+		if diff.DependenciesToRemove[0] != want[diff.PackageName] {
+			t.Errorf("%s removes %+v, want %+v", diff.PackageName, diff.DependenciesToRemove[0], want[diff.PackageName])
+		}
+	}
+}
+
+// TestDependencyField_FirstEdgeWins verifies the first edge declaring a
+// dependency decides its field, even when a later edge repeats it.
+func TestDependencyField_FirstEdgeWins(t *testing.T) {
+	graph := types.NewDependencyGraph("/root", types.WorkspaceTypePnpm)
+	graph.Edges = []*types.DependencyEdge{
+		{From: "@mono/ui", To: "@mono/api", Type: types.DependencyTypeProduction},
+		{From: "@mono/ui", To: "@mono/api", Type: types.DependencyTypeDevelopment},
+		{From: "@mono/api", To: "@mono/ui", Type: types.DependencyTypePeer},
+		{From: "@mono/api", To: "@mono/ui", Type: types.DependencyTypeProduction},
+	}
+	generator := NewBeforeAfterGenerator(graph, nil)
+
+	if got := generator.dependencyField("@mono/ui", "@mono/api"); got != "dependencies" {
+		t.Errorf("dependencyField(ui, api) = %q, want dependencies", got)
+	}
+	if got := generator.dependencyField("@mono/api", "@mono/ui"); got != "peerDependencies" {
+		t.Errorf("dependencyField(api, ui) = %q, want peerDependencies", got)
+	}
+	if got := generator.dependencyField("@mono/ui", "@mono/other"); got != "dependencies" {
+		t.Errorf("dependencyField() of a missing edge = %q, want dependencies", got)
+	}
+}
+
 func TestGeneratePackageJsonDiffs_DI(t *testing.T) {
 	generator := NewBeforeAfterGenerator(nil, nil)
 	strategy := &types.FixStrategy{
@@ -277,7 +335,7 @@ func TestGeneratePackageJsonDiffs_DI(t *testing.T) {
 		TargetPackages: []string{"@mono/ui"},
 	}
 
-	diffs := generator.generatePackageJsonDiffs(strategy)
+	diffs := generator.generatePackageJsonDiffs(nil, strategy)
 
 	if len(diffs) != 1 {
 		t.Errorf("Expected 1 diff, got %d", len(diffs))
@@ -0,0 +1,95 @@
+// Package analyzer provides dependency graph analysis for monorepo workspaces.
+// This file implements architecture layer boundary checking.
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/parser"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// ========================================
+// Boundary Checker
+// ========================================
+
+// BoundaryChecker finds dependencies that cross disallowed layer boundaries.
+type BoundaryChecker struct {
+	graph  *types.DependencyGraph
+	layers []types.LayerDefinition
+}
+
+// NewBoundaryChecker creates a checker for the given graph and layer definitions.
+func NewBoundaryChecker(graph *types.DependencyGraph, layers []types.LayerDefinition) *BoundaryChecker {
+	return &BoundaryChecker{
+		graph:  graph,
+		layers: layers,
+	}
+}
+
+// Check returns all boundary violations sorted by from/to package name.
+// Packages that match no layer are unconstrained.
+// Returns nil when no layers are defined.
+func (bc *BoundaryChecker) Check() []*types.BoundaryViolation {
+	if bc.graph == nil || len(bc.layers) == 0 {
+		return nil
+	}
+
+	// Allowed layer dependencies: layer -> set of layers it may depend on
+	allowed := make(map[string]map[string]bool, len(bc.layers))
+	for _, layer := range bc.layers {
+		set := map[string]bool{layer.Name: true}
+		for _, dep := range layer.CanDependOn {
+			set[dep] = true
+		}
+		allowed[layer.Name] = set
+	}
+
+	violations := []*types.BoundaryViolation{}
+	for _, edge := range bc.graph.Edges {
+		fromLayer := bc.LayerOf(edge.From)
+		toLayer := bc.LayerOf(edge.To)
+		if fromLayer == "" || toLayer == "" {
+			continue
+		}
+		if allowed[fromLayer][toLayer] {
+			continue
+		}
+
+		violations = append(violations, &types.BoundaryViolation{
+			From:           edge.From,
+			To:             edge.To,
+			FromLayer:      fromLayer,
+			ToLayer:        toLayer,
+			DependencyType: edge.Type,
+			Message: fmt.Sprintf("%s (layer %q) must not depend on %s (layer %q)",
+				edge.From, fromLayer, edge.To, toLayer),
+		})
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].From != violations[j].From {
+			return violations[i].From < violations[j].From
+		}
+		return violations[i].To < violations[j].To
+	})
+
+	return violations
+}
+
+// LayerOf returns the name of the first layer matching the package's path,
+// or "" if the package is unknown or matches no layer.
+func (bc *BoundaryChecker) LayerOf(pkgName string) string {
+	node, ok := bc.graph.Nodes[pkgName]
+	if !ok {
+		return ""
+	}
+
+	for _, layer := range bc.layers {
+		if parser.MatchPattern(layer.Pattern, node.Path) {
+			return layer.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,120 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// createBoundaryTestGraph creates a graph from package paths and [from, to] edges.
+func createBoundaryTestGraph(paths map[string]string, edges [][]string) *types.DependencyGraph {
+	graph := types.NewDependencyGraph("/test", types.WorkspaceTypePnpm)
+	for name, path := range paths {
+		graph.Nodes[name] = types.NewPackageNode(name, "1.0.0", path)
+	}
+	for _, e := range edges {
+		graph.Edges = append(graph.Edges, &types.DependencyEdge{
+			From: e[0],
+			To:   e[1],
+			Type: types.DependencyTypeProduction,
+		})
+	}
+	return graph
+}
+
+var boundaryTestLayers = []types.LayerDefinition{
+	{Name: "apps", Pattern: "apps/*", CanDependOn: []string{"libs"}},
+	{Name: "libs", Pattern: "libs/*"},
+}
+
+func TestBoundaryChecker_NoLayers(t *testing.T) {
+	graph := createBoundaryTestGraph(
+		map[string]string{"web": "apps/web", "ui": "libs/ui"},
+		[][]string{{"ui", "web"}},
+	)
+
+	if violations := NewBoundaryChecker(graph, nil).Check(); violations != nil {
+		t.Errorf("Expected nil violations without layers, got %v", violations)
+	}
+}
+
+func TestBoundaryChecker_AllowedDependencies(t *testing.T) {
+	graph := createBoundaryTestGraph(
+		map[string]string{"web": "apps/web", "admin": "apps/admin", "ui": "libs/ui", "utils": "libs/utils"},
+		[][]string{{"web", "ui"}, {"ui", "utils"}, {"admin", "web"}},
+	)
+
+	violations := NewBoundaryChecker(graph, boundaryTestLayers).Check()
+	if len(violations) != 0 {
+		t.Errorf("Expected no violations, got %d: %+v", len(violations), violations)
+	}
+}
+
+func TestBoundaryChecker_Violation(t *testing.T) {
+	graph := createBoundaryTestGraph(
+		map[string]string{"web": "apps/web", "ui": "libs/ui", "tools": "tools/gen"},
+		[][]string{{"ui", "web"}, {"tools", "web"}, {"web", "tools"}},
+	)
+
+	violations := NewBoundaryChecker(graph, boundaryTestLayers).Check()
+	if len(violations) != 1 {
+		t.Fatalf("Expected 1 violation, got %d: %+v", len(violations), violations)
+	}
+
+	v := violations[0]
+	if v.From != "ui" || v.To != "web" || v.FromLayer != "libs" || v.ToLayer != "apps" {
+		t.Errorf("Unexpected violation: %+v", v)
+	}
+	if v.DependencyType != types.DependencyTypeProduction {
+		t.Errorf("DependencyType = %s, want production", v.DependencyType)
+	}
+	if v.Message == "" {
+		t.Error("Expected a human-readable message")
+	}
+}
+
+func TestBoundaryChecker_LayerOf(t *testing.T) {
+	graph := createBoundaryTestGraph(
+		map[string]string{"web": "apps/web", "other": "scripts/other"},
+		nil,
+	)
+	checker := NewBoundaryChecker(graph, boundaryTestLayers)
+
+	tests := []struct {
+		pkg  string
+		want string
+	}{
+		{"web", "apps"},
+		{"other", ""},
+		{"missing", ""},
+	}
+	for _, tt := range tests {
+		if got := checker.LayerOf(tt.pkg); got != tt.want {
+			t.Errorf("LayerOf(%q) = %q, want %q", tt.pkg, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzer_BoundaryViolationsInResult(t *testing.T) {
+	workspace := &types.WorkspaceData{
+		RootPath:      "/test",
+		WorkspaceType: types.WorkspaceTypePnpm,
+		Packages: map[string]*types.PackageInfo{
+			"web": {Name: "web", Path: "apps/web", Dependencies: map[string]string{}},
+			"ui":  {Name: "ui", Path: "libs/ui", Dependencies: map[string]string{"web": "workspace:*"}},
+		},
+	}
+
+	a, err := NewAnalyzerWithConfig(&types.AnalysisConfig{Layers: boundaryTestLayers})
+	if err != nil {
+		t.Fatalf("NewAnalyzerWithConfig() error = %v", err)
+	}
+
+	result, err := a.Analyze(workspace)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if len(result.BoundaryViolations) != 1 {
+		t.Errorf("BoundaryViolations = %d, want 1", len(result.BoundaryViolations))
+	}
+}
@@ -0,0 +1,234 @@
+// Package analyzer provides dependency graph analysis for monorepo workspaces.
+// This file implements evaluation of declarative custom rules.
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/rules"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// ========================================
+// Custom Rule Checker
+// ========================================
+
+// CustomRuleChecker evaluates custom rules against a dependency graph.
+type CustomRuleChecker struct {
+	rules []compiledRule
+}
+
+// compiledRule is a custom rule with its compiled expression.
+type compiledRule struct {
+	rule     types.CustomRule
+	severity types.RuleSeverity
+	forbid   *rules.Expr
+}
+
+// NewCustomRuleChecker compiles the custom rules. Rules whose severity is off
+// are skipped. Returns an error naming the first invalid rule.
+func NewCustomRuleChecker(customRules []types.CustomRule) (*CustomRuleChecker, error) {
+	c := &CustomRuleChecker{}
+	names := map[string]bool{}
+	for i, rule := range customRules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("custom rule %d: name is required", i+1)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("custom rule %q: duplicate name", rule.Name)
+		}
+		names[rule.Name] = true
+
+		severity := effectiveSeverity(rule.Severity)
+		switch severity {
+		case types.RuleSeverityOff:
+			continue
+		case types.RuleSeverityError, types.RuleSeverityWarn:
+		default:
+			return nil, fmt.Errorf("custom rule %q: invalid severity %q (expected error, warn or off)", rule.Name, rule.Severity)
+		}
+
+		forbid, err := rules.Compile(rule.Forbid, rule.Scope)
+		if err != nil {
+			return nil, fmt.Errorf("custom rule %q: %w", rule.Name, err)
+		}
+		c.rules = append(c.rules, compiledRule{rule: rule, severity: severity, forbid: forbid})
+	}
+	return c, nil
+}
+
+// Check evaluates the rules against the non-excluded packages of the graph,
+// using the configured layers and the owners set on the graph. Violations are
+// returned in rule order, then by package and dependency.
+func (c *CustomRuleChecker) Check(
+	graph *types.DependencyGraph,
+	cycles []*types.CircularDependencyInfo,
+	layers []types.LayerDefinition,
+) []*types.CustomRuleViolation {
+	if graph == nil || len(c.rules) == 0 {
+		return nil
+	}
+
+	packages, edges := customRulePackages(graph, cycles, layers)
+	names := make([]string, 0, len(packages))
+	for name := range packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	g := rules.Graph{
+		Packages:      len(packages),
+		Edges:         len(edges),
+		Cycles:        len(cycles),
+		WorkspaceType: string(graph.WorkspaceType),
+	}
+
+	violations := []*types.CustomRuleViolation{}
+	for _, r := range c.rules {
+		seen := map[string]bool{}
+		add := func(pkg, dep, subject string) {
+			if seen[pkg+"\x00"+dep] {
+				return
+			}
+			seen[pkg+"\x00"+dep] = true
+			message := subject
+			if r.rule.Description != "" {
+				message = fmt.Sprintf("%s (%s)", r.rule.Description, subject)
+			}
+			violations = append(violations, &types.CustomRuleViolation{
+				Rule:       r.rule.Name,
+				Severity:   r.severity,
+				Message:    message,
+				Package:    pkg,
+				Dependency: dep,
+			})
+		}
+
+		switch r.rule.Scope {
+		case types.CustomRuleScopePackage:
+			for _, name := range names {
+				if r.forbid.Eval(rules.PackageEnv(g, packages[name])) {
+					add(name, "", name)
+				}
+			}
+		case types.CustomRuleScopeDependency:
+			for _, edge := range edges {
+				env := rules.DependencyEnv(g, packages[edge.From], packages[edge.To], edge.Type, edge.VersionRange)
+				if r.forbid.Eval(env) {
+					add(edge.From, edge.To, edge.From+" → "+edge.To)
+				}
+			}
+		case types.CustomRuleScopeExternal:
+			for _, name := range names {
+				node := graph.Nodes[name]
+				for _, deps := range externalDependencies(node) {
+					for _, dep := range sortedDeps(deps.versions) {
+						version := deps.versions[dep]
+						if r.forbid.Eval(rules.ExternalEnv(g, packages[name], dep, version, deps.depType)) {
+							add(name, dep, fmt.Sprintf("%s → %s@%s", name, dep, version))
+						}
+					}
+				}
+			}
+		case types.CustomRuleScopeWorkspace:
+			if r.forbid.Eval(rules.WorkspaceEnv(g)) {
+				add("", "", "workspace")
+			}
+		}
+	}
+	return violations
+}
+
+// customRulePackages describes the non-excluded packages of the graph to
+// rules, and returns the edges between them sorted by from/to/type.
+func customRulePackages(
+	graph *types.DependencyGraph,
+	cycles []*types.CircularDependencyInfo,
+	layers []types.LayerDefinition,
+) (map[string]*rules.Package, []*types.DependencyEdge) {
+	boundaries := NewBoundaryChecker(graph, layers)
+	inCycle := map[string]bool{}
+	for _, cycle := range cycles {
+		for _, name := range cycle.Cycle {
+			inCycle[name] = true
+		}
+	}
+
+	packages := map[string]*rules.Package{}
+	for name, node := range graph.Nodes {
+		if node.Excluded {
+			continue
+		}
+		var external []string
+		for _, deps := range externalDependencies(node) {
+			for dep := range deps.versions {
+				external = appendUnique(external, dep)
+			}
+		}
+		sort.Strings(external)
+		packages[name] = &rules.Package{
+			Name:                 name,
+			Version:              node.Version,
+			Path:                 node.Path,
+			Layer:                boundaries.LayerOf(name),
+			Owners:               node.Owners,
+			Dependencies:         []string{},
+			Dependents:           []string{},
+			ExternalDependencies: external,
+			InCycle:              inCycle[name],
+		}
+	}
+
+	var edges []*types.DependencyEdge
+	for _, edge := range graph.Edges {
+		from, to := packages[edge.From], packages[edge.To]
+		if from == nil || to == nil {
+			continue
+		}
+		edges = append(edges, edge)
+		from.Dependencies = appendUnique(from.Dependencies, edge.To)
+		to.Dependents = appendUnique(to.Dependents, edge.From)
+	}
+	for _, p := range packages {
+		sort.Strings(p.Dependencies)
+		sort.Strings(p.Dependents)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Type < b.Type
+	})
+	return packages, edges
+}
+
+// typedDeps are the external dependencies of one dependency type.
+type typedDeps struct {
+	depType  types.DependencyType
+	versions map[string]string
+}
+
+// externalDependencies returns the external dependencies of a package by
+// dependency type, production first.
+func externalDependencies(node *types.PackageNode) []typedDeps {
+	return []typedDeps{
+		{types.DependencyTypeProduction, node.ExternalDeps},
+		{types.DependencyTypeDevelopment, node.ExternalDevDeps},
+		{types.DependencyTypePeer, node.ExternalPeerDeps},
+		{types.DependencyTypeOptional, node.ExternalOptionalDeps},
+	}
+}
+
+// sortedDeps returns the names of a dependency map in sorted order.
+func sortedDeps(deps map[string]string) []string {
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
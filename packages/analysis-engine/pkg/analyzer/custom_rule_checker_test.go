@@ -0,0 +1,110 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// createCustomRuleTestGraph creates a graph where two apps depend on a
+// library, the library depends on moment and a legacy package is excluded.
+func createCustomRuleTestGraph() *types.DependencyGraph {
+	graph := createBoundaryTestGraph(
+		map[string]string{"web": "apps/web", "admin": "apps/admin", "ui": "libs/ui", "legacy": "libs/legacy"},
+		[][]string{{"web", "ui"}, {"admin", "ui"}, {"admin", "web"}, {"legacy", "web"}},
+	)
+	graph.Edges = append(graph.Edges, &types.DependencyEdge{From: "admin", To: "web", Type: types.DependencyTypeDevelopment})
+	graph.Nodes["ui"].ExternalDeps["moment"] = "^2.29.0"
+	graph.Nodes["ui"].ExternalDevDeps["moment"] = "^2.29.0"
+	graph.Nodes["web"].ExternalDeps["react"] = "^18.0.0"
+	graph.Nodes["web"].Owners = []string{"@org/web"}
+	graph.Nodes["legacy"].Excluded = true
+	graph.Nodes["legacy"].ExternalDeps["moment"] = "^1.0.0"
+	return graph
+}
+
+func TestCustomRuleChecker_Check(t *testing.T) {
+	checker, err := NewCustomRuleChecker([]types.CustomRule{
+		{Name: "apps-are-leaves", Description: "Packages under apps/ must not be depended on",
+			Scope: types.CustomRuleScopeDependency, Forbid: `to.path matches "apps/*"`},
+		{Name: "max-deps", Scope: types.CustomRuleScopePackage, Forbid: `len(dependencies) > 1`, Severity: types.RuleSeverityWarn},
+		{Name: "no-moment-in-libs", Scope: types.CustomRuleScopeExternal, Forbid: `name matches "moment" && package.layer == "libs"`},
+		{Name: "owned-apps", Scope: types.CustomRuleScopePackage, Forbid: `path matches "apps/*" && len(owners) == 0`},
+		{Name: "small", Scope: types.CustomRuleScopeWorkspace, Forbid: `graph.packages > 2 && graph.edges >= 3`},
+		{Name: "disabled", Scope: types.CustomRuleScopePackage, Forbid: `true`, Severity: types.RuleSeverityOff},
+	})
+	if err != nil {
+		t.Fatalf("NewCustomRuleChecker() error = %v", err)
+	}
+
+	violations := checker.Check(createCustomRuleTestGraph(), nil, boundaryTestLayers)
+
+	want := []types.CustomRuleViolation{
+		// The excluded legacy package and its dependency on web are left out,
+		// and the second admin → web edge is reported once
+		{Rule: "apps-are-leaves", Severity: types.RuleSeverityError, Package: "admin", Dependency: "web",
+			Message: "Packages under apps/ must not be depended on (admin → web)"},
+		{Rule: "max-deps", Severity: types.RuleSeverityWarn, Package: "admin", Message: "admin"},
+		{Rule: "no-moment-in-libs", Severity: types.RuleSeverityError, Package: "ui", Dependency: "moment", Message: "ui → moment@^2.29.0"},
+		{Rule: "owned-apps", Severity: types.RuleSeverityError, Package: "admin", Message: "admin"},
+		{Rule: "small", Severity: types.RuleSeverityError, Message: "workspace"},
+	}
+	if len(violations) != len(want) {
+		t.Fatalf("violations = %d, want %d: %+v", len(violations), len(want), violations)
+	}
+	for i, w := range want {
+		if *violations[i] != w {
+			t.Errorf("violations[%d] = %+v, want %+v", i, *violations[i], w)
+		}
+	}
+}
+
+func TestCustomRuleChecker_Attributes(t *testing.T) {
+	cycles := []*types.CircularDependencyInfo{types.NewCircularDependencyInfo([]string{"web", "ui", "web"})}
+	tests := []struct {
+		forbid string
+		want   []string
+	}{
+		{`len(dependents) >= 2`, []string{"ui"}}, // legacy → web is excluded
+		{`"web" in dependencies`, []string{"admin"}},
+		{`inCycle`, []string{"ui", "web"}},
+		{`externalDependencies matches "react"`, []string{"web"}},
+		{`layer == ""`, nil},
+	}
+	for _, tt := range tests {
+		checker, err := NewCustomRuleChecker([]types.CustomRule{{Name: "r", Scope: types.CustomRuleScopePackage, Forbid: tt.forbid}})
+		if err != nil {
+			t.Fatalf("NewCustomRuleChecker(%q) error = %v", tt.forbid, err)
+		}
+		var got []string
+		for _, v := range checker.Check(createCustomRuleTestGraph(), cycles, boundaryTestLayers) {
+			got = append(got, v.Package)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("%s: packages = %v, want %v", tt.forbid, got, tt.want)
+		}
+	}
+}
+
+func TestNewCustomRuleChecker_Errors(t *testing.T) {
+	tests := []struct {
+		rule types.CustomRule
+		want string
+	}{
+		{types.CustomRule{Scope: types.CustomRuleScopePackage, Forbid: "true"}, "custom rule 1: name is required"},
+		{types.CustomRule{Name: "r", Scope: "module", Forbid: "true"}, `custom rule "r": unknown scope "module"`},
+		{types.CustomRule{Name: "r", Scope: types.CustomRuleScopePackage, Forbid: "size > 1"}, `custom rule "r": column 1: unknown attribute "size"`},
+		{types.CustomRule{Name: "r", Scope: types.CustomRuleScopePackage, Forbid: "true", Severity: "fatal"}, `custom rule "r": invalid severity "fatal"`},
+	}
+	for _, tt := range tests {
+		if _, err := NewCustomRuleChecker([]types.CustomRule{tt.rule}); err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("NewCustomRuleChecker(%+v) error = %v, want %q", tt.rule, err, tt.want)
+		}
+	}
+
+	duplicate := types.CustomRule{Name: "r", Scope: types.CustomRuleScopeWorkspace, Forbid: "false"}
+	if _, err := NewCustomRuleChecker([]types.CustomRule{duplicate, duplicate}); err == nil || !strings.Contains(err.Error(), "duplicate name") {
+		t.Errorf("NewCustomRuleChecker() with duplicate names error = %v", err)
+	}
+}
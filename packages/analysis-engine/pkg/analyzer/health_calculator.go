@@ -47,6 +47,7 @@ type HealthCalculator struct {
 	graph     *types.DependencyGraph
 	cycles    []*types.CircularDependencyInfo
 	conflicts []*types.VersionConflictInfo
+	weights   types.HealthWeights // Normalized to sum to 1.0
 }
 
 // NewHealthCalculator creates a new calculator with analysis results.
@@ -54,11 +55,76 @@ func NewHealthCalculator(
 	graph *types.DependencyGraph,
 	cycles []*types.CircularDependencyInfo,
 	conflicts []*types.VersionConflictInfo,
+) *HealthCalculator {
+	return NewHealthCalculatorWithWeights(graph, cycles, conflicts, nil)
+}
+
+// NewHealthCalculatorWithWeights creates a calculator with custom factor
+// weights. The weights are normalized by their sum; nil weights, weights
+// that sum to zero and weights rejected by ValidateHealthWeights use the
+// default weights.
+func NewHealthCalculatorWithWeights(
+	graph *types.DependencyGraph,
+	cycles []*types.CircularDependencyInfo,
+	conflicts []*types.VersionConflictInfo,
+	weights *types.HealthWeights,
 ) *HealthCalculator {
 	return &HealthCalculator{
 		graph:     graph,
 		cycles:    cycles,
 		conflicts: conflicts,
+		weights:   normalizeWeights(weights),
+	}
+}
+
+// DefaultHealthWeights returns the default health factor weights.
+func DefaultHealthWeights() types.HealthWeights {
+	return types.HealthWeights{
+		Circular: WeightCircular,
+		Conflict: WeightConflict,
+		Depth:    WeightDepth,
+		Coupling: WeightCoupling,
+		Plugins:  WeightPlugins,
+	}
+}
+
+// ValidateHealthWeights returns an error if a weight is negative or not a
+// finite number.
+func ValidateHealthWeights(weights *types.HealthWeights) error {
+	if weights == nil {
+		return nil
+	}
+	for _, w := range []struct {
+		name  string
+		value float64
+	}{
+		{"circular", weights.Circular},
+		{"conflict", weights.Conflict},
+		{"depth", weights.Depth},
+		{"coupling", weights.Coupling},
+		{"plugins", weights.Plugins},
+	} {
+		if w.value < 0 || math.IsNaN(w.value) || math.IsInf(w.value, 0) {
+			return fmt.Errorf("invalid %s health weight %v: weights must be finite and not negative", w.name, w.value)
+		}
+	}
+	return nil
+}
+
+// normalizeWeights scales weights to sum to 1.0.
+func normalizeWeights(weights *types.HealthWeights) types.HealthWeights {
+	if weights == nil || ValidateHealthWeights(weights) != nil {
+		return DefaultHealthWeights()
+	}
+	sum := weights.Circular + weights.Conflict + weights.Depth + weights.Coupling
+	if sum <= 0 {
+		return DefaultHealthWeights()
+	}
+	return types.HealthWeights{
+		Circular: weights.Circular / sum,
+		Conflict: weights.Conflict / sum,
+		Depth:    weights.Depth / sum,
+		Coupling: weights.Coupling / sum,
 	}
 }
 
@@ -71,19 +137,19 @@ func (hc *HealthCalculator) Calculate() *types.HealthScoreResult {
 	couplingScore, couplingFactor := hc.calculateCouplingScore()
 
 	// Calculate weighted overall score
-	weighted := float64(circularScore)*WeightCircular +
-		float64(conflictScore)*WeightConflict +
-		float64(depthScore)*WeightDepth +
-		float64(couplingScore)*WeightCoupling
+	weighted := float64(circularScore)*hc.weights.Circular +
+		float64(conflictScore)*hc.weights.Conflict +
+		float64(depthScore)*hc.weights.Depth +
+		float64(couplingScore)*hc.weights.Coupling
 
 	overall := int(math.Round(weighted))
 	overall = boundScore(overall)
 
 	// Set weighted scores on factors
-	circularFactor.WeightedScore = int(math.Round(float64(circularScore) * WeightCircular))
-	conflictFactor.WeightedScore = int(math.Round(float64(conflictScore) * WeightConflict))
-	depthFactor.WeightedScore = int(math.Round(float64(depthScore) * WeightDepth))
-	couplingFactor.WeightedScore = int(math.Round(float64(couplingScore) * WeightCoupling))
+	circularFactor.WeightedScore = int(math.Round(float64(circularScore) * hc.weights.Circular))
+	conflictFactor.WeightedScore = int(math.Round(float64(conflictScore) * hc.weights.Conflict))
+	depthFactor.WeightedScore = int(math.Round(float64(depthScore) * hc.weights.Depth))
+	couplingFactor.WeightedScore = int(math.Round(float64(couplingScore) * hc.weights.Coupling))
 
 	return &types.HealthScoreResult{
 		Overall: overall,
@@ -111,7 +177,7 @@ func (hc *HealthCalculator) calculateCircularScore() (int, *types.HealthFactor)
 		return 100, &types.HealthFactor{
 			Name:            "Circular Dependencies",
 			Score:           100,
-			Weight:          WeightCircular,
+			Weight:          hc.weights.Circular,
 			Description:     "No circular dependencies detected",
 			Recommendations: []string{},
 		}
@@ -147,7 +213,7 @@ func (hc *HealthCalculator) calculateCircularScore() (int, *types.HealthFactor)
 	return score, &types.HealthFactor{
 		Name:            "Circular Dependencies",
 		Score:           score,
-		Weight:          WeightCircular,
+		Weight:          hc.weights.Circular,
 		Description:     fmt.Sprintf("%d cycles detected", len(hc.cycles)),
 		Recommendations: recommendations,
 	}
@@ -160,7 +226,7 @@ func (hc *HealthCalculator) calculateConflictScore() (int, *types.HealthFactor)
 		return 100, &types.HealthFactor{
 			Name:            "Version Conflicts",
 			Score:           100,
-			Weight:          WeightConflict,
+			Weight:          hc.weights.Conflict,
 			Description:     "No version conflicts detected",
 			Recommendations: []string{},
 		}
@@ -191,7 +257,7 @@ func (hc *HealthCalculator) calculateConflictScore() (int, *types.HealthFactor)
 	return score, &types.HealthFactor{
 		Name:            "Version Conflicts",
 		Score:           score,
-		Weight:          WeightConflict,
+		Weight:          hc.weights.Conflict,
 		Description:     fmt.Sprintf("%d conflicts detected", len(hc.conflicts)),
 		Recommendations: recommendations,
 	}
@@ -206,7 +272,7 @@ func (hc *HealthCalculator) calculateDepthScore() (int, *types.HealthFactor) {
 		return 100, &types.HealthFactor{
 			Name:            "Dependency Depth",
 			Score:           100,
-			Weight:          WeightDepth,
+			Weight:          hc.weights.Depth,
 			Description:     "No packages to analyze",
 			Recommendations: []string{},
 		}
@@ -228,7 +294,7 @@ func (hc *HealthCalculator) calculateDepthScore() (int, *types.HealthFactor) {
 	return score, &types.HealthFactor{
 		Name:            "Dependency Depth",
 		Score:           score,
-		Weight:          WeightDepth,
+		Weight:          hc.weights.Depth,
 		Description:     fmt.Sprintf("Max depth: %d, Avg depth: %.1f", maxDepth, avgDepth),
 		Recommendations: recommendations,
 	}
@@ -316,7 +382,7 @@ func (hc *HealthCalculator) calculateCouplingScore() (int, *types.HealthFactor)
 		return 100, &types.HealthFactor{
 			Name:            "Package Coupling",
 			Score:           100,
-			Weight:          WeightCoupling,
+			Weight:          hc.weights.Coupling,
 			Description:     "No packages to analyze",
 			Recommendations: []string{},
 		}
@@ -334,7 +400,7 @@ func (hc *HealthCalculator) calculateCouplingScore() (int, *types.HealthFactor)
 	return score, &types.HealthFactor{
 		Name:            "Package Coupling",
 		Score:           score,
-		Weight:          WeightCoupling,
+		Weight:          hc.weights.Coupling,
 		Description:     fmt.Sprintf("Avg instability: %.2f", metrics.AverageInstability),
 		Recommendations: recommendations,
 	}
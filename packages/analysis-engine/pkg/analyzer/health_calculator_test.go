@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"math"
 	"testing"
 
 	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
@@ -506,3 +507,61 @@ func TestWeightsSumToOne(t *testing.T) {
 			sum, WeightCircular, WeightConflict, WeightDepth, WeightCoupling)
 	}
 }
+
+// TestHealthCalculator_CustomWeights verifies weights are normalized and
+// applied to the overall score and factors.
+func TestHealthCalculator_CustomWeights(t *testing.T) {
+	graph := createHealthTestGraph(map[string][]string{
+		"@mono/a": {"@mono/b"},
+		"@mono/b": {"@mono/a"},
+	})
+	cycles := []*types.CircularDependencyInfo{
+		{Cycle: []string{"@mono/a", "@mono/b", "@mono/a"}, Type: types.CircularTypeDirect, Depth: 2},
+	}
+
+	// Only circular dependencies count; weights need not sum to 1.0
+	weights := &types.HealthWeights{Circular: 2}
+	result := NewHealthCalculatorWithWeights(graph, cycles, nil, weights).Calculate()
+
+	if result.Overall != result.Breakdown.CircularScore {
+		t.Errorf("Overall = %d, want circular score %d", result.Overall, result.Breakdown.CircularScore)
+	}
+	for _, f := range result.Factors {
+		want := 0.0
+		if f.Name == "Circular Dependencies" {
+			want = 1.0
+		}
+		if f.Weight != want {
+			t.Errorf("%s weight = %.2f, want %.2f", f.Name, f.Weight, want)
+		}
+	}
+
+	// Zero weights fall back to the defaults
+	defaults := NewHealthCalculator(graph, cycles, nil).Calculate()
+	zero := NewHealthCalculatorWithWeights(graph, cycles, nil, &types.HealthWeights{}).Calculate()
+	if zero.Overall != defaults.Overall {
+		t.Errorf("Overall with zero weights = %d, want default %d", zero.Overall, defaults.Overall)
+	}
+
+	// Negative weights are never used, even when the sum is positive
+	negative := NewHealthCalculatorWithWeights(graph, cycles, nil, &types.HealthWeights{Circular: 2, Depth: -1}).Calculate()
+	if negative.Overall != defaults.Overall {
+		t.Errorf("Overall with a negative weight = %d, want default %d", negative.Overall, defaults.Overall)
+	}
+}
+
+// TestValidateHealthWeights verifies negative and non-finite weights are rejected.
+func TestValidateHealthWeights(t *testing.T) {
+	valid := []*types.HealthWeights{nil, {}, {Circular: 2, Conflict: 0.5}}
+	for _, w := range valid {
+		if err := ValidateHealthWeights(w); err != nil {
+			t.Errorf("ValidateHealthWeights(%+v) error = %v", w, err)
+		}
+	}
+	invalid := []*types.HealthWeights{{Depth: -0.1}, {Coupling: math.NaN()}, {Circular: math.Inf(1)}, {Plugins: -1}}
+	for _, w := range invalid {
+		if err := ValidateHealthWeights(w); err == nil {
+			t.Errorf("ValidateHealthWeights(%+v) should fail", w)
+		}
+	}
+}
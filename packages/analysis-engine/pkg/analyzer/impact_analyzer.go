@@ -75,6 +75,15 @@ func (ia *ImpactAnalyzer) Analyze(cycle *types.CircularDependencyInfo) *types.Im
 	}
 }
 
+// Dependents returns every package that transitively depends on one of the
+// given packages, nearest first. The given packages themselves are not included.
+func (ia *ImpactAnalyzer) Dependents(packages []string) []types.IndirectDependent {
+	if ia.reverseDeps == nil {
+		ia.buildReverseDependencies()
+	}
+	return ia.findIndirectDependents(packages)
+}
+
 // buildReverseDependencies creates a reverse lookup map.
 // For each edge (A → B), A depends on B, so B's "dependents" include A.
 func (ia *ImpactAnalyzer) buildReverseDependencies() {
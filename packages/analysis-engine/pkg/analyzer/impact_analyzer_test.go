@@ -251,6 +251,40 @@ func TestFindIndirectDependentsNoDependents(t *testing.T) {
 	}
 }
 
+func TestDependents(t *testing.T) {
+	// A -> B -> C, D -> C; changing C affects B and D directly and A via B
+	graph := createImpactTestGraph(
+		[]string{"@mono/a", "@mono/b", "@mono/c", "@mono/d", "@mono/e"},
+		[][2]string{
+			{"@mono/a", "@mono/b"},
+			{"@mono/b", "@mono/c"},
+			{"@mono/d", "@mono/c"},
+		},
+	)
+
+	// Works without a workspace and without a prior Analyze call
+	analyzer := NewImpactAnalyzer(graph, nil)
+	dependents := analyzer.Dependents([]string{"@mono/c"})
+
+	distances := map[string]int{}
+	for _, dep := range dependents {
+		distances[dep.PackageName] = dep.Distance
+	}
+	want := map[string]int{"@mono/b": 1, "@mono/d": 1, "@mono/a": 2}
+	if len(distances) != len(want) {
+		t.Fatalf("Dependents() = %v, want %v", distances, want)
+	}
+	for pkg, distance := range want {
+		if distances[pkg] != distance {
+			t.Errorf("Dependents()[%s] distance = %d, want %d", pkg, distances[pkg], distance)
+		}
+	}
+
+	if got := analyzer.Dependents([]string{"@mono/e"}); len(got) != 0 {
+		t.Errorf("Dependents(@mono/e) = %v, want empty", got)
+	}
+}
+
 // ========================================
 // Tests for calculateRiskLevel
 // ========================================
@@ -4,6 +4,7 @@ package analyzer
 
 import (
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/parser"
@@ -19,6 +20,11 @@ type ImportTracer struct {
 	workspace *types.WorkspaceData
 	files     map[string][]byte // Source files (*.ts, *.js, *.tsx, *.jsx)
 	parser    *parser.ImportParser
+
+	// imports holds the parsed workspace package imports by file path, when
+	// created by NewImportTracerWithImports
+	imports  map[string][]types.ImportTrace
+	packages map[string]bool // Workspace package names, the targets of imports
 }
 
 // NewImportTracer creates a new tracer for the given workspace and files.
@@ -30,6 +36,35 @@ func NewImportTracer(workspace *types.WorkspaceData, files map[string][]byte) *I
 	}
 }
 
+// NewImportTracerWithImports creates a tracer that reuses the parsed imports
+// of source files, keyed by file path, and records the imports of every file
+// it parses. Parsed imports cover all workspace packages, so they can be
+// reused while the content of a file and the set of workspace package names
+// are unchanged. imports may be nil.
+func NewImportTracerWithImports(
+	workspace *types.WorkspaceData,
+	files map[string][]byte,
+	imports map[string][]types.ImportTrace,
+) *ImportTracer {
+	it := NewImportTracer(workspace, files)
+	it.imports = make(map[string][]types.ImportTrace, len(imports))
+	for path, traces := range imports {
+		it.imports[path] = traces
+	}
+	it.packages = make(map[string]bool, len(workspace.Packages))
+	for name := range workspace.Packages {
+		it.packages[name] = true
+	}
+	return it
+}
+
+// Imports returns the parsed imports by file path for tracers created by
+// NewImportTracerWithImports, including the reused ones. Returns nil for
+// other tracers.
+func (it *ImportTracer) Imports() map[string][]types.ImportTrace {
+	return it.imports
+}
+
 // Trace finds import statements that form the circular dependency.
 // Returns empty slice (not nil) if no traces found or files are empty.
 func (it *ImportTracer) Trace(cycle *types.CircularDependencyInfo) []types.ImportTrace {
@@ -59,6 +94,22 @@ func (it *ImportTracer) Trace(cycle *types.CircularDependencyInfo) []types.Impor
 	return traces
 }
 
+// TraceEdge finds the import statements by which fromPkg uses toPkg, sorted
+// by file and line. Returns empty slice (not nil) if none are found.
+func (it *ImportTracer) TraceEdge(fromPkg, toPkg string) []types.ImportTrace {
+	traces := it.traceEdge(fromPkg, toPkg)
+	if traces == nil {
+		return []types.ImportTrace{}
+	}
+	sort.Slice(traces, func(i, j int) bool {
+		if traces[i].FilePath != traces[j].FilePath {
+			return traces[i].FilePath < traces[j].FilePath
+		}
+		return traces[i].LineNumber < traces[j].LineNumber
+	})
+	return traces
+}
+
 // traceEdge finds imports from one package to another.
 func (it *ImportTracer) traceEdge(fromPkg, toPkg string) []types.ImportTrace {
 	var traces []types.ImportTrace
@@ -74,7 +125,12 @@ func (it *ImportTracer) traceEdge(fromPkg, toPkg string) []types.ImportTrace {
 
 	// Parse each source file
 	for filePath, content := range sourceFiles {
-		fileTraces := it.parser.ParseFile(content, filePath, targets)
+		var fileTraces []types.ImportTrace
+		if it.imports != nil {
+			fileTraces = it.fileImports(filePath, content, toPkg)
+		} else {
+			fileTraces = it.parser.ParseFile(content, filePath, targets)
+		}
 
 		// Set the FromPackage for each trace
 		for i := range fileTraces {
@@ -87,6 +143,24 @@ func (it *ImportTracer) traceEdge(fromPkg, toPkg string) []types.ImportTrace {
 	return traces
 }
 
+// fileImports returns the imports of toPkg in a source file, parsing the
+// imports of all workspace packages unless they were parsed before.
+func (it *ImportTracer) fileImports(filePath string, content []byte, toPkg string) []types.ImportTrace {
+	imports, ok := it.imports[filePath]
+	if !ok {
+		imports = it.parser.ParseFile(content, filePath, it.packages)
+		it.imports[filePath] = imports
+	}
+
+	var traces []types.ImportTrace
+	for _, trace := range imports {
+		if trace.ToPackage == toPkg {
+			traces = append(traces, trace)
+		}
+	}
+	return traces
+}
+
 // getSourceFilesForPackage returns source files belonging to a package.
 func (it *ImportTracer) getSourceFilesForPackage(pkgName string) map[string][]byte {
 	sourceFiles := make(map[string][]byte)
@@ -288,6 +288,38 @@ export const Component = () => {};
 	}
 }
 
+func TestImportTracer_TraceEdge(t *testing.T) {
+	workspace := &types.WorkspaceData{
+		Packages: map[string]*types.PackageInfo{
+			"@mono/ui":   {Name: "@mono/ui", Path: "packages/ui"},
+			"@mono/core": {Name: "@mono/core", Path: "packages/core"},
+		},
+	}
+	files := map[string][]byte{
+		"packages/ui/src/form.ts":   []byte("import { z } from 'zod';\nimport { validate } from '@mono/core';"),
+		"packages/ui/src/button.ts": []byte(`import { theme } from '@mono/core';`),
+	}
+
+	tracer := NewImportTracer(workspace, files)
+	traces := tracer.TraceEdge("@mono/ui", "@mono/core")
+
+	if len(traces) != 2 {
+		t.Fatalf("TraceEdge() returned %d traces, want 2", len(traces))
+	}
+	// Sorted by file path
+	if traces[0].FilePath != "packages/ui/src/button.ts" || traces[1].FilePath != "packages/ui/src/form.ts" {
+		t.Errorf("TraceEdge() files = %s, %s", traces[0].FilePath, traces[1].FilePath)
+	}
+	if traces[1].LineNumber != 2 || traces[1].FromPackage != "@mono/ui" {
+		t.Errorf("traces[1] = %+v, want line 2 from @mono/ui", traces[1])
+	}
+
+	// No imports in the other direction
+	if got := tracer.TraceEdge("@mono/core", "@mono/ui"); got == nil || len(got) != 0 {
+		t.Errorf("TraceEdge() = %v, want empty slice", got)
+	}
+}
+
 func TestIsSourceFile(t *testing.T) {
 	tests := []struct {
 		path string
@@ -317,3 +349,56 @@ func TestIsSourceFile(t *testing.T) {
 		})
 	}
 }
+
+func TestImportTracer_WithImports(t *testing.T) {
+	workspace := &types.WorkspaceData{
+		Packages: map[string]*types.PackageInfo{
+			"@mono/ui":   {Name: "@mono/ui", Path: "packages/ui"},
+			"@mono/api":  {Name: "@mono/api", Path: "packages/api"},
+			"@mono/core": {Name: "@mono/core", Path: "packages/core"},
+		},
+	}
+	files := map[string][]byte{
+		"packages/ui/src/index.ts":  []byte("import { api } from '@mono/api';\nimport { core } from '@mono/core';"),
+		"packages/api/src/index.ts": []byte(`import { ui } from '@mono/ui';`),
+	}
+	// Reused imports win over the file content
+	reused := map[string][]types.ImportTrace{
+		"packages/api/src/index.ts": {{ToPackage: "@mono/ui", FilePath: "packages/api/src/index.ts", LineNumber: 7, Statement: "cached"}},
+	}
+
+	tracer := NewImportTracerWithImports(workspace, files, reused)
+	traces := tracer.Trace(&types.CircularDependencyInfo{Cycle: []string{"@mono/ui", "@mono/api", "@mono/ui"}})
+
+	if len(traces) != 2 {
+		t.Fatalf("Trace() returned %d traces, want 2: %+v", len(traces), traces)
+	}
+	if traces[0].FromPackage != "@mono/ui" || traces[0].ToPackage != "@mono/api" {
+		t.Errorf("traces[0] = %+v, want @mono/ui -> @mono/api", traces[0])
+	}
+	if traces[1].FromPackage != "@mono/api" || traces[1].Statement != "cached" || traces[1].LineNumber != 7 {
+		t.Errorf("traces[1] = %+v, want reused import", traces[1])
+	}
+
+	imports := tracer.Imports()
+	if len(imports) != 2 {
+		t.Fatalf("Imports() has %d files, want 2", len(imports))
+	}
+	// Parsed files record the imports of every workspace package
+	ui := imports["packages/ui/src/index.ts"]
+	if len(ui) != 2 {
+		t.Fatalf("Imports()[ui] = %+v, want @mono/api and @mono/core", ui)
+	}
+	for _, trace := range ui {
+		if trace.FromPackage != "" {
+			t.Errorf("recorded import FromPackage = %q, want empty", trace.FromPackage)
+		}
+	}
+	if len(reused) != 1 {
+		t.Error("NewImportTracerWithImports() modified the reused imports")
+	}
+
+	if NewImportTracer(workspace, files).Imports() != nil {
+		t.Error("Imports() should be nil for NewImportTracer")
+	}
+}
@@ -0,0 +1,259 @@
+// Package analyzer provides dependency graph analysis for monorepo workspaces.
+// This file implements package ownership from CODEOWNERS and owner rules.
+package analyzer
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// ========================================
+// Owner Resolver
+// ========================================
+
+// OwnerResolver maps package directories to owners using CODEOWNERS rules.
+type OwnerResolver struct {
+	rules    []types.OwnerRule
+	patterns []*regexp.Regexp
+}
+
+// NewOwnerResolver creates a resolver for rules in CODEOWNERS order, where
+// the last matching rule wins.
+func NewOwnerResolver(rules []types.OwnerRule) *OwnerResolver {
+	r := &OwnerResolver{rules: rules}
+	for _, rule := range rules {
+		r.patterns = append(r.patterns, compileOwnerPattern(rule.Pattern))
+	}
+	return r
+}
+
+// OwnersOf returns the owners of the last rule matching the directory or one
+// of its parents, or nil if no rule matches. A rule for a directory covers
+// everything below it, so it covers the packages inside it. The directory is
+// relative to the workspace root and is moved below the root of each rule.
+func (r *OwnerResolver) OwnersOf(dir string) []string {
+	dir = strings.Trim(dir, "/")
+	for i := len(r.rules) - 1; i >= 0; i-- {
+		for d := path.Join(r.rules[i].Root, dir); d != "." && d != ""; d = path.Dir(d) {
+			if r.patterns[i].MatchString(d) {
+				if len(r.rules[i].Owners) == 0 {
+					return nil
+				}
+				return r.rules[i].Owners
+			}
+		}
+	}
+	return nil
+}
+
+// compileOwnerPattern converts a CODEOWNERS pattern into a regular expression
+// matching directory paths. Patterns with a leading or inner slash are
+// relative to the root; others match at any depth. A trailing "/" or "/**"
+// matches the directory itself, which covers its contents.
+func compileOwnerPattern(pattern string) *regexp.Regexp {
+	pattern = strings.TrimSuffix(pattern, "/**")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+		case pattern[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// ========================================
+// Ownership Reporter
+// ========================================
+
+// OwnershipReporter tags a graph and its issues with package owners and
+// groups the issues by owner.
+type OwnershipReporter struct {
+	graph    *types.DependencyGraph
+	resolver *OwnerResolver
+}
+
+// NewOwnershipReporter creates a reporter for the graph and owner rules.
+func NewOwnershipReporter(graph *types.DependencyGraph, rules []types.OwnerRule) *OwnershipReporter {
+	return &OwnershipReporter{
+		graph:    graph,
+		resolver: NewOwnerResolver(rules),
+	}
+}
+
+// Report sets the owners of the graph nodes and edges, cycles and version
+// conflicts, and returns the issues grouped by owner. Excluded packages are
+// left out of the report.
+func (r *OwnershipReporter) Report(
+	cycles []*types.CircularDependencyInfo,
+	conflicts []*types.VersionConflictInfo,
+	violations []*types.BoundaryViolation,
+) *types.Ownership {
+	ownership := &types.Ownership{
+		CrossTeamEdges: []*types.CrossTeamEdge{},
+		Teams:          []*types.TeamReport{},
+	}
+	teams := map[string]*types.TeamReport{}
+	team := func(owner string) *types.TeamReport {
+		if teams[owner] == nil {
+			teams[owner] = &types.TeamReport{Owner: owner, Packages: []string{}}
+		}
+		return teams[owner]
+	}
+
+	owners := map[string][]string{}
+	for name, node := range r.graph.Nodes {
+		node.Owners = r.resolver.OwnersOf(node.Path)
+		if node.Excluded {
+			continue
+		}
+		owners[name] = node.Owners
+		if len(node.Owners) == 0 {
+			ownership.Unowned = append(ownership.Unowned, name)
+		}
+		for _, owner := range node.Owners {
+			t := team(owner)
+			t.Packages = append(t.Packages, name)
+		}
+	}
+
+	for _, edge := range r.graph.Edges {
+		fromOwners, fromOK := owners[edge.From]
+		toOwners, toOK := owners[edge.To]
+		edge.FromOwners, edge.ToOwners = fromOwners, toOwners
+		if !fromOK || !toOK || sameOwners(fromOwners, toOwners) {
+			continue
+		}
+		ownership.CrossTeamEdges = append(ownership.CrossTeamEdges, &types.CrossTeamEdge{
+			From:           edge.From,
+			To:             edge.To,
+			FromOwners:     fromOwners,
+			ToOwners:       toOwners,
+			DependencyType: edge.Type,
+		})
+		for _, owner := range fromOwners {
+			if !contains(toOwners, owner) {
+				team(owner).OutgoingEdges++
+			}
+		}
+		for _, owner := range toOwners {
+			if !contains(fromOwners, owner) {
+				team(owner).IncomingEdges++
+			}
+		}
+	}
+
+	for _, cycle := range cycles {
+		cycle.Owners = ownersOf(owners, getUniquePackages(cycle.Cycle))
+		for _, owner := range cycle.Owners {
+			t := team(owner)
+			t.Cycles = appendUnique(t.Cycles, strings.Join(cycle.Cycle, " → "))
+		}
+	}
+
+	for _, conflict := range conflicts {
+		var packages []string
+		for _, version := range conflict.ConflictingVersions {
+			packages = append(packages, version.Packages...)
+		}
+		conflict.Owners = ownersOf(owners, packages)
+		for _, owner := range conflict.Owners {
+			t := team(owner)
+			t.VersionConflicts = appendUnique(t.VersionConflicts, conflict.PackageName)
+		}
+	}
+
+	// The dependent package has to change to fix a violation
+	for _, violation := range violations {
+		for _, owner := range owners[violation.From] {
+			t := team(owner)
+			t.BoundaryViolations = append(t.BoundaryViolations, violation.From+" → "+violation.To)
+		}
+	}
+
+	for _, t := range teams {
+		sort.Strings(t.Packages)
+		sort.Strings(t.Cycles)
+		sort.Strings(t.VersionConflicts)
+		sort.Strings(t.BoundaryViolations)
+		ownership.Teams = append(ownership.Teams, t)
+	}
+	sort.Slice(ownership.Teams, func(i, j int) bool {
+		return ownership.Teams[i].Owner < ownership.Teams[j].Owner
+	})
+	sort.Slice(ownership.CrossTeamEdges, func(i, j int) bool {
+		a, b := ownership.CrossTeamEdges[i], ownership.CrossTeamEdges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+	sort.Strings(ownership.Unowned)
+	return ownership
+}
+
+// ownersOf returns the sorted union of the owners of packages
+func ownersOf(owners map[string][]string, packages []string) []string {
+	var union []string
+	for _, pkg := range packages {
+		for _, owner := range owners[pkg] {
+			union = appendUnique(union, owner)
+		}
+	}
+	sort.Strings(union)
+	return union
+}
+
+// sameOwners reports whether two owner lists contain the same owners
+func sameOwners(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, owner := range a {
+		if !contains(b, owner) {
+			return false
+		}
+	}
+	return true
+}
+
+// contains reports whether list contains s
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// appendUnique appends s to list unless it is already present
+func appendUnique(list []string, s string) []string {
+	if contains(list, s) {
+		return list
+	}
+	return append(list, s)
+}
@@ -0,0 +1,227 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+var ownershipTestRules = []types.OwnerRule{
+	{Pattern: "*", Owners: []string{"@org/platform"}},
+	{Pattern: "/apps/", Owners: []string{"@org/web"}},
+	{Pattern: "libs/ui/**", Owners: []string{"@org/design", "@org/web"}},
+	{Pattern: "libs/legacy", Owners: nil},
+}
+
+func TestOwnerResolver_OwnersOf(t *testing.T) {
+	resolver := NewOwnerResolver(ownershipTestRules)
+
+	tests := []struct {
+		dir  string
+		want []string
+	}{
+		{"apps/web", []string{"@org/web"}},
+		{"apps/nested/admin", []string{"@org/web"}},
+		{"libs/ui", []string{"@org/design", "@org/web"}},
+		{"libs/utils", []string{"@org/platform"}},
+		{"libs/legacy/old", nil},
+		{"tools/apps", []string{"@org/platform"}}, // "/apps/" is anchored to the root
+	}
+	for _, tt := range tests {
+		if got := resolver.OwnersOf(tt.dir); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("OwnersOf(%q) = %v, want %v", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestOwnerResolver_Patterns(t *testing.T) {
+	tests := []struct {
+		pattern string
+		dir     string
+		want    bool
+	}{
+		{"ui", "libs/ui", true},
+		{"ui/", "libs/ui", true},
+		{"/ui", "libs/ui", false},
+		{"libs/*", "libs/ui", true},
+		{"libs/*/", "libs/ui/nested", true},
+		{"**/ui", "a/b/ui", true},
+		{"libs/**/core", "libs/a/b/core", true},
+		{"libs/**/core", "libs/core", true},
+		{"*.ts", "libs/ui", false},
+		{"lib?", "libs", true},
+		{"libs.old", "libsXold", false},
+	}
+	for _, tt := range tests {
+		resolver := NewOwnerResolver([]types.OwnerRule{{Pattern: tt.pattern, Owners: []string{"@team"}}})
+		if got := resolver.OwnersOf(tt.dir) != nil; got != tt.want {
+			t.Errorf("pattern %q matches %q = %v, want %v", tt.pattern, tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestOwnerResolver_Root(t *testing.T) {
+	// CODEOWNERS of a repository whose workspace is in frontend/
+	resolver := NewOwnerResolver([]types.OwnerRule{
+		{Pattern: "/frontend/", Owners: []string{"@org/frontend"}, Root: "frontend"},
+		{Pattern: "/frontend/libs/ui/", Owners: []string{"@org/design"}, Root: "frontend"},
+		{Pattern: "/libs/", Owners: []string{"@org/backend"}, Root: "frontend"},
+		{Pattern: "/apps/", Owners: []string{"@org/web"}}, // Workspace-relative owner rule
+	})
+
+	tests := []struct {
+		dir  string
+		want []string
+	}{
+		{"libs/ui", []string{"@org/design"}},
+		{"libs/utils", []string{"@org/frontend"}}, // "/libs/" is the repository's libs/
+		{"apps/web", []string{"@org/web"}},
+	}
+	for _, tt := range tests {
+		if got := resolver.OwnersOf(tt.dir); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("OwnersOf(%q) = %v, want %v", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestOwnershipReporter_Report(t *testing.T) {
+	graph := createBoundaryTestGraph(
+		map[string]string{"web": "apps/web", "ui": "libs/ui", "utils": "libs/utils", "old": "libs/legacy"},
+		[][]string{{"web", "ui"}, {"ui", "utils"}, {"utils", "ui"}, {"web", "old"}},
+	)
+	// Both cycles start with ui → utils and so share a cycle ID
+	cycles := []*types.CircularDependencyInfo{
+		{Cycle: []string{"ui", "utils", "ui"}},
+		{Cycle: []string{"ui", "utils", "old", "ui"}},
+	}
+	conflicts := []*types.VersionConflictInfo{{
+		PackageName: "react",
+		ConflictingVersions: []*types.ConflictingVersion{
+			{Version: "^17.0.0", Packages: []string{"web"}},
+			{Version: "^18.0.0", Packages: []string{"old"}},
+		},
+	}}
+	violations := []*types.BoundaryViolation{{From: "utils", To: "ui"}}
+
+	ownership := NewOwnershipReporter(graph, ownershipTestRules).Report(cycles, conflicts, violations)
+
+	if got := graph.Nodes["ui"].Owners; !reflect.DeepEqual(got, []string{"@org/design", "@org/web"}) {
+		t.Errorf("ui owners = %v", got)
+	}
+	if got := cycles[0].Owners; !reflect.DeepEqual(got, []string{"@org/design", "@org/platform", "@org/web"}) {
+		t.Errorf("cycle owners = %v", got)
+	}
+	if got := conflicts[0].Owners; !reflect.DeepEqual(got, []string{"@org/web"}) {
+		t.Errorf("conflict owners = %v", got)
+	}
+	if got := graph.Edges[0].ToOwners; !reflect.DeepEqual(got, []string{"@org/design", "@org/web"}) {
+		t.Errorf("edge web → ui ToOwners = %v", got)
+	}
+	if !reflect.DeepEqual(ownership.Unowned, []string{"old"}) {
+		t.Errorf("Unowned = %v, want [old]", ownership.Unowned)
+	}
+
+	// web → ui is shared by @org/web, so it is still a cross-team edge for @org/design
+	var edges []string
+	for _, e := range ownership.CrossTeamEdges {
+		edges = append(edges, e.From+"→"+e.To)
+	}
+	if want := []string{"ui→utils", "utils→ui", "web→old", "web→ui"}; !reflect.DeepEqual(edges, want) {
+		t.Errorf("CrossTeamEdges = %v, want %v", edges, want)
+	}
+
+	teams := map[string]*types.TeamReport{}
+	var names []string
+	for _, team := range ownership.Teams {
+		teams[team.Owner] = team
+		names = append(names, team.Owner)
+	}
+	if want := []string{"@org/design", "@org/platform", "@org/web"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("Teams = %v, want %v", names, want)
+	}
+
+	design := teams["@org/design"]
+	if !reflect.DeepEqual(design.Packages, []string{"ui"}) || design.IncomingEdges != 2 || design.OutgoingEdges != 1 {
+		t.Errorf("@org/design = %+v", design)
+	}
+	if want := []string{"ui → utils → old → ui", "ui → utils → ui"}; !reflect.DeepEqual(design.Cycles, want) {
+		t.Errorf("@org/design cycles = %v", design.Cycles)
+	}
+
+	platform := teams["@org/platform"]
+	if !reflect.DeepEqual(platform.BoundaryViolations, []string{"utils → ui"}) {
+		t.Errorf("@org/platform violations = %v", platform.BoundaryViolations)
+	}
+
+	web := teams["@org/web"]
+	if !reflect.DeepEqual(web.Packages, []string{"ui", "web"}) || !reflect.DeepEqual(web.VersionConflicts, []string{"react"}) {
+		t.Errorf("@org/web = %+v", web)
+	}
+	if web.OutgoingEdges != 2 || web.IncomingEdges != 1 {
+		t.Errorf("@org/web edges = %d out, %d in; want 2 out, 1 in", web.OutgoingEdges, web.IncomingEdges)
+	}
+}
+
+func TestAnalyzer_OwnershipInResult(t *testing.T) {
+	workspace := &types.WorkspaceData{
+		RootPath:      "/test",
+		WorkspaceType: types.WorkspaceTypePnpm,
+		Packages: map[string]*types.PackageInfo{
+			"web": {Name: "web", Path: "apps/web", Dependencies: map[string]string{"ui": "workspace:*"}},
+			"ui":  {Name: "ui", Path: "libs/ui", Dependencies: map[string]string{}},
+		},
+		CodeOwners: []types.OwnerRule{{Pattern: "/apps/", Owners: []string{"@org/web"}}},
+	}
+
+	result, err := NewAnalyzer().Analyze(workspace)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if result.Ownership == nil || len(result.Ownership.CrossTeamEdges) != 1 {
+		t.Fatalf("Ownership = %+v, want one cross-team edge", result.Ownership)
+	}
+
+	// Configured rules come after CODEOWNERS and win
+	a, err := NewAnalyzerWithConfig(&types.AnalysisConfig{
+		Owners: []types.OwnerRule{{Pattern: "*", Owners: []string{"@org/all"}}},
+	})
+	if err != nil {
+		t.Fatalf("NewAnalyzerWithConfig() error = %v", err)
+	}
+	result, err = a.Analyze(workspace)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if len(result.Ownership.CrossTeamEdges) != 0 || len(result.Ownership.Teams) != 1 {
+		t.Errorf("Ownership = %+v, want everything owned by @org/all", result.Ownership)
+	}
+
+	workspace.CodeOwners = nil
+	result, err = NewAnalyzer().Analyze(workspace)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if result.Ownership != nil {
+		t.Errorf("Ownership = %+v, want nil without rules", result.Ownership)
+	}
+}
+
+func TestAnalyzer_OwnershipWithSources(t *testing.T) {
+	workspace := &types.WorkspaceData{
+		RootPath: "/test",
+		Packages: map[string]*types.PackageInfo{
+			"web": {Name: "web", Path: "apps/web", Dependencies: map[string]string{"ui": "workspace:*"}},
+			"ui":  {Name: "ui", Path: "libs/ui", Dependencies: map[string]string{}},
+		},
+		CodeOwners: []types.OwnerRule{{Pattern: "/apps/", Owners: []string{"@org/web"}}},
+	}
+
+	result, err := NewAnalyzer().AnalyzeWithSources(workspace, nil)
+	if err != nil {
+		t.Fatalf("AnalyzeWithSources() error = %v", err)
+	}
+	if result.Ownership == nil || !reflect.DeepEqual(result.Ownership.Unowned, []string{"ui"}) {
+		t.Errorf("Ownership = %+v, want ui unowned", result.Ownership)
+	}
+}
@@ -0,0 +1,118 @@
+// Package analyzer provides dependency graph analysis for monorepo workspaces.
+// This file merges the findings of external rule plugins into a result.
+package analyzer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// WeightPlugins is the default weight of plugin findings. Next to the other
+// default weights, which add up to 1.0, it gives plugin findings a fifth of
+// the health score when plugins ran.
+const WeightPlugins = 0.25
+
+// Deduction constants for plugin findings.
+const (
+	DeductionPluginError   = 10
+	DeductionPluginWarning = 5
+	DeductionPluginInfo    = 2
+)
+
+// pluginFactorName is the name of the plugin health factor
+const pluginFactorName = "Plugin Findings"
+
+// ApplyPluginResults adds the findings and failures of plugin runs to
+// result. When at least one plugin ran successfully its findings become a
+// health score factor, weighted by the plugin weight relative to the sum of
+// weights (nil uses the defaults), and the overall score and rating are
+// recalculated. Failed plugins do not affect the score.
+func ApplyPluginResults(result *types.AnalysisResult, runs []*types.PluginResult, weights *types.HealthWeights) {
+	if result == nil {
+		return
+	}
+	succeeded := 0
+	for _, run := range runs {
+		if run.Error != "" {
+			result.PluginErrors = append(result.PluginErrors, &types.PluginError{
+				Plugin:  run.Plugin,
+				Message: run.Error,
+			})
+			continue
+		}
+		succeeded++
+		result.PluginFindings = append(result.PluginFindings, run.Findings...)
+	}
+	if succeeded == 0 || result.HealthScoreDetails == nil {
+		return
+	}
+
+	details := result.HealthScoreDetails
+	share := pluginShare(weights)
+	weighted := 0.0
+	for _, f := range details.Factors {
+		if f.Name == pluginFactorName {
+			continue
+		}
+		f.Weight *= 1 - share
+		f.WeightedScore = int(math.Round(float64(f.Score) * f.Weight))
+		weighted += float64(f.Score) * f.Weight
+	}
+
+	factor := pluginFactor(result.PluginFindings, succeeded)
+	factor.Weight = share
+	factor.WeightedScore = int(math.Round(float64(factor.Score) * factor.Weight))
+	details.Factors = append(details.Factors, factor)
+	weighted += float64(factor.Score) * factor.Weight
+
+	details.Overall = boundScore(int(math.Round(weighted)))
+	details.Rating = types.GetHealthRating(details.Overall)
+	result.HealthScore = details.Overall
+}
+
+// pluginShare returns the share of the health score given to plugin
+// findings. Weights that the health calculator replaces by the defaults are
+// replaced here too.
+func pluginShare(weights *types.HealthWeights) float64 {
+	w := DefaultHealthWeights()
+	if weights != nil && ValidateHealthWeights(weights) == nil &&
+		weights.Circular+weights.Conflict+weights.Depth+weights.Coupling > 0 {
+		w = *weights
+	}
+	return w.Plugins / (w.Circular + w.Conflict + w.Depth + w.Coupling + w.Plugins)
+}
+
+// pluginFactor computes the health factor of plugin findings.
+// Formula: 100 - (errors * 10 + warnings * 5 + info * 2)
+func pluginFactor(findings []*types.PluginFinding, plugins int) *types.HealthFactor {
+	deductions := 0
+	counts := map[types.PluginSeverity]int{}
+	for _, f := range findings {
+		counts[f.Severity]++
+		switch f.Severity {
+		case types.PluginSeverityError:
+			deductions += DeductionPluginError
+		case types.PluginSeverityWarning:
+			deductions += DeductionPluginWarning
+		default:
+			deductions += DeductionPluginInfo
+		}
+	}
+
+	recommendations := []string{}
+	if n := counts[types.PluginSeverityError]; n > 0 {
+		recommendations = append(recommendations, fmt.Sprintf("Fix %d plugin error(s)", n))
+	}
+	if n := counts[types.PluginSeverityWarning]; n > 0 {
+		recommendations = append(recommendations, fmt.Sprintf("Review %d plugin warning(s)", n))
+	}
+
+	return &types.HealthFactor{
+		Name:            pluginFactorName,
+		Score:           boundScore(100 - deductions),
+		Description:     fmt.Sprintf("%d findings from %d plugins", len(findings), plugins),
+		Recommendations: recommendations,
+	}
+}
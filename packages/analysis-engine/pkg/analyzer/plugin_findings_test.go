@@ -0,0 +1,113 @@
+package analyzer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// createPluginTestResult creates a result whose health score is 80, made of
+// two equally weighted factors
+func createPluginTestResult() *types.AnalysisResult {
+	return &types.AnalysisResult{
+		HealthScore: 80,
+		HealthScoreDetails: &types.HealthScoreResult{
+			Overall: 80,
+			Rating:  types.HealthRatingGood,
+			Factors: []*types.HealthFactor{
+				{Name: "Circular Dependencies", Score: 100, Weight: 0.5, WeightedScore: 50},
+				{Name: "Version Conflicts", Score: 60, Weight: 0.5, WeightedScore: 30},
+			},
+		},
+	}
+}
+
+func TestApplyPluginResults(t *testing.T) {
+	result := createPluginTestResult()
+	ApplyPluginResults(result, []*types.PluginResult{
+		{Plugin: "lint", Findings: []*types.PluginFinding{
+			{Plugin: "lint", Rule: "a", Severity: types.PluginSeverityError, Message: "a"},
+			{Plugin: "lint", Rule: "b", Severity: types.PluginSeverityWarning, Message: "b"},
+			{Plugin: "lint", Rule: "c", Severity: types.PluginSeverityInfo, Message: "c"},
+		}},
+		{Plugin: "broken", Error: "exit status 2"},
+	}, nil)
+
+	if len(result.PluginFindings) != 3 {
+		t.Errorf("PluginFindings = %d, want 3", len(result.PluginFindings))
+	}
+	if len(result.PluginErrors) != 1 || result.PluginErrors[0].Plugin != "broken" {
+		t.Errorf("PluginErrors = %+v, want broken", result.PluginErrors)
+	}
+
+	factors := result.HealthScoreDetails.Factors
+	if len(factors) != 3 {
+		t.Fatalf("Factors = %d, want 3", len(factors))
+	}
+	plugin := factors[2]
+	if plugin.Name != "Plugin Findings" || plugin.Score != 83 || plugin.Weight != 0.2 || plugin.WeightedScore != 17 {
+		t.Errorf("plugin factor = %+v, want score 83 weighted 17", plugin)
+	}
+	if factors[0].Weight != 0.4 || factors[0].WeightedScore != 40 || factors[1].WeightedScore != 24 {
+		t.Errorf("factors = %+v, %+v; want weights scaled to 0.4", factors[0], factors[1])
+	}
+	// 100*0.4 + 60*0.4 + 83*0.2 = 80.6
+	if result.HealthScore != 81 || result.HealthScoreDetails.Overall != 81 {
+		t.Errorf("HealthScore = %d, want 81", result.HealthScore)
+	}
+}
+
+func TestApplyPluginResults_AllFailed(t *testing.T) {
+	result := createPluginTestResult()
+	ApplyPluginResults(result, []*types.PluginResult{{Plugin: "broken", Error: "timed out after 30s"}}, nil)
+
+	if len(result.HealthScoreDetails.Factors) != 2 || result.HealthScore != 80 {
+		t.Errorf("health score changed without a successful plugin: %d, %d factors",
+			result.HealthScore, len(result.HealthScoreDetails.Factors))
+	}
+	if len(result.PluginErrors) != 1 {
+		t.Errorf("PluginErrors = %+v, want 1", result.PluginErrors)
+	}
+}
+
+func TestApplyPluginResults_RatingFollowsScore(t *testing.T) {
+	result := createPluginTestResult()
+	var findings []*types.PluginFinding
+	for i := 0; i < 10; i++ {
+		findings = append(findings, &types.PluginFinding{Plugin: "lint", Rule: "x", Severity: types.PluginSeverityError})
+	}
+	ApplyPluginResults(result, []*types.PluginResult{{Plugin: "lint", Findings: findings}}, nil)
+
+	// 100*0.4 + 60*0.4 + 0*0.2 = 64
+	if result.HealthScore != 64 || result.HealthScoreDetails.Rating != types.HealthRatingFair {
+		t.Errorf("HealthScore = %d (%s), want 64 (fair)", result.HealthScore, result.HealthScoreDetails.Rating)
+	}
+}
+
+func TestApplyPluginResults_Weights(t *testing.T) {
+	findings := []*types.PluginFinding{{Plugin: "lint", Rule: "x", Severity: types.PluginSeverityError}}
+
+	tests := []struct {
+		name    string
+		weights *types.HealthWeights
+		share   float64
+		score   int
+	}{
+		// 100*0.25 + 60*0.25 + 90*0.5 = 85
+		{"half", &types.HealthWeights{Circular: 0.5, Conflict: 0.5, Plugins: 1}, 0.5, 85},
+		{"none", &types.HealthWeights{Circular: 0.5, Conflict: 0.5}, 0, 80},
+		{"invalid uses defaults", &types.HealthWeights{Circular: 0.5, Plugins: -1}, 0.2, 82},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := createPluginTestResult()
+			ApplyPluginResults(result, []*types.PluginResult{{Plugin: "lint", Findings: findings}}, tt.weights)
+
+			plugin := result.HealthScoreDetails.Factors[2]
+			if math.Abs(plugin.Weight-tt.share) > 1e-9 || result.HealthScore != tt.score {
+				t.Errorf("plugin weight %v, score %d; want %v, %d", plugin.Weight, result.HealthScore, tt.share, tt.score)
+			}
+		})
+	}
+}
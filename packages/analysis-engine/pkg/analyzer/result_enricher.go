@@ -309,6 +309,14 @@ func formatTotalTime(minutes int) string {
 	return fmt.Sprintf("%d hours %d minutes", hours, remainingMinutes)
 }
 
+// CycleID returns the identifier used for a cycle in FixSummary (e.g., "core→ui").
+// Cycles are normalized to start at their smallest package, so the ID of a
+// cycle stays the same across analyses as long as that package and its
+// successor in the cycle do.
+func CycleID(cycle []string) string {
+	return generateCycleID(cycle)
+}
+
 // generateCycleID creates a unique identifier for the cycle (e.g., "core→ui").
 func generateCycleID(cycle []string) string {
 	if len(cycle) < 2 {
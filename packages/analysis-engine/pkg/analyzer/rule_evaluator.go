@@ -0,0 +1,460 @@
+// Package analyzer provides dependency graph analysis for monorepo workspaces.
+// This file implements check rule evaluation for CI/CD validation.
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// ========================================
+// Rule Evaluator
+// ========================================
+
+// RuleEvaluator turns an AnalysisResult into a pass/fail CheckResult
+// according to the configured rule severities and thresholds.
+type RuleEvaluator struct {
+	rules      types.RulesConfig
+	thresholds types.ThresholdsConfig
+	weights    *types.HealthWeights // Used to score packages with their own thresholds
+	overrides  []packageOverride    // Nearest (deepest) directory first
+}
+
+// packageOverride is a PackageOverride with compiled exclusion patterns.
+type packageOverride struct {
+	path       string
+	rules      types.RulesConfig
+	thresholds *types.ThresholdsConfig
+	exclude    *ExclusionMatcher
+}
+
+// NewRuleEvaluator creates an evaluator from the analysis configuration.
+// A nil config (or nil Rules/Thresholds) uses the defaults: every rule is an
+// error and no health score threshold is enforced. Overrides with invalid
+// exclusion patterns only apply their rules.
+func NewRuleEvaluator(config *types.AnalysisConfig) *RuleEvaluator {
+	re := &RuleEvaluator{}
+	if config != nil {
+		if config.Rules != nil {
+			re.rules = *config.Rules
+		}
+		if config.Thresholds != nil {
+			re.thresholds = *config.Thresholds
+		}
+		re.weights = config.HealthWeights
+		for _, o := range config.Overrides {
+			po := packageOverride{path: path.Clean(o.Path)}
+			if po.path == "." {
+				po.path = ""
+			}
+			if o.Rules != nil {
+				po.rules = *o.Rules
+			}
+			po.thresholds = o.Thresholds
+			po.exclude, _ = NewExclusionMatcher(o.Exclude)
+			re.overrides = append(re.overrides, po)
+		}
+		sort.SliceStable(re.overrides, func(i, j int) bool {
+			return len(re.overrides[i].path) > len(re.overrides[j].path)
+		})
+	}
+	return re
+}
+
+// Evaluate checks the analysis result against all rules.
+// The check fails if any rule configured as "error" has violations or the
+// health score is below the configured threshold. Version conflicts are only
+// reported when their rule is configured.
+func (re *RuleEvaluator) Evaluate(result *types.AnalysisResult) *types.CheckResult {
+	if result == nil {
+		return types.NewCheckResult(0)
+	}
+
+	check := types.NewCheckResult(result.HealthScore)
+	check.Packages = checkedPackages(result.Graph)
+
+	for _, cycle := range result.CircularDependencies {
+		pkg, file, line := cycleLocation(cycle, result.Graph)
+		rules, ignored := re.packageRules(pkg, result.Graph)
+		if ignored(cycle.Cycle...) {
+			continue
+		}
+		report(check, effectiveSeverity(rules.CircularDependencies), types.ValidationError{
+			Code:    types.CheckCodeCircularDetected,
+			Message: fmt.Sprintf("Circular dependency found: %s", strings.Join(cycle.Cycle, " -> ")),
+			File:    file,
+			Line:    line,
+			Package: pkg,
+		})
+	}
+
+	for _, violation := range result.BoundaryViolations {
+		rules, ignored := re.packageRules(violation.From, result.Graph)
+		if ignored(violation.To) {
+			continue
+		}
+		report(check, effectiveSeverity(rules.BoundaryViolations), types.ValidationError{
+			Code:    types.CheckCodeBoundaryViolation,
+			Message: violation.Message,
+			File:    packageJSONPath(violation.From, result.Graph),
+			Package: violation.From,
+		})
+	}
+
+	for _, conflict := range result.VersionConflicts {
+		pkg := conflictPackage(conflict)
+		rules, ignored := re.packageRules(pkg, result.Graph)
+		if ignored(conflict.PackageName) {
+			continue
+		}
+		severity := rules.VersionConflicts
+		if severity == "" {
+			severity = types.RuleSeverityOff
+		}
+		report(check, severity, types.ValidationError{
+			Code:    types.CheckCodeVersionConflict,
+			Message: conflictMessage(conflict),
+			File:    packageJSONPath(pkg, result.Graph),
+			Package: pkg,
+		})
+	}
+
+	for _, finding := range result.PluginFindings {
+		rules, ignored := re.packageRules(finding.Package, result.Graph)
+		if finding.Package != "" && ignored(finding.Package) {
+			continue
+		}
+		file := finding.File
+		if file == "" {
+			file = packageJSONPath(finding.Package, result.Graph)
+		}
+		report(check, pluginSeverity(rules.Plugins, finding.Severity), types.ValidationError{
+			Code:    types.CheckCodePluginFinding,
+			Message: fmt.Sprintf("%s/%s: %s", finding.Plugin, finding.Rule, finding.Message),
+			File:    file,
+			Line:    finding.Line,
+			Package: finding.Package,
+		})
+	}
+
+	// A failed plugin is reported but does not fail the check by itself
+	if re.rules.Plugins != types.RuleSeverityOff {
+		for _, e := range result.PluginErrors {
+			report(check, types.RuleSeverityWarn, types.ValidationError{
+				Code:    types.CheckCodePluginFailed,
+				Message: fmt.Sprintf("Plugin %s failed: %s", e.Plugin, e.Message),
+			})
+		}
+	}
+
+	for _, violation := range result.CustomRuleViolations {
+		rules, ignored := re.packageRules(violation.Package, result.Graph)
+		if violation.Dependency != "" && ignored(violation.Dependency) {
+			continue
+		}
+		report(check, customRuleSeverity(rules.CustomRules, violation.Severity), types.ValidationError{
+			Code:    types.CheckCodeCustomRuleViolation,
+			Message: fmt.Sprintf("%s: %s", violation.Rule, violation.Message),
+			File:    packageJSONPath(violation.Package, result.Graph),
+			Package: violation.Package,
+		})
+	}
+
+	re.checkHealthScore(check, result)
+
+	return check
+}
+
+// healthGroup is a set of packages checked against one health score threshold.
+type healthGroup struct {
+	label     string
+	threshold int
+	packages  map[string]bool
+}
+
+// checkHealthScore reports health scores below their threshold. Without
+// overrides that set thresholds, the workspace score is checked against the
+// workspace threshold. Otherwise the packages of each such override are
+// scored on their own and checked against its threshold, and the remaining
+// packages against the workspace threshold.
+func (re *RuleEvaluator) checkHealthScore(check *types.CheckResult, result *types.AnalysisResult) {
+	groups := re.healthGroups(result.Graph)
+	if groups == nil {
+		if re.thresholds.HealthScore > 0 && result.HealthScore < re.thresholds.HealthScore {
+			report(check, types.RuleSeverityError, types.ValidationError{
+				Code: types.CheckCodeLowHealthScore,
+				Message: fmt.Sprintf("Health score %d is below threshold %d",
+					result.HealthScore, re.thresholds.HealthScore),
+			})
+		}
+		return
+	}
+
+	for _, g := range groups {
+		if g.threshold <= 0 || len(g.packages) == 0 {
+			continue
+		}
+		if score := packagesHealthScore(result, g.packages, re.weights); score < g.threshold {
+			report(check, types.RuleSeverityError, types.ValidationError{
+				Code:    types.CheckCodeLowHealthScore,
+				Message: fmt.Sprintf("Health score %d of %s is below threshold %d", score, g.label, g.threshold),
+			})
+		}
+	}
+}
+
+// healthGroups assigns each checked package to the nearest override with
+// thresholds, or to the rest of the workspace. It returns nil when no
+// override sets thresholds. The rest of the workspace comes first, then the
+// overrides by path.
+func (re *RuleEvaluator) healthGroups(graph *types.DependencyGraph) []*healthGroup {
+	if graph == nil {
+		return nil
+	}
+	byPath := map[string]*healthGroup{}
+	for _, o := range re.overrides {
+		if o.thresholds != nil {
+			byPath[o.path] = &healthGroup{
+				label:     "packages in " + o.path,
+				threshold: o.thresholds.HealthScore,
+				packages:  map[string]bool{},
+			}
+		}
+	}
+	if len(byPath) == 0 {
+		return nil
+	}
+
+	rest := &healthGroup{label: "the other packages", threshold: re.thresholds.HealthScore, packages: map[string]bool{}}
+	for _, name := range checkedPackages(graph) {
+		group := rest
+		for _, o := range re.overrides {
+			if g := byPath[o.path]; g != nil && inDirectory(graph.Nodes[name].Path, o.path) {
+				group = g
+				break
+			}
+		}
+		group.packages[name] = true
+	}
+
+	groups := []*healthGroup{rest}
+	paths := make([]string, 0, len(byPath))
+	for p := range byPath {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		groups = append(groups, byPath[p])
+	}
+	return groups
+}
+
+// packagesHealthScore calculates the health score of a set of packages from
+// the dependencies between them and the cycles and version conflicts that
+// involve them.
+func packagesHealthScore(result *types.AnalysisResult, packages map[string]bool, weights *types.HealthWeights) int {
+	graph := filterPackages(result.Graph, func(node *types.PackageNode) bool { return packages[node.Name] })
+
+	var cycles []*types.CircularDependencyInfo
+	for _, cycle := range result.CircularDependencies {
+		for _, name := range cycle.Cycle {
+			if packages[name] {
+				cycles = append(cycles, cycle)
+				break
+			}
+		}
+	}
+
+	var conflicts []*types.VersionConflictInfo
+	for _, conflict := range result.VersionConflicts {
+		if conflictInvolves(conflict, packages) {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+
+	return NewHealthCalculatorWithWeights(graph, cycles, conflicts, weights).Calculate().Overall
+}
+
+// conflictInvolves reports whether a package of the set uses one of the
+// conflicting versions.
+func conflictInvolves(conflict *types.VersionConflictInfo, packages map[string]bool) bool {
+	for _, v := range conflict.ConflictingVersions {
+		for _, name := range v.Packages {
+			if packages[name] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inDirectory reports whether a package path is dir or below it. The
+// workspace root ("") contains every package.
+func inDirectory(pkgPath, dir string) bool {
+	return dir == "" || pkgPath == dir || strings.HasPrefix(pkgPath, dir+"/")
+}
+
+// packageRules returns the rules that apply to issues of a package, and a
+// function reporting whether an issue involving the given packages is
+// ignored by the exclusions of the package's overrides.
+func (re *RuleEvaluator) packageRules(pkg string, graph *types.DependencyGraph) (types.RulesConfig, func(...string) bool) {
+	rules := re.rules
+	var matchers []*ExclusionMatcher
+	if len(re.overrides) > 0 && graph != nil {
+		if node, ok := graph.Nodes[pkg]; ok {
+			var set types.RulesConfig
+			for _, o := range re.overrides {
+				if !inDirectory(node.Path, o.path) {
+					continue
+				}
+				set = mergeRules(set, o.rules)
+				matchers = append(matchers, o.exclude)
+			}
+			rules = mergeRules(set, rules)
+		}
+	}
+	return rules, func(names ...string) bool {
+		for _, m := range matchers {
+			for _, name := range names {
+				if m.IsExcluded(name) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// mergeRules fills the unset rules of rules from fallback.
+func mergeRules(rules, fallback types.RulesConfig) types.RulesConfig {
+	if rules.CircularDependencies == "" {
+		rules.CircularDependencies = fallback.CircularDependencies
+	}
+	if rules.BoundaryViolations == "" {
+		rules.BoundaryViolations = fallback.BoundaryViolations
+	}
+	if rules.VersionConflicts == "" {
+		rules.VersionConflicts = fallback.VersionConflicts
+	}
+	if rules.Plugins == "" {
+		rules.Plugins = fallback.Plugins
+	}
+	if rules.CustomRules == "" {
+		rules.CustomRules = fallback.CustomRules
+	}
+	return rules
+}
+
+// report records a violation as an error or warning depending on severity.
+func report(check *types.CheckResult, severity types.RuleSeverity, violation types.ValidationError) {
+	switch severity {
+	case types.RuleSeverityOff:
+		return
+	case types.RuleSeverityWarn:
+		check.Warnings = append(check.Warnings, types.ValidationWarning{
+			Code:    violation.Code,
+			Message: violation.Message,
+			File:    violation.File,
+			Line:    violation.Line,
+			Package: violation.Package,
+		})
+	default:
+		check.Errors = append(check.Errors, violation)
+		check.Passed = false
+	}
+}
+
+// effectiveSeverity applies the default severity (error) for unset rules.
+func effectiveSeverity(severity types.RuleSeverity) types.RuleSeverity {
+	if severity == "" {
+		return types.RuleSeverityError
+	}
+	return severity
+}
+
+// pluginSeverity caps the severity of a plugin finding by the plugins rule:
+// errors fail the check, warnings are reported as warnings and info findings
+// are not reported.
+func pluginSeverity(rule types.RuleSeverity, severity types.PluginSeverity) types.RuleSeverity {
+	switch {
+	case rule == types.RuleSeverityOff, severity == types.PluginSeverityInfo:
+		return types.RuleSeverityOff
+	case rule == types.RuleSeverityWarn, severity == types.PluginSeverityWarning:
+		return types.RuleSeverityWarn
+	default:
+		return types.RuleSeverityError
+	}
+}
+
+// customRuleSeverity caps the severity of a custom rule violation by the
+// customRules rule, so "warn" reports errors as warnings.
+func customRuleSeverity(rule, severity types.RuleSeverity) types.RuleSeverity {
+	if rule == types.RuleSeverityOff || rule == types.RuleSeverityWarn {
+		return rule
+	}
+	return effectiveSeverity(severity)
+}
+
+// cycleLocation returns the package and best source location for a cycle: the
+// first traced import statement if available, otherwise the first package's
+// package.json.
+func cycleLocation(cycle *types.CircularDependencyInfo, graph *types.DependencyGraph) (string, string, int) {
+	if len(cycle.ImportTraces) > 0 {
+		trace := cycle.ImportTraces[0]
+		return trace.FromPackage, trace.FilePath, trace.LineNumber
+	}
+	if len(cycle.Cycle) > 0 {
+		return cycle.Cycle[0], packageJSONPath(cycle.Cycle[0], graph), 0
+	}
+	return "", "", 0
+}
+
+// conflictMessage describes a version conflict with the packages using each version.
+func conflictMessage(conflict *types.VersionConflictInfo) string {
+	versions := make([]string, 0, len(conflict.ConflictingVersions))
+	for _, v := range conflict.ConflictingVersions {
+		versions = append(versions, fmt.Sprintf("%s (%s)", v.Version, strings.Join(v.Packages, ", ")))
+	}
+	return fmt.Sprintf("Version conflict for %s: %s", conflict.PackageName, strings.Join(versions, " vs "))
+}
+
+// conflictPackage returns the first workspace package using a conflicting version.
+func conflictPackage(conflict *types.VersionConflictInfo) string {
+	for _, v := range conflict.ConflictingVersions {
+		if len(v.Packages) > 0 {
+			return v.Packages[0]
+		}
+	}
+	return ""
+}
+
+// checkedPackages returns the sorted names of the non-excluded packages.
+func checkedPackages(graph *types.DependencyGraph) []string {
+	if graph == nil {
+		return nil
+	}
+	names := make([]string, 0, len(graph.Nodes))
+	for name, node := range graph.Nodes {
+		if !node.Excluded {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// packageJSONPath returns the relative package.json path for a package, or "" if unknown.
+func packageJSONPath(pkgName string, graph *types.DependencyGraph) string {
+	if graph == nil {
+		return ""
+	}
+	node, ok := graph.Nodes[pkgName]
+	if !ok || node.Path == "" {
+		return ""
+	}
+	return node.Path + "/package.json"
+}
@@ -0,0 +1,472 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// createRuleTestResult creates an AnalysisResult with one cycle and one boundary violation.
+func createRuleTestResult(healthScore int) *types.AnalysisResult {
+	graph := types.NewDependencyGraph("/test", types.WorkspaceTypePnpm)
+	graph.Nodes["a"] = types.NewPackageNode("a", "1.0.0", "packages/a")
+	graph.Nodes["b"] = types.NewPackageNode("b", "1.0.0", "packages/b")
+
+	return &types.AnalysisResult{
+		HealthScore: healthScore,
+		Graph:       graph,
+		CircularDependencies: []*types.CircularDependencyInfo{
+			types.NewCircularDependencyInfo([]string{"a", "b", "a"}),
+		},
+		BoundaryViolations: []*types.BoundaryViolation{
+			{From: "b", To: "a", FromLayer: "libs", ToLayer: "apps", Message: "b must not depend on a"},
+		},
+	}
+}
+
+func TestRuleEvaluator_Defaults(t *testing.T) {
+	check := NewRuleEvaluator(nil).Evaluate(createRuleTestResult(80))
+
+	if check.Passed {
+		t.Error("Expected check to fail with default error rules")
+	}
+	if len(check.Errors) != 2 {
+		t.Fatalf("Errors = %d, want 2: %+v", len(check.Errors), check.Errors)
+	}
+	if check.Errors[0].Code != types.CheckCodeCircularDetected {
+		t.Errorf("Errors[0].Code = %s, want %s", check.Errors[0].Code, types.CheckCodeCircularDetected)
+	}
+	if check.Errors[0].File != "packages/a/package.json" {
+		t.Errorf("Errors[0].File = %q, want packages/a/package.json", check.Errors[0].File)
+	}
+	if check.Errors[1].Code != types.CheckCodeBoundaryViolation || check.Errors[1].File != "packages/b/package.json" {
+		t.Errorf("Unexpected boundary error: %+v", check.Errors[1])
+	}
+	if check.HealthScore != 80 {
+		t.Errorf("HealthScore = %d, want 80", check.HealthScore)
+	}
+	if check.Errors[0].Package != "a" || check.Errors[1].Package != "b" {
+		t.Errorf("Packages = %q, %q; want a, b", check.Errors[0].Package, check.Errors[1].Package)
+	}
+	if len(check.Packages) != 2 || check.Packages[0] != "a" || check.Packages[1] != "b" {
+		t.Errorf("Packages = %v, want [a b]", check.Packages)
+	}
+}
+
+func TestRuleEvaluator_Severities(t *testing.T) {
+	tests := []struct {
+		name         string
+		rules        *types.RulesConfig
+		wantPassed   bool
+		wantErrors   int
+		wantWarnings int
+	}{
+		{
+			name:         "warn downgrades to warnings",
+			rules:        &types.RulesConfig{CircularDependencies: types.RuleSeverityWarn, BoundaryViolations: types.RuleSeverityWarn},
+			wantPassed:   true,
+			wantWarnings: 2,
+		},
+		{
+			name:       "off disables rules",
+			rules:      &types.RulesConfig{CircularDependencies: types.RuleSeverityOff, BoundaryViolations: types.RuleSeverityOff},
+			wantPassed: true,
+		},
+		{
+			name:         "mixed severities",
+			rules:        &types.RulesConfig{CircularDependencies: types.RuleSeverityError, BoundaryViolations: types.RuleSeverityWarn},
+			wantPassed:   false,
+			wantErrors:   1,
+			wantWarnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := NewRuleEvaluator(&types.AnalysisConfig{Rules: tt.rules}).Evaluate(createRuleTestResult(80))
+
+			if check.Passed != tt.wantPassed {
+				t.Errorf("Passed = %v, want %v", check.Passed, tt.wantPassed)
+			}
+			if len(check.Errors) != tt.wantErrors {
+				t.Errorf("Errors = %d, want %d", len(check.Errors), tt.wantErrors)
+			}
+			if len(check.Warnings) != tt.wantWarnings {
+				t.Errorf("Warnings = %d, want %d", len(check.Warnings), tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestRuleEvaluator_HealthScoreThreshold(t *testing.T) {
+	off := &types.RulesConfig{CircularDependencies: types.RuleSeverityOff, BoundaryViolations: types.RuleSeverityOff}
+
+	tests := []struct {
+		name       string
+		score      int
+		threshold  int
+		wantPassed bool
+	}{
+		{"disabled threshold", 10, 0, true},
+		{"score above threshold", 80, 70, true},
+		{"score equal to threshold", 70, 70, true},
+		{"score below threshold", 60, 70, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &types.AnalysisConfig{
+				Rules:      off,
+				Thresholds: &types.ThresholdsConfig{HealthScore: tt.threshold},
+			}
+			check := NewRuleEvaluator(config).Evaluate(createRuleTestResult(tt.score))
+
+			if check.Passed != tt.wantPassed {
+				t.Errorf("Passed = %v, want %v", check.Passed, tt.wantPassed)
+			}
+			if !tt.wantPassed && check.Errors[0].Code != types.CheckCodeLowHealthScore {
+				t.Errorf("Errors[0].Code = %s, want %s", check.Errors[0].Code, types.CheckCodeLowHealthScore)
+			}
+		})
+	}
+}
+
+func TestRuleEvaluator_UsesImportTraceLocation(t *testing.T) {
+	result := createRuleTestResult(80)
+	result.CircularDependencies[0].ImportTraces = []types.ImportTrace{
+		{FromPackage: "b", ToPackage: "a", FilePath: "packages/b/src/index.ts", LineNumber: 4},
+	}
+
+	check := NewRuleEvaluator(nil).Evaluate(result)
+	if check.Errors[0].File != "packages/b/src/index.ts" || check.Errors[0].Line != 4 {
+		t.Errorf("Expected import trace location, got %s:%d", check.Errors[0].File, check.Errors[0].Line)
+	}
+	if check.Errors[0].Package != "b" {
+		t.Errorf("Package = %q, want the importing package b", check.Errors[0].Package)
+	}
+
+	// Warnings keep the location
+	config := &types.AnalysisConfig{Rules: &types.RulesConfig{CircularDependencies: types.RuleSeverityWarn}}
+	check = NewRuleEvaluator(config).Evaluate(result)
+	if w := check.Warnings[0]; w.File != "packages/b/src/index.ts" || w.Line != 4 || w.Package != "b" {
+		t.Errorf("Expected warning with import trace location, got %+v", w)
+	}
+}
+
+func TestRuleEvaluator_NilResult(t *testing.T) {
+	check := NewRuleEvaluator(nil).Evaluate(nil)
+	if !check.Passed {
+		t.Error("Expected nil result to pass")
+	}
+}
+
+func TestRuleEvaluator_VersionConflicts(t *testing.T) {
+	result := createRuleTestResult(80)
+	result.VersionConflicts = []*types.VersionConflictInfo{
+		{
+			PackageName: "lodash",
+			ConflictingVersions: []*types.ConflictingVersion{
+				{Version: "^4.17.0", Packages: []string{"b"}},
+				{Version: "^3.10.0", Packages: []string{"a"}},
+			},
+		},
+	}
+	rules := &types.RulesConfig{CircularDependencies: types.RuleSeverityOff, BoundaryViolations: types.RuleSeverityOff}
+
+	// Off by default
+	check := NewRuleEvaluator(&types.AnalysisConfig{Rules: rules}).Evaluate(result)
+	if !check.Passed || len(check.Warnings) != 0 {
+		t.Errorf("Expected unconfigured version conflicts to be ignored: %+v", check)
+	}
+
+	rules.VersionConflicts = types.RuleSeverityWarn
+	check = NewRuleEvaluator(&types.AnalysisConfig{Rules: rules}).Evaluate(result)
+	if !check.Passed || len(check.Warnings) != 1 {
+		t.Fatalf("Warnings = %+v, want 1 version conflict", check.Warnings)
+	}
+	w := check.Warnings[0]
+	if w.Code != types.CheckCodeVersionConflict || w.Package != "b" || w.File != "packages/b/package.json" {
+		t.Errorf("Unexpected warning: %+v", w)
+	}
+	if want := "Version conflict for lodash: ^4.17.0 (b) vs ^3.10.0 (a)"; w.Message != want {
+		t.Errorf("Message = %q, want %q", w.Message, want)
+	}
+}
+
+func TestRuleEvaluator_Overrides(t *testing.T) {
+	result := createRuleTestResult(80)
+	result.Graph.Nodes["c"] = types.NewPackageNode("c", "1.0.0", "packages/c")
+	result.BoundaryViolations = append(result.BoundaryViolations,
+		&types.BoundaryViolation{From: "c", To: "legacy-api", Message: "c must not depend on legacy-api"})
+
+	tests := []struct {
+		name         string
+		overrides    []types.PackageOverride
+		wantErrors   []string // "code package"
+		wantWarnings []string
+	}{
+		{
+			name:       "no overrides",
+			wantErrors: []string{"CIRCULAR_DETECTED a", "BOUNDARY_VIOLATION b", "BOUNDARY_VIOLATION c"},
+		},
+		{
+			name: "package rules",
+			overrides: []types.PackageOverride{
+				{Path: "packages/b", Rules: &types.RulesConfig{BoundaryViolations: types.RuleSeverityWarn}},
+			},
+			wantErrors:   []string{"CIRCULAR_DETECTED a", "BOUNDARY_VIOLATION c"},
+			wantWarnings: []string{"BOUNDARY_VIOLATION b"},
+		},
+		{
+			name: "nearest override wins",
+			overrides: []types.PackageOverride{
+				{Path: "packages/a", Rules: &types.RulesConfig{CircularDependencies: types.RuleSeverityWarn}},
+				{Path: "packages", Rules: &types.RulesConfig{CircularDependencies: types.RuleSeverityOff, BoundaryViolations: types.RuleSeverityOff}},
+			},
+			wantWarnings: []string{"CIRCULAR_DETECTED a"},
+		},
+		{
+			name: "package exclusions",
+			overrides: []types.PackageOverride{
+				{Path: "packages/c", Exclude: []string{"legacy-*"}},
+				{Path: "packages/a", Exclude: []string{"b"}},
+			},
+			wantErrors: []string{"BOUNDARY_VIOLATION b"},
+		},
+		{
+			name: "other directories",
+			overrides: []types.PackageOverride{
+				{Path: "packages/a-extra", Rules: &types.RulesConfig{CircularDependencies: types.RuleSeverityOff}},
+			},
+			wantErrors: []string{"CIRCULAR_DETECTED a", "BOUNDARY_VIOLATION b", "BOUNDARY_VIOLATION c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := NewRuleEvaluator(&types.AnalysisConfig{Overrides: tt.overrides}).Evaluate(result)
+
+			var errs, warnings []string
+			for _, e := range check.Errors {
+				errs = append(errs, e.Code+" "+e.Package)
+			}
+			for _, w := range check.Warnings {
+				warnings = append(warnings, w.Code+" "+w.Package)
+			}
+			if strings.Join(errs, ",") != strings.Join(tt.wantErrors, ",") {
+				t.Errorf("Errors = %v, want %v", errs, tt.wantErrors)
+			}
+			if strings.Join(warnings, ",") != strings.Join(tt.wantWarnings, ",") {
+				t.Errorf("Warnings = %v, want %v", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestRuleEvaluator_PluginFindings(t *testing.T) {
+	newResult := func() *types.AnalysisResult {
+		result := createRuleTestResult(80)
+		result.CircularDependencies = nil
+		result.BoundaryViolations = nil
+		result.PluginFindings = []*types.PluginFinding{
+			{Plugin: "lint", Rule: "no-lodash", Severity: types.PluginSeverityError, Message: "lodash is banned", Package: "a"},
+			{Plugin: "lint", Rule: "readme", Severity: types.PluginSeverityWarning, Message: "missing README", Package: "b", File: "packages/b/index.ts", Line: 3},
+			{Plugin: "lint", Rule: "todo", Severity: types.PluginSeverityInfo, Message: "TODO left"},
+		}
+		result.PluginErrors = []*types.PluginError{{Plugin: "slow", Message: "timed out after 1s"}}
+		return result
+	}
+
+	tests := []struct {
+		name         string
+		rule         types.RuleSeverity
+		wantErrors   []string
+		wantWarnings []string
+	}{
+		{"default keeps plugin severities", "", []string{"lint/no-lodash: lodash is banned"},
+			[]string{"lint/readme: missing README", "Plugin slow failed: timed out after 1s"}},
+		{"warn caps errors", types.RuleSeverityWarn, nil,
+			[]string{"lint/no-lodash: lodash is banned", "lint/readme: missing README", "Plugin slow failed: timed out after 1s"}},
+		{"off ignores plugins", types.RuleSeverityOff, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := NewRuleEvaluator(&types.AnalysisConfig{Rules: &types.RulesConfig{Plugins: tt.rule}}).Evaluate(newResult())
+
+			var errors, warnings []string
+			for _, e := range check.Errors {
+				errors = append(errors, e.Message)
+			}
+			for _, w := range check.Warnings {
+				warnings = append(warnings, w.Message)
+			}
+			if strings.Join(errors, "|") != strings.Join(tt.wantErrors, "|") {
+				t.Errorf("Errors = %q, want %q", errors, tt.wantErrors)
+			}
+			if strings.Join(warnings, "|") != strings.Join(tt.wantWarnings, "|") {
+				t.Errorf("Warnings = %q, want %q", warnings, tt.wantWarnings)
+			}
+			if check.Passed != (len(tt.wantErrors) == 0) {
+				t.Errorf("Passed = %v", check.Passed)
+			}
+		})
+	}
+
+	check := NewRuleEvaluator(nil).Evaluate(newResult())
+	if e := check.Errors[0]; e.Code != types.CheckCodePluginFinding || e.File != "packages/a/package.json" || e.Package != "a" {
+		t.Errorf("Errors[0] = %+v, want located at the package.json of a", e)
+	}
+	if w := check.Warnings[0]; w.File != "packages/b/index.ts" || w.Line != 3 {
+		t.Errorf("Warnings[0] = %+v, want the reported location", w)
+	}
+	if w := check.Warnings[1]; w.Code != types.CheckCodePluginFailed {
+		t.Errorf("Warnings[1].Code = %s, want %s", w.Code, types.CheckCodePluginFailed)
+	}
+}
+
+func TestRuleEvaluator_CustomRules(t *testing.T) {
+	newResult := func() *types.AnalysisResult {
+		result := createRuleTestResult(80)
+		result.CircularDependencies = nil
+		result.BoundaryViolations = nil
+		result.CustomRuleViolations = []*types.CustomRuleViolation{
+			{Rule: "apps-are-leaves", Severity: types.RuleSeverityError, Message: "a → b", Package: "a", Dependency: "b"},
+			{Rule: "max-deps", Severity: types.RuleSeverityWarn, Message: "b", Package: "b"},
+			{Rule: "small", Severity: types.RuleSeverityError, Message: "workspace"},
+		}
+		return result
+	}
+
+	tests := []struct {
+		name         string
+		config       *types.AnalysisConfig
+		wantErrors   []string
+		wantWarnings []string
+	}{
+		{"default keeps rule severities", nil,
+			[]string{"apps-are-leaves: a → b", "small: workspace"}, []string{"max-deps: b"}},
+		{"warn caps errors", &types.AnalysisConfig{Rules: &types.RulesConfig{CustomRules: types.RuleSeverityWarn}}, nil,
+			[]string{"apps-are-leaves: a → b", "max-deps: b", "small: workspace"}},
+		{"off ignores custom rules", &types.AnalysisConfig{Rules: &types.RulesConfig{CustomRules: types.RuleSeverityOff}}, nil, nil},
+		{"package overrides", &types.AnalysisConfig{Overrides: []types.PackageOverride{
+			{Path: "packages/a", Exclude: []string{"b"}},
+			{Path: "packages/b", Rules: &types.RulesConfig{CustomRules: types.RuleSeverityOff}},
+		}}, []string{"small: workspace"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := NewRuleEvaluator(tt.config).Evaluate(newResult())
+
+			var errors, warnings []string
+			for _, e := range check.Errors {
+				errors = append(errors, e.Message)
+			}
+			for _, w := range check.Warnings {
+				warnings = append(warnings, w.Message)
+			}
+			if strings.Join(errors, "|") != strings.Join(tt.wantErrors, "|") {
+				t.Errorf("Errors = %q, want %q", errors, tt.wantErrors)
+			}
+			if strings.Join(warnings, "|") != strings.Join(tt.wantWarnings, "|") {
+				t.Errorf("Warnings = %q, want %q", warnings, tt.wantWarnings)
+			}
+		})
+	}
+
+	check := NewRuleEvaluator(nil).Evaluate(newResult())
+	if e := check.Errors[0]; e.Code != types.CheckCodeCustomRuleViolation || e.File != "packages/a/package.json" || e.Package != "a" {
+		t.Errorf("Errors[0] = %+v, want located at the package.json of a", e)
+	}
+	if e := check.Errors[1]; e.File != "" || e.Package != "" {
+		t.Errorf("Errors[1] = %+v, want no location for a workspace rule", e)
+	}
+}
+
+// createOverrideHealthResult creates a result where the legacy packages form
+// a cycle and the library packages are clean.
+func createOverrideHealthResult() *types.AnalysisResult {
+	graph := types.NewDependencyGraph("/test", types.WorkspaceTypePnpm)
+	for name, path := range map[string]string{
+		"legacy-a": "apps/legacy/a", "legacy-b": "apps/legacy/b", "ui": "libs/ui", "core": "libs/core",
+	} {
+		graph.Nodes[name] = types.NewPackageNode(name, "1.0.0", path)
+	}
+	graph.Nodes["legacy-a"].Dependencies = []string{"legacy-b", "ui"}
+	graph.Nodes["legacy-b"].Dependencies = []string{"legacy-a"}
+	graph.Nodes["ui"].Dependencies = []string{"core"}
+	graph.Edges = []*types.DependencyEdge{
+		{From: "legacy-a", To: "legacy-b", Type: types.DependencyTypeProduction},
+		{From: "legacy-a", To: "ui", Type: types.DependencyTypeProduction},
+		{From: "legacy-b", To: "legacy-a", Type: types.DependencyTypeProduction},
+		{From: "ui", To: "core", Type: types.DependencyTypeProduction},
+	}
+	cycles := []*types.CircularDependencyInfo{types.NewCircularDependencyInfo([]string{"legacy-a", "legacy-b", "legacy-a"})}
+	return &types.AnalysisResult{
+		HealthScore:          NewHealthCalculator(filterExcludedPackages(graph), cycles, nil).Calculate().Overall,
+		Graph:                graph,
+		CircularDependencies: cycles,
+	}
+}
+
+func TestRuleEvaluator_OverrideThresholds(t *testing.T) {
+	// The workspace scores 93, apps/legacy alone 94 and libs alone 100
+	tests := []struct {
+		name       string
+		overrides  []types.PackageOverride
+		wantErrors []string
+	}{
+		{
+			name:       "workspace threshold",
+			wantErrors: []string{"Health score 93 is below threshold 95"},
+		},
+		{
+			name:       "override without thresholds",
+			overrides:  []types.PackageOverride{{Path: "apps/legacy", Rules: &types.RulesConfig{}}},
+			wantErrors: []string{"Health score 93 is below threshold 95"},
+		},
+		{
+			name:      "looser package threshold",
+			overrides: []types.PackageOverride{{Path: "apps/legacy", Thresholds: &types.ThresholdsConfig{HealthScore: 90}}},
+		},
+		{
+			name:      "disabled package threshold",
+			overrides: []types.PackageOverride{{Path: "apps", Thresholds: &types.ThresholdsConfig{}}},
+		},
+		{
+			name: "nearest threshold applies",
+			overrides: []types.PackageOverride{
+				{Path: "apps", Thresholds: &types.ThresholdsConfig{HealthScore: 90}},
+				{Path: "apps/legacy", Thresholds: &types.ThresholdsConfig{HealthScore: 96}},
+			},
+			wantErrors: []string{"Health score 94 of packages in apps/legacy is below threshold 96"},
+		},
+		{
+			name:       "other packages scored without the override",
+			overrides:  []types.PackageOverride{{Path: "libs", Thresholds: &types.ThresholdsConfig{HealthScore: 100}}},
+			wantErrors: []string{"Health score 94 of the other packages is below threshold 95"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &types.AnalysisConfig{
+				Rules:      &types.RulesConfig{CircularDependencies: types.RuleSeverityOff},
+				Thresholds: &types.ThresholdsConfig{HealthScore: 95},
+				Overrides:  tt.overrides,
+			}
+			check := NewRuleEvaluator(config).Evaluate(createOverrideHealthResult())
+
+			var errs []string
+			for _, e := range check.Errors {
+				errs = append(errs, e.Message)
+			}
+			if strings.Join(errs, ",") != strings.Join(tt.wantErrors, ",") {
+				t.Errorf("Errors = %v, want %v", errs, tt.wantErrors)
+			}
+			if check.Passed != (len(tt.wantErrors) == 0) {
+				t.Errorf("Passed = %v", check.Passed)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Package parser provides CODEOWNERS parsing for workspace ownership.
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// CodeownersFiles are the locations of the CODEOWNERS file relative to the
+// workspace root, in the order GitHub looks them up.
+var CodeownersFiles = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// FindCodeowners returns the path and content of the first CODEOWNERS file
+// in files, or "" and nil if there is none.
+func FindCodeowners(files map[string][]byte) (string, []byte) {
+	for _, name := range CodeownersFiles {
+		if content, ok := files[name]; ok {
+			return name, content
+		}
+	}
+	return "", nil
+}
+
+// ParseCodeowners reads the rules of a CODEOWNERS file in file order.
+// Comments, blank lines and GitLab section headers are skipped. A pattern
+// without owners becomes a rule with no owners, which leaves the matched
+// paths unowned.
+func ParseCodeowners(content []byte) []types.OwnerRule {
+	var rules []types.OwnerRule
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// GitLab sections: [Section] or ^[Optional section] with default owners
+		if strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		// An inline comment starts at the first field after the pattern beginning with #
+		for i := 1; i < len(fields); i++ {
+			if strings.HasPrefix(fields[i], "#") {
+				fields = fields[:i]
+				break
+			}
+		}
+		pattern := strings.TrimPrefix(fields[0], `\`) // \#file escapes a leading #
+		rules = append(rules, types.OwnerRule{
+			Pattern: pattern,
+			Owners:  fields[1:],
+		})
+	}
+	return rules
+}
@@ -0,0 +1,88 @@
+// Package parser tests for CODEOWNERS parsing.
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+func TestParseCodeowners(t *testing.T) {
+	content := []byte(`# Default owners
+*                 @org/platform
+
+/apps/            @org/web @alice   # web apps
+libs/ui/**        @org/design
+\#notes           @bob
+
+[Documentation]
+docs/             @org/docs
+libs/legacy
+`)
+
+	want := []types.OwnerRule{
+		{Pattern: "*", Owners: []string{"@org/platform"}},
+		{Pattern: "/apps/", Owners: []string{"@org/web", "@alice"}},
+		{Pattern: "libs/ui/**", Owners: []string{"@org/design"}},
+		{Pattern: "#notes", Owners: []string{"@bob"}},
+		{Pattern: "docs/", Owners: []string{"@org/docs"}},
+		{Pattern: "libs/legacy", Owners: []string{}},
+	}
+	if got := ParseCodeowners(content); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseCodeowners() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseCodeowners_InlineComments(t *testing.T) {
+	tests := []struct {
+		line string
+		want types.OwnerRule
+	}{
+		{"/apps/ @org/web # web apps", types.OwnerRule{Pattern: "/apps/", Owners: []string{"@org/web"}}},
+		{"/apps/ @org/web\t# tab before the comment", types.OwnerRule{Pattern: "/apps/", Owners: []string{"@org/web"}}},
+		{"/apps/ @org/web #no space after the hash", types.OwnerRule{Pattern: "/apps/", Owners: []string{"@org/web"}}},
+		{"/apps/ @org/web # one # two", types.OwnerRule{Pattern: "/apps/", Owners: []string{"@org/web"}}},
+		{"libs/legacy # unowned", types.OwnerRule{Pattern: "libs/legacy", Owners: []string{}}},
+		{"notes#1 @bob", types.OwnerRule{Pattern: "notes#1", Owners: []string{"@bob"}}},
+	}
+	for _, tt := range tests {
+		got := ParseCodeowners([]byte(tt.line))
+		if len(got) != 1 || !reflect.DeepEqual(got[0], tt.want) {
+			t.Errorf("ParseCodeowners(%q) = %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestFindCodeowners(t *testing.T) {
+	files := map[string][]byte{
+		"CODEOWNERS":         []byte("* @root"),
+		".github/CODEOWNERS": []byte("* @github"),
+	}
+	name, content := FindCodeowners(files)
+	if name != ".github/CODEOWNERS" || string(content) != "* @github" {
+		t.Errorf("FindCodeowners() = %q, %q; want .github/CODEOWNERS first", name, content)
+	}
+
+	if name, content := FindCodeowners(map[string][]byte{}); name != "" || content != nil {
+		t.Errorf("FindCodeowners() = %q, %q; want none", name, content)
+	}
+}
+
+func TestParseCodeownersFile(t *testing.T) {
+	files := map[string][]byte{
+		"package.json":              []byte(`{"name": "monorepo-root", "workspaces": ["packages/*"]}`),
+		"packages/app/package.json": []byte(`{"name": "@mono/app"}`),
+		"CODEOWNERS":                []byte("/packages/ @org/web\n"),
+	}
+
+	p := NewParser("/workspace")
+	result, err := p.Parse(files)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := []types.OwnerRule{{Pattern: "/packages/", Owners: []string{"@org/web"}}}
+	if !reflect.DeepEqual(result.CodeOwners, want) {
+		t.Errorf("CodeOwners = %+v, want %+v", result.CodeOwners, want)
+	}
+}
@@ -5,6 +5,7 @@ package parser
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
@@ -74,10 +75,13 @@ func (p *Parser) Parse(files map[string][]byte) (*types.WorkspaceData, error) {
 	}
 
 	// Expand patterns to find package directories
+	// Sorted so that the first of several packages with the same name wins
 	packageDirs := ExpandGlobPatternsFromFiles(files, patterns)
+	sort.Strings(packageDirs)
 
 	// Parse each package
 	packages := make(map[string]*types.PackageInfo)
+	var diagnostics []types.Diagnostic
 	for _, dir := range packageDirs {
 		pkgPath := filepath.ToSlash(filepath.Join(dir, "package.json"))
 		pkgData, ok := files[pkgPath]
@@ -87,12 +91,30 @@ func (p *Parser) Parse(files map[string][]byte) (*types.WorkspaceData, error) {
 
 		pkg, err := ParsePackageJSON(pkgData)
 		if err != nil {
-			// Log warning but continue parsing other packages
+			diagnostics = append(diagnostics, types.Diagnostic{
+				File:    pkgPath,
+				Kind:    types.DiagnosticInvalidPackageJSON,
+				Message: fmt.Sprintf("%v; the package is not analyzed", err),
+			})
 			continue
 		}
 
 		if pkg.Name == "" {
-			continue // Skip packages without name
+			diagnostics = append(diagnostics, types.Diagnostic{
+				File:    pkgPath,
+				Kind:    types.DiagnosticMissingName,
+				Message: "package.json has no name; the package is not analyzed",
+			})
+			continue
+		}
+
+		if existing, ok := packages[pkg.Name]; ok {
+			diagnostics = append(diagnostics, types.Diagnostic{
+				File:    pkgPath,
+				Kind:    types.DiagnosticDuplicateName,
+				Message: fmt.Sprintf("package name %q is already used by %s; the package is not analyzed", pkg.Name, existing.Path),
+			})
+			continue
 		}
 
 		// Initialize empty maps if nil
@@ -119,13 +141,141 @@ func (p *Parser) Parse(files map[string][]byte) (*types.WorkspaceData, error) {
 		}
 	}
 
+	diagnostics = append(diagnostics, p.coverageDiagnostics(files, wsType, patterns, packageDirs)...)
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].File < diagnostics[j].File
+	})
+
+	var codeOwners []types.OwnerRule
+	if _, content := FindCodeowners(files); content != nil {
+		codeOwners = ParseCodeowners(content)
+	}
+
 	return &types.WorkspaceData{
 		RootPath:      p.rootPath,
 		WorkspaceType: wsType,
 		Packages:      packages,
+		Diagnostics:   diagnostics,
+		CodeOwners:    codeOwners,
 	}, nil
 }
 
+// coverageDiagnostics reports package.json files that no workspace pattern
+// matches and patterns that match no package directory.
+func (p *Parser) coverageDiagnostics(files map[string][]byte, wsType types.WorkspaceType, patterns, packageDirs []string) []types.Diagnostic {
+	var diagnostics []types.Diagnostic
+
+	matched := make(map[string]bool, len(packageDirs))
+	for _, dir := range packageDirs {
+		matched[dir] = true
+	}
+	var negations []string
+	for _, pattern := range patterns {
+		if IsNegationPattern(pattern) {
+			negations = append(negations, getNegationBase(pattern))
+		}
+	}
+	for filePath := range files {
+		filePath = filepath.ToSlash(filePath)
+		dir := filepath.ToSlash(filepath.Dir(filePath))
+		if filepath.Base(filePath) != "package.json" || dir == "." || matched[dir] {
+			continue
+		}
+		// Packages excluded by a negation pattern are left out on purpose
+		if len(FilterPaths([]string{dir}, negations)) > 0 {
+			continue
+		}
+		diagnostics = append(diagnostics, types.Diagnostic{
+			File:    filePath,
+			Kind:    types.DiagnosticUnmatchedPackage,
+			Message: "package.json is not matched by any workspace pattern; the package is not analyzed",
+		})
+	}
+
+	patternFile := "package.json"
+	if _, ok := files["pnpm-workspace.yaml"]; ok && wsType == types.WorkspaceTypePnpm {
+		patternFile = "pnpm-workspace.yaml"
+	}
+	for _, pattern := range patterns {
+		if IsNegationPattern(pattern) {
+			continue
+		}
+		found := false
+		for _, dir := range packageDirs {
+			if MatchPattern(pattern, dir) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			diagnostics = append(diagnostics, types.Diagnostic{
+				File:    patternFile,
+				Kind:    types.DiagnosticUnmatchedPattern,
+				Message: fmt.Sprintf("workspace pattern %q matches no package", pattern),
+			})
+		}
+	}
+	return diagnostics
+}
+
+// WorkspacePatterns returns the workspace glob patterns declared by the
+// workspace (pnpm-workspace.yaml or package.json "workspaces").
+func (p *Parser) WorkspacePatterns(files map[string][]byte) ([]string, error) {
+	rootPkgData, ok := files["package.json"]
+	if !ok {
+		return nil, fmt.Errorf("missing root package.json")
+	}
+
+	rootPkg, err := ParsePackageJSON(rootPkgData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse root package.json: %w", err)
+	}
+
+	return p.getWorkspacePatterns(files, p.DetectWorkspaceType(files), rootPkg)
+}
+
+// MissingPackageDiagnostics reports the directories matched by the workspace
+// patterns that have no package.json. dirs are slash-separated paths relative
+// to the workspace root, such as every directory of the workspace on disk.
+// Directories inside a package or containing one are not reported, so "**"
+// patterns only report directories without any package.
+func (p *Parser) MissingPackageDiagnostics(files map[string][]byte, dirs []string) ([]types.Diagnostic, error) {
+	patterns, err := p.WorkspacePatterns(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var packageDirs []string
+	for filePath := range files {
+		filePath = filepath.ToSlash(filePath)
+		if dir := filepath.ToSlash(filepath.Dir(filePath)); filepath.Base(filePath) == "package.json" && dir != "." {
+			packageDirs = append(packageDirs, dir)
+		}
+	}
+
+	candidates := FilterPaths(dirs, patterns)
+	sort.Strings(candidates)
+	var diagnostics []types.Diagnostic
+	for _, dir := range candidates {
+		related := false
+		for _, pkgDir := range packageDirs {
+			if dir == pkgDir || strings.HasPrefix(dir, pkgDir+"/") || strings.HasPrefix(pkgDir, dir+"/") {
+				related = true
+				break
+			}
+		}
+		if related {
+			continue
+		}
+		diagnostics = append(diagnostics, types.Diagnostic{
+			File:    dir,
+			Kind:    types.DiagnosticMissingPackageJSON,
+			Message: "directory matches a workspace pattern but has no package.json",
+		})
+	}
+	return diagnostics, nil
+}
+
 // getWorkspacePatterns extracts workspace patterns based on workspace type.
 func (p *Parser) getWorkspacePatterns(files map[string][]byte, wsType types.WorkspaceType, rootPkg *PackageJSON) ([]string, error) {
 	switch wsType {
@@ -72,6 +72,54 @@ func TestDetectWorkspaceType(t *testing.T) {
 	}
 }
 
+func TestWorkspacePatterns(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string][]byte
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "pnpm patterns without quotes",
+			files: map[string][]byte{
+				"pnpm-workspace.yaml": []byte("packages:\n  - 'apps/*'\n  - \"packages/*\"\n"),
+				"package.json":        []byte(`{"name": "root"}`),
+			},
+			want: []string{"apps/*", "packages/*"},
+		},
+		{
+			name: "npm workspaces from package.json",
+			files: map[string][]byte{
+				"package-lock.json": []byte(`{}`),
+				"package.json":      []byte(`{"name": "root", "workspaces": ["libs/*"]}`),
+			},
+			want: []string{"libs/*"},
+		},
+		{
+			name:    "missing root package.json",
+			files:   map[string][]byte{"pnpm-workspace.yaml": []byte("packages: []")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewParser("/workspace").WorkspacePatterns(tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WorkspacePatterns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("WorkspacePatterns() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("WorkspacePatterns()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
 func TestParseNpmWorkspace(t *testing.T) {
 	files := map[string][]byte{
 		"package.json": []byte(`{
@@ -227,3 +275,123 @@ func TestParseInvalidWorkspace(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDiagnostics(t *testing.T) {
+	files := map[string][]byte{
+		"package.json": []byte(`{
+			"name": "monorepo-root",
+			"workspaces": ["packages/*", "tools/*", "!packages/deprecated-*"]
+		}`),
+		"package-lock.json":                    []byte(`{}`),
+		"packages/app/package.json":            []byte(`{"name": "@mono/app", "version": "1.0.0"}`),
+		"packages/app-copy/package.json":       []byte(`{"name": "@mono/app", "version": "1.0.0"}`),
+		"packages/broken/package.json":         []byte(`{"name": "@mono/broken",}`),
+		"packages/nameless/package.json":       []byte(`{"version": "1.0.0"}`),
+		"packages/deprecated-old/package.json": []byte(`{"name": "@mono/deprecated-old"}`),
+		"apps/stray/package.json":              []byte(`{"name": "@mono/stray"}`),
+	}
+
+	p := NewParser("/workspace")
+	result, err := p.Parse(files)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(result.Packages) != 1 {
+		t.Errorf("Packages count = %d, want 1", len(result.Packages))
+	}
+	if pkg := result.Packages["@mono/app"]; pkg == nil || pkg.Path != "packages/app" {
+		t.Errorf("@mono/app = %+v, want the package at packages/app", pkg)
+	}
+
+	want := []struct {
+		file string
+		kind types.DiagnosticKind
+	}{
+		{"apps/stray/package.json", types.DiagnosticUnmatchedPackage},
+		{"package.json", types.DiagnosticUnmatchedPattern},
+		{"packages/app-copy/package.json", types.DiagnosticDuplicateName},
+		{"packages/broken/package.json", types.DiagnosticInvalidPackageJSON},
+		{"packages/nameless/package.json", types.DiagnosticMissingName},
+	}
+	if len(result.Diagnostics) != len(want) {
+		t.Fatalf("Diagnostics = %+v, want %d", result.Diagnostics, len(want))
+	}
+	for i, w := range want {
+		got := result.Diagnostics[i]
+		if got.File != w.file || got.Kind != w.kind {
+			t.Errorf("Diagnostics[%d] = %s %s, want %s %s", i, got.File, got.Kind, w.file, w.kind)
+		}
+		if got.Message == "" {
+			t.Errorf("Diagnostics[%d] has no message", i)
+		}
+	}
+}
+
+func TestParseDiagnosticsPnpmPattern(t *testing.T) {
+	files := map[string][]byte{
+		"package.json":              []byte(`{"name": "monorepo-root"}`),
+		"pnpm-workspace.yaml":       []byte("packages:\n  - 'packages/*'\n  - 'libs/*'\n"),
+		"packages/app/package.json": []byte(`{"name": "@mono/app"}`),
+	}
+
+	p := NewParser("/workspace")
+	result, err := p.Parse(files)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(result.Diagnostics) != 1 {
+		t.Fatalf("Diagnostics = %+v, want 1", result.Diagnostics)
+	}
+	if d := result.Diagnostics[0]; d.File != "pnpm-workspace.yaml" || d.Kind != types.DiagnosticUnmatchedPattern {
+		t.Errorf("Diagnostic = %+v, want unmatched libs/* in pnpm-workspace.yaml", d)
+	}
+}
+
+func TestParseNoDiagnostics(t *testing.T) {
+	files := map[string][]byte{
+		"package.json":              []byte(`{"name": "monorepo-root", "workspaces": ["packages/*"]}`),
+		"packages/app/package.json": []byte(`{"name": "@mono/app"}`),
+	}
+
+	p := NewParser("/workspace")
+	result, err := p.Parse(files)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if result.Diagnostics != nil {
+		t.Errorf("Diagnostics = %+v, want none", result.Diagnostics)
+	}
+}
+
+func TestMissingPackageDiagnostics(t *testing.T) {
+	files := map[string][]byte{
+		"package.json":                 []byte(`{"name": "monorepo-root", "workspaces": ["packages/*", "libs/**"]}`),
+		"packages/app/package.json":    []byte(`{"name": "@mono/app"}`),
+		"libs/group/util/package.json": []byte(`{"name": "@mono/util"}`),
+		"packages/app/src/index.ts":    []byte(`export {}`),
+		"packages/notes/README.md":     []byte(`# Notes`),
+		"libs/group/util/src/index.ts": []byte(`export {}`),
+	}
+	dirs := []string{
+		"packages", "packages/app", "packages/app/src", "packages/notes",
+		"libs", "libs/group", "libs/group/util", "libs/group/util/src", "libs/empty",
+		"docs",
+	}
+
+	p := NewParser("/workspace")
+	diagnostics, err := p.MissingPackageDiagnostics(files, dirs)
+	if err != nil {
+		t.Fatalf("MissingPackageDiagnostics() error = %v", err)
+	}
+
+	want := []string{"libs/empty", "packages/notes"}
+	if len(diagnostics) != len(want) {
+		t.Fatalf("diagnostics = %+v, want %v", diagnostics, want)
+	}
+	for i, dir := range want {
+		if diagnostics[i].File != dir || diagnostics[i].Kind != types.DiagnosticMissingPackageJSON {
+			t.Errorf("diagnostics[%d] = %+v, want missing package.json in %s", i, diagnostics[i], dir)
+		}
+	}
+}
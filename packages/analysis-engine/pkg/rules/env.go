@@ -0,0 +1,150 @@
+package rules
+
+import (
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// ========================================
+// Environments
+// ========================================
+
+// Env maps attribute names to values: string, float64, bool, []string or a
+// nested Env for objects such as a dependency's from and to packages.
+type Env map[string]interface{}
+
+// Graph describes the workspace. It is available as "graph" in every scope.
+type Graph struct {
+	Packages      int    // Analyzed (non-excluded) packages
+	Edges         int    // Dependencies between analyzed packages
+	Cycles        int    // Circular dependencies
+	WorkspaceType string // npm, yarn or pnpm
+}
+
+// Package describes a workspace package.
+type Package struct {
+	Name                 string
+	Version              string
+	Path                 string   // Relative to the workspace root
+	Layer                string   // First matching layer, or ""
+	Owners               []string // From CODEOWNERS and owner rules
+	Dependencies         []string // Workspace packages it depends on, of any dependency type
+	Dependents           []string // Workspace packages that depend on it
+	ExternalDependencies []string // Names of its external dependencies, of any dependency type
+	InCycle              bool     // Part of a circular dependency
+}
+
+// graphAttrs are the attributes of a Graph.
+var graphAttrs = &valueType{kind: kindRecord, fields: map[string]*valueType{
+	"packages":      numberType,
+	"edges":         numberType,
+	"cycles":        numberType,
+	"workspaceType": stringType,
+}}
+
+// packageAttrs are the attributes of a Package.
+var packageAttrs = &valueType{kind: kindRecord, fields: map[string]*valueType{
+	"name":                 stringType,
+	"version":              stringType,
+	"path":                 stringType,
+	"layer":                stringType,
+	"owners":               listType,
+	"dependencies":         listType,
+	"dependents":           listType,
+	"externalDependencies": listType,
+	"inCycle":              boolType,
+}}
+
+// scopeTypes are the attributes in scope of each rule scope.
+var scopeTypes = map[types.CustomRuleScope]*valueType{
+	// The package's attributes are in scope directly
+	types.CustomRuleScopePackage: withGraph(packageAttrs.fields),
+	types.CustomRuleScopeDependency: withGraph(map[string]*valueType{
+		"from":         packageAttrs,
+		"to":           packageAttrs,
+		"type":         stringType, // production, development, peer or optional
+		"versionRange": stringType,
+	}),
+	types.CustomRuleScopeExternal: withGraph(map[string]*valueType{
+		"package": packageAttrs, // The depending workspace package
+		"name":    stringType,
+		"version": stringType, // Version range
+		"type":    stringType,
+	}),
+	types.CustomRuleScopeWorkspace: withGraph(nil),
+}
+
+// withGraph returns a record of fields and the graph attribute.
+func withGraph(fields map[string]*valueType) *valueType {
+	t := &valueType{kind: kindRecord, fields: map[string]*valueType{"graph": graphAttrs}}
+	for name, ft := range fields {
+		t.fields[name] = ft
+	}
+	return t
+}
+
+// PackageEnv returns the environment of a package scope rule.
+func PackageEnv(g Graph, p *Package) Env {
+	env := p.env()
+	env["graph"] = g.env()
+	return env
+}
+
+// DependencyEnv returns the environment of a dependency scope rule for the
+// dependency of from on to.
+func DependencyEnv(g Graph, from, to *Package, depType types.DependencyType, versionRange string) Env {
+	return Env{
+		"graph":        g.env(),
+		"from":         from.env(),
+		"to":           to.env(),
+		"type":         string(depType),
+		"versionRange": versionRange,
+	}
+}
+
+// ExternalEnv returns the environment of an external scope rule for the
+// external dependency name of p.
+func ExternalEnv(g Graph, p *Package, name, version string, depType types.DependencyType) Env {
+	return Env{
+		"graph":   g.env(),
+		"package": p.env(),
+		"name":    name,
+		"version": version,
+		"type":    string(depType),
+	}
+}
+
+// WorkspaceEnv returns the environment of a workspace scope rule.
+func WorkspaceEnv(g Graph) Env {
+	return Env{"graph": g.env()}
+}
+
+func (g Graph) env() Env {
+	return Env{
+		"packages":      float64(g.Packages),
+		"edges":         float64(g.Edges),
+		"cycles":        float64(g.Cycles),
+		"workspaceType": g.WorkspaceType,
+	}
+}
+
+func (p *Package) env() Env {
+	return Env{
+		"name":                 p.Name,
+		"version":              p.Version,
+		"path":                 p.Path,
+		"layer":                p.Layer,
+		"owners":               list(p.Owners),
+		"dependencies":         list(p.Dependencies),
+		"dependents":           list(p.Dependents),
+		"externalDependencies": list(p.ExternalDependencies),
+		"inCycle":              p.InCycle,
+	}
+}
+
+// list returns s, or an empty list for nil
+func list(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
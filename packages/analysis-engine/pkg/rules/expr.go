@@ -0,0 +1,596 @@
+// Package rules implements the expression language of custom rules.
+//
+// A rule's expression is evaluated against the attributes of a package, a
+// dependency between workspace packages, an external dependency or the
+// workspace, depending on the rule's scope:
+//
+//	len(dependencies) > 15
+//	to.path matches "apps/**"
+//	name matches "moment" && package.path matches "libs/*"
+//
+// Values are strings, numbers, booleans and lists of strings. Operators, from
+// lowest to highest precedence:
+//
+//	||
+//	&&
+//	!
+//	==  !=  <  <=  >  >=  matches  in
+//
+// "matches" takes a string literal holding a glob or a "regex:" pattern and
+// is true when the string, or any element of a list, matches it. "in" tests
+// list membership, or substrings when both operands are strings. len()
+// returns the length of a list or string. Expressions are type checked when
+// they are compiled, so a compiled expression cannot fail.
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/parser"
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+// ========================================
+// Compiled Expressions
+// ========================================
+
+// Expr is a compiled, type checked expression.
+type Expr struct {
+	source string
+	eval   evalFunc
+}
+
+// evalFunc computes the value of an expression node.
+type evalFunc func(env Env) interface{}
+
+// Compile parses an expression and checks it against the attributes of the
+// scope. The expression must be a boolean.
+func Compile(source string, scope types.CustomRuleScope) (*Expr, error) {
+	attrs, ok := scopeTypes[scope]
+	if !ok {
+		return nil, fmt.Errorf("unknown scope %q (expected package, dependency, external or workspace)", scope)
+	}
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &compiler{tokens: tokens, attrs: attrs}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, errorAt(tok.pos, "unexpected %s", tok)
+	}
+	if node.typ.kind != kindBool {
+		return nil, errorAt(1, "expression must be a boolean, not a %s", node.typ)
+	}
+	return &Expr{source: source, eval: node.eval}, nil
+}
+
+// Eval evaluates the expression against an environment built for the scope
+// it was compiled for.
+func (e *Expr) Eval(env Env) bool {
+	return e.eval(env).(bool)
+}
+
+// String returns the source of the expression.
+func (e *Expr) String() string {
+	return e.source
+}
+
+// Error is a syntax or type error in an expression.
+type Error struct {
+	Column  int    // 1-based column of the offending token
+	Message string // Description of the problem
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Message)
+}
+
+// errorAt creates an Error at a 1-based column.
+func errorAt(column int, format string, args ...interface{}) *Error {
+	return &Error{Column: column, Message: fmt.Sprintf(format, args...)}
+}
+
+// ========================================
+// Lexer
+// ========================================
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+)
+
+// token is a lexical token with its 1-based column.
+type token struct {
+	kind tokenKind
+	text string // Unquoted for strings
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return strconv.Quote(t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// operators lists the operator tokens, longest first.
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", "[", "]", ",", "."}
+
+// lex splits an expression into tokens.
+func lex(src string) ([]token, error) {
+	var tokens []token
+	runes := []rune(src)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				b.WriteRune(runes[j])
+			}
+			if j == len(runes) {
+				return nil, errorAt(pos, "unterminated string")
+			}
+			tokens = append(tokens, token{kind: tokString, text: b.String(), pos: pos})
+			i = j + 1
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: string(runes[i:j]), pos: pos})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: string(runes[i:j]), pos: pos})
+			i = j
+		default:
+			op := ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(string(runes[i:]), candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, errorAt(pos, "unexpected character %q", r)
+			}
+			tokens = append(tokens, token{kind: tokOp, text: op, pos: pos})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(runes) + 1}), nil
+}
+
+// ========================================
+// Parser and Type Checker
+// ========================================
+
+// node is a type checked expression node.
+type node struct {
+	typ     *valueType
+	eval    evalFunc
+	literal *string // Value of string literals, for matches
+	pos     int
+}
+
+// compiler parses tokens into type checked nodes by recursive descent.
+type compiler struct {
+	tokens []token
+	i      int
+	attrs  *valueType // Record of the attributes in scope
+}
+
+func (p *compiler) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *compiler) next() token {
+	tok := p.tokens[p.i]
+	if tok.kind != tokEOF {
+		p.i++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is the operator op.
+func (p *compiler) accept(op string) bool {
+	if tok := p.peek(); tok.kind == tokOp && tok.text == op {
+		p.i++
+		return true
+	}
+	return false
+}
+
+// expect consumes the operator op or fails.
+func (p *compiler) expect(op string) error {
+	if !p.accept(op) {
+		tok := p.peek()
+		return errorAt(tok.pos, "expected %q, found %s", op, tok)
+	}
+	return nil
+}
+
+// parseOr parses: and { "||" and }
+func (p *compiler) parseOr() (*node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().text == "||" && p.peek().kind == tokOp {
+		op := p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if err := requireKind(op, kindBool, left, right); err != nil {
+			return nil, err
+		}
+		l, r := left.eval, right.eval
+		left = &node{typ: boolType, pos: left.pos, eval: func(env Env) interface{} {
+			return l(env).(bool) || r(env).(bool)
+		}}
+	}
+	return left, nil
+}
+
+// parseAnd parses: unary { "&&" unary }
+func (p *compiler) parseAnd() (*node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().text == "&&" && p.peek().kind == tokOp {
+		op := p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if err := requireKind(op, kindBool, left, right); err != nil {
+			return nil, err
+		}
+		l, r := left.eval, right.eval
+		left = &node{typ: boolType, pos: left.pos, eval: func(env Env) interface{} {
+			return l(env).(bool) && r(env).(bool)
+		}}
+	}
+	return left, nil
+}
+
+// parseUnary parses: "!" unary | comparison
+func (p *compiler) parseUnary() (*node, error) {
+	if tok := p.peek(); tok.kind == tokOp && tok.text == "!" {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if err := requireKind(tok, kindBool, operand); err != nil {
+			return nil, err
+		}
+		eval := operand.eval
+		return &node{typ: boolType, pos: tok.pos, eval: func(env Env) interface{} {
+			return !eval(env).(bool)
+		}}, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison parses: primary [ op primary ]
+func (p *compiler) parseComparison() (*node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	op := p.peek()
+	switch {
+	case op.kind == tokOp && (op.text == "==" || op.text == "!=" || op.text == "<" || op.text == "<=" || op.text == ">" || op.text == ">="):
+	case op.kind == tokIdent && (op.text == "matches" || op.text == "in"):
+	default:
+		return left, nil
+	}
+	p.next()
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.text {
+	case "matches":
+		return matchesNode(op, left, right)
+	case "in":
+		return inNode(op, left, right)
+	case "==", "!=":
+		if left.typ.kind != right.typ.kind || left.typ.kind == kindList || left.typ.kind == kindRecord {
+			return nil, errorAt(op.pos, "cannot compare %s %s %s", left.typ, op.text, right.typ)
+		}
+		l, r, negate := left.eval, right.eval, op.text == "!="
+		return &node{typ: boolType, pos: left.pos, eval: func(env Env) interface{} {
+			return (l(env) == r(env)) != negate
+		}}, nil
+	default:
+		if err := requireKind(op, kindNumber, left, right); err != nil {
+			return nil, err
+		}
+		l, r, cmp := left.eval, right.eval, op.text
+		return &node{typ: boolType, pos: left.pos, eval: func(env Env) interface{} {
+			a, b := l(env).(float64), r(env).(float64)
+			switch cmp {
+			case "<":
+				return a < b
+			case "<=":
+				return a <= b
+			case ">":
+				return a > b
+			default:
+				return a >= b
+			}
+		}}, nil
+	}
+}
+
+// matchesNode compiles "left matches pattern" for a string or list left side.
+func matchesNode(op token, left, right *node) (*node, error) {
+	if right.literal == nil {
+		return nil, errorAt(right.pos, "the pattern of matches must be a string literal")
+	}
+	var match func(string) bool
+	if expr, ok := strings.CutPrefix(*right.literal, "regex:"); ok {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, errorAt(right.pos, "invalid regular expression: %v", err)
+		}
+		match = re.MatchString
+	} else {
+		pattern := *right.literal
+		match = func(s string) bool { return parser.MatchPattern(pattern, s) }
+	}
+
+	l := left.eval
+	switch left.typ.kind {
+	case kindString:
+		return &node{typ: boolType, pos: left.pos, eval: func(env Env) interface{} {
+			return match(l(env).(string))
+		}}, nil
+	case kindList:
+		return &node{typ: boolType, pos: left.pos, eval: func(env Env) interface{} {
+			for _, s := range l(env).([]string) {
+				if match(s) {
+					return true
+				}
+			}
+			return false
+		}}, nil
+	}
+	return nil, errorAt(op.pos, "matches needs a string or list, not a %s", left.typ)
+}
+
+// inNode compiles "left in right": list membership or a substring test.
+func inNode(op token, left, right *node) (*node, error) {
+	if left.typ.kind != kindString || (right.typ.kind != kindList && right.typ.kind != kindString) {
+		return nil, errorAt(op.pos, "cannot test %s in %s", left.typ, right.typ)
+	}
+	l, r := left.eval, right.eval
+	if right.typ.kind == kindString {
+		return &node{typ: boolType, pos: left.pos, eval: func(env Env) interface{} {
+			return strings.Contains(r(env).(string), l(env).(string))
+		}}, nil
+	}
+	return &node{typ: boolType, pos: left.pos, eval: func(env Env) interface{} {
+		s := l(env).(string)
+		for _, item := range r(env).([]string) {
+			if item == s {
+				return true
+			}
+		}
+		return false
+	}}, nil
+}
+
+// parsePrimary parses literals, attributes, function calls, lists and
+// parenthesized expressions.
+func (p *compiler) parsePrimary() (*node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokString:
+		value := tok.text
+		return &node{typ: stringType, pos: tok.pos, literal: &value, eval: func(Env) interface{} { return value }}, nil
+	case tokNumber:
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, errorAt(tok.pos, "invalid number %q", tok.text)
+		}
+		return &node{typ: numberType, pos: tok.pos, eval: func(Env) interface{} { return value }}, nil
+	case tokIdent:
+		switch tok.text {
+		case "true", "false":
+			value := tok.text == "true"
+			return &node{typ: boolType, pos: tok.pos, eval: func(Env) interface{} { return value }}, nil
+		case "matches", "in":
+			return nil, errorAt(tok.pos, "unexpected %s", tok)
+		}
+		if p.accept("(") {
+			return p.parseCall(tok)
+		}
+		return p.parseAttribute(tok)
+	case tokOp:
+		switch tok.text {
+		case "(":
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return inner, p.expect(")")
+		case "[":
+			return p.parseList(tok)
+		}
+	}
+	return nil, errorAt(tok.pos, "unexpected %s", tok)
+}
+
+// parseAttribute parses an attribute and its field selectors, e.g. to.path.
+func (p *compiler) parseAttribute(tok token) (*node, error) {
+	typ, err := p.attrs.field(tok)
+	if err != nil {
+		return nil, err
+	}
+	path := []string{tok.text}
+	for p.accept(".") {
+		field := p.next()
+		if field.kind != tokIdent {
+			return nil, errorAt(field.pos, "expected an attribute name after \".\", found %s", field)
+		}
+		if typ, err = typ.field(field); err != nil {
+			return nil, err
+		}
+		path = append(path, field.text)
+	}
+	return &node{typ: typ, pos: tok.pos, eval: func(env Env) interface{} {
+		var v interface{} = env
+		for _, name := range path {
+			v = v.(Env)[name]
+		}
+		return v
+	}}, nil
+}
+
+// parseCall parses the arguments of a function call. The only function is
+// len(list or string).
+func (p *compiler) parseCall(fn token) (*node, error) {
+	if fn.text != "len" {
+		return nil, errorAt(fn.pos, "unknown function %q (expected len)", fn.text)
+	}
+	arg, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	eval := arg.eval
+	switch arg.typ.kind {
+	case kindList:
+		return &node{typ: numberType, pos: fn.pos, eval: func(env Env) interface{} {
+			return float64(len(eval(env).([]string)))
+		}}, nil
+	case kindString:
+		return &node{typ: numberType, pos: fn.pos, eval: func(env Env) interface{} {
+			return float64(len([]rune(eval(env).(string))))
+		}}, nil
+	}
+	return nil, errorAt(arg.pos, "len needs a list or string, not a %s", arg.typ)
+}
+
+// parseList parses a list literal of strings after its "[".
+func (p *compiler) parseList(open token) (*node, error) {
+	var items []string
+	for !p.accept("]") {
+		if len(items) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		tok := p.next()
+		if tok.kind != tokString {
+			return nil, errorAt(tok.pos, "list elements must be string literals, found %s", tok)
+		}
+		items = append(items, tok.text)
+	}
+	if items == nil {
+		items = []string{}
+	}
+	return &node{typ: listType, pos: open.pos, eval: func(Env) interface{} { return items }}, nil
+}
+
+// requireKind checks that all operands of op have the given kind.
+func requireKind(op token, k kind, operands ...*node) error {
+	for _, operand := range operands {
+		if operand.typ.kind != k {
+			return errorAt(operand.pos, "%s needs a %s, not a %s", op.text, kindNames[k], operand.typ)
+		}
+	}
+	return nil
+}
+
+// ========================================
+// Value Types
+// ========================================
+
+type kind int
+
+const (
+	kindString kind = iota
+	kindNumber
+	kindBool
+	kindList
+	kindRecord
+)
+
+var kindNames = map[kind]string{
+	kindString: "string",
+	kindNumber: "number",
+	kindBool:   "boolean",
+	kindList:   "list",
+	kindRecord: "object",
+}
+
+// valueType is the static type of an expression. Records list their fields.
+type valueType struct {
+	kind   kind
+	fields map[string]*valueType
+}
+
+var (
+	stringType = &valueType{kind: kindString}
+	numberType = &valueType{kind: kindNumber}
+	boolType   = &valueType{kind: kindBool}
+	listType   = &valueType{kind: kindList}
+)
+
+func (t *valueType) String() string {
+	return kindNames[t.kind]
+}
+
+// field returns the type of a record field, or an error listing the fields.
+func (t *valueType) field(name token) (*valueType, error) {
+	if t.kind != kindRecord {
+		return nil, errorAt(name.pos, "%s has no attribute %q", t, name.text)
+	}
+	if ft, ok := t.fields[name.text]; ok {
+		return ft, nil
+	}
+	names := make([]string, 0, len(t.fields))
+	for n := range t.fields {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return nil, errorAt(name.pos, "unknown attribute %q (expected one of %s)", name.text, strings.Join(names, ", "))
+}
@@ -0,0 +1,176 @@
+package rules
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/j620656786206/MonoGuard/packages/analysis-engine/pkg/types"
+)
+
+var testGraph = Graph{Packages: 3, Edges: 2, Cycles: 1, WorkspaceType: "pnpm"}
+
+var (
+	webPackage = &Package{
+		Name:                 "@mono/web",
+		Version:              "1.0.0",
+		Path:                 "apps/web",
+		Layer:                "apps",
+		Owners:               []string{"@org/web"},
+		Dependencies:         []string{"@mono/ui", "@mono/utils"},
+		ExternalDependencies: []string{"moment", "react"},
+	}
+	uiPackage = &Package{
+		Name:       "@mono/ui",
+		Version:    "2.1.0",
+		Path:       "libs/ui",
+		Dependents: []string{"@mono/web"},
+		InCycle:    true,
+	}
+)
+
+func TestEval_Package(t *testing.T) {
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`len(dependencies) > 1`, true},
+		{`len(dependencies) > 15`, false},
+		{`path matches "apps/*"`, true},
+		{`path matches "apps/**" && layer == "apps"`, true},
+		{`name matches "regex:^@mono/(web|api)$"`, true},
+		{`name == "@mono/ui" || version != "1.0.0"`, false},
+		{`!(path matches "libs/*")`, true},
+		{`"@org/web" in owners`, true},
+		{`"web" in name`, true},
+		{`name in ["@mono/api", "@mono/admin"]`, false},
+		{`externalDependencies matches "mom*"`, true},
+		{`inCycle`, false},
+		{`len(dependents) == 0 && graph.packages >= 3`, true},
+		{`graph.workspaceType == 'pnpm' && graph.cycles < 1.5`, true},
+		{`len(name) == 9`, true},
+	}
+	for _, tt := range tests {
+		expr, err := Compile(tt.expr, types.CustomRuleScopePackage)
+		if err != nil {
+			t.Errorf("Compile(%q) error = %v", tt.expr, err)
+			continue
+		}
+		if got := expr.Eval(PackageEnv(testGraph, webPackage)); got != tt.want {
+			t.Errorf("Eval(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestEval_OtherScopes(t *testing.T) {
+	tests := []struct {
+		scope types.CustomRuleScope
+		expr  string
+		env   Env
+		want  bool
+	}{
+		{types.CustomRuleScopeDependency, `to.path matches "libs/*" && from.layer == "apps"`,
+			DependencyEnv(testGraph, webPackage, uiPackage, types.DependencyTypeProduction, "workspace:*"), true},
+		{types.CustomRuleScopeDependency, `to.inCycle && type == "development"`,
+			DependencyEnv(testGraph, webPackage, uiPackage, types.DependencyTypeProduction, "workspace:*"), false},
+		{types.CustomRuleScopeExternal, `name matches "moment" && package.path matches "apps/*"`,
+			ExternalEnv(testGraph, webPackage, "moment", "^2.29.0", types.DependencyTypeProduction), true},
+		{types.CustomRuleScopeExternal, `version matches "^1*"`,
+			ExternalEnv(testGraph, webPackage, "moment", "^2.29.0", types.DependencyTypeProduction), false},
+		{types.CustomRuleScopeWorkspace, `graph.cycles > 0`, WorkspaceEnv(testGraph), true},
+	}
+	for _, tt := range tests {
+		expr, err := Compile(tt.expr, tt.scope)
+		if err != nil {
+			t.Errorf("Compile(%q) error = %v", tt.expr, err)
+			continue
+		}
+		if got := expr.Eval(tt.env); got != tt.want {
+			t.Errorf("Eval(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	tests := []struct {
+		scope  types.CustomRuleScope
+		expr   string
+		column int
+		want   string
+	}{
+		{types.CustomRuleScopePackage, `dependencies > 15`, 1, "> needs a number, not a list"},
+		{types.CustomRuleScopePackage, `len(dependencies)`, 1, "expression must be a boolean, not a number"},
+		{types.CustomRuleScopePackage, `size > 3`, 1, `unknown attribute "size" (expected one of dependencies, dependents,`},
+		{types.CustomRuleScopePackage, `name matches pattern`, 14, `unknown attribute "pattern"`},
+		{types.CustomRuleScopePackage, `name matches path`, 14, "the pattern of matches must be a string literal"},
+		{types.CustomRuleScopePackage, `name matches "regex:("`, 14, "invalid regular expression"},
+		{types.CustomRuleScopePackage, `name == 3`, 6, "cannot compare string == number"},
+		{types.CustomRuleScopePackage, `(inCycle`, 9, `expected ")", found end of expression`},
+		{types.CustomRuleScopePackage, `inCycle inCycle`, 9, `unexpected "inCycle"`},
+		{types.CustomRuleScopePackage, `name == "web`, 9, "unterminated string"},
+		{types.CustomRuleScopePackage, `name = "web"`, 6, `unexpected character '='`},
+		{types.CustomRuleScopePackage, `count(dependencies) > 1`, 1, `unknown function "count"`},
+		{types.CustomRuleScopePackage, `name in [path]`, 10, "list elements must be string literals"},
+		{types.CustomRuleScopePackage, `graph.nodes > 1`, 7, `unknown attribute "nodes"`},
+		{types.CustomRuleScopeDependency, `path matches "apps/*"`, 1, `unknown attribute "path"`},
+		{types.CustomRuleScopeExternal, `package.name.length > 1`, 14, `string has no attribute "length"`},
+	}
+	for _, tt := range tests {
+		_, err := Compile(tt.expr, tt.scope)
+		var exprErr *Error
+		if !errors.As(err, &exprErr) {
+			t.Errorf("Compile(%q) error = %v, want an expression error", tt.expr, err)
+			continue
+		}
+		if exprErr.Column != tt.column || !strings.HasPrefix(exprErr.Message, tt.want) {
+			t.Errorf("Compile(%q) error = %v, want column %d: %s", tt.expr, err, tt.column, tt.want)
+		}
+	}
+
+	if _, err := Compile(`true`, "module"); err == nil || !strings.Contains(err.Error(), `unknown scope "module"`) {
+		t.Errorf("Compile() with unknown scope error = %v", err)
+	}
+}
+
+// TestScopeTypes verifies every attribute the compiler accepts is set by the
+// environment builders
+func TestScopeTypes(t *testing.T) {
+	envs := map[types.CustomRuleScope]Env{
+		types.CustomRuleScopePackage:    PackageEnv(testGraph, &Package{}),
+		types.CustomRuleScopeDependency: DependencyEnv(testGraph, &Package{}, &Package{}, types.DependencyTypePeer, ""),
+		types.CustomRuleScopeExternal:   ExternalEnv(testGraph, &Package{}, "", "", types.DependencyTypePeer),
+		types.CustomRuleScopeWorkspace:  WorkspaceEnv(testGraph),
+	}
+	var check func(path string, typ *valueType, value interface{})
+	check = func(path string, typ *valueType, value interface{}) {
+		ok := false
+		switch typ.kind {
+		case kindString:
+			_, ok = value.(string)
+		case kindNumber:
+			_, ok = value.(float64)
+		case kindBool:
+			_, ok = value.(bool)
+		case kindList:
+			var list []string
+			list, ok = value.([]string)
+			ok = ok && list != nil
+		case kindRecord:
+			var env Env
+			if env, ok = value.(Env); ok {
+				for name, ft := range typ.fields {
+					check(path+"."+name, ft, env[name])
+				}
+				if len(env) != len(typ.fields) {
+					t.Errorf("%s has %d attributes, want %d", path, len(env), len(typ.fields))
+				}
+			}
+		}
+		if !ok {
+			t.Errorf("%s = %#v, want a %s", path, value, typ)
+		}
+	}
+	for scope, typ := range scopeTypes {
+		check(string(scope), typ, envs[scope])
+	}
+}
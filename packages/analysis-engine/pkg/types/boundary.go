@@ -0,0 +1,31 @@
+// Package types defines Go types that match TypeScript definitions in @monoguard/types.
+// This file contains architecture layer boundary types.
+package types
+
+// ========================================
+// Layer Boundary Types
+// ========================================
+
+// LayerDefinition groups workspace packages into an architectural layer.
+// A package belongs to the first layer whose Pattern matches its path.
+type LayerDefinition struct {
+	// Name identifies the layer (e.g., "apps", "libs")
+	Name string `json:"name"`
+
+	// Pattern is a glob matched against package paths relative to the workspace root (e.g., "apps/*")
+	Pattern string `json:"pattern"`
+
+	// CanDependOn lists the other layers this layer may depend on.
+	// Dependencies within the same layer are always allowed.
+	CanDependOn []string `json:"canDependOn,omitempty"`
+}
+
+// BoundaryViolation represents a dependency that crosses a disallowed layer boundary.
+type BoundaryViolation struct {
+	From           string         `json:"from"`           // Dependent package name
+	To             string         `json:"to"`             // Dependency package name
+	FromLayer      string         `json:"fromLayer"`      // Layer of the dependent package
+	ToLayer        string         `json:"toLayer"`        // Layer of the dependency package
+	DependencyType DependencyType `json:"dependencyType"` // production, development, peer, optional
+	Message        string         `json:"message"`        // Human-readable description
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLayerDefinition_JSONSerialization(t *testing.T) {
+	layer := LayerDefinition{Name: "apps", Pattern: "apps/*", CanDependOn: []string{"libs"}}
+
+	data, err := json.Marshal(layer)
+	if err != nil {
+		t.Fatalf("Failed to marshal LayerDefinition: %v", err)
+	}
+
+	jsonStr := string(data)
+	for _, key := range []string{`"name"`, `"pattern"`, `"canDependOn"`} {
+		if !strings.Contains(jsonStr, key) {
+			t.Errorf("Expected JSON to contain key %s. JSON: %s", key, jsonStr)
+		}
+	}
+
+	var decoded LayerDefinition
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal LayerDefinition: %v", err)
+	}
+	if decoded.Name != "apps" || len(decoded.CanDependOn) != 1 {
+		t.Errorf("Decoded layer mismatch: %+v", decoded)
+	}
+}
+
+func TestBoundaryViolation_JSONSerialization(t *testing.T) {
+	violation := &BoundaryViolation{
+		From:           "@mono/ui",
+		To:             "@mono/web",
+		FromLayer:      "libs",
+		ToLayer:        "apps",
+		DependencyType: DependencyTypeProduction,
+		Message:        "@mono/ui must not depend on @mono/web",
+	}
+
+	data, err := json.Marshal(violation)
+	if err != nil {
+		t.Fatalf("Failed to marshal BoundaryViolation: %v", err)
+	}
+
+	jsonStr := string(data)
+	for _, key := range []string{`"from"`, `"to"`, `"fromLayer"`, `"toLayer"`, `"dependencyType"`, `"message"`} {
+		if !strings.Contains(jsonStr, key) {
+			t.Errorf("Expected JSON to contain key %s. JSON: %s", key, jsonStr)
+		}
+	}
+}
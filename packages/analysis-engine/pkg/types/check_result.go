@@ -0,0 +1,74 @@
+// Package types defines Go types that match TypeScript definitions in @monoguard/types.
+// This file contains check (CI validation) result types.
+package types
+
+// ========================================
+// Check Result Types
+// ========================================
+
+// CheckResult represents validation-only output for CI/CD pipelines.
+// Matches @monoguard/types CheckResult interface.
+type CheckResult struct {
+	Passed      bool                `json:"passed"`             // False if any rule produced an error
+	Errors      []ValidationError   `json:"errors"`             // Rule violations configured as "error"
+	Warnings    []ValidationWarning `json:"warnings"`           // Rule violations configured as "warn"
+	HealthScore int                 `json:"healthScore"`        // Overall health score (0-100)
+	Packages    []string            `json:"packages,omitempty"` // Names of the checked (non-excluded) packages
+	Baseline    *BaselineStatus     `json:"baseline,omitempty"` // Set when known issues were filtered by a baseline file
+}
+
+// BaselineStatus describes how a baseline of known issues was applied to a check.
+// Matches @monoguard/types BaselineStatus interface.
+type BaselineStatus struct {
+	File  string   `json:"file"`  // Path of the baseline file
+	Known int      `json:"known"` // Issues found that are recorded in the baseline and did not fail the check
+	Fixed []string `json:"fixed"` // Baseline entries that no longer occur and can be removed
+}
+
+// ValidationError is a failure found during a check.
+// Matches @monoguard/types ValidationError interface.
+type ValidationError struct {
+	Code    string `json:"code"`              // UPPER_SNAKE_CASE error code
+	Message string `json:"message"`           // Human-readable description
+	File    string `json:"file,omitempty"`    // Related file path (relative to workspace root)
+	Line    int    `json:"line,omitempty"`    // 1-based line number in File
+	Package string `json:"package,omitempty"` // Workspace package the error belongs to
+}
+
+// ValidationWarning is a non-blocking issue found during a check.
+// Matches @monoguard/types ValidationWarning interface.
+type ValidationWarning struct {
+	Code    string `json:"code"`              // UPPER_SNAKE_CASE warning code
+	Message string `json:"message"`           // Human-readable description
+	File    string `json:"file,omitempty"`    // Related file path (relative to workspace root)
+	Line    int    `json:"line,omitempty"`    // 1-based line number in File
+	Package string `json:"package,omitempty"` // Workspace package the warning belongs to
+}
+
+// Check codes identify which rule produced a ValidationError or ValidationWarning.
+const (
+	// CheckCodeCircularDetected is reported for each circular dependency.
+	CheckCodeCircularDetected = "CIRCULAR_DETECTED"
+	// CheckCodeBoundaryViolation is reported for each layer boundary violation.
+	CheckCodeBoundaryViolation = "BOUNDARY_VIOLATION"
+	// CheckCodeVersionConflict is reported for each external dependency with conflicting versions.
+	CheckCodeVersionConflict = "VERSION_CONFLICT"
+	// CheckCodeLowHealthScore is reported when the health score is below the threshold.
+	CheckCodeLowHealthScore = "LOW_HEALTH_SCORE"
+	// CheckCodePluginFinding is reported for each finding of an external rule plugin.
+	CheckCodePluginFinding = "PLUGIN_FINDING"
+	// CheckCodePluginFailed is reported as a warning for each plugin that failed to run.
+	CheckCodePluginFailed = "PLUGIN_FAILED"
+	// CheckCodeCustomRuleViolation is reported for each violation of a custom rule.
+	CheckCodeCustomRuleViolation = "CUSTOM_RULE_VIOLATION"
+)
+
+// NewCheckResult creates a passing CheckResult with initialized slices.
+func NewCheckResult(healthScore int) *CheckResult {
+	return &CheckResult{
+		Passed:      true,
+		Errors:      []ValidationError{},
+		Warnings:    []ValidationWarning{},
+		HealthScore: healthScore,
+	}
+}
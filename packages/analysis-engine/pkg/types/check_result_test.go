@@ -0,0 +1,90 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCheckResultJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *CheckResult
+		wantKeys []string
+	}{
+		{
+			name:     "passed check with camelCase JSON",
+			input:    NewCheckResult(95),
+			wantKeys: []string{"passed", "errors", "warnings", "healthScore"},
+		},
+		{
+			name: "failed check with errors and warnings",
+			input: &CheckResult{
+				Passed: false,
+				Errors: []ValidationError{
+					{
+						Code:    CheckCodeCircularDetected,
+						Message: "Circular dependency found: A -> B -> A",
+						File:    "packages/a/src/index.ts",
+						Line:    3,
+					},
+				},
+				Warnings: []ValidationWarning{
+					{Code: CheckCodeLowHealthScore, Message: "Health score below threshold"},
+				},
+				HealthScore: 45,
+			},
+			wantKeys: []string{"passed", "errors", "warnings", "healthScore"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonBytes, err := json.Marshal(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to marshal CheckResult: %v", err)
+			}
+
+			var parsed map[string]interface{}
+			if err := json.Unmarshal(jsonBytes, &parsed); err != nil {
+				t.Fatalf("Failed to unmarshal JSON: %v", err)
+			}
+
+			for _, key := range tt.wantKeys {
+				if _, ok := parsed[key]; !ok {
+					t.Errorf("Missing expected key %q in JSON output", key)
+				}
+			}
+		})
+	}
+}
+
+func TestValidationErrorJSON_OmitsEmptyLocation(t *testing.T) {
+	jsonBytes, err := json.Marshal(ValidationError{Code: CheckCodeLowHealthScore, Message: "low"})
+	if err != nil {
+		t.Fatalf("Failed to marshal ValidationError: %v", err)
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(jsonBytes, &parsed); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	for _, key := range []string{"file", "line"} {
+		if _, ok := parsed[key]; ok {
+			t.Errorf("Expected %q to be omitted when empty", key)
+		}
+	}
+}
+
+func TestNewCheckResult(t *testing.T) {
+	r := NewCheckResult(80)
+	if !r.Passed {
+		t.Error("NewCheckResult should start as passed")
+	}
+	if r.Errors == nil || r.Warnings == nil {
+		t.Error("NewCheckResult should initialize slices")
+	}
+	if r.HealthScore != 80 {
+		t.Errorf("HealthScore = %d, want 80", r.HealthScore)
+	}
+}
@@ -24,6 +24,7 @@ type CircularDependencyInfo struct {
 	ImpactAssessment      *ImpactAssessment      `json:"impactAssessment,omitempty"`      // Story 3.6: Impact assessment
 	QuickFix              *QuickFixSummary       `json:"quickFix,omitempty"`              // Story 3.8: Quick access to best fix
 	PriorityScore         float64                `json:"priorityScore"`                   // Story 3.8: Priority for sorting (higher = fix first)
+	Owners                []string               `json:"owners,omitempty"`                // Owners of the packages in the cycle
 }
 
 // CircularType classifies the cycle length.
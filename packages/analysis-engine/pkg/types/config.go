@@ -1,5 +1,5 @@
 // Package types defines Go types that match TypeScript definitions in @monoguard/types.
-// This file contains analysis configuration types for Story 2.6.
+// This file contains analysis configuration types for Story 2.6 and check rules.
 package types
 
 // ========================================
@@ -9,7 +9,64 @@ package types
 // AnalysisConfig holds configuration options for analysis.
 // Matches @monoguard/types AnalysisConfig interface.
 type AnalysisConfig struct {
-	Exclude []string `json:"exclude,omitempty"` // Exclusion patterns (exact, glob, or regex:)
+	Exclude       []string          `json:"exclude,omitempty"`       // Exclusion patterns (exact, glob, or regex:)
+	Layers        []LayerDefinition `json:"layers,omitempty"`        // Architecture layers for boundary checks
+	Rules         *RulesConfig      `json:"rules,omitempty"`         // Rule severities used by check
+	Thresholds    *ThresholdsConfig `json:"thresholds,omitempty"`    // Thresholds used by check
+	HealthWeights *HealthWeights    `json:"healthWeights,omitempty"` // Health score factor weights (nil uses the defaults)
+	Overrides     []PackageOverride `json:"overrides,omitempty"`     // Check settings for the packages below a directory
+	Owners        []OwnerRule       `json:"owners,omitempty"`        // Package owners, applied after CODEOWNERS
+	CustomRules   []CustomRule      `json:"customRules,omitempty"`   // Declarative rules evaluated by the analysis
+}
+
+// RuleSeverity controls how violations of a rule are reported by check.
+type RuleSeverity string
+
+const (
+	RuleSeverityError RuleSeverity = "error" // Violations fail the check
+	RuleSeverityWarn  RuleSeverity = "warn"  // Violations are reported as warnings
+	RuleSeverityOff   RuleSeverity = "off"   // Rule is disabled
+)
+
+// RulesConfig sets the severity of each check rule.
+// Empty values default to RuleSeverityError, except VersionConflicts which
+// defaults to RuleSeverityOff. Plugin findings and custom rule violations
+// carry their own severity; Plugins and CustomRules cap it, so "warn"
+// reports errors as warnings.
+type RulesConfig struct {
+	CircularDependencies RuleSeverity `json:"circularDependencies,omitempty"`
+	BoundaryViolations   RuleSeverity `json:"boundaryViolations,omitempty"`
+	VersionConflicts     RuleSeverity `json:"versionConflicts,omitempty"`
+	Plugins              RuleSeverity `json:"plugins,omitempty"`
+	CustomRules          RuleSeverity `json:"customRules,omitempty"`
+}
+
+// ThresholdsConfig sets numeric limits enforced by check.
+type ThresholdsConfig struct {
+	HealthScore int `json:"healthScore,omitempty"` // Minimum health score (0 disables)
+}
+
+// PackageOverride adjusts check rules for the packages in a directory and
+// below it. When several overrides contain a package, the nearest one takes
+// precedence for each rule it sets, and all of their exclusions apply. The
+// packages of the nearest override with thresholds are scored separately
+// and checked against its thresholds instead of the inherited ones.
+type PackageOverride struct {
+	Path       string            `json:"path"`                 // Directory relative to the workspace root
+	Exclude    []string          `json:"exclude,omitempty"`    // Packages whose issues with these packages are ignored (exact, glob, or regex:)
+	Rules      *RulesConfig      `json:"rules,omitempty"`      // Rule severities; unset rules keep the inherited severity
+	Thresholds *ThresholdsConfig `json:"thresholds,omitempty"` // Thresholds for the packages; nil inherits them
+}
+
+// HealthWeights sets the relative weight of each health score factor.
+// Weights are normalized by their sum, so they need not add up to 1.0.
+// The plugin weight only counts when plugins ran.
+type HealthWeights struct {
+	Circular float64 `json:"circular"`          // Circular dependencies
+	Conflict float64 `json:"conflict"`          // Version conflicts
+	Depth    float64 `json:"depth"`             // Dependency depth
+	Coupling float64 `json:"coupling"`          // Package coupling
+	Plugins  float64 `json:"plugins,omitempty"` // Plugin findings
 }
 
 // AnalysisInput represents the complete input to the analyze function.
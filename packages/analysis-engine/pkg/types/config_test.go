@@ -184,3 +184,69 @@ func TestNewAnalysisConfig(t *testing.T) {
 		t.Errorf("Exclude length = %d, want 0", len(config.Exclude))
 	}
 }
+
+// TestAnalysisConfig_CheckRules verifies rule and threshold configuration parsing.
+func TestAnalysisConfig_CheckRules(t *testing.T) {
+	input := `{
+		"layers": [{"name": "apps", "pattern": "apps/*", "canDependOn": ["libs"]}],
+		"rules": {"circularDependencies": "warn", "boundaryViolations": "off"},
+		"thresholds": {"healthScore": 70}
+	}`
+
+	var config AnalysisConfig
+	if err := json.Unmarshal([]byte(input), &config); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if len(config.Layers) != 1 || config.Layers[0].Pattern != "apps/*" {
+		t.Errorf("Layers = %+v, want one apps layer", config.Layers)
+	}
+	if config.Rules == nil || config.Rules.CircularDependencies != RuleSeverityWarn || config.Rules.BoundaryViolations != RuleSeverityOff {
+		t.Errorf("Rules = %+v, want warn/off", config.Rules)
+	}
+	if config.Thresholds == nil || config.Thresholds.HealthScore != 70 {
+		t.Errorf("Thresholds = %+v, want healthScore 70", config.Thresholds)
+	}
+}
+
+// TestAnalysisConfig_HealthWeightsAndConflictRule verifies the JSON keys of
+// health weights and the version conflict rule.
+func TestAnalysisConfig_HealthWeightsAndConflictRule(t *testing.T) {
+	config := &AnalysisConfig{
+		Rules:         &RulesConfig{VersionConflicts: RuleSeverityWarn},
+		HealthWeights: &HealthWeights{Circular: 0.5, Conflict: 0.5},
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `{"rules":{"versionConflicts":"warn"},"healthWeights":{"circular":0.5,"conflict":0.5,"depth":0,"coupling":0}}`
+	if string(data) != want {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+}
+
+func TestAnalysisConfig_Overrides(t *testing.T) {
+	input := `{"overrides":[{"path":"apps/web","exclude":["@mono/legacy-*"],"rules":{"boundaryViolations":"warn"}}]}`
+
+	var config AnalysisConfig
+	if err := json.Unmarshal([]byte(input), &config); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if len(config.Overrides) != 1 {
+		t.Fatalf("Overrides = %+v, want 1", config.Overrides)
+	}
+	o := config.Overrides[0]
+	if o.Path != "apps/web" || len(o.Exclude) != 1 || o.Rules == nil || o.Rules.BoundaryViolations != RuleSeverityWarn {
+		t.Errorf("Override = %+v", o)
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != input {
+		t.Errorf("JSON = %s, want %s", data, input)
+	}
+}
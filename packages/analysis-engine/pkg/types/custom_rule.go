@@ -0,0 +1,47 @@
+// Package types defines Go types that match TypeScript definitions in @monoguard/types.
+// This file contains declarative custom rule types.
+package types
+
+// ========================================
+// Custom Rule Types
+// ========================================
+
+// CustomRuleScope selects what a custom rule is evaluated for.
+type CustomRuleScope string
+
+const (
+	CustomRuleScopePackage    CustomRuleScope = "package"    // Once per workspace package
+	CustomRuleScopeDependency CustomRuleScope = "dependency" // Once per dependency between workspace packages
+	CustomRuleScopeExternal   CustomRuleScope = "external"   // Once per external dependency of a workspace package
+	CustomRuleScopeWorkspace  CustomRuleScope = "workspace"  // Once for the whole workspace
+)
+
+// CustomRule is a rule written as an expression over package, dependency and
+// graph attributes. Every package, dependency or external dependency (per
+// Scope) for which Forbid is true is a violation.
+type CustomRule struct {
+	// Name identifies the rule in findings (e.g., "no-app-dependents")
+	Name string `json:"name"`
+
+	// Description explains the rule and is used as the message of its violations
+	Description string `json:"description,omitempty"`
+
+	// Scope selects the attributes available to Forbid
+	Scope CustomRuleScope `json:"scope"`
+
+	// Forbid is the expression describing a violation (e.g., `len(dependencies) > 15`)
+	Forbid string `json:"forbid"`
+
+	// Severity of the violations: error (default) or warn; off disables the rule
+	Severity RuleSeverity `json:"severity,omitempty"`
+}
+
+// CustomRuleViolation is a package, dependency or workspace that matched the
+// Forbid expression of a custom rule.
+type CustomRuleViolation struct {
+	Rule       string       `json:"rule"`                 // Name of the rule
+	Severity   RuleSeverity `json:"severity"`             // error or warn
+	Message    string       `json:"message"`              // Rule description and what violated it
+	Package    string       `json:"package,omitempty"`    // Violating package; the dependent package for dependency rules
+	Dependency string       `json:"dependency,omitempty"` // Internal or external dependency, for dependency and external rules
+}
@@ -0,0 +1,27 @@
+// Package types defines Go types that match TypeScript definitions in @monoguard/types.
+// This file contains workspace diagnostic types.
+package types
+
+// ========================================
+// Workspace Diagnostic Types
+// ========================================
+
+// DiagnosticKind classifies a workspace hygiene problem.
+type DiagnosticKind string
+
+const (
+	DiagnosticInvalidPackageJSON DiagnosticKind = "invalid-package-json" // package.json cannot be parsed
+	DiagnosticMissingName        DiagnosticKind = "missing-name"         // package.json has no name
+	DiagnosticMissingPackageJSON DiagnosticKind = "missing-package-json" // Directory matched by a workspace pattern has no package.json
+	DiagnosticDuplicateName      DiagnosticKind = "duplicate-name"       // Package name already used by another package
+	DiagnosticUnmatchedPackage   DiagnosticKind = "unmatched-package"    // package.json not matched by any workspace pattern
+	DiagnosticUnmatchedPattern   DiagnosticKind = "unmatched-pattern"    // Workspace pattern that matches no package
+)
+
+// Diagnostic is a workspace problem found while parsing. The affected
+// packages are left out of the analysis; diagnostics say which and why.
+type Diagnostic struct {
+	File    string         `json:"file"` // Path relative to the workspace root
+	Kind    DiagnosticKind `json:"kind"`
+	Message string         `json:"message"`
+}
@@ -34,6 +34,7 @@ type PackageNode struct {
 	ExternalPeerDeps     map[string]string `json:"externalPeerDeps,omitempty"`
 	ExternalOptionalDeps map[string]string `json:"externalOptionalDeps,omitempty"`
 	Excluded             bool              `json:"excluded,omitempty"` // Story 2.6: True if excluded from analysis
+	Owners               []string          `json:"owners,omitempty"`   // Owning teams from CODEOWNERS or owner rules
 }
 
 // DependencyEdge represents a directed edge between packages in the dependency graph.
@@ -44,6 +45,8 @@ type DependencyEdge struct {
 	To           string         `json:"to"`
 	Type         DependencyType `json:"type"`
 	VersionRange string         `json:"versionRange"`
+	FromOwners   []string       `json:"fromOwners,omitempty"` // Owners of the dependent package
+	ToOwners     []string       `json:"toOwners,omitempty"`   // Owners of the dependency package
 }
 
 // DependencyType classifies the type of dependency relationship.
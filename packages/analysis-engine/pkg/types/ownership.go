@@ -0,0 +1,52 @@
+// Package types defines Go types that match TypeScript definitions in @monoguard/types.
+// This file contains package ownership types.
+package types
+
+// ========================================
+// Ownership Types
+// ========================================
+
+// OwnerRule assigns owners to the packages whose directory matches Pattern.
+// Patterns follow CODEOWNERS syntax and the last matching rule wins.
+type OwnerRule struct {
+	// Pattern is matched against paths relative to the workspace root (e.g., "/apps/", "libs/ui/**")
+	Pattern string `json:"pattern"`
+
+	// Owners lists the owning teams or users (e.g., "@org/frontend"). Empty
+	// owners leave the matched packages unowned.
+	Owners []string `json:"owners"`
+
+	// Root is the workspace directory relative to the directory Pattern is
+	// relative to, e.g. "frontend" for the CODEOWNERS file of a repository
+	// whose workspace is in frontend/. Empty when they are the same.
+	Root string `json:"root,omitempty"`
+}
+
+// Ownership is the owner-centric view of an analysis result. It is only
+// present when CODEOWNERS or owner rules are configured.
+type Ownership struct {
+	CrossTeamEdges []*CrossTeamEdge `json:"crossTeamEdges"`    // Dependencies between packages of different owners, sorted by from/to
+	Teams          []*TeamReport    `json:"teams"`             // One report per owner, sorted by owner
+	Unowned        []string         `json:"unowned,omitempty"` // Packages no rule assigns an owner to
+}
+
+// CrossTeamEdge is a dependency between packages that have different owners.
+type CrossTeamEdge struct {
+	From           string         `json:"from"`
+	To             string         `json:"to"`
+	FromOwners     []string       `json:"fromOwners"`
+	ToOwners       []string       `json:"toOwners"`
+	DependencyType DependencyType `json:"dependencyType"`
+}
+
+// TeamReport lists the packages and issues of one owner, so that fix work
+// can be routed to the owning team.
+type TeamReport struct {
+	Owner              string   `json:"owner"`
+	Packages           []string `json:"packages"`
+	Cycles             []string `json:"cycles,omitempty"`             // Paths of the cycles through the owner's packages
+	VersionConflicts   []string `json:"versionConflicts,omitempty"`   // External packages with versions that conflict in the owner's packages
+	BoundaryViolations []string `json:"boundaryViolations,omitempty"` // "from → to" of violations by the owner's packages
+	IncomingEdges      int      `json:"incomingEdges"`                // Dependencies of other owners' packages on the owner's packages
+	OutgoingEdges      int      `json:"outgoingEdges"`                // Dependencies of the owner's packages on other owners' packages
+}
@@ -0,0 +1,43 @@
+// Package types defines Go types that match TypeScript definitions in @monoguard/types.
+// This file contains external rule plugin types.
+package types
+
+// ========================================
+// Plugin Types
+// ========================================
+
+// PluginSeverity classifies a plugin finding.
+type PluginSeverity string
+
+const (
+	PluginSeverityError   PluginSeverity = "error"   // Fails the check
+	PluginSeverityWarning PluginSeverity = "warning" // Reported as a check warning
+	PluginSeverityInfo    PluginSeverity = "info"    // Only reported by analyze
+)
+
+// PluginFinding is an issue reported by an external rule plugin.
+type PluginFinding struct {
+	Plugin   string         `json:"plugin"`            // Name of the plugin in the configuration
+	Rule     string         `json:"rule"`              // Plugin-defined rule ID (e.g., "no-lodash")
+	Severity PluginSeverity `json:"severity"`          // error, warning or info
+	Message  string         `json:"message"`           // Human-readable description
+	Package  string         `json:"package,omitempty"` // Workspace package the finding belongs to
+	File     string         `json:"file,omitempty"`    // Related file path (relative to workspace root)
+	Line     int            `json:"line,omitempty"`    // 1-based line number in File
+}
+
+// PluginResult is the outcome of running one plugin. A plugin that failed
+// (crashed, timed out or returned invalid output) has an Error and no
+// findings.
+type PluginResult struct {
+	Plugin   string           `json:"plugin"`
+	Findings []*PluginFinding `json:"findings"`
+	Error    string           `json:"error,omitempty"`
+}
+
+// PluginError records a plugin that failed; its findings are missing from
+// the result.
+type PluginError struct {
+	Plugin  string `json:"plugin"`
+	Message string `json:"message"`
+}
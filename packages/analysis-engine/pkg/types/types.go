@@ -23,6 +23,8 @@ type WorkspaceData struct {
 	RootPath      string                  `json:"rootPath"`
 	WorkspaceType WorkspaceType           `json:"workspaceType"`
 	Packages      map[string]*PackageInfo `json:"packages"`
+	Diagnostics   []Diagnostic            `json:"diagnostics,omitempty"` // Problems that left packages out, sorted by file
+	CodeOwners    []OwnerRule             `json:"codeOwners,omitempty"`  // Rules of the workspace CODEOWNERS file, in file order
 }
 
 // PackageInfo represents a single package in the workspace with full dependency information.
@@ -52,6 +54,11 @@ type AnalysisResult struct {
 	Graph                *DependencyGraph          `json:"graph,omitempty"`
 	CircularDependencies []*CircularDependencyInfo `json:"circularDependencies,omitempty"` // Story 2.3
 	VersionConflicts     []*VersionConflictInfo    `json:"versionConflicts,omitempty"`     // Story 2.4
+	BoundaryViolations   []*BoundaryViolation      `json:"boundaryViolations,omitempty"`   // Layer boundary violations (when layers are configured)
+	Ownership            *Ownership                `json:"ownership,omitempty"`            // Issues by owner (when CODEOWNERS or owner rules exist)
+	PluginFindings       []*PluginFinding          `json:"pluginFindings,omitempty"`       // Findings of external rule plugins (when plugins are configured)
+	PluginErrors         []*PluginError            `json:"pluginErrors,omitempty"`         // Plugins that failed to run
+	CustomRuleViolations []*CustomRuleViolation    `json:"customRuleViolations,omitempty"` // Violations of custom rules (when custom rules are configured)
 	CreatedAt            string                    `json:"createdAt,omitempty"`            // ISO 8601 format
 	Placeholder          bool                      `json:"placeholder,omitempty"`          // True when returning placeholder data
 	FixSummary           *FixSummary               `json:"fixSummary,omitempty"`           // Story 3.8 - aggregated fix summary
@@ -62,13 +69,6 @@ type VersionInfo struct {
 	Version string `json:"version"`
 }
 
-// CheckResult represents validation-only output for CI/CD pipelines.
-type CheckResult struct {
-	Passed      bool     `json:"passed"`
-	Errors      []string `json:"errors"`
-	Placeholder bool     `json:"placeholder,omitempty"` // True when returning placeholder data
-}
-
 // Package represents a single package in the workspace.
 type Package struct {
 	Name         string   `json:"name"`
@@ -62,60 +62,6 @@ func TestAnalysisResultJSON(t *testing.T) {
 	}
 }
 
-func TestCheckResultJSON(t *testing.T) {
-	tests := []struct {
-		name     string
-		input    CheckResult
-		wantKeys []string
-	}{
-		{
-			name: "passed check with camelCase JSON",
-			input: CheckResult{
-				Passed: true,
-				Errors: []string{},
-			},
-			wantKeys: []string{"passed", "errors"},
-		},
-		{
-			name: "failed check with errors",
-			input: CheckResult{
-				Passed: false,
-				Errors: []string{"circular dependency detected", "missing package"},
-			},
-			wantKeys: []string{"passed", "errors"},
-		},
-		{
-			name: "placeholder check",
-			input: CheckResult{
-				Passed:      true,
-				Errors:      []string{},
-				Placeholder: true,
-			},
-			wantKeys: []string{"passed", "errors", "placeholder"},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			jsonBytes, err := json.Marshal(tt.input)
-			if err != nil {
-				t.Fatalf("Failed to marshal CheckResult: %v", err)
-			}
-
-			var parsed map[string]interface{}
-			if err := json.Unmarshal(jsonBytes, &parsed); err != nil {
-				t.Fatalf("Failed to unmarshal JSON: %v", err)
-			}
-
-			for _, key := range tt.wantKeys {
-				if _, ok := parsed[key]; !ok {
-					t.Errorf("Missing expected key %q in JSON output", key)
-				}
-			}
-		})
-	}
-}
-
 func TestVersionInfoJSON(t *testing.T) {
 	v := VersionInfo{Version: "0.1.0"}
 
@@ -14,6 +14,7 @@ type VersionConflictInfo struct {
 	Severity            ConflictSeverity      `json:"severity"`
 	Resolution          string                `json:"resolution"`
 	Impact              string                `json:"impact"`
+	Owners              []string              `json:"owners,omitempty"` // Owners of the packages using a conflicting version
 }
 
 // ConflictingVersion represents one version and which packages use it.
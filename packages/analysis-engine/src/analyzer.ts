@@ -26,6 +26,22 @@ export interface WorkspaceInput {
 export interface AnalysisConfig {
   /** Patterns to exclude from analysis (exact, glob, or regex:) */
   exclude?: string[]
+  /** Architecture layers for boundary checking */
+  layers?: Array<{
+    name: string
+    pattern: string
+    canDependOn?: string[]
+  }>
+  /** Rule severities used by check */
+  rules?: {
+    circularDependencies?: 'error' | 'warn' | 'off'
+    boundaryViolations?: 'error' | 'warn' | 'off'
+  }
+  /** Thresholds used by check */
+  thresholds?: {
+    /** Minimum health score (0 disables the threshold) */
+    healthScore?: number
+  }
 }
 
 /**
@@ -136,8 +152,22 @@ export interface VersionConflictInfo {
  */
 export interface WasmCheckResult {
   passed: boolean
-  errors: string[]
-  placeholder?: boolean
+  errors: Array<{
+    code: string
+    message: string
+    file?: string
+    line?: number
+    package?: string
+  }>
+  warnings: Array<{
+    code: string
+    message: string
+    file?: string
+    line?: number
+    package?: string
+  }>
+  healthScore: number
+  packages?: string[]
 }
 
 /**
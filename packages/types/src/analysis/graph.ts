@@ -33,6 +33,8 @@ export interface PackageNode {
   devDependencies: string[]
   /** Peer dependencies */
   peerDependencies: string[]
+  /** Owners from CODEOWNERS or owner rules */
+  owners?: string[]
 }
 
 /**
@@ -49,6 +51,10 @@ export interface DependencyEdge {
   type: DependencyType
   /** Version range specified */
   versionRange: string
+  /** Owners of the source package */
+  fromOwners?: string[]
+  /** Owners of the target package */
+  toOwners?: string[]
 }
 
 /**
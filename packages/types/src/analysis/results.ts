@@ -27,6 +27,141 @@ export interface AnalysisResult {
   createdAt?: string
   /** Aggregated fix summary for all circular dependencies (Story 3.8) */
   fixSummary?: FixSummary
+  /** Dependencies crossing disallowed architecture layers */
+  boundaryViolations?: BoundaryViolation[]
+  /** Cross-team dependencies and issues by owner (only with CODEOWNERS or owner rules) */
+  ownership?: Ownership
+  /** Findings of external rule plugins (when plugins are configured) */
+  pluginFindings?: PluginFinding[]
+  /** Plugins that failed to run */
+  pluginErrors?: PluginError[]
+  /** Violations of the configured custom rules */
+  customRuleViolations?: CustomRuleViolation[]
+}
+
+/**
+ * BoundaryViolation - Dependency that crosses a disallowed layer boundary
+ *
+ * Matches Go: pkg/types/boundary.go
+ */
+export interface BoundaryViolation {
+  /** Depending package */
+  from: string
+  /** Dependency package */
+  to: string
+  /** Layer of the depending package */
+  fromLayer: string
+  /** Layer of the dependency package */
+  toLayer: string
+  /** Dependency type of the offending edge */
+  dependencyType: DependencyType
+  /** Human-readable description */
+  message: string
+}
+
+/**
+ * PluginSeverity - Severity of a plugin finding
+ *
+ * Matches Go: pkg/types/plugin.go
+ */
+export type PluginSeverity = 'error' | 'warning' | 'info'
+
+/**
+ * PluginFinding - Issue reported by an external rule plugin
+ *
+ * Matches Go: pkg/types/plugin.go
+ */
+export interface PluginFinding {
+  /** Name of the plugin in the configuration */
+  plugin: string
+  /** Plugin-defined rule ID (e.g., "no-lodash") */
+  rule: string
+  severity: PluginSeverity
+  /** Human-readable description */
+  message: string
+  /** Workspace package the finding belongs to */
+  package?: string
+  /** Related file path (relative to workspace root) */
+  file?: string
+  /** 1-based line number in file */
+  line?: number
+}
+
+/**
+ * PluginError - Plugin that failed to run
+ *
+ * Matches Go: pkg/types/plugin.go
+ */
+export interface PluginError {
+  plugin: string
+  message: string
+}
+
+/**
+ * CustomRuleViolation - Package, dependency or workspace matching the forbid
+ * expression of a custom rule
+ *
+ * Matches Go: pkg/types/custom_rule.go
+ */
+export interface CustomRuleViolation {
+  /** Name of the rule */
+  rule: string
+  severity: 'error' | 'warn'
+  /** Rule description and what violated it */
+  message: string
+  /** Violating package; the dependent package for dependency rules */
+  package?: string
+  /** Internal or external dependency, for dependency and external rules */
+  dependency?: string
+}
+
+/**
+ * Ownership - Owner-centric view of an analysis result
+ *
+ * Matches Go: pkg/types/ownership.go
+ */
+export interface Ownership {
+  /** Dependencies between packages of different owners, sorted by from/to */
+  crossTeamEdges: CrossTeamEdge[]
+  /** One report per owner, sorted by owner */
+  teams: TeamReport[]
+  /** Packages no rule assigns an owner to */
+  unowned?: string[]
+}
+
+/**
+ * CrossTeamEdge - Dependency between packages that have different owners
+ *
+ * Matches Go: pkg/types/ownership.go
+ */
+export interface CrossTeamEdge {
+  from: string
+  to: string
+  fromOwners: string[]
+  toOwners: string[]
+  dependencyType: DependencyType
+}
+
+/**
+ * TeamReport - Packages and issues of one owner
+ *
+ * Matches Go: pkg/types/ownership.go
+ */
+export interface TeamReport {
+  /** Owning team or user (e.g., "@org/frontend") */
+  owner: string
+  /** Packages of the owner */
+  packages: string[]
+  /** Paths of the cycles through the owner's packages */
+  cycles?: string[]
+  /** External packages with versions that conflict in the owner's packages */
+  versionConflicts?: string[]
+  /** "from → to" of boundary violations by the owner's packages */
+  boundaryViolations?: string[]
+  /** Dependencies of other owners' packages on the owner's packages */
+  incomingEdges: number
+  /** Dependencies of the owner's packages on other owners' packages */
+  outgoingEdges: number
 }
 
 /**
@@ -61,6 +196,8 @@ export interface CircularDependencyInfo {
   quickFix?: QuickFixSummary
   /** Priority score for sorting - higher = fix first (Story 3.8) */
   priorityScore: number
+  /** Owners of the packages in the cycle */
+  owners?: string[]
 }
 
 /**
@@ -290,6 +427,24 @@ export interface CheckResult {
   warnings: ValidationWarning[]
   /** Health score (0-100) */
   healthScore: number
+  /** Names of the checked (non-excluded) packages (optional) */
+  packages?: string[]
+  /** How a baseline of known issues was applied (optional) */
+  baseline?: BaselineStatus
+}
+
+/**
+ * BaselineStatus - Known issues filtered from a check by a baseline file
+ *
+ * Matches Go: pkg/types/check_result.go
+ */
+export interface BaselineStatus {
+  /** Path of the baseline file */
+  file: string
+  /** Issues found that are recorded in the baseline and did not fail the check */
+  known: number
+  /** Baseline entries that no longer occur and can be removed */
+  fixed: string[]
 }
 
 /**
@@ -306,6 +461,8 @@ export interface ValidationError {
   file?: string
   /** Line number (optional) */
   line?: number
+  /** Workspace package the error belongs to (optional) */
+  package?: string
 }
 
 /**
@@ -320,6 +477,10 @@ export interface ValidationWarning {
   message: string
   /** Related file path (optional) */
   file?: string
+  /** Line number (optional) */
+  line?: number
+  /** Workspace package the warning belongs to (optional) */
+  package?: string
 }
 
 /**
@@ -354,6 +515,8 @@ export interface VersionConflictInfo {
   resolution: string
   /** Impact description */
   impact: string
+  /** Owners of the packages using the conflicting versions */
+  owners?: string[]
 }
 
 /**
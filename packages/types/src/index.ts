@@ -11,6 +11,7 @@ export type {
 export type {
   AnalysisMetadata,
   AnalysisResult,
+  BaselineStatus,
   CheckResult,
   CircularDependencyInfo,
   FixStrategy,
@@ -18,6 +18,131 @@ export interface WorkspaceInput {
 export interface AnalysisConfig {
   /** Patterns to exclude from analysis (exact, glob, or regex:) */
   exclude?: string[]
+  /** Architecture layers for boundary checking */
+  layers?: LayerDefinition[]
+  /** Package owners, applied after the CODEOWNERS file */
+  owners?: OwnerRule[]
+  /** Declarative rules evaluated during analysis */
+  customRules?: CustomRule[]
+  /** Rule severities used by check */
+  rules?: RulesConfig
+  /** Thresholds used by check */
+  thresholds?: ThresholdsConfig
+  /** Health score factor weights (unset uses the defaults) */
+  healthWeights?: HealthWeights
+  /** Check settings for the packages below a directory */
+  overrides?: PackageOverride[]
+}
+
+/**
+ * LayerDefinition - Architecture layer matched by package path glob
+ *
+ * Matches Go: pkg/types/boundary.go
+ */
+export interface LayerDefinition {
+  /** Layer name referenced by canDependOn */
+  name: string
+  /** Glob matched against the package path (e.g. "packages/ui/*") */
+  pattern: string
+  /** Layers this layer may depend on (same-layer dependencies are always allowed) */
+  canDependOn?: string[]
+}
+
+/**
+ * OwnerRule - Owners of the packages matching a CODEOWNERS pattern
+ *
+ * Matches Go: pkg/types/ownership.go
+ */
+export interface OwnerRule {
+  /** CODEOWNERS pattern matched against the package path (e.g. "/apps/") */
+  pattern: string
+  /** Owning teams or users; empty leaves the packages unowned */
+  owners: string[]
+  /** Workspace directory relative to the root the pattern is relative to */
+  root?: string
+}
+
+/**
+ * CustomRuleScope - What a custom rule is evaluated for
+ */
+export type CustomRuleScope = 'package' | 'dependency' | 'external' | 'workspace'
+
+/**
+ * CustomRule - Rule written as an expression over package, dependency and
+ * graph attributes
+ *
+ * Matches Go: pkg/types/custom_rule.go
+ */
+export interface CustomRule {
+  /** Identifies the rule in findings */
+  name: string
+  /** Used as the message of its violations */
+  description?: string
+  scope: CustomRuleScope
+  /** Expression describing a violation (e.g. "len(dependencies) > 15") */
+  forbid: string
+  /** Severity of the violations (default "error"); "off" disables the rule */
+  severity?: RuleSeverity
+}
+
+/**
+ * RuleSeverity - How check reports violations of a rule
+ */
+export type RuleSeverity = 'error' | 'warn' | 'off'
+
+/**
+ * RulesConfig - Per-rule severities (unset means "error", except
+ * versionConflicts which is "off")
+ */
+export interface RulesConfig {
+  circularDependencies?: RuleSeverity
+  boundaryViolations?: RuleSeverity
+  versionConflicts?: RuleSeverity
+  /** Caps the severity of plugin findings */
+  plugins?: RuleSeverity
+  /** Caps the severity of custom rule violations */
+  customRules?: RuleSeverity
+}
+
+/**
+ * ThresholdsConfig - Numeric limits enforced by check
+ */
+export interface ThresholdsConfig {
+  /** Minimum health score (0 disables the threshold) */
+  healthScore?: number
+}
+
+/**
+ * HealthWeights - Relative weight of each health score factor
+ *
+ * Weights are normalized by their sum. Matches Go: pkg/types/config.go
+ */
+export interface HealthWeights {
+  circular: number
+  conflict: number
+  depth: number
+  coupling: number
+  /** Plugin findings, counted when plugins ran */
+  plugins?: number
+}
+
+/**
+ * PackageOverride - Check settings for the packages in a directory
+ *
+ * The nearest override takes precedence for each rule it sets, and the
+ * exclusions of every enclosing override apply. The packages of the nearest
+ * override with thresholds are scored and checked on their own.
+ * Matches Go: pkg/types/config.go
+ */
+export interface PackageOverride {
+  /** Directory relative to the workspace root */
+  path: string
+  /** Packages whose issues with these packages are ignored */
+  exclude?: string[]
+  /** Rule severities; unset rules keep the inherited severity */
+  rules?: RulesConfig
+  /** Thresholds for the packages; unset inherits them */
+  thresholds?: ThresholdsConfig
 }
 
 /**